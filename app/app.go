@@ -0,0 +1,322 @@
+// DESIGN PATTERN: Dependency Injection + Composition Root + Factory Pattern
+//
+// Package app wires the Sender Service object graph. main only loads configuration, calls New and
+// runs the routers; tests and embedders can instead mount Public/Internal on an httptest.Server.
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/experiments"
+	"sender-service/handlers"
+	"sender-service/idgen"
+	"sender-service/middleware"
+	"sender-service/models"
+	"sender-service/repositories"
+	"sender-service/services"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Deps - Container of externally supplied dependencies; nil fields are built from the configuration
+type Deps struct {
+	DB           *gorm.DB                         // Primary database (nil = connect with cfg.Database)
+	Clock        clock.Clock                      // Time source (nil = real clock, or frozen per CLOCK_FROZEN_AT)
+	IDs          idgen.Generator                  // ID/token generator (nil = idgen.New with the configured seed)
+	EmailSender  services.EmailSender             // Email delivery (nil = EMAIL_PROVIDER per cfg.Email)
+	AuthClient   services.AuthGateway             // Auth Service gateway (nil = HTTP client for cfg.AuthService)
+	TransferRepo *repositories.TransferRepository // Transfer storage (nil = regional stores per cfg.Database)
+}
+
+// Option - Override point applied to Deps before the graph is wired
+type Option func(*Deps)
+
+// WithEmailSender - Delivers email through the given sender instead of SMTP
+func WithEmailSender(sender services.EmailSender) Option {
+	return func(d *Deps) { d.EmailSender = sender }
+}
+
+// WithAuthClient - Talks to the given Auth Service gateway instead of the HTTP client
+func WithAuthClient(auth services.AuthGateway) Option {
+	return func(d *Deps) { d.AuthClient = auth }
+}
+
+// WithRepo - Stores transfers in the given repository instead of opening the configured regions
+func WithRepo(transferRepo *repositories.TransferRepository) Option {
+	return func(d *Deps) { d.TransferRepo = transferRepo }
+}
+
+// App - The fully wired service: both routers plus the background work they depend on
+type App struct {
+	Public   *gin.Engine // Browser/app-facing router (CORS, load shedding)
+	Internal *gin.Engine // Trusted services and operators only (separate port)
+
+	outboxRelay      *services.OutboxRelay      // Delivers domain events in order
+	outboxDispatcher *services.OutboxDispatcher // Drains transfer outboxes
+	scheduler        *services.Scheduler        // Periodic jobs
+	initiationQueue  *services.InitiationQueue  // Async initiation workers
+}
+
+// New - Builds the object graph and both routers without starting any listener or worker
+func New(cfg *config.Config, deps Deps, opts ...Option) (*App, error) {
+	// 0. OVERRIDES: Options win over fields set on deps
+	for _, opt := range opts {
+		opt(&deps)
+	}
+
+	// 1. TIME SOURCE: Real clock, or frozen clock in deterministic test mode
+	clk := deps.Clock
+	if clk == nil {
+		clk = clock.New(cfg.Testing.FrozenClockAt)
+		if !cfg.Testing.FrozenClockAt.IsZero() {
+			log.Printf("Warning: clock frozen at %s (test mode)", cfg.Testing.FrozenClockAt.Format(time.RFC3339))
+		}
+	}
+
+	// 2. ID GENERATION: crypto/rand, or seeded sequence when built with -tags deterministic
+	ids := deps.IDs
+	if ids == nil {
+		ids = idgen.New(cfg.Testing.IDSeed, cfg.Claims.TokenBytes)
+		if idgen.Deterministic {
+			log.Printf("Warning: deterministic ID/token generation enabled (seed %d)", cfg.Testing.IDSeed)
+		}
+	}
+
+	// HEALTH MONITORING: Database and Auth Service latency/errors drive load shedding
+	healthMonitor := services.NewHealthMonitor(clk, cfg)
+
+	// QUERY DIAGNOSTICS: Structured slow-query logs and per-type metrics for every connection
+	queryLogger := repositories.NewQueryLogger(cfg.Database.SlowQueryThreshold, cfg.Database.LogLevel)
+
+	// 3. DATABASE CONNECTION: Using GORM with PostgreSQL unless the caller brought its own
+	db := deps.DB
+	if db == nil {
+		var err error
+		if db, err = openDatabase(primaryDSN(cfg), clk, healthMonitor, queryLogger); err != nil {
+			return nil, err
+		}
+	}
+
+	// DATABASE MIGRATION: Auto-create transfer and outbox tables, then fail fast (or warn) on drift
+	if err := prepareSchema(db, cfg, clk, "primary database", primaryModels...); err != nil {
+		return nil, err
+	}
+
+	// 4. REPOSITORY LAYER (Data Access)
+	// DATA RESIDENCY: Each region keeps its transfers in its own databases; the default region is the
+	// primary (or DB_SHARD_DSNS), senders are routed by the region on their Auth Service profile
+	transferRepo := deps.TransferRepo
+	if transferRepo == nil {
+		var err error
+		if transferRepo, err = openTransferRepository(cfg, db, clk, healthMonitor, queryLogger); err != nil {
+			return nil, err
+		}
+	}
+	log.Printf("Transfer storage regions: %v (default %s)", transferRepo.Regions(), cfg.Database.Region)
+	outboxRepo := repositories.NewOutboxRepository(db)
+	deadLetterRepo := repositories.NewDeadLetterRepository(db)
+	preferenceRepo := repositories.NewPreferenceRepository(db)
+	auditRepo := repositories.NewAuditRepository(db)
+	notificationRepo := repositories.NewNotificationRepository(db)
+	emailQuotaRepo := repositories.NewEmailQuotaRepository(db)
+	consentRepo := repositories.NewConsentRepository(db)
+	sagaRepo := repositories.NewSagaRepository(db)
+	webhookRepo := repositories.NewWebhookRepository(db)
+	webhookDeliveryRepo := repositories.NewWebhookDeliveryRepository(db)
+	emailTemplateRepo := repositories.NewEmailTemplateRepository(db)
+	emailArchiveRepo := repositories.NewEmailArchiveRepository(db)
+	jobRunRepo := repositories.NewJobRunRepository(db)
+	claimRateRepo := repositories.NewClaimRateRepository(db)
+	initiationJobRepo := repositories.NewInitiationJobRepository(db)
+
+	// 5. SERVICE LAYER (Business Logic + Email Integration)
+	emailThrottle, err := services.NewEmailThrottle(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email rate limits: %w", err)
+	}
+	emailQuota := services.NewEmailQuota(emailQuotaRepo, emailThrottle, clk, cfg)
+	consentService := services.NewConsentService(consentRepo, clk, cfg)
+	emailSender := deps.EmailSender
+	if emailSender == nil {
+		if emailSender, err = services.NewEmailSender(cfg, clk); err != nil {
+			return nil, fmt.Errorf("invalid email provider settings: %w", err)
+		}
+	}
+	emailArchiveStore, err := services.NewEmailArchiveStore(cfg, clk)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email archive settings: %w", err)
+	}
+	emailArchiveService, err := services.NewEmailArchiveService(emailArchiveRepo, emailArchiveStore, clk, ids, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email archive settings: %w", err)
+	}
+	emailService := services.NewEmailService(cfg, emailSender, emailThrottle, emailQuota, consentService, healthMonitor, emailArchiveService, clk)
+	if err := services.LoadEmailTemplateDir(cfg.Email.TemplateDir); err != nil {
+		return nil, fmt.Errorf("invalid EMAIL_TEMPLATE_DIR: %w", err)
+	}
+	emailTemplateService := services.NewEmailTemplateService(emailTemplateRepo, clk)
+	if err := emailTemplateService.Refresh(context.Background()); err != nil {
+		return nil, err
+	}
+	authClient := deps.AuthClient
+	if authClient == nil {
+		authClient = services.NewAuthClient(cfg, healthMonitor, clk)
+	}
+	eventPublisher := services.NewEventPublisher(outboxRepo, clk, ids)
+	deadLetterService := services.NewDeadLetterService(deadLetterRepo, clk, ids)
+	deadLetterService.Register(models.DeadLetterKindEmail, services.NewEmailDeadLetterHandler(transferRepo, emailService))
+	deadLetterService.Register(models.DeadLetterKindEvent, services.NewEventDeadLetterHandler(outboxRepo, clk))
+	deadLetterService.Register(models.DeadLetterKindCredit, services.NewCreditDeadLetterHandler(transferRepo, authClient))
+	deadLetterService.Register(models.DeadLetterKindOutbox, services.NewOutboxDeadLetterHandler(transferRepo, clk))
+	deadLetterService.Register(models.DeadLetterKindWebhook, services.NewWebhookDeadLetterHandler(webhookDeliveryRepo, clk))
+	sagaService := services.NewSagaService(sagaRepo, authClient, clk, ids, cfg)
+	contentScanner := services.NewContentScanner(cfg)
+	conversionTable := services.NewConversionTable(cfg)
+	validationHooks, err := services.NewHookRegistry(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load validation hooks: %w", err)
+	}
+	claimAssertions, err := services.NewClaimAssertionVerifier(cfg, clk)
+	if err != nil {
+		return nil, fmt.Errorf("invalid claim assertion settings: %w", err)
+	}
+	notificationService := services.NewNotificationService(notificationRepo, clk, ids, cfg)
+	notificationRouter := services.NewNotificationRouter(authClient, transferRepo, emailService, cfg)
+	notificationRouter.Register(services.ChannelInApp, notificationService)
+	claimLatencyService := services.NewClaimLatencyService(transferRepo, clk)
+	volumeService := services.NewTransferVolumeService(transferRepo, clk)
+	rejectionMetrics := services.NewRejectionMetrics(clk)
+	claimThrottle := services.NewClaimThrottle(claimRateRepo, clk, cfg)
+	captchaVerifier, err := services.NewCaptchaVerifier(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CAPTCHA settings: %w", err)
+	}
+	experimentRegistry, err := experiments.Parse(cfg.Experiments)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EXPERIMENTS: %w", err)
+	}
+	experimentService := services.NewExperimentService(experimentRegistry, transferRepo, clk)
+	transferService := services.NewTransferService(transferRepo, auditRepo, emailService, authClient, eventPublisher, deadLetterService, sagaService, contentScanner, conversionTable, validationHooks, claimAssertions, claimLatencyService, volumeService, rejectionMetrics, claimThrottle, experimentService, clk, ids, cfg)
+	preferenceService := services.NewPreferenceService(preferenceRepo, authClient)
+	digestService := services.NewDigestService(preferenceRepo, transferRepo, emailService, clk, cfg)
+	escheatmentService := services.NewEscheatmentService(transferRepo, clk, cfg)
+	webhookService := services.NewWebhookService(webhookRepo, webhookDeliveryRepo, clk, ids)
+	integrationFeed := services.NewIntegrationFeedService(outboxRepo, clk, cfg)
+	nudgeService := services.NewNudgeService(transferRepo, preferenceRepo, emailService, clk, cfg)
+	canaryMailbox, err := services.NewCanaryMailbox(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid canary settings: %w", err)
+	}
+	canaryService := services.NewCanaryService(transferService, canaryMailbox, clk, ids, cfg)
+
+	// EVENT DELIVERY: Configured sink, then webhook subscriptions, then Slack/Teams channels
+	webhookSigner, err := services.NewWebhookSigner(cfg, clk)
+	if err != nil {
+		return nil, err
+	}
+	webhookSink := services.NewWebhookSink(services.NewEventSink(cfg, webhookSigner), webhookRepo, webhookDeliveryRepo, webhookSigner, deadLetterService, cfg, clk, ids)
+	eventSink, err := services.NewChatSink(services.NewTransferCallbackSink(webhookSink, transferRepo, webhookSigner, cfg), authClient, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CHAT_CHANNELS: %w", err)
+	}
+
+	// 6. BACKGROUND WORK: Built here, started by Start
+	scheduler, err := services.NewScheduler(jobRunRepo, clk, ids, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid job schedules: %w", err)
+	}
+	a := &App{
+		outboxRelay:      services.NewOutboxRelay(outboxRepo, eventSink, deadLetterService, clk, cfg),
+		outboxDispatcher: services.NewOutboxDispatcher(transferRepo, auditRepo, eventPublisher, notificationRouter, emailService, deadLetterService, clk, cfg),
+		scheduler:        scheduler,
+		initiationQueue:  services.NewInitiationQueue(transferService, initiationJobRepo, clk, ids, cfg),
+	}
+	a.scheduler.Every("sender_digest", cfg.Digest.CheckInterval, digestService.Run)
+	a.scheduler.Every("escheatment_flag", cfg.Escheatment.CheckInterval, escheatmentService.FlagEscheatable)
+	a.scheduler.Every("expiration_sweep", cfg.Expiration.SweepInterval, transferService.SweepExpired)
+	a.scheduler.EveryInstance("email_template_refresh", cfg.Email.TemplateRefreshInterval, emailTemplateService.Refresh)
+	a.scheduler.Every("saga_compensation_retry", cfg.Saga.RetryInterval, sagaService.RetryPending)
+	a.scheduler.Every("webhook_delivery_retry", cfg.Events.WebhookRetryInterval, webhookSink.RetryDue)
+	if emailArchiveService.Enabled() {
+		a.scheduler.Every("email_archive_purge", cfg.EmailArchive.PurgeInterval, emailArchiveService.Purge)
+	}
+	if cfg.Nudge.LifetimeFraction > 0 {
+		a.scheduler.Every("stale_transfer_nudge", cfg.Nudge.CheckInterval, nudgeService.Run)
+	}
+	if canaryService.Enabled() {
+		a.scheduler.Every("canary", cfg.Canary.Interval, canaryService.Run)
+	}
+	if cfg.Jobs.HistoryRetention > 0 {
+		a.scheduler.Every("job_run_purge", 24*time.Hour, a.scheduler.PurgeHistory)
+	}
+	a.scheduler.Every("initiation_job_purge", time.Hour, a.initiationQueue.Purge)
+	if cfg.Claims.RateLimitPerIP > 0 || cfg.Claims.RateLimitPerEmail > 0 {
+		a.scheduler.Every("claim_rate_purge", time.Hour, claimThrottle.Purge)
+	}
+
+	// 7. HANDLER LAYER (HTTP Interface)
+	userTokens, err := services.NewUserTokenVerifier(cfg, clk)
+	if err != nil {
+		return nil, err
+	}
+	transferHandler := handlers.NewTransferHandler(transferService, a.initiationQueue, claimThrottle, clk, cfg)
+	schemaHandler := handlers.NewSchemaHandler()
+	deadLetterHandler := handlers.NewDeadLetterHandler(deadLetterService)
+	sagaHandler := handlers.NewSagaHandler(sagaService)
+	healthHandler := handlers.NewHealthHandler(healthMonitor)
+	preferenceHandler := handlers.NewPreferenceHandler(preferenceService)
+	programHandler := handlers.NewProgramHandler(conversionTable)
+	reportHandler := handlers.NewReportHandler(escheatmentService, claimLatencyService, experimentService, volumeService)
+	metricsHandler := handlers.NewMetricsHandler(queryLogger, claimLatencyService, volumeService, rejectionMetrics, a.outboxDispatcher, canaryService)
+	claimPageHandler := handlers.NewClaimPageHandler(transferService, claimThrottle, clk, cfg)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	emailHandler := handlers.NewEmailHandler(emailQuota, emailArchiveService, services.NewEmailDiagnostics(cfg, clk), a.outboxDispatcher, cfg)
+	consentHandler := handlers.NewConsentHandler(consentService, transferService)
+	configHandler := handlers.NewConfigHandler(cfg)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	emailTemplateHandler := handlers.NewEmailTemplateHandler(emailTemplateService)
+	integrationHandler := handlers.NewIntegrationHandler(integrationFeed)
+	jobHandler := handlers.NewJobHandler(a.scheduler)
+
+	// 8. WEB SERVER CONFIGURATION
+	if cfg.Environment == "production" {
+		gin.SetMode(gin.ReleaseMode) // Optimized for production
+	}
+
+	// PUBLIC ROUTER: CORS for the frontend, load shedding on initiation
+	a.Public = gin.Default()
+	a.Public.Use(middleware.ErrorEnvelope()) // Errors recorded with c.Error -> {code, message, details}
+	if err := setupProxies(a.Public, cfg); err != nil {
+		return nil, err
+	}
+	setupCORS(a.Public, cfg)
+	setupPublicRoutes(a.Public, cfg, healthMonitor, userTokens, claimThrottle, captchaVerifier, transferHandler, schemaHandler, preferenceHandler, programHandler, claimPageHandler, notificationHandler, consentHandler, integrationHandler)
+
+	// INTERNAL ROUTER: Separate port, no CORS, per-group service/admin auth
+	a.Internal = gin.Default()
+	a.Internal.Use(middleware.ErrorEnvelope())
+	if err := setupProxies(a.Internal, cfg); err != nil {
+		return nil, err
+	}
+	setupInternalRoutes(a.Internal, cfg, transferHandler, deadLetterHandler, healthHandler, reportHandler, metricsHandler, emailHandler, consentHandler, sagaHandler, configHandler, webhookHandler, emailTemplateHandler, jobHandler)
+
+	return a, nil
+}
+
+// Start - Launches the outbox workers, scheduled jobs and async initiation pool until ctx is cancelled
+func (a *App) Start(ctx context.Context) {
+	// BACKGROUND WORKERS: Dispatcher drains transfer outboxes; relay delivers domain events in order
+	go a.outboxRelay.Start(ctx)
+	go a.outboxDispatcher.Start(ctx)
+
+	// SCHEDULED JOBS: Periodic background work
+	a.scheduler.Start(ctx)
+
+	// QUEUE-BACKED INITIATION: Worker pool for Prefer: respond-async / INITIATION_MODE=async
+	a.initiationQueue.Start(ctx)
+}
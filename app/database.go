@@ -0,0 +1,88 @@
+// DESIGN PATTERN: Factory Pattern (database connections)
+package app
+
+import (
+	"fmt"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/models"
+	"sender-service/repositories"
+	"sender-service/services"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// primaryDSN - PostgreSQL DSN for the primary database
+func primaryDSN(cfg *config.Config) string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+		cfg.Database.Host,
+		cfg.Database.User,
+		cfg.Database.Password,
+		cfg.Database.Name,
+		cfg.Database.Port,
+		cfg.Database.SSLMode,
+	)
+}
+
+// openTransferRepository - Opens every configured region's stores; the default region uses the primary
+// (or DB_SHARD_DSNS), other regions must list their own shards
+func openTransferRepository(cfg *config.Config, primary *gorm.DB, clk clock.Clock, healthMonitor *services.HealthMonitor, queryLogger *repositories.QueryLogger) (*repositories.TransferRepository, error) {
+	defaultStore, err := openRegionStore(cfg, cfg.Database.Region, primary, cfg.Database.ShardDSNs, cfg.Database.ReplicaDSNs, clk, healthMonitor, queryLogger)
+	if err != nil {
+		return nil, err
+	}
+	regionStores := map[string]repositories.RegionStore{cfg.Database.Region: defaultStore}
+	for region, regionDB := range cfg.Database.Regions {
+		if regionStores[region], err = openRegionStore(cfg, region, nil, regionDB.ShardDSNs, regionDB.ReplicaDSNs, clk, healthMonitor, queryLogger); err != nil {
+			return nil, fmt.Errorf("region %s: %w", region, err)
+		}
+	}
+	return repositories.NewRegionalTransferRepository(cfg.Database.Region, regionStores), nil
+}
+
+// openRegionStore - Opens a region's transfer shards (falling back to primary when none are listed) and replicas
+func openRegionStore(cfg *config.Config, region string, primary *gorm.DB, shardDSNs, replicaDSNs []string, clk clock.Clock, healthMonitor *services.HealthMonitor, queryLogger *repositories.QueryLogger) (repositories.RegionStore, error) {
+	store := repositories.RegionStore{}
+	if len(shardDSNs) == 0 {
+		store.Shards = []*gorm.DB{primary}
+	}
+	for i, shardDSN := range shardDSNs {
+		// SHARDING: Transfers live on the listed shards, routed by sender ID
+		shard, err := openDatabase(shardDSN, clk, healthMonitor, queryLogger)
+		if err != nil {
+			return store, err
+		}
+		if err := prepareSchema(shard, cfg, clk, fmt.Sprintf("region %s shard %d", region, i), models.ShardModels...); err != nil {
+			return store, err
+		}
+		store.Shards = append(store.Shards, shard)
+	}
+
+	// READ REPLICAS: History reads go to replicas unless a consistency token demands the primary
+	store.Replicas = make([]*gorm.DB, len(replicaDSNs))
+	for i, replicaDSN := range replicaDSNs {
+		if replicaDSN == "-" {
+			continue
+		}
+		replica, err := openDatabase(replicaDSN, clk, healthMonitor, queryLogger)
+		if err != nil {
+			return store, err
+		}
+		store.Replicas[i] = replica
+	}
+	return store, nil
+}
+
+// openDatabase - Connects with the injected clock, query logger and health plugin
+func openDatabase(dsn string, clk clock.Clock, healthMonitor *services.HealthMonitor, queryLogger *repositories.QueryLogger) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{NowFunc: clk.Now, Logger: queryLogger})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if err := db.Use(repositories.NewHealthPlugin(services.DependencyDatabase, healthMonitor)); err != nil {
+		return nil, fmt.Errorf("failed to register database health plugin: %w", err)
+	}
+	return db, nil
+}
@@ -0,0 +1,227 @@
+// DESIGN PATTERN: Front Controller Pattern (route tables) + Middleware Chain
+package app
+
+import (
+	"fmt"
+	"net/http"
+	_ "net/http/pprof" // Registers profiling handlers on http.DefaultServeMux
+	"sender-service/config"
+	"sender-service/handlers"
+	"sender-service/middleware"
+	"sender-service/services"
+	"sender-service/web"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupProxies - Trust forwarding headers only from configured proxies so c.ClientIP() is the real client
+func setupProxies(r *gin.Engine, cfg *config.Config) error {
+	var trusted []string // nil trusts no proxy: ClientIP() is the socket peer
+	if len(cfg.Proxy.TrustedProxies) > 0 {
+		trusted = cfg.Proxy.TrustedProxies
+	}
+	if err := r.SetTrustedProxies(trusted); err != nil {
+		return fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+	r.RemoteIPHeaders = cfg.Proxy.RemoteIPHeaders
+
+	switch cfg.Proxy.Platform {
+	case "":
+	case "cloudflare":
+		r.TrustedPlatform = gin.PlatformCloudflare
+	case "google":
+		r.TrustedPlatform = gin.PlatformGoogleAppEngine
+	case "flyio":
+		r.TrustedPlatform = gin.PlatformFlyIO
+	default:
+		return fmt.Errorf("unknown TRUSTED_PLATFORM: %s", cfg.Proxy.Platform)
+	}
+	return nil
+}
+
+// setupCORS - Middleware for Cross-Origin Resource Sharing
+func setupCORS(r *gin.Engine, cfg *config.Config) {
+	allowedHeaders := "Content-Type, Authorization, Accept-Language, Prefer, X-Consistency-Token, Idempotency-Key, X-Captcha-Token"
+	if cfg.UserAuth.AllowIDHeader {
+		allowedHeaders += ", X-User-ID" // Development fallback identification
+	}
+
+	r.Use(func(c *gin.Context) {
+		// Set CORS headers to allow frontend communication
+		c.Writer.Header().Set("Access-Control-Allow-Origin", cfg.Cors.AllowedOrigins)
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Consistency-Token, Location, Retry-After, Content-Language, Idempotent-Replayed, Link, X-Next-Cursor, X-Prev-Cursor")
+
+		// Handle preflight OPTIONS requests
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(204) // No Content response
+			return
+		}
+		c.Next()
+	})
+}
+
+// setupPublicRoutes - Public router: browser/app-facing endpoints (Front Controller Pattern)
+func setupPublicRoutes(r *gin.Engine, cfg *config.Config,
+	healthMonitor *services.HealthMonitor,
+	userTokens *services.UserTokenVerifier,
+	claimThrottle *services.ClaimThrottle,
+	captcha *services.CaptchaVerifier,
+	transferHandler *handlers.TransferHandler,
+	schemaHandler *handlers.SchemaHandler,
+	preferenceHandler *handlers.PreferenceHandler,
+	programHandler *handlers.ProgramHandler,
+	claimPageHandler *handlers.ClaimPageHandler,
+	notificationHandler *handlers.NotificationHandler,
+	consentHandler *handlers.ConsentHandler,
+	integrationHandler *handlers.IntegrationHandler) {
+	// USER AUTHENTICATION: Sender/user endpoints need an Auth Service access token; claim routes use
+	// their link token (and the Bearer header for claim assertions) instead
+	userAuth := middleware.UserAuth(userTokens, cfg.UserAuth.AllowIDHeader)
+
+	// SERVICE AUTHENTICATION: Completion by transfer ID finalizes a saga step, so only the receiver/claim
+	// service may call it (receivers themselves claim with their link token)
+	completionAuth := middleware.ServiceAuth(cfg.Internal.ServiceKeys, cfg.Internal.CompletionCallers...)
+
+	// LOAD SHEDDING: Only initiations are shed; claims/completions keep sagas finishing during outages
+	initiationGuards := []gin.HandlerFunc{userAuth}
+	if cfg.LoadShedding.Enabled {
+		initiationGuards = append(initiationGuards, middleware.LoadShedding(healthMonitor, cfg.LoadShedding.RetryAfter))
+	}
+
+	// CLAIM RATE LIMIT: Per-IP hourly budget for claim attempts, every other receiver action on the link
+	// (defer, consent, forward, decline) and landing page previews (the per-receiver budget is applied
+	// once the token resolves to a transfer)
+	claimGuards := []gin.HandlerFunc{middleware.ClaimRateLimit(claimThrottle)}
+	previewGuards := []gin.HandlerFunc{middleware.ClaimRateLimit(claimThrottle)}
+
+	// CAPTCHA: Optional bot check on claims (and, with CAPTCHA_PREVIEW, on the landing page), verified
+	// after the rate limit so throttled clients never reach the provider
+	if captcha != nil {
+		claimGuards = append(claimGuards, middleware.Captcha(captcha))
+		if cfg.Captcha.Preview {
+			previewGuards = append(previewGuards, middleware.Captcha(captcha))
+		}
+	}
+
+	// TRANSFER MANAGEMENT ENDPOINTS
+	r.POST("/transfer", append(initiationGuards, transferHandler.InitiateTransfer)...)                 // Create new transfer
+	r.POST("/transfers/bulk", append(initiationGuards, transferHandler.InitiateBulkTransfer)...)       // One transfer per receiver, all or nothing
+	r.GET("/transfer/jobs/:jobId", userAuth, transferHandler.GetInitiationJob)                         // Poll async initiation
+	r.GET("/transfer/:id", userAuth, transferHandler.GetTransfer)                                      // One transfer, sender only
+	r.GET("/transfers/:userId", userAuth, transferHandler.GetTransfers)                                // Get user's transfer history (self only)
+	r.POST("/transfer/:id/complete", completionAuth, transferHandler.CompleteTransfer)                 // Complete transfer (Saga step), trusted callers only
+	r.POST("/transfer/:id/extend", userAuth, transferHandler.ExtendTransfer)                           // Sender pushes expiry forward
+	r.POST("/transfer/claim/:token", append(claimGuards, transferHandler.ClaimTransfer)...)            // Receiver claims with the link token
+	r.POST("/transfer/claim/:token/defer", append(claimGuards, transferHandler.DeferClaim)...)         // Receiver saves the claim for later
+	r.PUT("/transfer/claim/:token/consent", append(claimGuards, consentHandler.UpdateClaimConsent)...) // Receiver marketing opt-in/out
+	r.POST("/transfer/claim/:token/forward", append(claimGuards, transferHandler.ForwardClaim)...)     // Receiver regifts to someone else
+	r.POST("/transfer/claim/:token/decline", append(claimGuards, transferHandler.DeclineClaim)...)     // Receiver turns the gift down
+	r.POST("/transfer/decline/:token", append(claimGuards, transferHandler.DeclineClaim)...)           // Same decline, for links built without /claim
+
+	// EMAIL PREVIEW: Render the receiver's claim email while the sender composes
+	r.POST("/emails/preview", userAuth, transferHandler.PreviewClaimEmail) // Subject + HTML, nothing sent
+	r.GET("/emails/themes", transferHandler.ListEmailThemes)               // Themes accepted as "theme"
+
+	// NOTIFICATION PREFERENCES: Digest opt-in
+	r.GET("/preferences/:userId", userAuth, preferenceHandler.GetPreferences)    // Current preferences
+	r.PUT("/preferences/:userId", userAuth, preferenceHandler.UpdatePreferences) // Update digest frequency
+
+	// IN-APP NOTIFICATIONS: Pending gifts for registered receivers
+	r.GET("/notifications/:userId", userAuth, notificationHandler.GetNotifications)               // List (?unread=true)
+	r.POST("/notifications/:userId/:id/read", userAuth, notificationHandler.MarkNotificationRead) // Mark read
+
+	// POINT PROGRAMS: Cross-program conversion table
+	r.GET("/programs/rates", programHandler.GetRates) // Configured conversion rates
+
+	// CLAIM LANDING PAGE: Optional server-rendered page with preloaded critical assets
+	if cfg.Frontend.LandingPage {
+		r.GET("/claim/:token", append(previewGuards, claimPageHandler.ClaimPage)...)     // Landing page for claim emails
+		r.Group("/static", handlers.StaticAssets()).StaticFS("/", http.FS(web.Static())) // CSS, logo
+	}
+
+	// ENGAGEMENT TRACKING: First open (pixel) and click (redirect) of claim emails, reported in transfer.completed
+	if cfg.Email.TrackingURL != "" {
+		r.GET("/track/:token/open.gif", claimPageHandler.TrackOpen) // Transparent 1x1 GIF
+		r.GET("/track/:token/click", claimPageHandler.TrackClick)   // 302 to the frontend claim page
+	}
+
+	// NO-CODE INTEGRATIONS: Polling feed for Zapier/IFTTT-style tools, per-integration X-API-Key
+	integrations := r.Group("/integrations", middleware.IntegrationAuth(cfg.Integrations.APIKeys))
+	integrations.GET("/events", integrationHandler.ListEvents) // Claims and expirations after ?since=
+
+	// EVENT CONTRACTS: Versioned schemas for published domain events
+	r.GET("/schemas", schemaHandler.ListSchemas)     // List schema definitions
+	r.GET("/schemas/:name", schemaHandler.GetSchema) // Fetch one definition (e.g. transfer.initiated.v1)
+}
+
+// setupInternalRoutes - Internal router: trusted services and operators only, never exposed publicly
+func setupInternalRoutes(r *gin.Engine, cfg *config.Config,
+	transferHandler *handlers.TransferHandler,
+	deadLetterHandler *handlers.DeadLetterHandler,
+	healthHandler *handlers.HealthHandler,
+	reportHandler *handlers.ReportHandler,
+	metricsHandler *handlers.MetricsHandler,
+	emailHandler *handlers.EmailHandler,
+	consentHandler *handlers.ConsentHandler,
+	sagaHandler *handlers.SagaHandler,
+	configHandler *handlers.ConfigHandler,
+	webhookHandler *handlers.WebhookHandler,
+	emailTemplateHandler *handlers.EmailTemplateHandler,
+	jobHandler *handlers.JobHandler) {
+	// INTERNAL ENDPOINTS: Trusted services (receiver service, fraud system) with per-service keys
+	internal := r.Group("/internal", middleware.ServiceAuth(cfg.Internal.ServiceKeys))
+	internal.PATCH("/transfer/:id/status", transferHandler.UpdateTransferStatus) // Reason-coded status change
+	internal.POST("/email/bounces", transferHandler.RecordEmailBounce)           // Provider bounce: flags sent claim emails as bounced
+	internal.GET("/consent/:email", consentHandler.GetConsent)                   // Marketing consent lookup
+	internal.PUT("/consent/:email", consentHandler.UpdateConsent)                // Record consent (e.g. at sign-up)
+
+	// ADMIN ENDPOINTS: Operational tooling guarded by X-Admin-Key
+	admin := r.Group("/admin", middleware.AdminAuth(cfg.Admin.APIKey))
+	admin.GET("/health/downstream", healthHandler.DownstreamHealth)                              // Load-shedding inputs
+	admin.GET("/config", configHandler.GetConfig)                                                // Effective config (secrets redacted) + validation report
+	admin.GET("/metrics/queries", metricsHandler.QueryMetrics)                                   // Per-query-type DB metrics
+	admin.GET("/metrics/claim-latency", metricsHandler.ClaimLatencyMetrics)                      // Live created->completed histogram
+	admin.GET("/metrics/transfer-volume", metricsHandler.TransferVolumeMetrics)                  // Live hourly transfers/points (48h)
+	admin.GET("/metrics/rejections", metricsHandler.RejectionMetrics)                            // Initiation/claim rejections by reason code (insufficient_points, self_transfer, ...)
+	admin.GET("/metrics/notifications", metricsHandler.NotificationMetrics)                      // Email circuit state + deferred claim notifications
+	admin.GET("/metrics/email-queue", metricsHandler.EmailQueueMetrics)                          // Durable email jobs: queued, retrying (with last error), dead
+	admin.GET("/metrics/canary", metricsHandler.CanaryMetrics)                                   // Synthetic end-to-end transfer health
+	admin.POST("/emails/requeue", emailHandler.RequeueEmails)                                    // Bulk re-drive of failed/retrying email jobs (status, from/to, provider, dry_run)
+	admin.GET("/email/quota", emailHandler.EmailQuota)                                           // Daily sends/remaining per SMTP provider
+	admin.POST("/email/test", emailHandler.TestEmail)                                            // Send a test message, report DNS/MX/connect/TLS/auth/send steps
+	admin.GET("/email/archive", emailHandler.ListArchivedEmails)                                 // Sent email copies (?recipient=&template=&from=&to=&limit=)
+	admin.GET("/email/archive/:id", emailHandler.DownloadArchivedEmail)                          // One copy as message/rfc822 (digest-checked)
+	admin.GET("/email/templates", emailTemplateHandler.ListEmailTemplates)                       // Built-ins, required variables, overrides
+	admin.POST("/email/templates/:name/lint", emailTemplateHandler.LintEmailTemplate)            // Validate without storing
+	admin.GET("/email/templates/:name/versions", emailTemplateHandler.ListEmailTemplateVersions) // Upload history (?tenant=)
+	admin.PUT("/email/templates/:name", emailTemplateHandler.UploadEmailTemplate)                // Save the next draft version (?tenant=)
+	admin.POST("/email/templates/:name/activate", emailTemplateHandler.ActivateEmailTemplate)    // Use a version for sends (?tenant=&version=, clean lint only)
+	admin.DELETE("/email/templates/:name", emailTemplateHandler.RevertEmailTemplate)             // Deactivate, fall back (?tenant=)
+	admin.GET("/dead-letters", deadLetterHandler.ListDeadLetters)                                // List dead letters
+	admin.GET("/dead-letters/:id", deadLetterHandler.GetDeadLetter)                              // Inspect reason + attempt history
+	admin.POST("/dead-letters/:id/retry", deadLetterHandler.RetryDeadLetter)                     // Re-drive
+	admin.POST("/dead-letters/:id/discard", deadLetterHandler.DiscardDeadLetter)                 // Drop
+	admin.GET("/webhooks", webhookHandler.ListWebhooks)                                          // Integrator subscriptions
+	admin.POST("/webhooks", webhookHandler.CreateWebhook)                                        // Register (url, event_types, template)
+	admin.PUT("/webhooks/:id", webhookHandler.UpdateWebhook)                                     // Replace, pause with active=false
+	admin.DELETE("/webhooks/:id", webhookHandler.DeleteWebhook)                                  // Unsubscribe
+	admin.GET("/webhooks/:id/deliveries", webhookHandler.ListDeliveries)                         // Delivery log (?status=pending|delivered|failed)
+	admin.GET("/jobs", jobHandler.ListJobs)                                                      // Scheduled jobs: cadence, next run, latest run
+	admin.GET("/jobs/:name/runs", jobHandler.ListJobRuns)                                        // Run history, newest first (?limit=)
+	admin.GET("/saga-steps", sagaHandler.ListSagaSteps)                                          // Sender refunds (?status=pending|failed)
+	admin.POST("/saga-steps/:id/retry", sagaHandler.RetrySagaStep)                               // Re-apply a stuck refund
+	admin.GET("/reports/escheatment", reportHandler.EscheatmentReport)                           // Unclaimed points (?period=&from=&to=&format=csv)
+	admin.GET("/reports/claim-latency", reportHandler.ClaimLatencyReport)                        // Claim latency histogram (?from=&to=)
+	admin.GET("/reports/transfer-volume", reportHandler.TransferVolumeReport)                    // Daily volume series + forecast (?days=)
+	admin.GET("/experiments", reportHandler.ListExperiments)                                     // Configured cohorts
+	admin.GET("/reports/experiments/:name", reportHandler.ExperimentReport)                      // Conversion per cohort (?from=&to=)
+	admin.GET("/transfers/:id", transferHandler.GetTransferAdmin)                                // Any transfer, no ownership check
+	admin.GET("/transfers/:id/chain", transferHandler.GetTransferChain)                          // Original + reissues/reversals/forwards
+	admin.GET("/transfers/:id/explain", transferHandler.ExplainTransfer)                         // Why it is stuck + allowed next actions
+
+	// PROFILING: net/http/pprof handlers, admin-only
+	admin.GET("/debug/pprof/*profile", gin.WrapH(http.StripPrefix("/admin", http.DefaultServeMux))) // CPU, heap, goroutine profiles
+}
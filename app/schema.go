@@ -0,0 +1,64 @@
+// DESIGN PATTERN: Template Method (migrate -> record version -> detect drift)
+package app
+
+import (
+	"fmt"
+	"log"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/models"
+	"sender-service/repositories"
+
+	"gorm.io/gorm"
+)
+
+// primaryModels - Tables on the primary database (transfers live here when the default region has no shards)
+var primaryModels = []interface{}{
+	&models.Transfer{}, &models.OutboxEvent{}, &models.DeadLetter{}, &models.NotificationPreference{},
+	&models.TransferAuditLog{}, &models.Notification{}, &models.EmailSendCount{}, &models.MarketingConsent{},
+	&models.SagaStep{}, &models.TransferOutboxMessage{}, &models.WebhookSubscription{},
+	&models.EmailTemplateOverride{}, &models.ArchivedEmail{}, &models.JobRun{}, &models.ClaimRateCounter{},
+	&models.RetiredClaimToken{}, &models.WebhookDelivery{}, &models.InitiationJob{}, &models.JobLastRun{},
+}
+
+// prepareSchema - Migrates (unless DB_AUTO_MIGRATE=false), records the schema version and reports
+// drift per DB_SCHEMA_DRIFT: warn logs it, fail refuses to start, off skips the check
+func prepareSchema(db *gorm.DB, cfg *config.Config, clk clock.Clock, label string, entities ...interface{}) error {
+	mode := cfg.Database.SchemaDrift
+	if mode != "warn" && mode != "fail" && mode != "off" {
+		return fmt.Errorf("unknown DB_SCHEMA_DRIFT: %s", mode)
+	}
+
+	// 1. MIGRATION: Bring tables up to the models and note the version they now match
+	if cfg.Database.AutoMigrate {
+		hashed, err := repositories.HashLegacyClaimTokens(db)
+		if err != nil {
+			return fmt.Errorf("failed to hash claim tokens on %s: %w", label, err)
+		}
+		if hashed > 0 {
+			log.Printf("Hashed %d plaintext claim token(s) on %s", hashed, label)
+		}
+		if err := db.AutoMigrate(entities...); err != nil {
+			return fmt.Errorf("failed to migrate %s: %w", label, err)
+		}
+		if err := repositories.RecordSchemaVersion(db, models.SchemaVersion, clk.Now()); err != nil {
+			return fmt.Errorf("failed to record %s schema version: %w", label, err)
+		}
+	}
+
+	// 2. DRIFT DETECTION: Catch what AutoMigrate cannot (dropped/renamed columns, other builds' migrations)
+	if mode == "off" {
+		return nil
+	}
+	drift, err := repositories.CheckSchema(db, models.SchemaVersion, entities...)
+	if err != nil {
+		return fmt.Errorf("failed to inspect %s schema: %w", label, err)
+	}
+	for _, difference := range drift {
+		log.Printf("Schema drift on %s: %s", label, difference)
+	}
+	if len(drift) > 0 && mode == "fail" {
+		return fmt.Errorf("%w on %s (%d differences)", repositories.ErrSchemaDrift, label, len(drift))
+	}
+	return nil
+}
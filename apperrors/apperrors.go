@@ -0,0 +1,75 @@
+// DESIGN PATTERN: Sentinel Errors + Error Taxonomy (stable codes for API clients)
+package apperrors
+
+import "errors"
+
+// Error - Domain error with a stable machine-readable code; its category decides the HTTP status
+type Error struct {
+	Code    string      // Stable identifier clients branch on (e.g. transfer_expired)
+	Message string      // Human-readable description (may change, never branch on it)
+	Details interface{} // Optional context (validation messages, lint reports, ...)
+	parent  *Error      // Broader error this one refines (nil for categories)
+}
+
+// Error - Implements the error interface with the human-readable message
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap - Lets errors.Is match the broader error and, through it, the category
+func (e *Error) Unwrap() error {
+	if e.parent == nil {
+		return nil
+	}
+	return e.parent
+}
+
+// WithMessage - Same code and category with a more specific message (e.g. one naming the bad item)
+func (e *Error) WithMessage(message string) *Error {
+	return &Error{Code: e.Code, Message: message, parent: e}
+}
+
+// New - Declares a domain error refining a category (or another domain error)
+func New(parent *Error, code, message string) *Error {
+	return &Error{Code: code, Message: message, parent: parent}
+}
+
+// Categories - Broad kinds of failure; the error middleware maps each to one HTTP status
+var (
+	ErrInvalidInput    = &Error{Code: "invalid_request", Message: "Invalid request data"}
+	ErrUnauthorized    = &Error{Code: "unauthorized", Message: "Authentication required"}
+	ErrForbidden       = &Error{Code: "forbidden", Message: "Access denied"}
+	ErrNotFound        = &Error{Code: "not_found", Message: "Resource not found"}
+	ErrConflict        = &Error{Code: "conflict", Message: "Request conflicts with the current state"}
+	ErrGone            = &Error{Code: "gone", Message: "Resource is no longer available"}
+	ErrUnprocessable   = &Error{Code: "unprocessable", Message: "Request cannot be processed"}
+	ErrLocked          = &Error{Code: "locked", Message: "Resource is locked"}
+	ErrTooManyRequests = &Error{Code: "too_many_requests", Message: "Too many requests, please retry later"}
+	ErrInternal        = &Error{Code: "internal_error", Message: "Internal server error"}
+	ErrUpstream        = &Error{Code: "upstream_failed", Message: "A downstream service failed"}
+	ErrUnavailable     = &Error{Code: "unavailable", Message: "Service is temporarily unavailable"}
+)
+
+// Domain errors shared across layers
+var (
+	ErrInsufficientPoints = New(ErrInvalidInput, "insufficient_points", "insufficient points")
+	ErrTransferExpired    = New(ErrGone, "transfer_expired", "claim link has expired")
+	ErrAuthRequired       = New(ErrUnauthorized, "authentication_required", "User authentication required")
+	ErrNotOwner           = New(ErrForbidden, "not_owner", "You can only access your own data")
+)
+
+// WithDetails - Attaches context to any error, keeping its code, category and full message
+func WithDetails(err error, details interface{}) *Error {
+	found := From(err)
+	return &Error{Code: found.Code, Message: err.Error(), Details: details, parent: found}
+}
+
+// From - Most specific domain error in err's chain; unclassified errors become internal errors
+// carrying the original message
+func From(err error) *Error {
+	var domain *Error
+	if errors.As(err, &domain) {
+		return domain
+	}
+	return &Error{Code: ErrInternal.Code, Message: err.Error(), parent: ErrInternal}
+}
@@ -0,0 +1,123 @@
+// DESIGN PATTERN: Adapter (binding/validator errors -> field-level API errors)
+package apperrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError - One rejected input, addressed by the name the client sent (JSON key or query parameter)
+type FieldError struct {
+	Field   string `json:"field"`   // e.g. receiver_email, transfers[2].points ("" when the body is unreadable)
+	Rule    string `json:"rule"`    // Failed rule (required, email, max, type, malformed, ...)
+	Message string `json:"message"` // Human-readable explanation for display next to the input
+}
+
+// Invalid - Request binding failure (body or query) with one FieldError per rejected input as details
+func Invalid(message string, cause error) *Error {
+	return &Error{Code: ErrInvalidInput.Code, Message: message, Details: FieldErrors(cause), parent: ErrInvalidInput}
+}
+
+// FieldErrors - Translates gin binding errors into field-level errors
+func FieldErrors(err error) []FieldError {
+	// 1. VALIDATION RULES: One entry per failed binding tag
+	var invalid validator.ValidationErrors
+	if errors.As(err, &invalid) {
+		fields := make([]FieldError, 0, len(invalid))
+		for _, failure := range invalid {
+			field := fieldPath(failure)
+			fields = append(fields, FieldError{Field: field, Rule: failure.Tag(), Message: ruleMessage(field, failure)})
+		}
+		return fields
+	}
+
+	// 2. WRONG JSON TYPE: e.g. "points": "ten"
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return []FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: fmt.Sprintf("%s must be %s", typeErr.Field, jsonType(typeErr.Type)),
+		}}
+	}
+
+	// 3. UNREADABLE INPUT: Empty or malformed body, unparseable query values
+	if errors.Is(err, io.EOF) {
+		return []FieldError{{Rule: "malformed", Message: "request body must be a JSON object"}}
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return []FieldError{{Rule: "malformed", Message: "request body is not valid JSON"}}
+	}
+	return []FieldError{{Rule: "malformed", Message: err.Error()}}
+}
+
+// fieldPath - Namespace without the root struct (names come from the tag name func handlers register)
+func fieldPath(failure validator.FieldError) string {
+	if _, path, ok := strings.Cut(failure.Namespace(), "."); ok {
+		return path
+	}
+	return failure.Field()
+}
+
+// ruleMessage - Explanation of the common binding rules; others name the rule
+func ruleMessage(field string, failure validator.FieldError) string {
+	param := failure.Param()
+	switch failure.Tag() {
+	case "required":
+		return field + " is required"
+	case "required_without":
+		return fmt.Sprintf("%s is required when %s is not provided", field, strings.ToLower(param))
+	case "email":
+		return field + " must be a valid email address"
+	case "url":
+		return field + " must be a valid URL"
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, strings.Join(strings.Fields(param), ", "))
+	case "min":
+		return fmt.Sprintf("%s must be at least %s%s", field, param, sizeUnit(failure.Kind()))
+	case "max":
+		return fmt.Sprintf("%s must be at most %s%s", field, param, sizeUnit(failure.Kind()))
+	case "len":
+		return fmt.Sprintf("%s must be exactly %s%s", field, param, sizeUnit(failure.Kind()))
+	}
+	if param != "" {
+		return fmt.Sprintf("%s failed the %s=%s rule", field, failure.Tag(), param)
+	}
+	return fmt.Sprintf("%s failed the %s rule", field, failure.Tag())
+}
+
+// sizeUnit - What min/max/len count for a field of this kind (numbers compare by value)
+func sizeUnit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	}
+	return ""
+}
+
+// jsonType - JSON name for the Go type a value failed to decode into
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	}
+	return "an object"
+}
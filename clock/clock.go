@@ -0,0 +1,60 @@
+// DESIGN PATTERN: Strategy Pattern (time source) + Null Object for tests
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock - Abstracts the current time so expiry, scheduling and ID generation are testable
+type Clock interface {
+	Now() time.Time
+}
+
+// Real - Production clock backed by time.Now
+type Real struct{}
+
+// Now - Current wall-clock time
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// Frozen - Deterministic clock that only moves when told to (test mode)
+type Frozen struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFrozen - Factory method for a clock stopped at the given instant
+func NewFrozen(at time.Time) *Frozen {
+	return &Frozen{now: at}
+}
+
+// Now - The frozen instant
+func (f *Frozen) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set - Moves the clock to an absolute instant
+func (f *Frozen) Set(at time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = at
+}
+
+// Advance - Moves the clock forward (e.g. past a transfer's expiry)
+func (f *Frozen) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// New - Factory method: frozen at frozenAt when set (RFC 3339), real clock otherwise
+func New(frozenAt time.Time) Clock {
+	if frozenAt.IsZero() {
+		return Real{}
+	}
+	return NewFrozen(frozenAt)
+}
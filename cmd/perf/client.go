@@ -0,0 +1,112 @@
+// DESIGN PATTERN: Gateway (minimal typed client for the public API)
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// apiClient - Calls the sender service as a sender would, reusing connections across workers
+type apiClient struct {
+	baseURL string       // e.g. https://staging.example.com
+	token   string       // Auth Service access token of the load-test sender
+	http    *http.Client // Shared client; the pool is sized to the worker count
+}
+
+// apiResult - Outcome of one call as the load test sees it
+type apiResult struct {
+	Status  int           // HTTP status (0 when the request never got a response)
+	Code    string        // Error envelope code, or the transport error class
+	Latency time.Duration // Request start to body fully read
+}
+
+// newAPIClient - Keep-alive pool with one idle connection per worker, so the test measures the
+// service rather than TCP/TLS handshakes
+func newAPIClient(baseURL, token string, workers int, timeout time.Duration) *apiClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = workers
+	transport.MaxIdleConnsPerHost = workers
+	return &apiClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		http:    &http.Client{Transport: transport, Timeout: timeout},
+	}
+}
+
+// initiate - POST /transfer with a unique Idempotency-Key
+func (c *apiClient) initiate(ctx context.Context, receiverEmail string, points int, idempotencyKey string) apiResult {
+	body, _ := json.Marshal(map[string]interface{}{
+		"receiver_email": receiverEmail,
+		"receiver_name":  "Perf Receiver",
+		"points":         points,
+		"message":        "Capacity check",
+	})
+	return c.do(ctx, http.MethodPost, "/transfer", body, map[string]string{"Idempotency-Key": idempotencyKey})
+}
+
+// history - GET /transfers/:userId (first page)
+func (c *apiClient) history(ctx context.Context, userID string) apiResult {
+	return c.do(ctx, http.MethodGet, "/transfers/"+url.PathEscape(userID)+"?limit=20", nil, nil)
+}
+
+// do - Sends one request and classifies the outcome
+func (c *apiClient) do(ctx context.Context, method, path string, body []byte, headers map[string]string) apiResult {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return apiResult{Code: "invalid_request"}
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	started := time.Now()
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return apiResult{Code: transportErrorCode(ctx, err), Latency: time.Since(started)}
+	}
+	defer resp.Body.Close()
+	payload, err := io.ReadAll(resp.Body)
+	result := apiResult{Status: resp.StatusCode, Latency: time.Since(started)}
+	if err != nil {
+		result.Code = "read_error"
+		return result
+	}
+
+	// ERROR ENVELOPE: {"success": false, "code": "..."} on every failed request
+	if resp.StatusCode >= 400 {
+		var envelope struct {
+			Code string `json:"code"`
+		}
+		if json.Unmarshal(payload, &envelope) != nil || envelope.Code == "" {
+			envelope.Code = fmt.Sprintf("http_%d", resp.StatusCode)
+		}
+		result.Code = envelope.Code
+	}
+	return result
+}
+
+// transportErrorCode - Groups network failures into a few labels for the report
+func transportErrorCode(ctx context.Context, err error) string {
+	switch {
+	case ctx.Err() != nil:
+		return "cancelled"
+	case strings.Contains(err.Error(), "Client.Timeout"):
+		return "timeout"
+	case strings.Contains(err.Error(), "connection refused"):
+		return "connection_refused"
+	case strings.Contains(err.Error(), "connection reset"):
+		return "connection_reset"
+	}
+	return "transport_error"
+}
@@ -0,0 +1,232 @@
+// DESIGN PATTERN: Command Pattern (pre-release capacity check)
+//
+// perf runs a closed-loop load test against a running instance and reports throughput, latency
+// percentiles and error rates.
+//
+//	go run ./cmd/perf -target https://staging.example.com -token "$SENDER_JWT" -user user_123 \
+//	    [-scenario initiate|history] [-rps 50] [-duration 1m] [-workers 20] [-max-error-rate 0.01] [-max-p99 1s]
+//
+// Each worker sends its next request only after the previous one answered, and a shared pacer
+// caps the total at -rps (0 = as fast as the workers go). A saturated service therefore shows up
+// as achieved RPS below target rather than as an unbounded queue. The initiate scenario creates
+// real pending transfers to -receiver-domain addresses: run it against staging, with limits and
+// auto-complete configured as in production. Exits 1 when a threshold is exceeded.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+func main() {
+	target := flag.String("target", "http://localhost:8002", "Base URL of the instance under test")
+	token := flag.String("token", os.Getenv("PERF_TOKEN"), "Access token of the load-test sender (default $PERF_TOKEN)")
+	userID := flag.String("user", "", "User ID in the token (history scenario)")
+	scenario := flag.String("scenario", "initiate", "initiate (POST /transfer) or history (GET /transfers/:userId)")
+	rps := flag.Float64("rps", 20, "Target requests per second across all workers (0 = unpaced)")
+	duration := flag.Duration("duration", 30*time.Second, "How long to send requests")
+	workers := flag.Int("workers", 10, "Concurrent closed-loop workers (also the connection pool size)")
+	timeout := flag.Duration("timeout", 10*time.Second, "Per-request timeout")
+	points := flag.Int("points", 1, "Points per initiated transfer")
+	receiverDomain := flag.String("receiver-domain", "perf.invalid", "Domain of the generated receiver addresses")
+	maxErrorRate := flag.Float64("max-error-rate", 0.01, "Fail when more than this fraction of requests fail (0 disables)")
+	maxP99 := flag.Duration("max-p99", 0, "Fail when the 99th percentile latency exceeds this (0 disables)")
+	flag.Parse()
+
+	if *token == "" {
+		log.Fatal("-token (or PERF_TOKEN) is required")
+	}
+	if *workers < 1 || *duration <= 0 {
+		log.Fatal("-workers must be at least 1 and -duration positive")
+	}
+	client := newAPIClient(*target, *token, *workers, *timeout)
+
+	// 1. SCENARIO: One call per iteration; run IDs keep receivers and idempotency keys unique
+	runID := time.Now().UTC().Format("20060102T150405")
+	var call func(ctx context.Context, n int64) apiResult
+	switch *scenario {
+	case "initiate":
+		call = func(ctx context.Context, n int64) apiResult {
+			receiver := fmt.Sprintf("perf+%s-%d@%s", runID, n, *receiverDomain)
+			return client.initiate(ctx, receiver, *points, fmt.Sprintf("perf-%s-%d", runID, n))
+		}
+	case "history":
+		if *userID == "" {
+			log.Fatal("-user is required for the history scenario")
+		}
+		call = func(ctx context.Context, n int64) apiResult { return client.history(ctx, *userID) }
+	default:
+		log.Fatalf("unknown -scenario %q (initiate or history)", *scenario)
+	}
+
+	// 2. LOAD: Closed-loop workers drawing send slots from the pacer until the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), *duration)
+	defer cancel()
+	slots := pace(ctx, *rps)
+
+	log.Printf("perf: %s against %s for %s, %d workers, target %s", *scenario, *target, *duration, *workers, describeRate(*rps))
+	var (
+		sequence int64
+		mu       sync.Mutex
+		results  []apiResult
+		wg       sync.WaitGroup
+	)
+	started := time.Now()
+	for i := 0; i < *workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range slots {
+				result := call(context.Background(), atomic.AddInt64(&sequence, 1))
+				mu.Lock()
+				results = append(results, result)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(started)
+
+	// 3. REPORT: Throughput, outcome mix and latency distribution
+	report := summarize(results, elapsed)
+	report.print(*rps)
+	if failed := report.check(*maxErrorRate, *maxP99); len(failed) > 0 {
+		for _, reason := range failed {
+			log.Printf("perf: FAIL %s", reason)
+		}
+		os.Exit(1)
+	}
+	log.Printf("perf: PASS")
+}
+
+// pace - Send slots at rps (unbuffered, so a slot is only taken by an idle worker); closed when ctx ends
+func pace(ctx context.Context, rps float64) <-chan struct{} {
+	slots := make(chan struct{})
+	go func() {
+		defer close(slots)
+		var ticker *time.Ticker
+		if rps > 0 {
+			ticker = time.NewTicker(time.Duration(float64(time.Second) / rps))
+			defer ticker.Stop()
+		}
+		for {
+			if ticker != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case slots <- struct{}{}:
+			}
+		}
+	}()
+	return slots
+}
+
+// perfReport - Aggregated results of one run
+type perfReport struct {
+	Requests  int             // Calls completed
+	Errors    int             // Transport failures and HTTP >= 400
+	Elapsed   time.Duration   // Wall time of the run
+	Outcomes  map[string]int  // "201", "422 daily_limit_exceeded", "timeout", ...
+	Latencies []time.Duration // Sorted ascending
+}
+
+// summarize - Builds the report from raw results
+func summarize(results []apiResult, elapsed time.Duration) *perfReport {
+	report := &perfReport{Requests: len(results), Elapsed: elapsed, Outcomes: map[string]int{}}
+	for _, result := range results {
+		label := fmt.Sprintf("%d", result.Status)
+		switch {
+		case result.Status == 0:
+			label = result.Code
+		case result.Code != "":
+			label += " " + result.Code
+		}
+		report.Outcomes[label]++
+		if result.Status == 0 || result.Status >= 400 {
+			report.Errors++
+		}
+		report.Latencies = append(report.Latencies, result.Latency)
+	}
+	sort.Slice(report.Latencies, func(i, j int) bool { return report.Latencies[i] < report.Latencies[j] })
+	return report
+}
+
+// percentile - Nearest-rank percentile of the sorted latencies
+func (r *perfReport) percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	rank := int(p/100*float64(len(r.Latencies))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(r.Latencies) {
+		rank = len(r.Latencies) - 1
+	}
+	return r.Latencies[rank]
+}
+
+// errorRate - Fraction of failed requests
+func (r *perfReport) errorRate() float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Requests)
+}
+
+// print - Human-readable summary on stdout
+func (r *perfReport) print(targetRPS float64) {
+	achieved := float64(r.Requests) / r.Elapsed.Seconds()
+	fmt.Printf("requests     %d in %s (%.1f req/s, target %s)\n", r.Requests, r.Elapsed.Round(time.Millisecond), achieved, describeRate(targetRPS))
+	fmt.Printf("errors       %d (%.2f%%)\n", r.Errors, 100*r.errorRate())
+	fmt.Printf("latency      p50 %s  p90 %s  p95 %s  p99 %s  max %s\n",
+		r.percentile(50), r.percentile(90), r.percentile(95), r.percentile(99), r.percentile(100))
+
+	labels := make([]string, 0, len(r.Outcomes))
+	for label := range r.Outcomes {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		fmt.Printf("outcome      %-40s %d\n", label, r.Outcomes[label])
+	}
+	if targetRPS > 0 && achieved < 0.9*targetRPS {
+		fmt.Printf("note         achieved rate is below 90%% of target: workers were waiting on responses (raise -workers or see the tuning guide)\n")
+	}
+}
+
+// check - Threshold violations (empty when the run passes)
+func (r *perfReport) check(maxErrorRate float64, maxP99 time.Duration) []string {
+	var failed []string
+	if r.Requests == 0 {
+		failed = append(failed, "no requests completed")
+	}
+	if maxErrorRate > 0 && r.errorRate() > maxErrorRate {
+		failed = append(failed, fmt.Sprintf("error rate %.2f%% above %.2f%%", 100*r.errorRate(), 100*maxErrorRate))
+	}
+	if p99 := r.percentile(99); maxP99 > 0 && p99 > maxP99 {
+		failed = append(failed, fmt.Sprintf("p99 latency %s above %s", p99, maxP99))
+	}
+	return failed
+}
+
+// describeRate - "50 req/s" or "unpaced"
+func describeRate(rps float64) string {
+	if rps <= 0 {
+		return "unpaced"
+	}
+	return fmt.Sprintf("%g req/s", rps)
+}
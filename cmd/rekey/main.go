@@ -0,0 +1,82 @@
+// DESIGN PATTERN: Command Pattern (offline maintenance tool)
+//
+// rekey re-encrypts archived email copies with the primary EMAIL_ARCHIVE_ENCRYPTION_KEYS key after a
+// rotation (plaintext copies from before encryption was enabled are encrypted too).
+//
+//	go run ./cmd/rekey [-batch 200] [-dry-run]
+//
+// It reads the service's own configuration (environment / .env). Keep the retiring key listed after
+// the new primary until the run reports no retained copies, then remove it. Safe to re-run.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/idgen"
+	"sender-service/models"
+	"sender-service/repositories"
+	"sender-service/services"
+	"sort"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func main() {
+	batchSize := flag.Int("batch", 200, "Archived emails re-encrypted per batch")
+	dryRun := flag.Bool("dry-run", false, "Only report how many copies each key protects")
+	flag.Parse()
+
+	cfg := config.LoadConfig()
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
+		cfg.Database.Host, cfg.Database.User, cfg.Database.Password, cfg.Database.Name, cfg.Database.Port, cfg.Database.SSLMode)
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("connect %s/%s: %v", cfg.Database.Host, cfg.Database.Name, err)
+	}
+	if err := db.AutoMigrate(&models.ArchivedEmail{}); err != nil {
+		log.Fatalf("migrate archived_emails: %v", err)
+	}
+	repo := repositories.NewEmailArchiveRepository(db)
+
+	if *dryRun {
+		counts, err := repo.CountByKey()
+		if err != nil {
+			log.Fatalf("count archived emails: %v", err)
+		}
+		keyIDs := make([]string, 0, len(counts))
+		for keyID := range counts {
+			keyIDs = append(keyIDs, keyID)
+		}
+		sort.Strings(keyIDs)
+		for _, keyID := range keyIDs {
+			label := keyID
+			if label == "" {
+				label = "(plaintext)"
+			}
+			log.Printf("Dry run: %d archived email(s) under %s", counts[keyID], label)
+		}
+		return
+	}
+
+	clk := clock.Real{}
+	store, err := services.NewEmailArchiveStore(cfg, clk)
+	if err != nil {
+		log.Fatalf("invalid email archive settings: %v", err)
+	}
+	archive, err := services.NewEmailArchiveService(repo, store, clk, idgen.NewRandomGenerator(idgen.MinTokenBytes), cfg)
+	if err != nil {
+		log.Fatalf("invalid email archive settings: %v", err)
+	}
+
+	result, err := archive.Rekey(context.Background(), *batchSize)
+	if err != nil {
+		log.Fatalf("rekey: %v", err)
+	}
+	log.Printf("Rekey complete: %d copies re-encrypted with %q, %d failed, %d still under other keys",
+		result.Rekeyed, result.KeyID, result.Failed, result.Retained)
+}
@@ -0,0 +1,209 @@
+// DESIGN PATTERN: Command Pattern (offline maintenance tool)
+//
+// reshard moves transfers to the shard that owns them under a new shard layout, together with
+// their shard outbox messages (pending claim notifications carry the raw claim token) and
+// retired claim tokens.
+//
+//	go run ./cmd/reshard -from "dsnA,dsnB" -to "dsnA,dsnB,dsnC" [-batch 500] [-dry-run]
+//
+// Rows are copied to their new shard (idempotent) before being deleted from the old one, so the
+// tool can be re-run safely after an interruption. Stop writers and relays before running it.
+package main
+
+import (
+	"flag"
+	"log"
+	"sender-service/models"
+	"sender-service/repositories"
+	"strings"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func main() {
+	from := flag.String("from", "", "Comma-separated DSNs of the current shard layout")
+	to := flag.String("to", "", "Comma-separated DSNs of the target shard layout")
+	batchSize := flag.Int("batch", 500, "Transfers moved per batch")
+	dryRun := flag.Bool("dry-run", false, "Only report how many transfers would move")
+	flag.Parse()
+
+	fromDSNs, toDSNs := splitDSNs(*from), splitDSNs(*to)
+	if len(fromDSNs) == 0 || len(toDSNs) == 0 {
+		log.Fatal("both -from and -to are required")
+	}
+
+	targets := make([]*gorm.DB, len(toDSNs))
+	for i, dsn := range toDSNs {
+		targets[i] = open(dsn)
+		if !*dryRun {
+			if err := targets[i].AutoMigrate(models.ShardModels...); err != nil {
+				log.Fatalf("migrate %s: %v", redact(dsn), err)
+			}
+		}
+	}
+
+	moved := 0
+	for _, sourceDSN := range fromDSNs {
+		source := open(sourceDSN)
+		lastID := ""
+
+		for {
+			var batch []models.Transfer
+			// GORM: SELECT * FROM transfers WHERE id > ? ORDER BY id LIMIT ? (keyset pagination)
+			if err := source.Where("id > ?", lastID).Order("id ASC").Limit(*batchSize).Find(&batch).Error; err != nil {
+				log.Fatalf("read %s: %v", redact(sourceDSN), err)
+			}
+			if len(batch) == 0 {
+				break
+			}
+			lastID = batch[len(batch)-1].ID
+
+			for i := range batch {
+				transfer := &batch[i]
+				targetIndex := repositories.ShardIndex(transfer.SenderID, len(toDSNs))
+				if toDSNs[targetIndex] == sourceDSN {
+					continue // Already on its owning shard
+				}
+
+				moved++
+				if *dryRun {
+					continue
+				}
+
+				if err := moveTransfer(source, targets[targetIndex], transfer); err != nil {
+					log.Fatalf("move %s from %s to shard %d: %v", transfer.ID, redact(sourceDSN), targetIndex, err)
+				}
+			}
+		}
+
+		// ORPHANED TOKENS: Tombstones of deleted transfers have no sender to route by; they only
+		// need to survive somewhere, so move them off a shard that leaves the layout
+		if !contains(toDSNs, sourceDSN) && !*dryRun {
+			if err := moveOrphanedTokens(source, targets); err != nil {
+				log.Fatalf("move retired tokens from %s: %v", redact(sourceDSN), err)
+			}
+		}
+	}
+
+	if *dryRun {
+		log.Printf("Dry run: %d transfers would move", moved)
+		return
+	}
+	log.Printf("Resharding complete: %d transfers moved", moved)
+}
+
+// moveTransfer - Copies a transfer with its outbox messages and retired tokens to the target shard,
+// then deletes them from the source
+func moveTransfer(source, target *gorm.DB, transfer *models.Transfer) error {
+	var messages []models.TransferOutboxMessage
+	if err := source.Where("transfer_id = ?", transfer.ID).Order("id ASC").Find(&messages).Error; err != nil {
+		return err
+	}
+	var tokens []models.RetiredClaimToken
+	if err := source.Where("transfer_id = ?", transfer.ID).Find(&tokens).Error; err != nil {
+		return err
+	}
+
+	// 1. COPY: Outbox IDs are per-shard sequences, so messages get fresh IDs (same order) and any
+	// copies left by an interrupted run are replaced rather than duplicated
+	err := target.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(transfer).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("transfer_id = ?", transfer.ID).Delete(&models.TransferOutboxMessage{}).Error; err != nil {
+			return err
+		}
+		for i := range messages {
+			message := messages[i]
+			message.ID = 0
+			if err := tx.Create(&message).Error; err != nil {
+				return err
+			}
+		}
+		if len(tokens) > 0 {
+			return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tokens).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// 2. DELETE: Only once the target holds everything
+	return source.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("transfer_id = ?", transfer.ID).Delete(&models.TransferOutboxMessage{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("transfer_id = ?", transfer.ID).Delete(&models.RetiredClaimToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Transfer{}, "id = ?", transfer.ID).Error
+	})
+}
+
+// moveOrphanedTokens - Moves the remaining retired tokens of a shard that is leaving the layout,
+// spread by transfer ID (token uniqueness is checked on every shard, so any shard will do)
+func moveOrphanedTokens(source *gorm.DB, targets []*gorm.DB) error {
+	for {
+		var tokens []models.RetiredClaimToken
+		if err := source.Order("token_hash ASC").Limit(500).Find(&tokens).Error; err != nil {
+			return err
+		}
+		if len(tokens) == 0 {
+			return nil
+		}
+		for i := range tokens {
+			token := &tokens[i]
+			target := targets[repositories.ShardIndex(token.TransferID, len(targets))]
+			if err := target.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error; err != nil {
+				return err
+			}
+			if err := source.Delete(&models.RetiredClaimToken{}, "token_hash = ?", token.TokenHash).Error; err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// contains - Whether a DSN is part of a layout
+func contains(dsns []string, dsn string) bool {
+	for _, candidate := range dsns {
+		if candidate == dsn {
+			return true
+		}
+	}
+	return false
+}
+
+// open - Connects to one shard
+func open(dsn string) *gorm.DB {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("connect %s: %v", redact(dsn), err)
+	}
+	return db
+}
+
+// splitDSNs - Parses a comma-separated DSN list
+func splitDSNs(list string) []string {
+	dsns := []string{}
+	for _, dsn := range strings.Split(list, ",") {
+		if dsn = strings.TrimSpace(dsn); dsn != "" {
+			dsns = append(dsns, dsn)
+		}
+	}
+	return dsns
+}
+
+// redact - Hides passwords in log output
+func redact(dsn string) string {
+	fields := strings.Fields(dsn)
+	for i, field := range fields {
+		if strings.HasPrefix(field, "password=") {
+			fields[i] = "password=***"
+		}
+	}
+	return strings.Join(fields, " ")
+}
@@ -4,19 +4,54 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 // Config - Centralized configuration container for sender service
 type Config struct {
-	Port        string         // Service port (8002)
-	Environment string         // Runtime environment
-	Database    DatabaseConfig // Database configuration
-	AuthService string         // URL for Auth Service (Service Integration)
-	Email       EmailConfig    // Email service configuration (Strategy Pattern)
-	Frontend    FrontendConfig // Frontend application configuration
-	Cors        CorsConfig     // CORS settings
+	Port          string              // Service port (8002)
+	InternalPort  string              // Internal/admin API port (8102), never exposed publicly
+	Environment   string              // Runtime environment
+	Database      DatabaseConfig      // Database configuration
+	AuthService   string              // URL for Auth Service (Service Integration)
+	Email         EmailConfig         // Email service configuration (Strategy Pattern)
+	EmailArchive  EmailArchiveConfig  // Audit copies of sent emails
+	Frontend      FrontendConfig      // Frontend application configuration
+	Proxy         ProxyConfig         // Trusted proxies for real client IP extraction
+	Cors          CorsConfig          // CORS settings
+	Events        EventsConfig        // Domain event publishing
+	Outbox        OutboxConfig        // Outbox relay tuning
+	Internal      InternalConfig      // Trusted service-to-service API
+	Admin         AdminConfig         // Operations/admin API settings
+	Integrations  IntegrationsConfig  // Polling feed for no-code tools
+	LoadShedding  LoadSheddingConfig  // Adaptive rejection of initiations during downstream trouble
+	Initiation    InitiationConfig    // Sync vs queue-backed initiation
+	Notifications NotificationsConfig // Claim notification channels
+	Digest        DigestConfig        // Sender summary emails
+	ContentScan   ContentScanConfig   // URL reputation checks on sender messages
+	Hooks         HooksConfig         // Custom validation hooks
+	Policy        PolicyConfig        // Open Policy Agent integration
+	Claims        ClaimsConfig        // Claim protection rules
+	Captcha       CaptchaConfig       // Bot checks on the public claim endpoints
+	Limits        LimitsConfig        // Per-transfer and per-sender point limits
+	Assertions    AssertionsConfig    // Signed receiver assertions (JWT) on completion
+	UserAuth      UserAuthConfig      // Access tokens (JWT) identifying senders and users
+	Programs      ProgramsConfig      // Point programs and conversion rates
+	Escheatment   EscheatmentConfig   // Unclaimed points reporting
+	Nudge         NudgeConfig         // Stale pending transfer reminders
+	Experiments   string              // JSON experiment definitions (cohorts for expiry/reminder timing)
+	Promo         PromoConfig         // Promotional block for consenting receivers
+	Expiration    ExpirationConfig    // Background expiry sweeper
+	Saga          SagaConfig          // Compensation of half-finished claims
+	Canary        CanaryConfig        // Synthetic end-to-end transfer self-test
+	Jobs          JobsConfig          // Cron schedules, enable flags and run history of periodic jobs
+	Testing       TestingConfig       // Test-mode switches (never enable in production)
+
+	Sources map[string]string // Environment variable -> env, file, default or invalid (see /admin/config)
 }
 
 // DatabaseConfig - Encapsulates database connection details
@@ -27,20 +62,74 @@ type DatabaseConfig struct {
 	User     string // Database username
 	Password string // Database password
 	SSLMode  string // SSL mode for secure connection
+
+	ShardDSNs   []string // Optional transfer shards (sharded by sender ID); primary DB keeps outbox/admin tables
+	ReplicaDSNs []string // Optional read replicas, aligned with shards (or the primary when unsharded)
+
+	Region  string                          // Residency region of the primary/DB_SHARD_DSNS transfers (default for unknown regions)
+	Regions map[string]RegionDatabaseConfig // Additional regions whose transfers live in their own databases
+
+	SlowQueryThreshold time.Duration // Queries slower than this are logged with their caller
+	LogLevel           string        // GORM log level: silent, error, warn, info
+
+	AutoMigrate bool   // Run AutoMigrate at startup (disable where migrations are applied out of band)
+	SchemaDrift string // Startup drift check: warn, fail or off
+}
+
+// RegionDatabaseConfig - Transfer databases of one data residency region
+type RegionDatabaseConfig struct {
+	ShardDSNs   []string // Transfer shards of the region (sharded by sender ID)
+	ReplicaDSNs []string // Optional read replicas, aligned with the region's shards
 }
 
 // EmailConfig - Encapsulates email service configuration (Strategy Pattern)
 type EmailConfig struct {
+	Provider     string // Delivery backend: smtp, sendgrid, ses or mailgun
 	GmailAddress string // Gmail account for sending emails
 	GmailAppPass string // Gmail app password
 	From         string // Sender email address
 	SMTPHost     string // SMTP server host
 	SMTPPort     string // SMTP server port
+
+	APIKey        string // SendGrid or Mailgun API key
+	APIURL        string // API base URL override (Mailgun EU region, proxies, test doubles)
+	MailgunDomain string // Mailgun sending domain
+	SESRegion     string // AWS region of the SES endpoint
+	SESAccessKey  string // AWS access key ID allowed ses:SendEmail
+	SESSecretKey  string // AWS secret access key
+
+	RateLimits   map[string]string // SMTP host -> "count/duration" (overrides built-in provider caps)
+	RateBurst    int               // Sends allowed back-to-back before pacing
+	QueueSize    int               // Queued deliveries per provider before rejecting
+	QuotaDeferAt float64           // Share of the daily quota after which digests/reminders are deferred
+
+	TemplateRefreshInterval time.Duration // How often activated template overrides are reloaded (0 = startup only)
+	TemplateDir             string        // Directory of <name>.html / <name>.subject.txt files replacing the built-ins (empty = built-ins)
+	TrackingURL             string        // Public base URL of this service for open/click tracking (empty disables)
+}
+
+// EmailArchiveConfig - Encapsulates audit copies of every sent email (compliance, support disputes)
+type EmailArchiveConfig struct {
+	Backend   string // local, s3 or gcs (empty disables archiving)
+	Dir       string // Root directory of the local backend
+	Bucket    string // Bucket of the s3/gcs backends
+	Prefix    string // Key prefix inside the directory or bucket
+	Endpoint  string // Object storage URL (defaults per backend; set for S3-compatible stores)
+	Region    string // Signing region (s3 default us-east-1, gcs auto)
+	AccessKey string // S3 access key ID / GCS HMAC key ID
+	SecretKey string // S3 secret access key / GCS HMAC secret
+
+	EncryptionKeys []string // "kid:secret" keys encrypting stored copies, primary first (empty stores plaintext)
+
+	Retention      time.Duration     // How long a copy is kept (0 keeps copies forever)
+	RetentionRules map[string]string // Template -> retention overriding Retention (e.g. transfer_claim:17520h)
+	PurgeInterval  time.Duration     // How often expired copies are deleted
 }
 
 // FrontendConfig - Encapsulates frontend application settings
 type FrontendConfig struct {
-	URL string // Frontend application URL for claim links
+	URL         string // Frontend application URL for claim links
+	LandingPage bool   // Serve the server-rendered claim landing page at /claim/:token
 }
 
 // CorsConfig - Encapsulates CORS policy settings
@@ -48,18 +137,280 @@ type CorsConfig struct {
 	AllowedOrigins string // Allowed frontend domains
 }
 
+// EventsConfig - Encapsulates domain event publishing settings
+type EventsConfig struct {
+	Endpoint    string   // Optional consumer URL; events are logged when empty
+	SigningKeys []string // "kid:secret" HMAC keys signing endpoint and webhook deliveries (every key signs during rotation)
+
+	CallbackHosts     []string // Hosts a transfer's callback_url may point at ("*.example.com" covers subdomains; empty disables callbacks)
+	CallbackAllowHTTP bool     // Accept plain http callback URLs (local testing only)
+
+	WebhookMaxAttempts   int           // Attempts per subscription before a webhook delivery is dead-lettered
+	WebhookRetryInterval time.Duration // Delay between polls for webhook deliveries due a retry
+}
+
+// OutboxConfig - Encapsulates outbox relay settings
+type OutboxConfig struct {
+	RelayInterval time.Duration // Delay between relay polls
+	BatchSize     int           // Events fetched per poll
+	MaxAttempts   int           // Delivery attempts before an event is dead-lettered
+
+	DispatchInterval time.Duration // Delay between transfer outbox polls (emails, notifications, events)
+	EmailWorkers     int           // Email-bound messages delivered concurrently per shard batch
+
+	EmailMaxAttempts   int           // Attempts before an email or claim notification is dead-lettered
+	EmailBaseBackoff   time.Duration // Delay after the first failed email attempt (doubles per attempt)
+	EmailMaxBackoff    time.Duration // Upper bound of the email retry delay
+	EmailBackoffJitter float64       // Random spread of each email retry delay (0.2 = +/-20%)
+}
+
+// AdminConfig - Encapsulates admin API access settings
+type AdminConfig struct {
+	APIKey string // Shared key required in X-Admin-Key; admin API disabled when empty
+}
+
+// IntegrationsConfig - Encapsulates the polling event feed for no-code tools (Zapier, IFTTT)
+type IntegrationsConfig struct {
+	APIKeys     map[string]string // Integration name -> key sent as X-API-Key (empty disables the feed)
+	SettleDelay time.Duration     // Events younger than this are held back so cursors never skip in-flight rows
+}
+
+// LoadSheddingConfig - Encapsulates downstream-health thresholds for load shedding
+type LoadSheddingConfig struct {
+	Enabled            bool          // Master switch
+	Window             time.Duration // Sliding window for error rate/latency
+	MinSamples         int           // Calls needed in the window before shedding can trigger
+	ErrorRateThreshold float64       // Shed when a dependency's error rate reaches this (0..1)
+	LatencyThreshold   time.Duration // Shed when a dependency's p95 latency reaches this
+	RetryAfter         time.Duration // Retry-After advertised to rejected clients
+}
+
+// InitiationConfig - Encapsulates the queue-backed initiation mode
+type InitiationConfig struct {
+	Mode         string        // "sync" (default) or "async"; clients may also send Prefer: respond-async
+	QueueSize    int           // Queued jobs (across instances) before 503
+	Workers      int           // Concurrent background initiations per instance
+	JobRetention time.Duration // How long finished jobs remain pollable
+	PollInterval time.Duration // Idle workers look for jobs queued by other instances this often
+	JobLease     time.Duration // A processing job not finished within this is run again (crashed worker)
+
+	IdempotencyWindow time.Duration // How long an Idempotency-Key replays the original transfer
+}
+
+// NotificationsConfig - Encapsulates non-email claim notification channels and sender status emails
+type NotificationsConfig struct {
+	SMSGatewayURL string // HTTP SMS gateway (empty disables SMS; receivers fall back to email)
+	SMSAPIKey     string // Optional X-API-Key for the gateway
+	ChatChannels  string // JSON Slack/Teams webhook definitions for lifecycle messages (empty disables)
+	SenderOnClaim bool   // Email the sender when the receiver claims
+	SenderOnFail  bool   // Email the sender when completion fails for insufficient points
+}
+
+// DigestConfig - Encapsulates sender digest job settings
+type DigestConfig struct {
+	CheckInterval      time.Duration // How often the job looks for due digests (0 disables)
+	ExpiringSoonWindow time.Duration // Pending transfers expiring within this window are highlighted
+}
+
+// ContentScanConfig - Encapsulates URL reputation scanning of sender content
+type ContentScanConfig struct {
+	Endpoint string        // URL reputation service (empty disables scanning)
+	APIKey   string        // Optional X-API-Key for the reputation service
+	Policy   string        // block or strip flagged URLs
+	FailOpen bool          // Accept unscanned content when the service is unavailable
+	Timeout  time.Duration // Per-request timeout
+}
+
+// PolicyConfig - Encapsulates Open Policy Agent settings (sidecar URL or local bundle)
+type PolicyConfig struct {
+	URL          string        // OPA sidecar base URL (e.g. http://localhost:8181)
+	BundlePath   string        // Local policy bundle evaluated with the opa CLI
+	Binary       string        // opa executable used with BundlePath
+	DecisionPath string        // Policy package path returning {allow, reasons}
+	Timeout      time.Duration // Per-evaluation timeout
+	FailOpen     bool          // Allow operations when OPA is unavailable
+}
+
+// HooksConfig - Encapsulates custom validation hooks that can veto transfers
+type HooksConfig struct {
+	Webhooks map[string]string // Hook name -> validator URL (e.g. hr:https://hr.internal/validate)
+	Plugins  []string          // Paths to Go plugins exporting Hook
+	Timeout  time.Duration     // Per-call webhook timeout
+	FailOpen bool              // Allow operations when a webhook validator is unreachable
+}
+
+// ClaimsConfig - Encapsulates claim passphrase rules
+type ClaimsConfig struct {
+	PassphraseMinPoints   int           // Transfers of at least this many points must set a passphrase (0 = optional)
+	PassphraseMaxAttempts int           // Wrong passphrases tolerated before the claim is locked
+	AutoComplete          bool          // Complete transfers to registered users immediately (no claim link)
+	DeferralExtension     time.Duration // Expiry extension when a receiver saves a claim for later (0 disables)
+	MaxLifetime           time.Duration // Latest a sender may extend expiry to, measured from creation
+	Forwarding            bool          // Receivers may forward an unclaimed gift to someone else
+	TokenBytes            int           // Random bytes per claim token (minimum 16)
+	RateLimitPerIP        int           // Claim attempts + previews per client IP per hour (0 = unlimited)
+	RateLimitPerEmail     int           // Claim attempts + previews per receiver address per hour (0 = unlimited)
+}
+
+// CaptchaConfig - Encapsulates server-side verification of CAPTCHA tokens on claim endpoints
+type CaptchaConfig struct {
+	Provider  string        // recaptcha or turnstile (empty disables the check)
+	Secret    string        // Provider secret key
+	VerifyURL string        // Siteverify endpoint override (defaults to the provider's)
+	MinScore  float64       // Lowest accepted reCAPTCHA v3 score (0 accepts any; ignored by Turnstile)
+	Action    string        // Expected action name the widget was rendered with (empty skips the check)
+	Preview   bool          // Also require a token on the claim landing page (GET /claim/:token)
+	FailOpen  bool          // Let claims through when the provider cannot be reached
+	Timeout   time.Duration // Per-verification timeout
+}
+
+// LimitsConfig - Business limits on what a sender may initiate (0 = unlimited)
+type LimitsConfig struct {
+	MaxPointsPerTransfer int // Largest single transfer
+	MaxPointsPerDay      int // Points a sender may send per UTC day (pending, on hold, completed and forwarded transfers)
+	MaxPending           int // Transfers a sender may have waiting to be claimed at once
+}
+
+// UserAuthConfig - Encapsulates verification of Auth Service access tokens (JWT) on user endpoints
+type UserAuthConfig struct {
+	Secret        string        // HS256 shared secret with the Auth Service
+	Secrets       []string      // Versioned HS256 secrets "kid:secret"; tokens naming any listed kid verify (rotation)
+	JWKSURL       string        // Auth Service JWKS endpoint for RS256 tokens
+	JWKSRefresh   time.Duration // How long fetched signing keys are trusted before re-fetching
+	Issuer        string        // Expected iss (empty skips the check)
+	Audience      string        // Expected aud (empty skips the check)
+	Leeway        time.Duration // Clock skew tolerated on exp/nbf
+	AllowIDHeader bool          // Also accept a bare X-User-ID header when no token is sent (development only)
+}
+
+// AssertionsConfig - Encapsulates verification of Auth Service claim assertions (JWT)
+type AssertionsConfig struct {
+	Secret        string        // HS256 shared secret with the Auth Service
+	Secrets       []string      // Versioned HS256 secrets "kid:secret"; assertions naming any listed kid verify (rotation)
+	PublicKeyPath string        // PEM RSA public key for RS256 assertions
+	Issuer        string        // Expected iss (empty skips the check)
+	Audience      string        // Expected aud (empty skips the check)
+	MaxTTL        time.Duration // Longest accepted exp - iat (assertions must be short-lived)
+	Leeway        time.Duration // Clock skew tolerated on exp/iat
+	Required      bool          // Reject completions without an assertion
+}
+
+// ProgramsConfig - Encapsulates point programs for cross-program transfers
+type ProgramsConfig struct {
+	Default         string   // Program assumed when a request names none
+	ConversionRates []string // "source>target=from:to" entries (e.g. loyalty>reward=2:1)
+}
+
+// PromoConfig - Encapsulates the promotional block shown only with marketing consent
+type PromoConfig struct {
+	Headline string // Promo title (empty disables the block)
+	Body     string // Promo text
+	URL      string // Call-to-action link
+}
+
+// ExpirationConfig - Encapsulates the background expiry sweeper
+type ExpirationConfig struct {
+	SweepInterval time.Duration // How often expired pending transfers are swept
+	BatchSize     int           // Transfers expired per shard per sweep
+	NotifySender  bool          // Email the sender when a transfer expires
+}
+
+// SagaConfig - Encapsulates compensation of deductions whose transfer never completed
+type SagaConfig struct {
+	RetryInterval time.Duration // How often stuck compensations are retried (0 disables)
+	MaxAttempts   int           // Automatic attempts before a compensation waits for an operator
+}
+
+// CanaryConfig - Encapsulates the periodic synthetic transfer to a canary mailbox (initiate, deliver, claim)
+type CanaryConfig struct {
+	Interval        time.Duration // How often the canary runs (0 disables)
+	SenderID        string        // Synthetic sender; its Auth calls are simulated and no points move
+	Mailbox         string        // Receiver address the claim email must reach
+	Points          int           // Points per canary transfer
+	Verify          string        // How delivery is checked: imap or api
+	IMAPAddr        string        // IMAP server host:port (implicit TLS, e.g. imap.gmail.com:993)
+	IMAPUser        string        // IMAP login
+	IMAPPassword    string        // IMAP password / app password
+	APIURL          string        // Mailbox search API queried with ?query=<marker> (e.g. Mailpit /api/v1/search)
+	APIToken        string        // Bearer token for the mailbox API (optional)
+	DeliveryTimeout time.Duration // How long the claim email may take to arrive
+	PollInterval    time.Duration // Delay between mailbox checks
+	AlertAfter      int           // Consecutive failed runs before an alert is raised
+}
+
+// ScheduledJobs - Periodic jobs whose schedule can be set with JOB_<NAME>_SCHEDULE / JOB_<NAME>_ENABLED
+var ScheduledJobs = []string{
+	"expiration_sweep", "stale_transfer_nudge", "sender_digest", "escheatment_flag", "email_template_refresh",
+	"saga_compensation_retry", "email_archive_purge", "canary", "job_run_purge", "claim_rate_purge",
+	"webhook_delivery_retry", "initiation_job_purge",
+}
+
+// JobsConfig - Encapsulates scheduling of the periodic background jobs
+type JobsConfig struct {
+	Jitter           time.Duration          // Random delay (0..Jitter) before each run, spreading instances apart
+	HistoryRetention time.Duration          // How long job run history is kept (0 keeps it forever)
+	Schedules        map[string]JobSchedule // Job name -> per-job overrides (every ScheduledJobs entry present)
+}
+
+// JobSchedule - Per-job overrides of the built-in cadence
+type JobSchedule struct {
+	Enabled  bool   // JOB_<NAME>_ENABLED (default true)
+	Schedule string // JOB_<NAME>_SCHEDULE: cron expression, @daily style macro or @every <duration> (empty = the job's interval setting)
+}
+
+// NudgeConfig - Encapsulates reminders to senders about unclaimed transfers
+type NudgeConfig struct {
+	LifetimeFraction float64       // Nudge once this share of the claim window has passed (0 disables)
+	CheckInterval    time.Duration // How often the nudge job runs
+}
+
+// EscheatmentConfig - Encapsulates unclaimed points reporting
+type EscheatmentConfig struct {
+	Threshold     time.Duration // Time after expiry before an unclaimed transfer is reportable
+	CheckInterval time.Duration // How often the flagging job runs (0 disables)
+}
+
+// InternalConfig - Encapsulates trusted-service access to /internal endpoints
+type InternalConfig struct {
+	ServiceKeys       map[string]string // Service name -> shared key (empty disables the internal API)
+	CompletionCallers []string          // Services allowed to call POST /transfer/:id/complete (keys from ServiceKeys)
+}
+
+// ProxyConfig - Encapsulates load balancer / reverse proxy trust
+type ProxyConfig struct {
+	TrustedProxies  []string // CIDRs/IPs whose forwarding headers are believed (empty trusts none)
+	RemoteIPHeaders []string // Headers carrying the client IP, checked in order
+	Platform        string   // Optional CDN platform header: cloudflare, google, flyio
+}
+
+// TestingConfig - Encapsulates deterministic test-mode settings
+type TestingConfig struct {
+	FrozenClockAt time.Time // When set, the service clock is frozen at this instant
+	IDSeed        int64     // Seed for IDs/tokens in binaries built with -tags deterministic
+}
+
 // LoadConfig - Factory method that creates configured Config instance
 func LoadConfig() *Config {
 	// Load environment variables with fallback to OS environment
+	// SOURCE ATTRIBUTION: .env never overrides the process environment, so only unset keys come from the file
+	envFileKeys, loadedSources = map[string]bool{}, map[string]string{}
+	if fileValues, err := godotenv.Read(); err == nil {
+		for key := range fileValues {
+			if _, set := os.LookupEnv(key); !set {
+				envFileKeys[key] = true
+			}
+		}
+	}
 	err := godotenv.Load()
 	if err != nil {
 		log.Println("Warning: .env file not found, using environment variables")
 	}
 
 	// Factory construction with sensible defaults
-	return &Config{
-		Port:        getEnv("PORT", "8002"), // Sender service default port
-		Environment: getEnv("ENVIRONMENT", "development"),
+	cfg := &Config{
+		Port:         getEnv("PORT", "8002"),          // Sender service default port
+		InternalPort: getEnv("INTERNAL_PORT", "8102"), // Admin, internal callbacks, metrics, pprof
+		Environment:  getEnv("ENVIRONMENT", "development"),
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnv("DB_PORT", "5432"),
@@ -67,28 +418,382 @@ func LoadConfig() *Config {
 			User:     getEnv("DB_USER", "point_user"),
 			Password: getEnv("DB_PASSWORD", "password123"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+			ShardDSNs:   getEnvList("DB_SHARD_DSNS"),   // Comma-separated; order defines shard numbers
+			ReplicaDSNs: getEnvList("DB_REPLICA_DSNS"), // Comma-separated; "-" skips a shard
+
+			Region:  getEnv("DB_REGION", "default"),
+			Regions: getEnvRegions("DB_REGIONS"), // e.g. "eu" -> DB_EU_SHARD_DSNS, DB_EU_REPLICA_DSNS
+
+			SlowQueryThreshold: getEnvDuration("DB_SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+			LogLevel:           getEnv("DB_LOG_LEVEL", "warn"),
+
+			AutoMigrate: getEnvBool("DB_AUTO_MIGRATE", true),
+			SchemaDrift: getEnv("DB_SCHEMA_DRIFT", "warn"),
 		},
 		AuthService: getEnv("AUTH_SERVICE_URL", "http://localhost:8001"), // Service integration
 		Email: EmailConfig{
+			Provider:     getEnv("EMAIL_PROVIDER", "smtp"),
 			GmailAddress: getEnv("GMAIL_ADDRESS", ""),      // Email strategy configuration
 			GmailAppPass: getEnv("GMAIL_APP_PASSWORD", ""), // Email strategy configuration
 			From:         getEnv("EMAIL_FROM", "noreply@pointtransfer.com"),
 			SMTPHost:     getEnv("SMTP_HOST", "smtp.gmail.com"), // Default to Gmail
 			SMTPPort:     getEnv("SMTP_PORT", "587"),            // Default TLS port
+			RateLimits:   getEnvMap("EMAIL_RATE_LIMITS"),
+			RateBurst:    getEnvInt("EMAIL_RATE_BURST", 1),
+			QueueSize:    getEnvInt("EMAIL_QUEUE_SIZE", 1000),
+			QuotaDeferAt: getEnvFloat("EMAIL_QUOTA_DEFER_AT", 0.9),
+
+			APIKey:        getEnv("EMAIL_API_KEY", ""),
+			APIURL:        strings.TrimRight(getEnv("EMAIL_API_URL", ""), "/"),
+			MailgunDomain: getEnv("MAILGUN_DOMAIN", ""),
+			SESRegion:     getEnv("SES_REGION", "us-east-1"),
+			SESAccessKey:  getEnv("SES_ACCESS_KEY_ID", ""),
+			SESSecretKey:  getEnv("SES_SECRET_ACCESS_KEY", ""),
+
+			TemplateRefreshInterval: getEnvDuration("EMAIL_TEMPLATE_REFRESH_INTERVAL", time.Minute),
+			TemplateDir:             getEnv("EMAIL_TEMPLATE_DIR", ""),
+			TrackingURL:             strings.TrimRight(getEnv("EMAIL_TRACKING_URL", ""), "/"), // e.g. https://points.example.com
+		},
+		EmailArchive: EmailArchiveConfig{
+			Backend:   getEnv("EMAIL_ARCHIVE_BACKEND", ""),
+			Dir:       getEnv("EMAIL_ARCHIVE_DIR", "email-archive"),
+			Bucket:    getEnv("EMAIL_ARCHIVE_BUCKET", ""),
+			Prefix:    strings.Trim(getEnv("EMAIL_ARCHIVE_PREFIX", ""), "/"),
+			Endpoint:  strings.TrimRight(getEnv("EMAIL_ARCHIVE_ENDPOINT", ""), "/"),
+			Region:    getEnv("EMAIL_ARCHIVE_REGION", ""),
+			AccessKey: getEnv("EMAIL_ARCHIVE_ACCESS_KEY", ""),
+			SecretKey: getEnv("EMAIL_ARCHIVE_SECRET_KEY", ""),
+
+			EncryptionKeys: getEnvList("EMAIL_ARCHIVE_ENCRYPTION_KEYS"),
+
+			Retention:      getEnvDuration("EMAIL_ARCHIVE_RETENTION", 365*24*time.Hour),
+			RetentionRules: getEnvMap("EMAIL_ARCHIVE_RETENTION_RULES"),
+			PurgeInterval:  getEnvDuration("EMAIL_ARCHIVE_PURGE_INTERVAL", time.Hour),
 		},
 		Frontend: FrontendConfig{
-			URL: getEnv("FRONTEND_URL", "http://localhost:3000"), // Frontend URL for claim links
+			URL:         getEnv("FRONTEND_URL", "http://localhost:3000"), // Frontend URL for claim links
+			LandingPage: getEnvBool("CLAIM_LANDING_PAGE_ENABLED", false),
+		},
+		Proxy: ProxyConfig{
+			TrustedProxies:  getEnvList("TRUSTED_PROXIES"), // e.g. 10.0.0.0/8,192.168.1.10
+			RemoteIPHeaders: getEnvListDefault("REMOTE_IP_HEADERS", []string{"X-Forwarded-For", "X-Real-IP"}),
+			Platform:        getEnv("TRUSTED_PLATFORM", ""),
 		},
 		Cors: CorsConfig{
 			AllowedOrigins: getEnv("ALLOWED_ORIGINS", "http://localhost:3000"),
 		},
+		Events: EventsConfig{
+			Endpoint:    getEnv("EVENTS_ENDPOINT", ""),
+			SigningKeys: getEnvList("WEBHOOK_SIGNING_KEYS"),
+
+			CallbackHosts:     getEnvList("CALLBACK_URL_ALLOWLIST"),
+			CallbackAllowHTTP: getEnvBool("CALLBACK_ALLOW_HTTP", false),
+
+			WebhookMaxAttempts:   getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			WebhookRetryInterval: getEnvDuration("WEBHOOK_RETRY_INTERVAL", 30*time.Second),
+		},
+		Outbox: OutboxConfig{
+			RelayInterval: getEnvDuration("OUTBOX_RELAY_INTERVAL", 2*time.Second),
+			BatchSize:     getEnvInt("OUTBOX_BATCH_SIZE", 100),
+			MaxAttempts:   getEnvInt("OUTBOX_MAX_ATTEMPTS", 10),
+
+			DispatchInterval: getEnvDuration("OUTBOX_DISPATCH_INTERVAL", time.Second),
+			EmailWorkers:     getEnvInt("OUTBOX_EMAIL_WORKERS", 4),
+
+			EmailMaxAttempts:   getEnvInt("OUTBOX_EMAIL_MAX_ATTEMPTS", 8),
+			EmailBaseBackoff:   getEnvDuration("OUTBOX_EMAIL_BASE_BACKOFF", 30*time.Second),
+			EmailMaxBackoff:    getEnvDuration("OUTBOX_EMAIL_MAX_BACKOFF", time.Hour),
+			EmailBackoffJitter: getEnvFloat("OUTBOX_EMAIL_BACKOFF_JITTER", 0.2),
+		},
+		Internal: InternalConfig{
+			ServiceKeys:       getEnvMap("INTERNAL_SERVICE_KEYS"), // e.g. receiver-service:key1,fraud-system:key2
+			CompletionCallers: getEnvListDefault("COMPLETION_CALLERS", []string{"receiver-service"}),
+		},
+		Admin: AdminConfig{
+			APIKey: getEnv("ADMIN_API_KEY", ""),
+		},
+		Integrations: IntegrationsConfig{
+			APIKeys:     getEnvMap("INTEGRATION_API_KEYS"), // e.g. zapier:key1,ifttt:key2
+			SettleDelay: getEnvDuration("INTEGRATION_SETTLE_DELAY", 5*time.Second),
+		},
+		LoadShedding: LoadSheddingConfig{
+			Enabled:            getEnvBool("LOAD_SHEDDING_ENABLED", true),
+			Window:             getEnvDuration("LOAD_SHEDDING_WINDOW", 30*time.Second),
+			MinSamples:         getEnvInt("LOAD_SHEDDING_MIN_SAMPLES", 20),
+			ErrorRateThreshold: getEnvFloat("LOAD_SHEDDING_ERROR_RATE", 0.5),
+			LatencyThreshold:   getEnvDuration("LOAD_SHEDDING_P95_LATENCY", 3*time.Second),
+			RetryAfter:         getEnvDuration("LOAD_SHEDDING_RETRY_AFTER", 15*time.Second),
+		},
+		Initiation: InitiationConfig{
+			Mode:         getEnv("INITIATION_MODE", "sync"),
+			QueueSize:    getEnvInt("INITIATION_QUEUE_SIZE", 1000),
+			Workers:      getEnvInt("INITIATION_WORKERS", 8),
+			JobRetention: getEnvDuration("INITIATION_JOB_RETENTION", time.Hour),
+			PollInterval: getEnvDuration("INITIATION_POLL_INTERVAL", time.Second),
+			JobLease:     getEnvDuration("INITIATION_JOB_LEASE", 5*time.Minute),
+
+			IdempotencyWindow: getEnvDuration("IDEMPOTENCY_WINDOW", 24*time.Hour),
+		},
+		Notifications: NotificationsConfig{
+			SMSGatewayURL: getEnv("SMS_GATEWAY_URL", ""),
+			SMSAPIKey:     getEnv("SMS_GATEWAY_API_KEY", ""),
+			ChatChannels:  getEnv("CHAT_CHANNELS", ""),
+			SenderOnClaim: getEnvBool("SENDER_NOTIFY_COMPLETED", true),
+			SenderOnFail:  getEnvBool("SENDER_NOTIFY_FAILED", true),
+		},
+		Digest: DigestConfig{
+			CheckInterval:      getEnvDuration("DIGEST_CHECK_INTERVAL", time.Hour),
+			ExpiringSoonWindow: getEnvDuration("DIGEST_EXPIRING_SOON_WINDOW", 6*time.Hour),
+		},
+		ContentScan: ContentScanConfig{
+			Endpoint: getEnv("URL_SCAN_ENDPOINT", ""),
+			APIKey:   getEnv("URL_SCAN_API_KEY", ""),
+			Policy:   getEnv("URL_SCAN_POLICY", "block"),
+			FailOpen: getEnvBool("URL_SCAN_FAIL_OPEN", false),
+			Timeout:  getEnvDuration("URL_SCAN_TIMEOUT", 5*time.Second),
+		},
+		Policy: PolicyConfig{
+			URL:          getEnv("OPA_URL", ""),
+			BundlePath:   getEnv("OPA_BUNDLE_PATH", ""),
+			Binary:       getEnv("OPA_BINARY", "opa"),
+			DecisionPath: getEnv("OPA_DECISION_PATH", "sender/transfer"),
+			Timeout:      getEnvDuration("OPA_TIMEOUT", 2*time.Second),
+			FailOpen:     getEnvBool("OPA_FAIL_OPEN", false),
+		},
+		Hooks: HooksConfig{
+			Webhooks: getEnvMap("VALIDATION_WEBHOOKS"),
+			Plugins:  getEnvList("VALIDATION_PLUGINS"),
+			Timeout:  getEnvDuration("VALIDATION_WEBHOOK_TIMEOUT", 3*time.Second),
+			FailOpen: getEnvBool("VALIDATION_WEBHOOK_FAIL_OPEN", false),
+		},
+		Claims: ClaimsConfig{
+			PassphraseMinPoints:   getEnvInt("CLAIM_PASSPHRASE_MIN_POINTS", 0),
+			PassphraseMaxAttempts: getEnvInt("CLAIM_PASSPHRASE_MAX_ATTEMPTS", 5),
+			AutoComplete:          getEnvBool("CLAIM_AUTO_COMPLETE", false),
+			DeferralExtension:     getEnvDuration("CLAIM_DEFERRAL_EXTENSION", 72*time.Hour),
+			MaxLifetime:           getEnvDuration("TRANSFER_MAX_LIFETIME", 30*24*time.Hour),
+			Forwarding:            getEnvBool("CLAIM_FORWARDING_ENABLED", true),
+			TokenBytes:            getEnvInt("CLAIM_TOKEN_BYTES", 32),
+			RateLimitPerIP:        getEnvInt("CLAIM_RATE_LIMIT_PER_IP", 60),
+			RateLimitPerEmail:     getEnvInt("CLAIM_RATE_LIMIT_PER_EMAIL", 20),
+		},
+		Captcha: CaptchaConfig{
+			Provider:  getEnv("CAPTCHA_PROVIDER", ""),
+			Secret:    getEnv("CAPTCHA_SECRET", ""),
+			VerifyURL: getEnv("CAPTCHA_VERIFY_URL", ""),
+			MinScore:  getEnvFloat("CAPTCHA_MIN_SCORE", 0.5),
+			Action:    getEnv("CAPTCHA_ACTION", ""),
+			Preview:   getEnvBool("CAPTCHA_PREVIEW", false),
+			FailOpen:  getEnvBool("CAPTCHA_FAIL_OPEN", false),
+			Timeout:   getEnvDuration("CAPTCHA_TIMEOUT", 5*time.Second),
+		},
+		Limits: LimitsConfig{
+			MaxPointsPerTransfer: getEnvInt("TRANSFER_MAX_POINTS", 0),
+			MaxPointsPerDay:      getEnvInt("TRANSFER_DAILY_MAX_POINTS", 0),
+			MaxPending:           getEnvInt("TRANSFER_MAX_PENDING", 0),
+		},
+		Assertions: AssertionsConfig{
+			Secret:        getEnv("CLAIM_ASSERTION_SECRET", ""),
+			Secrets:       getEnvList("CLAIM_ASSERTION_SECRETS"),
+			PublicKeyPath: getEnv("CLAIM_ASSERTION_PUBLIC_KEY", ""),
+			Issuer:        getEnv("CLAIM_ASSERTION_ISSUER", "auth-service"),
+			Audience:      getEnv("CLAIM_ASSERTION_AUDIENCE", "sender-service"),
+			MaxTTL:        getEnvDuration("CLAIM_ASSERTION_MAX_TTL", 5*time.Minute),
+			Leeway:        getEnvDuration("CLAIM_ASSERTION_LEEWAY", 30*time.Second),
+			Required:      getEnvBool("CLAIM_ASSERTION_REQUIRED", os.Getenv("CLAIM_ASSERTION_SECRET") != "" || os.Getenv("CLAIM_ASSERTION_SECRETS") != "" || os.Getenv("CLAIM_ASSERTION_PUBLIC_KEY") != ""),
+		},
+		UserAuth: UserAuthConfig{
+			Secret:        getEnv("JWT_SECRET", ""),
+			Secrets:       getEnvList("JWT_SECRETS"),
+			JWKSURL:       getEnv("JWT_JWKS_URL", ""), // e.g. http://localhost:8001/.well-known/jwks.json
+			JWKSRefresh:   getEnvDuration("JWT_JWKS_REFRESH", 15*time.Minute),
+			Issuer:        getEnv("JWT_ISSUER", "auth-service"),
+			Audience:      getEnv("JWT_AUDIENCE", "sender-service"),
+			Leeway:        getEnvDuration("JWT_LEEWAY", 30*time.Second),
+			AllowIDHeader: getEnvBool("JWT_ALLOW_USER_ID_HEADER", false),
+		},
+		Programs: ProgramsConfig{
+			Default:         getEnv("POINT_PROGRAM_DEFAULT", "loyalty"),
+			ConversionRates: getEnvList("POINT_CONVERSION_RATES"),
+		},
+		Experiments: getEnv("EXPERIMENTS", ""),
+		Promo: PromoConfig{
+			Headline: getEnv("PROMO_HEADLINE", ""),
+			Body:     getEnv("PROMO_BODY", ""),
+			URL:      getEnv("PROMO_URL", ""),
+		},
+		Expiration: ExpirationConfig{
+			SweepInterval: getEnvDuration("EXPIRATION_SWEEP_INTERVAL", 5*time.Minute),
+			BatchSize:     getEnvInt("EXPIRATION_SWEEP_BATCH_SIZE", 200),
+			NotifySender:  getEnvBool("EXPIRATION_NOTIFY_SENDER", true),
+		},
+		Saga: SagaConfig{
+			RetryInterval: getEnvDuration("SAGA_RETRY_INTERVAL", time.Minute),
+			MaxAttempts:   getEnvInt("SAGA_MAX_ATTEMPTS", 10),
+		},
+		Canary: CanaryConfig{
+			Interval:        getEnvDuration("CANARY_INTERVAL", 0),
+			SenderID:        getEnv("CANARY_SENDER_ID", "canary"),
+			Mailbox:         getEnv("CANARY_MAILBOX", ""),
+			Points:          getEnvInt("CANARY_POINTS", 1),
+			Verify:          getEnv("CANARY_VERIFY", "imap"),
+			IMAPAddr:        getEnv("CANARY_IMAP_ADDR", ""),
+			IMAPUser:        getEnv("CANARY_IMAP_USER", ""),
+			IMAPPassword:    getEnv("CANARY_IMAP_PASSWORD", ""),
+			APIURL:          getEnv("CANARY_MAILBOX_API_URL", ""), // e.g. http://localhost:8025/api/v1/search
+			APIToken:        getEnv("CANARY_MAILBOX_API_TOKEN", ""),
+			DeliveryTimeout: getEnvDuration("CANARY_DELIVERY_TIMEOUT", 5*time.Minute),
+			PollInterval:    getEnvDuration("CANARY_POLL_INTERVAL", 15*time.Second),
+			AlertAfter:      getEnvInt("CANARY_ALERT_AFTER", 2),
+		},
+		Jobs: JobsConfig{
+			Jitter:           getEnvDuration("JOB_JITTER", 0),
+			HistoryRetention: getEnvDuration("JOB_HISTORY_RETENTION", 30*24*time.Hour),
+			Schedules:        getEnvJobSchedules(ScheduledJobs),
+		},
+		Nudge: NudgeConfig{
+			LifetimeFraction: getEnvFloat("STALE_NUDGE_LIFETIME_FRACTION", 0.5),
+			CheckInterval:    getEnvDuration("STALE_NUDGE_CHECK_INTERVAL", time.Hour),
+		},
+		Escheatment: EscheatmentConfig{
+			Threshold:     getEnvDuration("ESCHEATMENT_THRESHOLD", 365*24*time.Hour),
+			CheckInterval: getEnvDuration("ESCHEATMENT_CHECK_INTERVAL", 24*time.Hour),
+		},
+		Testing: TestingConfig{
+			FrozenClockAt: getEnvTime("CLOCK_FROZEN_AT", time.Time{}), // RFC 3339, e.g. 2025-01-01T00:00:00Z
+			IDSeed:        int64(getEnvInt("ID_SEED", 1)),
+		},
 	}
+	cfg.Sources = loadedSources
+	return cfg
 }
 
 // getEnv - Helper with fallback values (Null Object Pattern)
 func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := readEnv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+// getEnvInt - Integer variant of getEnv; invalid values fall back to the default
+func getEnvInt(key string, defaultValue int) int {
+	if value := readEnv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+		log.Printf("Warning: invalid integer for %s, using default %d", key, defaultValue)
+		markInvalid(key)
+	}
+	return defaultValue
+}
+
+// getEnvDuration - Duration variant of getEnv (e.g. "30s", "5m")
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := readEnv(key); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			return parsed
+		}
+		log.Printf("Warning: invalid duration for %s, using default %s", key, defaultValue)
+		markInvalid(key)
+	}
+	return defaultValue
+}
+
+// getEnvTime - RFC 3339 timestamp variant of getEnv
+func getEnvTime(key string, defaultValue time.Time) time.Time {
+	if value := readEnv(key); value != "" {
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			return parsed
+		}
+		log.Printf("Warning: invalid RFC 3339 time for %s, ignoring", key)
+		markInvalid(key)
+	}
+	return defaultValue
+}
+
+// getEnvBool - Boolean variant of getEnv (true/false/1/0)
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := readEnv(key); value != "" {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+		log.Printf("Warning: invalid boolean for %s, using default %t", key, defaultValue)
+		markInvalid(key)
+	}
+	return defaultValue
+}
+
+// getEnvFloat - Float variant of getEnv
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := readEnv(key); value != "" {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+		log.Printf("Warning: invalid number for %s, using default %v", key, defaultValue)
+		markInvalid(key)
+	}
+	return defaultValue
+}
+
+// getEnvList - Comma-separated variant of getEnv (empty entries dropped)
+func getEnvList(key string) []string {
+	values := []string{}
+	for _, value := range strings.Split(readEnv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvListDefault - getEnvList with a fallback when the variable is unset or empty
+func getEnvListDefault(key string, defaultValue []string) []string {
+	if values := getEnvList(key); len(values) > 0 {
+		return values
+	}
+	return defaultValue
+}
+
+// getEnvRegions - Region names from key, each with DB_<REGION>_SHARD_DSNS / DB_<REGION>_REPLICA_DSNS
+func getEnvRegions(key string) map[string]RegionDatabaseConfig {
+	regions := map[string]RegionDatabaseConfig{}
+	for _, region := range getEnvList(key) {
+		prefix := "DB_" + strings.ToUpper(region) + "_"
+		shardDSNs := getEnvList(prefix + "SHARD_DSNS")
+		if len(shardDSNs) == 0 {
+			log.Printf("Warning: region %s has no %sSHARD_DSNS, ignoring", region, prefix)
+			continue
+		}
+		regions[region] = RegionDatabaseConfig{ShardDSNs: shardDSNs, ReplicaDSNs: getEnvList(prefix + "REPLICA_DSNS")}
+	}
+	return regions
+}
+
+// getEnvJobSchedules - JOB_<NAME>_ENABLED and JOB_<NAME>_SCHEDULE for each job name
+func getEnvJobSchedules(names []string) map[string]JobSchedule {
+	schedules := make(map[string]JobSchedule, len(names))
+	for _, name := range names {
+		prefix := "JOB_" + strings.ToUpper(name) + "_"
+		schedules[name] = JobSchedule{
+			Enabled:  getEnvBool(prefix+"ENABLED", true),
+			Schedule: strings.TrimSpace(getEnv(prefix+"SCHEDULE", "")),
+		}
+	}
+	return schedules
+}
+
+// getEnvMap - Comma-separated name:value pairs (malformed entries dropped)
+func getEnvMap(key string) map[string]string {
+	values := map[string]string{}
+	for _, entry := range getEnvList(key) {
+		name, value, ok := strings.Cut(entry, ":")
+		if !ok || strings.TrimSpace(name) == "" || strings.TrimSpace(value) == "" {
+			log.Printf("Warning: ignoring malformed %s entry", key)
+			continue
+		}
+		values[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return values
+}
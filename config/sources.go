@@ -0,0 +1,36 @@
+// DESIGN PATTERN: Observer Pattern (records each variable read by LoadConfig)
+package config
+
+import "os"
+
+// Where a configuration variable's value came from
+const (
+	SourceEnv     = "env"     // Process environment
+	SourceFile    = "file"    // .env file loaded by godotenv
+	SourceDefault = "default" // Unset or empty; built-in default applied
+	SourceInvalid = "invalid" // Set but unparsable; built-in default applied
+)
+
+var (
+	envFileKeys   = map[string]bool{}   // Variables that only exist because .env set them
+	loadedSources = map[string]string{} // Variable -> source, filled while LoadConfig runs
+)
+
+// readEnv - os.Getenv that remembers where the value came from (for /admin/config)
+func readEnv(key string) string {
+	value := os.Getenv(key)
+	switch {
+	case value == "":
+		loadedSources[key] = SourceDefault
+	case envFileKeys[key]:
+		loadedSources[key] = SourceFile
+	default:
+		loadedSources[key] = SourceEnv
+	}
+	return value
+}
+
+// markInvalid - Records that a set value was rejected and the default used instead
+func markInvalid(key string) {
+	loadedSources[key] = SourceInvalid
+}
@@ -0,0 +1,405 @@
+// DESIGN PATTERN: Specification Pattern (configuration rules) + Report Object
+package config
+
+import (
+	"fmt"
+	"sender-service/cron"
+	"sender-service/keyring"
+	"sort"
+	"strings"
+)
+
+// redacted - Placeholder for secrets in the admin config view
+const redacted = "[redacted]"
+
+// ValidationReport - Grouped findings of Validate; the service still starts with errors present
+type ValidationReport struct {
+	Errors   map[string][]string `json:"errors"`   // Group -> settings that will break features
+	Warnings map[string][]string `json:"warnings"` // Group -> risky or surprising settings
+}
+
+// Lines - One printable line per finding, errors first, groups sorted
+func (r *ValidationReport) Lines() []string {
+	var lines []string
+	for _, severity := range []struct {
+		label  string
+		groups map[string][]string
+	}{{"ERROR", r.Errors}, {"WARNING", r.Warnings}} {
+		groups := make([]string, 0, len(severity.groups))
+		for group := range severity.groups {
+			groups = append(groups, group)
+		}
+		sort.Strings(groups)
+		for _, group := range groups {
+			for _, message := range severity.groups[group] {
+				lines = append(lines, fmt.Sprintf("%s [%s] %s", severity.label, group, message))
+			}
+		}
+	}
+	return lines
+}
+
+// errorf - Records an error under group
+func (r *ValidationReport) errorf(group, format string, args ...interface{}) {
+	r.Errors[group] = append(r.Errors[group], fmt.Sprintf(format, args...))
+}
+
+// warnf - Records a warning under group
+func (r *ValidationReport) warnf(group, format string, args ...interface{}) {
+	r.Warnings[group] = append(r.Warnings[group], fmt.Sprintf(format, args...))
+}
+
+// Validate - Checks the loaded configuration for broken or risky combinations
+func (c *Config) Validate() *ValidationReport {
+	report := &ValidationReport{Errors: map[string][]string{}, Warnings: map[string][]string{}}
+	production := c.Environment == "production"
+
+	// 1. SERVER: Ports and environment
+	if c.Port == c.InternalPort {
+		report.errorf("server", "PORT and INTERNAL_PORT are both %s; the internal API would be public", c.Port)
+	}
+	if c.Environment != "production" && c.Environment != "development" {
+		report.warnf("server", "ENVIRONMENT=%q is neither production nor development; development behaviour applies", c.Environment)
+	}
+
+	// 2. DATABASE: Default credentials and schema checks
+	if production && c.Database.Password == "password123" {
+		report.errorf("database", "production mode but DB_PASSWORD is the built-in default")
+	}
+	if production && c.Database.SSLMode == "disable" {
+		report.warnf("database", "production mode with DB_SSLMODE=disable; database traffic is unencrypted")
+	}
+	if c.Database.SchemaDrift != "warn" && c.Database.SchemaDrift != "fail" && c.Database.SchemaDrift != "off" {
+		report.errorf("database", "DB_SCHEMA_DRIFT=%q must be warn, fail or off", c.Database.SchemaDrift)
+	}
+	if production && !c.Database.AutoMigrate && c.Database.SchemaDrift == "off" {
+		report.warnf("database", "DB_AUTO_MIGRATE=false with DB_SCHEMA_DRIFT=off; schema mismatches go unnoticed")
+	}
+
+	// 3. EMAIL: Claim links are only delivered by email for most receivers
+	switch c.Email.Provider {
+	case "", "smtp":
+		if c.Email.SMTPHost == "" || c.Email.SMTPPort == "" {
+			report.errorf("email", "SMTP_HOST and SMTP_PORT are required to send claim emails")
+		}
+		if production && (c.Email.GmailAddress == "" || c.Email.GmailAppPass == "") {
+			report.warnf("email", "production mode but SMTP credentials empty (GMAIL_ADDRESS/GMAIL_APP_PASSWORD); sends are unauthenticated")
+		}
+	case "sendgrid":
+		if c.Email.APIKey == "" {
+			report.errorf("email", "EMAIL_PROVIDER=sendgrid needs EMAIL_API_KEY; startup will fail")
+		}
+	case "mailgun":
+		if c.Email.APIKey == "" || c.Email.MailgunDomain == "" {
+			report.errorf("email", "EMAIL_PROVIDER=mailgun needs EMAIL_API_KEY and MAILGUN_DOMAIN; startup will fail")
+		}
+	case "ses":
+		if c.Email.SESRegion == "" || c.Email.SESAccessKey == "" || c.Email.SESSecretKey == "" {
+			report.errorf("email", "EMAIL_PROVIDER=ses needs SES_REGION, SES_ACCESS_KEY_ID and SES_SECRET_ACCESS_KEY; startup will fail")
+		}
+	default:
+		report.errorf("email", "EMAIL_PROVIDER=%q must be smtp, sendgrid, ses or mailgun", c.Email.Provider)
+	}
+	if production && strings.HasPrefix(c.Email.TrackingURL, "http://") {
+		report.warnf("email", "production mode but EMAIL_TRACKING_URL %s is not HTTPS; tracked claim links leak tokens in clear text", c.Email.TrackingURL)
+	}
+
+	switch c.EmailArchive.Backend {
+	case "", "local":
+	case "s3", "gcs":
+		if c.EmailArchive.Bucket == "" || c.EmailArchive.AccessKey == "" || c.EmailArchive.SecretKey == "" {
+			report.errorf("email", "EMAIL_ARCHIVE_BACKEND=%s needs EMAIL_ARCHIVE_BUCKET, EMAIL_ARCHIVE_ACCESS_KEY and EMAIL_ARCHIVE_SECRET_KEY; startup will fail", c.EmailArchive.Backend)
+		}
+	default:
+		report.errorf("email", "EMAIL_ARCHIVE_BACKEND=%q must be local, s3 or gcs", c.EmailArchive.Backend)
+	}
+	if c.EmailArchive.Retention < 0 {
+		report.errorf("email", "EMAIL_ARCHIVE_RETENTION=%s must not be negative", c.EmailArchive.Retention)
+	}
+	report.checkKeys("email", "EMAIL_ARCHIVE_ENCRYPTION_KEYS", c.EmailArchive.EncryptionKeys)
+	if production && c.EmailArchive.Backend != "" && len(c.EmailArchive.EncryptionKeys) == 0 {
+		report.warnf("email", "production mode but EMAIL_ARCHIVE_ENCRYPTION_KEYS is empty; archived emails (recipient PII) are stored in plaintext")
+	}
+	report.checkKeys("events", "WEBHOOK_SIGNING_KEYS", c.Events.SigningKeys)
+	for _, host := range c.Events.CallbackHosts {
+		if strings.ContainsAny(host, "/:") {
+			report.errorf("events", "CALLBACK_URL_ALLOWLIST entry %q must be a host name, without scheme, port or path", host)
+		}
+	}
+	if len(c.Events.CallbackHosts) > 0 && len(c.Events.SigningKeys) == 0 {
+		report.warnf("events", "transfer callbacks are enabled but WEBHOOK_SIGNING_KEYS is empty; callbacks are sent unsigned")
+	}
+	if production && c.Events.CallbackAllowHTTP {
+		report.warnf("events", "CALLBACK_ALLOW_HTTP=true in production; callbacks may travel unencrypted")
+	}
+
+	// 4. CORS + FRONTEND: Browsers reject wildcard origins on credentialed requests
+	for _, origin := range strings.Split(c.Cors.AllowedOrigins, ",") {
+		if strings.TrimSpace(origin) == "*" {
+			report.errorf("cors", "ALLOWED_ORIGINS contains '*' with credentials=true; browsers reject it, list the frontend origins instead")
+		}
+	}
+	if c.Frontend.URL == "" {
+		report.errorf("frontend", "FRONTEND_URL is empty; claim links cannot be built")
+	} else if production && strings.HasPrefix(c.Frontend.URL, "http://") {
+		report.warnf("frontend", "production mode but FRONTEND_URL %s is not HTTPS; claim tokens travel in clear text", c.Frontend.URL)
+	}
+
+	// 5. ACCESS: Admin and trusted-service APIs
+	if c.Admin.APIKey == "" {
+		report.warnf("admin", "ADMIN_API_KEY is empty; the admin API is disabled")
+	} else if production && len(c.Admin.APIKey) < 16 {
+		report.warnf("admin", "ADMIN_API_KEY is shorter than 16 characters")
+	}
+	if len(c.Internal.ServiceKeys) == 0 {
+		report.warnf("internal", "INTERNAL_SERVICE_KEYS is empty; trusted status changes, consent sync and POST /transfer/:id/complete are disabled")
+	}
+	completionCallers := 0
+	for _, service := range c.Internal.CompletionCallers {
+		if _, ok := c.Internal.ServiceKeys[service]; ok {
+			completionCallers++
+		} else if len(c.Internal.ServiceKeys) > 0 {
+			report.warnf("internal", "COMPLETION_CALLERS lists %s but INTERNAL_SERVICE_KEYS has no key for it", service)
+		}
+	}
+	if len(c.Internal.ServiceKeys) > 0 && completionCallers == 0 {
+		report.errorf("internal", "no COMPLETION_CALLERS service has a key in INTERNAL_SERVICE_KEYS; POST /transfer/:id/complete rejects every call")
+	}
+	if len(c.Integrations.APIKeys) == 0 {
+		report.warnf("integrations", "INTEGRATION_API_KEYS is empty; GET /integrations/events is disabled")
+	}
+	if c.Integrations.SettleDelay < 0 {
+		report.errorf("integrations", "INTEGRATION_SETTLE_DELAY=%s must not be negative", c.Integrations.SettleDelay)
+	}
+	switch c.Proxy.Platform {
+	case "", "cloudflare", "google", "flyio":
+	default:
+		report.errorf("proxy", "TRUSTED_PLATFORM=%q must be cloudflare, google or flyio", c.Proxy.Platform)
+	}
+
+	if c.UserAuth.Secret == "" && len(c.UserAuth.Secrets) == 0 && c.UserAuth.JWKSURL == "" && !c.UserAuth.AllowIDHeader {
+		report.errorf("user_auth", "none of JWT_SECRET, JWT_SECRETS or JWT_JWKS_URL is set; every sender and user endpoint answers 401")
+	}
+	report.checkKeys("user_auth", "JWT_SECRETS", c.UserAuth.Secrets)
+	if c.UserAuth.AllowIDHeader {
+		if production {
+			report.errorf("user_auth", "JWT_ALLOW_USER_ID_HEADER=true in production mode; anyone can act as any user with X-User-ID")
+		} else {
+			report.warnf("user_auth", "JWT_ALLOW_USER_ID_HEADER=true trusts a bare X-User-ID header when no token is sent")
+		}
+	}
+	if production && strings.HasPrefix(c.UserAuth.JWKSURL, "http://") {
+		report.warnf("user_auth", "production mode but JWT_JWKS_URL %s is not HTTPS; signing keys could be substituted in transit", c.UserAuth.JWKSURL)
+	}
+
+	// 6. CLAIMS: Token strength, assertion coverage and sender limits
+	if c.Claims.TokenBytes < 16 {
+		report.errorf("claims", "CLAIM_TOKEN_BYTES=%d is below the 16-byte minimum", c.Claims.TokenBytes)
+	}
+	if c.Claims.RateLimitPerIP < 0 || c.Claims.RateLimitPerEmail < 0 {
+		report.errorf("claims", "CLAIM_RATE_LIMIT_PER_IP and CLAIM_RATE_LIMIT_PER_EMAIL must not be negative (0 disables)")
+	}
+	if production && c.Claims.RateLimitPerIP == 0 && c.Claims.RateLimitPerEmail == 0 {
+		report.warnf("claims", "claim rate limits are disabled; claim tokens of a known receiver can be guessed at full speed")
+	}
+	switch c.Captcha.Provider {
+	case "":
+		if c.Captcha.Preview {
+			report.warnf("captcha", "CAPTCHA_PREVIEW=true has no effect without CAPTCHA_PROVIDER")
+		}
+	case "recaptcha", "turnstile":
+		if c.Captcha.Secret == "" {
+			report.errorf("captcha", "CAPTCHA_PROVIDER=%s needs CAPTCHA_SECRET; startup will fail", c.Captcha.Provider)
+		}
+		if c.Captcha.MinScore < 0 || c.Captcha.MinScore > 1 {
+			report.errorf("captcha", "CAPTCHA_MIN_SCORE=%g must be between 0 and 1", c.Captcha.MinScore)
+		}
+		if c.Captcha.FailOpen {
+			report.warnf("captcha", "CAPTCHA_FAIL_OPEN=true lets claims through unchecked while %s is unreachable", c.Captcha.Provider)
+		}
+	default:
+		report.errorf("captcha", "CAPTCHA_PROVIDER=%q must be recaptcha or turnstile; startup will fail", c.Captcha.Provider)
+	}
+	if c.Claims.MaxLifetime <= 0 {
+		report.errorf("claims", "TRANSFER_MAX_LIFETIME must be positive; senders cannot extend transfers")
+	}
+	hmacAssertions := c.Assertions.Secret != "" || len(c.Assertions.Secrets) > 0
+	if hmacAssertions && c.Assertions.PublicKeyPath != "" {
+		report.warnf("claims", "both an HS256 secret and CLAIM_ASSERTION_PUBLIC_KEY are set; both HS256 and RS256 assertions are accepted")
+	}
+	report.checkKeys("claims", "CLAIM_ASSERTION_SECRETS", c.Assertions.Secrets)
+	if c.Assertions.Required && !hmacAssertions && c.Assertions.PublicKeyPath == "" {
+		report.errorf("claims", "CLAIM_ASSERTION_REQUIRED=true but no assertion key is configured; startup will fail")
+	}
+	if production && !c.Assertions.Required {
+		report.warnf("claims", "production mode without required claim assertions; anyone holding a claim link can complete it")
+	}
+
+	if c.Limits.MaxPointsPerTransfer < 0 || c.Limits.MaxPointsPerDay < 0 || c.Limits.MaxPending < 0 {
+		report.errorf("limits", "TRANSFER_MAX_POINTS, TRANSFER_DAILY_MAX_POINTS and TRANSFER_MAX_PENDING must not be negative (0 = unlimited)")
+	}
+	if max, daily := c.Limits.MaxPointsPerTransfer, c.Limits.MaxPointsPerDay; max > 0 && daily > 0 && daily < max {
+		report.warnf("limits", "TRANSFER_DAILY_MAX_POINTS=%d is below TRANSFER_MAX_POINTS=%d; the daily limit caps single transfers", daily, max)
+	}
+
+	// 7. WORKERS: Initiation, email delivery, content scanning and job schedules
+	if c.Initiation.Mode != "sync" && c.Initiation.Mode != "async" {
+		report.errorf("initiation", "INITIATION_MODE=%q must be sync or async", c.Initiation.Mode)
+	}
+	if c.Initiation.Workers < 1 {
+		report.errorf("initiation", "INITIATION_WORKERS must be at least 1; queued initiations would never run")
+	}
+	if c.Initiation.PollInterval <= 0 || c.Initiation.JobLease <= 0 {
+		report.errorf("initiation", "INITIATION_POLL_INTERVAL and INITIATION_JOB_LEASE must be positive")
+	}
+	if c.Outbox.EmailWorkers < 1 {
+		report.errorf("outbox", "OUTBOX_EMAIL_WORKERS must be at least 1; queued emails would never be sent")
+	}
+	if c.Outbox.EmailMaxAttempts < 1 {
+		report.errorf("outbox", "OUTBOX_EMAIL_MAX_ATTEMPTS must be at least 1")
+	}
+	if c.Events.WebhookMaxAttempts < 1 {
+		report.errorf("events", "WEBHOOK_MAX_ATTEMPTS must be at least 1")
+	}
+	if c.Outbox.EmailBaseBackoff <= 0 || c.Outbox.EmailMaxBackoff < c.Outbox.EmailBaseBackoff {
+		report.errorf("outbox", "OUTBOX_EMAIL_BASE_BACKOFF must be positive and at most OUTBOX_EMAIL_MAX_BACKOFF")
+	}
+	if j := c.Outbox.EmailBackoffJitter; j < 0 || j >= 1 {
+		report.errorf("outbox", "OUTBOX_EMAIL_BACKOFF_JITTER=%g must be in [0, 1)", j)
+	}
+	if c.ContentScan.Policy != "block" && c.ContentScan.Policy != "strip" {
+		report.errorf("content_scan", "URL_SCAN_POLICY=%q must be block or strip", c.ContentScan.Policy)
+	}
+	for _, name := range ScheduledJobs {
+		if schedule := c.Jobs.Schedules[name].Schedule; schedule != "" {
+			if _, err := cron.Parse(schedule); err != nil {
+				report.errorf("jobs", "JOB_%s_SCHEDULE: %v; startup will fail", strings.ToUpper(name), err)
+			}
+		}
+	}
+	if c.Jobs.Jitter < 0 || c.Jobs.HistoryRetention < 0 {
+		report.errorf("jobs", "JOB_JITTER and JOB_HISTORY_RETENTION must not be negative")
+	}
+
+	// 8. CANARY: The self-test needs a mailbox it can read back
+	if c.Canary.Interval > 0 {
+		if c.Canary.Mailbox == "" {
+			report.errorf("canary", "CANARY_INTERVAL is set but CANARY_MAILBOX is empty; startup will fail")
+		}
+		switch c.Canary.Verify {
+		case "imap":
+			if c.Canary.IMAPAddr == "" || c.Canary.IMAPUser == "" || c.Canary.IMAPPassword == "" {
+				report.errorf("canary", "CANARY_VERIFY=imap needs CANARY_IMAP_ADDR, CANARY_IMAP_USER and CANARY_IMAP_PASSWORD; startup will fail")
+			}
+		case "api":
+			if c.Canary.APIURL == "" {
+				report.errorf("canary", "CANARY_VERIFY=api needs CANARY_MAILBOX_API_URL; startup will fail")
+			}
+		default:
+			report.errorf("canary", "CANARY_VERIFY=%q must be imap or api", c.Canary.Verify)
+		}
+		if c.Canary.DeliveryTimeout >= c.Canary.Interval {
+			report.warnf("canary", "CANARY_DELIVERY_TIMEOUT=%s is not shorter than CANARY_INTERVAL=%s; slow runs delay the next one", c.Canary.DeliveryTimeout, c.Canary.Interval)
+		}
+		if c.Canary.Points < 1 {
+			report.errorf("canary", "CANARY_POINTS must be at least 1")
+		}
+	}
+
+	// 9. TESTING: Test-mode switches must never reach production
+	if !c.Testing.FrozenClockAt.IsZero() {
+		if production {
+			report.errorf("testing", "CLOCK_FROZEN_AT is set in production mode")
+		} else {
+			report.warnf("testing", "CLOCK_FROZEN_AT freezes the service clock")
+		}
+	}
+
+	return report
+}
+
+// checkKeys - Versioned key lists must parse; short secrets are flagged
+func (r *ValidationReport) checkKeys(group, name string, entries []string) {
+	keys, err := keyring.Parse(entries)
+	if err != nil {
+		r.errorf(group, "%s is invalid (%v); startup will fail", name, err)
+		return
+	}
+	for _, key := range keys.Keys() {
+		if len(key.Secret) < 16 {
+			r.warnf(group, "%s key %q is shorter than 16 characters", name, key.ID)
+		}
+	}
+}
+
+// Redacted - Copy safe to show operators: passwords, keys and DSNs are masked
+func (c *Config) Redacted() *Config {
+	safe := *c
+	safe.Database.Password = redactSecret(c.Database.Password)
+	safe.Database.ShardDSNs = redactList(c.Database.ShardDSNs)
+	safe.Database.ReplicaDSNs = redactList(c.Database.ReplicaDSNs)
+	safe.Database.Regions = make(map[string]RegionDatabaseConfig, len(c.Database.Regions))
+	for region, regionDB := range c.Database.Regions {
+		safe.Database.Regions[region] = RegionDatabaseConfig{
+			ShardDSNs:   redactList(regionDB.ShardDSNs),
+			ReplicaDSNs: redactList(regionDB.ReplicaDSNs),
+		}
+	}
+	safe.Email.GmailAppPass = redactSecret(c.Email.GmailAppPass)
+	safe.Email.APIKey = redactSecret(c.Email.APIKey)
+	safe.Email.SESSecretKey = redactSecret(c.Email.SESSecretKey)
+	safe.EmailArchive.SecretKey = redactSecret(c.EmailArchive.SecretKey)
+	safe.EmailArchive.EncryptionKeys = redactKeys(c.EmailArchive.EncryptionKeys)
+	safe.Events.SigningKeys = redactKeys(c.Events.SigningKeys)
+	safe.Admin.APIKey = redactSecret(c.Admin.APIKey)
+	safe.Notifications.SMSAPIKey = redactSecret(c.Notifications.SMSAPIKey)
+	safe.Notifications.ChatChannels = redactSecret(c.Notifications.ChatChannels) // Webhook URLs embed their credentials
+	safe.ContentScan.APIKey = redactSecret(c.ContentScan.APIKey)
+	safe.Assertions.Secret = redactSecret(c.Assertions.Secret)
+	safe.Captcha.Secret = redactSecret(c.Captcha.Secret)
+	safe.Assertions.Secrets = redactKeys(c.Assertions.Secrets)
+	safe.UserAuth.Secret = redactSecret(c.UserAuth.Secret)
+	safe.UserAuth.Secrets = redactKeys(c.UserAuth.Secrets)
+	safe.Canary.IMAPPassword = redactSecret(c.Canary.IMAPPassword)
+	safe.Canary.APIToken = redactSecret(c.Canary.APIToken)
+	safe.Internal.ServiceKeys = make(map[string]string, len(c.Internal.ServiceKeys))
+	for service, key := range c.Internal.ServiceKeys {
+		safe.Internal.ServiceKeys[service] = redactSecret(key)
+	}
+	safe.Integrations.APIKeys = make(map[string]string, len(c.Integrations.APIKeys))
+	for integration, key := range c.Integrations.APIKeys {
+		safe.Integrations.APIKeys[integration] = redactSecret(key)
+	}
+	return &safe
+}
+
+// redactSecret - Masks a set secret (empty stays empty so "not configured" remains visible)
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redacted
+}
+
+// redactKeys - Masks the secrets of "kid:secret" entries (key IDs stay visible to follow a rotation)
+func redactKeys(entries []string) []string {
+	masked := make([]string, len(entries))
+	for i, entry := range entries {
+		id, secret, _ := strings.Cut(entry, ":")
+		masked[i] = id + ":" + redactSecret(secret)
+	}
+	return masked
+}
+
+// redactList - Masks every entry ("-" placeholders stay readable)
+func redactList(values []string) []string {
+	masked := make([]string, len(values))
+	for i, value := range values {
+		if value == "-" {
+			masked[i] = value
+			continue
+		}
+		masked[i] = redactSecret(value)
+	}
+	return masked
+}
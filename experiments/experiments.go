@@ -0,0 +1,120 @@
+// DESIGN PATTERN: Registry + Strategy (deterministic weighted cohort assignment)
+package experiments
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+)
+
+// Experiment - Named split of traffic into weighted variants, each carrying parameters
+type Experiment struct {
+	Name     string    `json:"name"`     // Also the key in Transfer.Cohorts
+	Variants []Variant `json:"variants"` // At least two, weights > 0
+}
+
+// Variant - One cohort; Params are read by the feature under test (e.g. "expiry": "72h")
+type Variant struct {
+	Name   string            `json:"name"`
+	Weight int               `json:"weight"`
+	Params map[string]string `json:"params"`
+}
+
+// Registry - Active experiments (shared by transfer timing and email variants)
+type Registry struct {
+	experiments []Experiment
+}
+
+// Parse - Builds a registry from the EXPERIMENTS JSON array ("" = no experiments)
+func Parse(raw string) (*Registry, error) {
+	registry := &Registry{}
+	if raw == "" {
+		return registry, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &registry.experiments); err != nil {
+		return nil, fmt.Errorf("invalid experiments definition: %v", err)
+	}
+
+	seen := map[string]bool{}
+	for _, experiment := range registry.experiments {
+		if experiment.Name == "" || seen[experiment.Name] {
+			return nil, errors.New("experiments need unique, non-empty names")
+		}
+		seen[experiment.Name] = true
+		if len(experiment.Variants) < 2 {
+			return nil, fmt.Errorf("experiment %s needs at least two variants", experiment.Name)
+		}
+		for _, variant := range experiment.Variants {
+			if variant.Name == "" || variant.Weight <= 0 {
+				return nil, fmt.Errorf("experiment %s: variants need a name and a positive weight", experiment.Name)
+			}
+		}
+	}
+	return registry, nil
+}
+
+// List - Configured experiments
+func (r *Registry) List() []Experiment {
+	return r.experiments
+}
+
+// Find - Experiment by name
+func (r *Registry) Find(name string) (*Experiment, bool) {
+	for i := range r.experiments {
+		if r.experiments[i].Name == name {
+			return &r.experiments[i], true
+		}
+	}
+	return nil, false
+}
+
+// Assign - Cohort per experiment for a unit (e.g. sender ID) plus the merged variant params.
+// The same unit always lands in the same variant while the experiment definition is unchanged.
+func (r *Registry) Assign(unitID string) (map[string]string, map[string]string) {
+	if len(r.experiments) == 0 {
+		return nil, nil
+	}
+
+	cohorts := map[string]string{}
+	params := map[string]string{}
+	for _, experiment := range r.experiments {
+		variant := experiment.pick(unitID)
+		cohorts[experiment.Name] = variant.Name
+		for _, key := range sortedKeys(variant.Params) {
+			params[key] = variant.Params[key]
+		}
+	}
+	return cohorts, params
+}
+
+// pick - Hashes experiment+unit into the cumulative weight range
+func (e *Experiment) pick(unitID string) Variant {
+	total := 0
+	for _, variant := range e.Variants {
+		total += variant.Weight
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(e.Name + ":" + unitID))
+	point := int(hash.Sum32() % uint32(total))
+
+	for _, variant := range e.Variants {
+		if point < variant.Weight {
+			return variant
+		}
+		point -= variant.Weight
+	}
+	return e.Variants[len(e.Variants)-1]
+}
+
+// sortedKeys - Stable param merge order (later experiments win on conflicting keys)
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,118 @@
+// DESIGN PATTERN: Controller Pattern + Template View
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/i18n"
+	"sender-service/middleware"
+	"sender-service/models"
+	"sender-service/services"
+	"sender-service/web"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClaimPageHandler - Serves the optional server-rendered claim landing page
+type ClaimPageHandler struct {
+	transferService *services.TransferService // Composition: HAS-A business service
+	throttle        *services.ClaimThrottle   // Composition: HAS-A claim rate limit (Retry-After)
+	clock           clock.Clock               // Composition: HAS-A time source
+	frontendURL     string                    // SPA that performs the actual claim
+}
+
+// NewClaimPageHandler - Factory method with dependency injection
+func NewClaimPageHandler(transferService *services.TransferService, throttle *services.ClaimThrottle, clk clock.Clock, cfg *config.Config) *ClaimPageHandler {
+	return &ClaimPageHandler{transferService: transferService, throttle: throttle, clock: clk, frontendURL: cfg.Frontend.URL}
+}
+
+// ClaimPage - HTTP handler rendering the landing page for a claim token
+func (h *ClaimPageHandler) ClaimPage(c *gin.Context) {
+	token := c.Param("token")
+	transfer, err := h.transferService.PreviewClaim(token)
+	if errors.Is(err, services.ErrClaimRateLimited) {
+		middleware.SetRetryAfter(c, h.throttle.RetryAfter())
+		c.String(http.StatusTooManyRequests, "Too many requests for this claim link, please try again later")
+		return
+	}
+	if err != nil {
+		c.String(http.StatusNotFound, "Transfer not found")
+		return
+	}
+
+	// 1. PRELOAD: Link headers for every client, HTTP/2 push where the connection supports it
+	preloadCriticalAssets(c)
+
+	// 2. RENDER: Landing page with a link into the SPA claim flow
+	data := web.ClaimPageData{
+		ReceiverName:   transfer.ReceiverName,
+		SenderEmail:    transfer.SenderEmail,
+		Points:         transfer.Points,
+		Message:        transfer.Message,
+		ExpiresAt:      transfer.ExpiresAt.UTC().Format("Jan 2, 2006 15:04 MST"),
+		Claimable:      transfer.Status == models.TransferStatusPending && h.clock.Now().Before(transfer.ExpiresAt),
+		Protected:      transfer.PassphraseProtected(),
+		PassphraseHint: transfer.PassphraseHint,
+		ClaimURL:       fmt.Sprintf("%s/#/claim/%s", h.frontendURL, token),
+	}
+
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Header("Cache-Control", "no-store") // Page reflects live transfer status
+	c.Status(http.StatusOK)
+	if err := web.ClaimPage.Execute(c.Writer, data); err != nil {
+		fmt.Printf("Failed to render claim page: %v\n", err)
+	}
+}
+
+// trackingPixel - 1x1 transparent GIF served for claim email opens
+var trackingPixel = []byte("GIF89a\x01\x00\x01\x00\x80\x00\x00\x00\x00\x00\xff\xff\xff!\xf9\x04\x01\x00\x00\x00\x00,\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02D\x01\x00;")
+
+// TrackOpen - Records the first claim email open; always answers with the pixel so tokens cannot be probed
+func (h *ClaimPageHandler) TrackOpen(c *gin.Context) {
+	h.transferService.RecordEmailOpen(c.Param("token"))
+
+	c.Header("Cache-Control", "no-store") // Every open must reach the service, not a proxy cache
+	c.Data(http.StatusOK, "image/gif", trackingPixel)
+}
+
+// TrackClick - Records the first claim link click, then redirects into the SPA claim flow (keeping a
+// supported ?lang=)
+func (h *ClaimPageHandler) TrackClick(c *gin.Context) {
+	token := c.Param("token")
+	h.transferService.RecordLinkClick(token)
+
+	target := fmt.Sprintf("%s/#/claim/%s", h.frontendURL, url.PathEscape(token))
+	if locale := i18n.Match(c.Query("lang")); locale != "" {
+		target += "?lang=" + locale
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusFound, target)
+}
+
+// StaticAssets - Day-long caching for the small embedded assets
+func StaticAssets() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=86400")
+		c.Next()
+	}
+}
+
+// preloadCriticalAssets - Adds Link: rel=preload and pushes assets over HTTP/2 when available
+func preloadCriticalAssets(c *gin.Context) {
+	links := make([]string, 0, len(web.CriticalAssets))
+	pusher := c.Writer.Pusher()
+	for _, asset := range web.CriticalAssets {
+		links = append(links, fmt.Sprintf("<%s>; rel=preload; as=%s", asset.Path, asset.As))
+		if pusher != nil {
+			if err := pusher.Push(asset.Path, nil); err != nil {
+				pusher = nil // Client disabled push; preload headers still apply
+			}
+		}
+	}
+	c.Header("Link", strings.Join(links, ", "))
+}
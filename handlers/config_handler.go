@@ -0,0 +1,30 @@
+// DESIGN PATTERN: Controller Pattern + Request Handler
+package handlers
+
+import (
+	"net/http"
+	"sender-service/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConfigHandler - Exposes the effective configuration and its validation report to operators
+type ConfigHandler struct {
+	config *config.Config // Composition: HAS-A loaded configuration
+}
+
+// NewConfigHandler - Factory method with dependency injection
+func NewConfigHandler(config *config.Config) *ConfigHandler {
+	return &ConfigHandler{config: config}
+}
+
+// GetConfig - HTTP handler returning the redacted configuration (with per-variable sources) and the validation report
+func (h *ConfigHandler) GetConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"config":     h.config.Redacted(),
+			"validation": h.config.Validate(),
+		},
+	})
+}
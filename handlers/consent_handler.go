@@ -0,0 +1,74 @@
+// DESIGN PATTERN: Controller Pattern + Request Handler
+package handlers
+
+import (
+	"net/http"
+	"sender-service/apperrors"
+	"sender-service/middleware"
+	"sender-service/models"
+	"sender-service/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConsentHandler - Handles HTTP requests for marketing consent
+type ConsentHandler struct {
+	consentService  *services.ConsentService  // Composition: HAS-A consent service
+	transferService *services.TransferService // Composition: HAS-A transfer lookup (claim tokens)
+}
+
+// NewConsentHandler - Factory method with dependency injection
+func NewConsentHandler(consentService *services.ConsentService, transferService *services.TransferService) *ConsentHandler {
+	return &ConsentHandler{consentService: consentService, transferService: transferService}
+}
+
+// UpdateClaimConsent - HTTP handler: the receiver records consent from their claim link
+func (h *ConsentHandler) UpdateClaimConsent(c *gin.Context) {
+	var req models.ConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return
+	}
+
+	transfer, err := h.transferService.ResolveClaim(c.Param("token"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.record(c, transfer.ReceiverEmail, *req.Consented, services.ConsentSourceClaimLink)
+}
+
+// GetConsent - HTTP handler for trusted services reading an address's consent
+func (h *ConsentHandler) GetConsent(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.consentService.Get(c.Param("email")),
+	})
+}
+
+// UpdateConsent - HTTP handler for trusted services (e.g. Auth Service sign-up) recording consent
+func (h *ConsentHandler) UpdateConsent(c *gin.Context) {
+	var req models.ConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return
+	}
+
+	h.record(c, c.Param("email"), *req.Consented, c.GetString(middleware.ServiceNameKey))
+}
+
+// record - Shared persistence + response
+func (h *ConsentHandler) record(c *gin.Context, email string, consented bool, source string) {
+	consent, err := h.consentService.Record(email, consented, source)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Consent recorded",
+		"data":    consent,
+	})
+}
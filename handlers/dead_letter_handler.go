@@ -0,0 +1,81 @@
+// DESIGN PATTERN: Controller Pattern + Request Handler
+package handlers
+
+import (
+	"net/http"
+	"sender-service/apperrors"
+	"sender-service/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeadLetterHandler - Handles admin HTTP requests for dead-letter management
+type DeadLetterHandler struct {
+	deadLetterService *services.DeadLetterService // Composition: HAS-A business service
+}
+
+// NewDeadLetterHandler - Factory method with dependency injection
+func NewDeadLetterHandler(deadLetterService *services.DeadLetterService) *DeadLetterHandler {
+	return &DeadLetterHandler{deadLetterService: deadLetterService}
+}
+
+// ListDeadLetters - HTTP handler listing dead letters (?kind=email|event|webhook&status=open&limit=100)
+func (h *DeadLetterHandler) ListDeadLetters(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	deadLetters, err := h.deadLetterService.List(c.Query("kind"), c.DefaultQuery("status", "open"), limit)
+	if err != nil {
+		c.Error(apperrors.ErrInternal.WithMessage("Failed to fetch dead letters"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    deadLetters,
+	})
+}
+
+// GetDeadLetter - HTTP handler returning one dead letter with reason and attempt history
+func (h *DeadLetterHandler) GetDeadLetter(c *gin.Context) {
+	deadLetter, err := h.deadLetterService.Get(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    deadLetter,
+	})
+}
+
+// RetryDeadLetter - HTTP handler re-driving a dead letter
+func (h *DeadLetterHandler) RetryDeadLetter(c *gin.Context) {
+	deadLetter, err := h.deadLetterService.Retry(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Dead letter re-driven successfully",
+		"data":    deadLetter,
+	})
+}
+
+// DiscardDeadLetter - HTTP handler dropping a dead letter
+func (h *DeadLetterHandler) DiscardDeadLetter(c *gin.Context) {
+	deadLetter, err := h.deadLetterService.Discard(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Dead letter discarded",
+		"data":    deadLetter,
+	})
+}
@@ -0,0 +1,153 @@
+// DESIGN PATTERN: Controller Pattern + Request Handler
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sender-service/apperrors"
+	"sender-service/config"
+	"sender-service/models"
+	"sender-service/repositories"
+	"sender-service/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errInvalidArchiveDate - Unparseable from/to on the archive listing
+var errInvalidArchiveDate = apperrors.New(apperrors.ErrInvalidInput, "invalid_archive_date", "from/to must be YYYY-MM-DD or RFC 3339")
+
+// errInvalidRequeueDate - Unparseable from/to on a bulk re-queue
+var errInvalidRequeueDate = apperrors.New(apperrors.ErrInvalidInput, "invalid_requeue_date", "from/to must be YYYY-MM-DD or RFC 3339")
+
+// defaultArchivePageSize - Archived emails listed when no limit is given
+const defaultArchivePageSize = 50
+
+// defaultRequeueLimit - Email jobs re-queued per call when no limit is given
+const defaultRequeueLimit = 500
+
+// EmailHandler - Admin view of email provider usage, archived copies, SMTP diagnostics and the email queue
+type EmailHandler struct {
+	quota       *services.EmailQuota          // Composition: HAS-A quota tracker
+	archive     *services.EmailArchiveService // Composition: HAS-A sent email archive
+	diagnostics *services.EmailDiagnostics    // Composition: HAS-A SMTP test sender
+	dispatcher  *services.OutboxDispatcher    // Composition: HAS-A email queue (bulk re-queue)
+	config      *config.Config                // Composition: HAS-A configuration
+}
+
+// NewEmailHandler - Factory method with dependency injection
+func NewEmailHandler(quota *services.EmailQuota, archive *services.EmailArchiveService, diagnostics *services.EmailDiagnostics,
+	dispatcher *services.OutboxDispatcher, config *config.Config) *EmailHandler {
+	return &EmailHandler{quota: quota, archive: archive, diagnostics: diagnostics, dispatcher: dispatcher, config: config}
+}
+
+// EmailQuota - HTTP handler returning today's sends, deferrals and remaining quota per provider
+func (h *EmailHandler) EmailQuota(c *gin.Context) {
+	statuses, err := h.quota.Snapshot(services.EmailProviderKey(h.config))
+	if err != nil {
+		c.Error(apperrors.ErrInternal.WithMessage("Failed to load email quota"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    statuses,
+	})
+}
+
+// TestEmail - HTTP handler sending a test message with the current SMTP settings; answers 200 with the
+// step-by-step report whether or not the server accepted it
+func (h *EmailHandler) TestEmail(c *gin.Context) {
+	var req models.EmailTestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperrors.Invalid("Invalid email test request", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.diagnostics.TestSend(c.Request.Context(), req.To),
+	})
+}
+
+// RequeueEmails - HTTP handler re-queueing failed (or retrying) email jobs in bulk, optionally as a dry run
+func (h *EmailHandler) RequeueEmails(c *gin.Context) {
+	var req models.EmailRequeueRequest
+	if err := bindOptionalJSON(c, &req); err != nil {
+		c.Error(apperrors.Invalid("Invalid requeue request", err))
+		return
+	}
+	from, errFrom := parseReportDate(req.From)
+	to, errTo := parseReportDate(req.To)
+	if errFrom != nil || errTo != nil {
+		c.Error(errInvalidRequeueDate)
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultRequeueLimit
+	}
+
+	result, err := h.dispatcher.RequeueEmails(repositories.OutboxRequeueFilter{
+		Retrying: req.Status == "retrying",
+		From:     from,
+		To:       to,
+		Provider: req.Provider,
+		Limit:    req.Limit,
+	}, req.DryRun)
+	if err != nil {
+		c.Error(apperrors.ErrInternal.WithMessage("Failed to re-queue emails"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ListArchivedEmails - HTTP handler listing archived copies of sent emails, newest first
+func (h *EmailHandler) ListArchivedEmails(c *gin.Context) {
+	var query models.EmailArchiveQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(apperrors.Invalid("Invalid archive query", err))
+		return
+	}
+	from, errFrom := parseReportDate(query.From)
+	to, errTo := parseReportDate(query.To)
+	if errFrom != nil || errTo != nil {
+		c.Error(errInvalidArchiveDate)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultArchivePageSize
+	}
+
+	emails, err := h.archive.List(repositories.EmailArchiveFilter{
+		Recipient: query.Recipient,
+		Template:  query.Template,
+		From:      from,
+		To:        to,
+		Limit:     query.Limit,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    emails,
+	})
+}
+
+// DownloadArchivedEmail - HTTP handler returning one archived copy (headers + body) as sent
+func (h *EmailHandler) DownloadArchivedEmail(c *gin.Context) {
+	archived, message, err := h.archive.Open(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", archived.ID+".eml"))
+	c.Header("X-Archive-SHA256", archived.SHA256)
+	c.Data(http.StatusOK, "message/rfc822", message)
+}
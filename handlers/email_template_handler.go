@@ -0,0 +1,168 @@
+// DESIGN PATTERN: Controller Pattern + Request Handler
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"sender-service/apperrors"
+	"sender-service/models"
+	"sender-service/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmailTemplateHandler - Handles admin HTTP requests for custom email templates
+type EmailTemplateHandler struct {
+	templateService *services.EmailTemplateService // Composition: HAS-A business service
+}
+
+// NewEmailTemplateHandler - Factory method with dependency injection
+func NewEmailTemplateHandler(templateService *services.EmailTemplateService) *EmailTemplateHandler {
+	return &EmailTemplateHandler{templateService: templateService}
+}
+
+// ListEmailTemplates - HTTP handler listing built-in templates, required variables and active overrides
+func (h *EmailTemplateHandler) ListEmailTemplates(c *gin.Context) {
+	statuses, err := h.templateService.List()
+	if err != nil {
+		c.Error(apperrors.ErrInternal.WithMessage("Failed to fetch email templates"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    statuses,
+	})
+}
+
+// LintEmailTemplate - HTTP handler validating a template without storing it
+func (h *EmailTemplateHandler) LintEmailTemplate(c *gin.Context) {
+	var upload models.EmailTemplateUpload
+	if !bindTemplateUpload(c, &upload) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    services.LintEmailTemplate(c.Param("name"), upload.Subject, upload.HTML),
+	})
+}
+
+// ListEmailTemplateVersions - HTTP handler listing a tenant's uploaded versions (?tenant=)
+func (h *EmailTemplateHandler) ListEmailTemplateVersions(c *gin.Context) {
+	var scope models.EmailTemplateScope
+	if !bindTemplateScope(c, &scope) {
+		return
+	}
+
+	versions, err := h.templateService.Versions(scope.Tenant, c.Param("name"))
+	if errors.Is(err, services.ErrUnknownEmailTemplate) {
+		c.Error(err)
+		return
+	}
+	if err != nil {
+		c.Error(apperrors.ErrInternal.WithMessage("Failed to fetch email template versions"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    versions,
+	})
+}
+
+// UploadEmailTemplate - HTTP handler storing the next version as a draft (?tenant=; inactive until activated)
+func (h *EmailTemplateHandler) UploadEmailTemplate(c *gin.Context) {
+	var (
+		scope  models.EmailTemplateScope
+		upload models.EmailTemplateUpload
+	)
+	if !bindTemplateScope(c, &scope) || !bindTemplateUpload(c, &upload) {
+		return
+	}
+
+	override, report, err := h.templateService.Upload(scope.Tenant, c.Param("name"), upload)
+	if errors.Is(err, services.ErrUnknownEmailTemplate) {
+		c.Error(err)
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Draft version saved; activate it to use it for sends",
+		"data":    gin.H{"template": override, "lint": report},
+	})
+}
+
+// ActivateEmailTemplate - HTTP handler switching the tenant's sends to a version (?tenant=&version=,
+// default latest); 422 with the lint report if broken
+func (h *EmailTemplateHandler) ActivateEmailTemplate(c *gin.Context) {
+	var scope models.EmailTemplateScope
+	if !bindTemplateScope(c, &scope) {
+		return
+	}
+
+	override, report, err := h.templateService.Activate(scope.Tenant, c.Param("name"), scope.Version)
+	switch {
+	case errors.Is(err, services.ErrEmailTemplateNotFound):
+		c.Error(err)
+		return
+	case errors.Is(err, services.ErrEmailTemplateInvalid):
+		c.Error(apperrors.WithDetails(err, report))
+		return
+	case err != nil:
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Email template activated",
+		"data":    gin.H{"template": override, "lint": report},
+	})
+}
+
+// RevertEmailTemplate - HTTP handler deactivating the tenant's override (?tenant=); versions are kept
+func (h *EmailTemplateHandler) RevertEmailTemplate(c *gin.Context) {
+	var scope models.EmailTemplateScope
+	if !bindTemplateScope(c, &scope) {
+		return
+	}
+
+	err := h.templateService.Revert(scope.Tenant, c.Param("name"))
+	if errors.Is(err, services.ErrEmailTemplateNotFound) {
+		c.Error(err)
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Email template override deactivated",
+	})
+}
+
+// bindTemplateScope - Parses ?tenant=&version=, answering 400 on failure
+func bindTemplateScope(c *gin.Context, scope *models.EmailTemplateScope) bool {
+	if err := c.ShouldBindQuery(scope); err != nil {
+		c.Error(apperrors.Invalid("Invalid tenant or version", err))
+		return false
+	}
+	return true
+}
+
+// bindTemplateUpload - Parses the upload body, answering 400 on failure
+func bindTemplateUpload(c *gin.Context, upload *models.EmailTemplateUpload) bool {
+	if err := c.ShouldBindJSON(upload); err != nil {
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,28 @@
+// DESIGN PATTERN: Controller Pattern + Request Handler
+package handlers
+
+import (
+	"net/http"
+	"sender-service/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler - Exposes downstream health as seen by the load shedder
+type HealthHandler struct {
+	monitor *services.HealthMonitor // Composition: HAS-A health monitor
+}
+
+// NewHealthHandler - Factory method with dependency injection
+func NewHealthHandler(monitor *services.HealthMonitor) *HealthHandler {
+	return &HealthHandler{monitor: monitor}
+}
+
+// DownstreamHealth - HTTP handler returning error rates and p95 latencies per dependency
+func (h *HealthHandler) DownstreamHealth(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"degraded": h.monitor.Degraded(),
+		"data":     h.monitor.Snapshot(),
+	})
+}
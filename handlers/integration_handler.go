@@ -0,0 +1,52 @@
+// DESIGN PATTERN: Controller Pattern + Request Handler
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"sender-service/apperrors"
+	"sender-service/models"
+	"sender-service/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IntegrationHandler - Handles polling requests from no-code integration tools
+type IntegrationHandler struct {
+	feedService *services.IntegrationFeedService // Composition: HAS-A business service
+}
+
+// NewIntegrationHandler - Factory method with dependency injection
+func NewIntegrationHandler(feedService *services.IntegrationFeedService) *IntegrationHandler {
+	return &IntegrationHandler{feedService: feedService}
+}
+
+// ListEvents - HTTP handler returning claims and expirations after ?since= (?limit=, ?trigger=)
+func (h *IntegrationHandler) ListEvents(c *gin.Context) {
+	var req models.IntegrationFeedRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(apperrors.Invalid("Invalid feed parameters", err))
+		return
+	}
+
+	feed, err := h.feedService.Events(req)
+	if errors.Is(err, services.ErrInvalidSince) {
+		c.Error(err)
+		return
+	}
+	if err != nil {
+		c.Error(apperrors.ErrInternal.WithMessage("Failed to fetch integration events"))
+		return
+	}
+
+	// LINKS: The feed only moves forward; next is offered while more settled events are waiting
+	if feed.HasMore {
+		setPageLinks(c, pageLink{rel: "next", params: map[string]string{"since": feed.NextCursor}})
+	}
+	setCursorHeaders(c, feed.NextCursor, "")
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    feed,
+	})
+}
@@ -0,0 +1,51 @@
+// DESIGN PATTERN: Controller Pattern + Request Handler
+package handlers
+
+import (
+	"net/http"
+	"sender-service/apperrors"
+	"sender-service/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobHandler - Handles admin HTTP requests about scheduled jobs
+type JobHandler struct {
+	scheduler *services.Scheduler // Composition: HAS-A job scheduler
+}
+
+// NewJobHandler - Factory method with dependency injection
+func NewJobHandler(scheduler *services.Scheduler) *JobHandler {
+	return &JobHandler{scheduler: scheduler}
+}
+
+// ListJobs - HTTP handler listing scheduled jobs with cadence, next run and latest run
+func (h *JobHandler) ListJobs(c *gin.Context) {
+	jobs, err := h.scheduler.Jobs()
+	if err != nil {
+		c.Error(apperrors.ErrInternal.WithMessage("Failed to fetch job runs"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    jobs,
+	})
+}
+
+// ListJobRuns - HTTP handler returning one job's run history, newest first (?limit=50)
+func (h *JobHandler) ListJobRuns(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	runs, err := h.scheduler.Runs(c.Param("name"), limit)
+	if err != nil {
+		c.Error(apperrors.ErrInternal.WithMessage("Failed to fetch job runs"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    runs,
+	})
+}
@@ -0,0 +1,92 @@
+// DESIGN PATTERN: Controller Pattern + Request Handler
+package handlers
+
+import (
+	"net/http"
+	"sender-service/repositories"
+	"sender-service/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsHandler - Exposes database query metrics for diagnosing hotspots
+type MetricsHandler struct {
+	queryLogger  *repositories.QueryLogger       // Composition: HAS-A query metrics source
+	claimLatency *services.ClaimLatencyService   // Composition: HAS-A claim latency histogram
+	volume       *services.TransferVolumeService // Composition: HAS-A volume counters
+	rejections   *services.RejectionMetrics      // Composition: HAS-A rejection counters
+	dispatcher   *services.OutboxDispatcher      // Composition: HAS-A deferred notification backlog
+	canary       *services.CanaryService         // Composition: HAS-A end-to-end self-test
+}
+
+// NewMetricsHandler - Factory method with dependency injection
+func NewMetricsHandler(queryLogger *repositories.QueryLogger, claimLatency *services.ClaimLatencyService, volume *services.TransferVolumeService,
+	rejections *services.RejectionMetrics, dispatcher *services.OutboxDispatcher, canary *services.CanaryService) *MetricsHandler {
+	return &MetricsHandler{queryLogger: queryLogger, claimLatency: claimLatency, volume: volume, rejections: rejections, dispatcher: dispatcher, canary: canary}
+}
+
+// QueryMetrics - HTTP handler returning per-query-type counts, errors and slow queries
+func (h *MetricsHandler) QueryMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.queryLogger.Snapshot(),
+	})
+}
+
+// ClaimLatencyMetrics - HTTP handler returning the live claim latency histogram (since process start)
+func (h *MetricsHandler) ClaimLatencyMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.claimLatency.Live(),
+	})
+}
+
+// TransferVolumeMetrics - HTTP handler returning live hourly transfer/points counters (last 48h, since process start)
+func (h *MetricsHandler) TransferVolumeMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.volume.Live(),
+	})
+}
+
+// RejectionMetrics - HTTP handler returning initiation/claim outcomes labeled by error code (since process start)
+func (h *MetricsHandler) RejectionMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.rejections.Snapshot(),
+	})
+}
+
+// NotificationMetrics - HTTP handler returning the email circuit state and the deferred claim notification count
+func (h *MetricsHandler) NotificationMetrics(c *gin.Context) {
+	backlog, err := h.dispatcher.NotificationBacklog()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    backlog,
+	})
+}
+
+// EmailQueueMetrics - HTTP handler returning the durable email queue: backlog, retrying jobs and dead jobs
+func (h *MetricsHandler) EmailQueueMetrics(c *gin.Context) {
+	queue, err := h.dispatcher.EmailQueue()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    queue,
+	})
+}
+
+// CanaryMetrics - HTTP handler returning end-to-end health from the synthetic canary transfers (since process start)
+func (h *MetricsHandler) CanaryMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.canary.Status(),
+	})
+}
@@ -0,0 +1,57 @@
+// DESIGN PATTERN: Controller Pattern + Request Handler
+package handlers
+
+import (
+	"net/http"
+	"sender-service/apperrors"
+	"sender-service/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler - Handles HTTP requests for in-app notifications
+type NotificationHandler struct {
+	notificationService *services.NotificationService // Composition: HAS-A business service
+}
+
+// NewNotificationHandler - Factory method with dependency injection
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+// GetNotifications - HTTP handler listing the caller's notifications (?unread=true for unread only)
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, ok := requireSelf(c)
+	if !ok {
+		return
+	}
+
+	notifications, err := h.notificationService.List(userID, c.Query("unread") == "true")
+	if err != nil {
+		c.Error(apperrors.ErrInternal.WithMessage("Failed to fetch notifications"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    notifications,
+	})
+}
+
+// MarkNotificationRead - HTTP handler marking one notification as read
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	userID, ok := requireSelf(c)
+	if !ok {
+		return
+	}
+
+	if err := h.notificationService.MarkRead(userID, c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Notification marked as read",
+	})
+}
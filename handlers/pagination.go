@@ -0,0 +1,55 @@
+// DESIGN PATTERN: Helper (RFC 8288 Link headers and cursor headers for paginated lists)
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Cursor headers mirroring the JSON envelope (empty cursors are omitted)
+const (
+	nextCursorHeader = "X-Next-Cursor"
+	prevCursorHeader = "X-Prev-Cursor"
+)
+
+// pageLink - One Link header relation: the current request with some query parameters replaced
+type pageLink struct {
+	rel    string            // next, prev
+	params map[string]string // Query parameters to set; an empty value removes the parameter
+}
+
+// setPageLinks - Writes a Link header pointing at the current path and query with each link's parameters
+// applied (relative references, resolved against the request URL like Location)
+func setPageLinks(c *gin.Context, links ...pageLink) {
+	values := make([]string, 0, len(links))
+	for _, link := range links {
+		query := c.Request.URL.Query()
+		for key, value := range link.params {
+			if value == "" {
+				query.Del(key)
+				continue
+			}
+			query.Set(key, value)
+		}
+		target := c.Request.URL.Path
+		if encoded := query.Encode(); encoded != "" {
+			target += "?" + encoded
+		}
+		values = append(values, fmt.Sprintf("<%s>; rel=\"%s\"", target, link.rel))
+	}
+	if len(values) > 0 {
+		c.Header("Link", strings.Join(values, ", "))
+	}
+}
+
+// setCursorHeaders - Exposes next/prev cursors as headers for clients that do not parse the body
+func setCursorHeaders(c *gin.Context, next, prev string) {
+	if next != "" {
+		c.Header(nextCursorHeader, next)
+	}
+	if prev != "" {
+		c.Header(prevCursorHeader, prev)
+	}
+}
@@ -0,0 +1,75 @@
+// DESIGN PATTERN: Controller Pattern + Request Handler
+package handlers
+
+import (
+	"net/http"
+	"sender-service/apperrors"
+	"sender-service/middleware"
+	"sender-service/models"
+	"sender-service/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreferenceHandler - Handles HTTP requests for notification preferences
+type PreferenceHandler struct {
+	preferenceService *services.PreferenceService // Composition: HAS-A business service
+}
+
+// NewPreferenceHandler - Factory method with dependency injection
+func NewPreferenceHandler(preferenceService *services.PreferenceService) *PreferenceHandler {
+	return &PreferenceHandler{preferenceService: preferenceService}
+}
+
+// GetPreferences - HTTP handler returning the caller's notification preferences
+func (h *PreferenceHandler) GetPreferences(c *gin.Context) {
+	userID, ok := requireSelf(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.preferenceService.Get(userID),
+	})
+}
+
+// UpdatePreferences - HTTP handler changing digest opt-in
+func (h *PreferenceHandler) UpdatePreferences(c *gin.Context) {
+	userID, ok := requireSelf(c)
+	if !ok {
+		return
+	}
+
+	var req models.PreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return
+	}
+
+	preference, err := h.preferenceService.Update(userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Preferences updated",
+		"data":    preference,
+	})
+}
+
+// requireSelf - Ensures the authenticated user (access token subject) matches :userId
+func requireSelf(c *gin.Context) (string, bool) {
+	userID := c.GetString(middleware.UserIDKey)
+	if userID == "" {
+		c.Error(apperrors.ErrAuthRequired)
+		return "", false
+	}
+	if userID != c.Param("userId") {
+		c.Error(apperrors.ErrNotOwner)
+		return "", false
+	}
+	return userID, true
+}
@@ -0,0 +1,30 @@
+// DESIGN PATTERN: Controller Pattern + Request Handler
+package handlers
+
+import (
+	"net/http"
+	"sender-service/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProgramHandler - Exposes the point program conversion table
+type ProgramHandler struct {
+	conversions *services.ConversionTable // Composition: HAS-A rate table
+}
+
+// NewProgramHandler - Factory method with dependency injection
+func NewProgramHandler(conversions *services.ConversionTable) *ProgramHandler {
+	return &ProgramHandler{conversions: conversions}
+}
+
+// GetRates - HTTP handler listing cross-program conversion rates
+func (h *ProgramHandler) GetRates(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"default_program": h.conversions.DefaultProgram(),
+			"rates":           h.conversions.Rates(),
+		},
+	})
+}
@@ -0,0 +1,143 @@
+// DESIGN PATTERN: Controller Pattern + Request Handler
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sender-service/apperrors"
+	"sender-service/services"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errInvalidReportDate - Unparseable ?from= or ?to=
+var errInvalidReportDate = apperrors.New(apperrors.ErrInvalidInput, "invalid_report_date", "from/to must be YYYY-MM-DD or RFC 3339")
+
+// ReportHandler - Admin compliance reports
+type ReportHandler struct {
+	escheatmentService *services.EscheatmentService    // Composition: HAS-A reporting service
+	claimLatency       *services.ClaimLatencyService   // Composition: HAS-A claim latency analytics
+	experiments        *services.ExperimentService     // Composition: HAS-A experiment cohorts
+	volume             *services.TransferVolumeService // Composition: HAS-A volume series
+}
+
+// NewReportHandler - Factory method with dependency injection
+func NewReportHandler(escheatmentService *services.EscheatmentService,
+	claimLatency *services.ClaimLatencyService,
+	experiments *services.ExperimentService,
+	volume *services.TransferVolumeService) *ReportHandler {
+	return &ReportHandler{escheatmentService: escheatmentService, claimLatency: claimLatency, experiments: experiments, volume: volume}
+}
+
+// EscheatmentReport - HTTP handler for unclaimed points per sender and period (?format=csv to export)
+func (h *ReportHandler) EscheatmentReport(c *gin.Context) {
+	from, errFrom := parseReportDate(c.Query("from"))
+	to, errTo := parseReportDate(c.Query("to"))
+	if errFrom != nil || errTo != nil {
+		c.Error(errInvalidReportDate)
+		return
+	}
+
+	period := c.DefaultQuery("period", "month")
+	rows, err := h.escheatmentService.Report(period, from, to)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=escheatment-%s.csv", period))
+		if err := h.escheatmentService.WriteCSV(c.Writer, rows); err != nil {
+			fmt.Printf("Failed to write escheatment export: %v\n", err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    rows,
+	})
+}
+
+// ClaimLatencyReport - HTTP handler for the created->completed latency distribution (tunes expiry/reminders)
+func (h *ReportHandler) ClaimLatencyReport(c *gin.Context) {
+	from, errFrom := parseReportDate(c.Query("from"))
+	to, errTo := parseReportDate(c.Query("to"))
+	if errFrom != nil || errTo != nil {
+		c.Error(errInvalidReportDate)
+		return
+	}
+
+	histogram, err := h.claimLatency.Report(from, to)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    histogram,
+	})
+}
+
+// ListExperiments - HTTP handler listing configured experiments and their variants
+func (h *ReportHandler) ListExperiments(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.experiments.Experiments(),
+	})
+}
+
+// ExperimentReport - HTTP handler for claim conversion per cohort of one experiment
+func (h *ReportHandler) ExperimentReport(c *gin.Context) {
+	from, errFrom := parseReportDate(c.Query("from"))
+	to, errTo := parseReportDate(c.Query("to"))
+	if errFrom != nil || errTo != nil {
+		c.Error(errInvalidReportDate)
+		return
+	}
+
+	rows, err := h.experiments.Report(c.Param("name"), from, to)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    rows,
+	})
+}
+
+// TransferVolumeReport - HTTP handler for daily transfer/points volume over the last ?days= (default 30)
+func (h *ReportHandler) TransferVolumeReport(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil {
+		days = 0 // Rejected below with the range message
+	}
+
+	series, err := h.volume.Daily(days)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    series,
+	})
+}
+
+// parseReportDate - Accepts a plain date or a full timestamp ("" means unset)
+func parseReportDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,52 @@
+// DESIGN PATTERN: Controller Pattern + Request Handler
+package handlers
+
+import (
+	"net/http"
+	"sender-service/apperrors"
+	"sender-service/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SagaHandler - Handles admin HTTP requests for saga compensations
+type SagaHandler struct {
+	sagaService *services.SagaService // Composition: HAS-A business service
+}
+
+// NewSagaHandler - Factory method with dependency injection
+func NewSagaHandler(sagaService *services.SagaService) *SagaHandler {
+	return &SagaHandler{sagaService: sagaService}
+}
+
+// ListSagaSteps - HTTP handler listing compensations (?status=pending|failed|succeeded&limit=100)
+func (h *SagaHandler) ListSagaSteps(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	steps, err := h.sagaService.List(c.Query("status"), limit)
+	if err != nil {
+		c.Error(apperrors.ErrInternal.WithMessage("Failed to fetch saga steps"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    steps,
+	})
+}
+
+// RetrySagaStep - HTTP handler re-applying a stuck compensation
+func (h *SagaHandler) RetrySagaStep(c *gin.Context) {
+	step, err := h.sagaService.Retry(c.Param("id"))
+	if err != nil {
+		c.Error(apperrors.WithDetails(err, step))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Compensation applied",
+		"data":    step,
+	})
+}
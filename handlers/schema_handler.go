@@ -0,0 +1,50 @@
+// DESIGN PATTERN: Controller Pattern + Registry Lookup
+package handlers
+
+import (
+	"net/http"
+	"sender-service/apperrors"
+	"sender-service/schemas"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errSchemaNotFound - Unknown schema name
+var errSchemaNotFound = apperrors.New(apperrors.ErrNotFound, "schema_not_found", "Schema not found")
+
+// SchemaHandler - Serves published event schema definitions to consumers
+type SchemaHandler struct{}
+
+// NewSchemaHandler - Factory method for schema handler
+func NewSchemaHandler() *SchemaHandler {
+	return &SchemaHandler{}
+}
+
+// ListSchemas - HTTP handler listing every published event schema
+func (h *SchemaHandler) ListSchemas(c *gin.Context) {
+	type schemaRef struct {
+		Name string `json:"name"` // Schema identifier (type.vN)
+		URL  string `json:"url"`  // Path to fetch the definition
+	}
+
+	refs := []schemaRef{}
+	for _, name := range schemas.Names() {
+		refs = append(refs, schemaRef{Name: name, URL: "/schemas/" + name})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    refs,
+	})
+}
+
+// GetSchema - HTTP handler returning a single raw JSON Schema definition
+func (h *SchemaHandler) GetSchema(c *gin.Context) {
+	definition, ok := schemas.Get(c.Param("name"))
+	if !ok {
+		c.Error(errSchemaNotFound)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/schema+json", definition)
+}
@@ -2,100 +2,601 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/middleware"
 	"sender-service/models"
 	"sender-service/services"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+// consistencyTokenHeader - Carries read-your-writes tokens in both directions
+const consistencyTokenHeader = "X-Consistency-Token"
+
+// Client retry de-duplication on POST /transfer
+const (
+	idempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
+)
+
+// errIdempotencyKeyTooLong - Keys are stored in an indexed column
+var errIdempotencyKeyTooLong = apperrors.New(apperrors.ErrInvalidInput, "idempotency_key_too_long", "Idempotency-Key must be at most 255 characters")
+
 // TransferHandler - Handles HTTP requests for transfer operations
 type TransferHandler struct {
 	transferService *services.TransferService // Composition: HAS-A business service
+	initiationQueue *services.InitiationQueue // Composition: HAS-A async initiation queue
+	throttle        *services.ClaimThrottle   // Composition: HAS-A claim rate limit (Retry-After)
+	clock           clock.Clock               // Composition: HAS-A time source (expires_in_seconds)
+	asyncByDefault  bool                      // INITIATION_MODE=async
 }
 
 // NewTransferHandler - Factory method with dependency injection
-func NewTransferHandler(transferService *services.TransferService) *TransferHandler {
-	return &TransferHandler{transferService: transferService}
+func NewTransferHandler(transferService *services.TransferService, initiationQueue *services.InitiationQueue, throttle *services.ClaimThrottle,
+	clk clock.Clock, cfg *config.Config) *TransferHandler {
+	return &TransferHandler{
+		transferService: transferService,
+		initiationQueue: initiationQueue,
+		throttle:        throttle,
+		clock:           clk,
+		asyncByDefault:  cfg.Initiation.Mode == "async",
+	}
 }
 
 // InitiateTransfer - HTTP handler to create a new points transfer
 func (h *TransferHandler) InitiateTransfer(c *gin.Context) {
 	var req models.TransferRequest
 
-	// 1. REQUEST VALIDATION: Parse and validate JSON input
+	// 1. REQUEST VALIDATION: Parse and validate JSON input (and ?tz=, before anything is written)
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   "Invalid request data",
-			"details": err.Error(), // Development details
-		})
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return
+	}
+	view, ok := presentation(c, h.clock.Now())
+	if !ok {
 		return
 	}
 
-	// 2. AUTHENTICATION: Extract user ID from header (simplified JWT)
-	userID := c.GetHeader("X-User-ID")
+	// 2. AUTHENTICATION: User verified by the UserAuth middleware
+	userID := c.GetString(middleware.UserIDKey)
 	if userID == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"success": false,
-			"error":   "User authentication required",
+		c.Error(apperrors.ErrAuthRequired)
+		return
+	}
+
+	// 3. IDEMPOTENCY: Retries with the same key get the original transfer back
+	req.IdempotencyKey = strings.TrimSpace(c.GetHeader(idempotencyKeyHeader))
+	if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+		c.Error(errIdempotencyKeyTooLong)
+		return
+	}
+	replay, err := h.transferService.Replay(userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if replay != nil {
+		c.Header("Idempotent-Replayed", "true")
+		c.JSON(http.StatusCreated, gin.H{
+			"success": true,
+			"message": "Transfer initiated successfully",
+			"data":    presentTransfer(replay, view),
 		})
 		return
 	}
 
-	// 3. BUSINESS LOGIC: Delegate to service layer
+	// 4. ASYNC MODE: Enqueue and answer 202 immediately (config or Prefer: respond-async)
+	if h.asyncByDefault || prefersAsync(c) {
+		h.enqueueTransfer(c, userID, req)
+		return
+	}
+
+	// 5. BUSINESS LOGIC: Delegate to service layer
 	transfer, err := h.transferService.InitiateTransfer(userID, req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   err.Error(), // Business error
-		})
+		c.Error(err)
 		return
 	}
 
-	// 4. SUCCESS RESPONSE
-	c.JSON(http.StatusCreated, gin.H{
+	// 6. SUCCESS RESPONSE: Consistency token lets the next history read see this transfer
+	response := gin.H{
 		"success": true,
 		"message": "Transfer initiated successfully",
-		"data":    transfer,
+		"data":    presentTransfer(transfer, view),
+	}
+	if token := h.transferService.ConsistencyToken(transfer); token != "" {
+		c.Header(consistencyTokenHeader, token)
+		response["consistency_token"] = token
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+// InitiateBulkTransfer - HTTP handler creating one transfer per receiver, all or nothing
+func (h *TransferHandler) InitiateBulkTransfer(c *gin.Context) {
+	var req models.BulkTransferRequest
+
+	// 1. REQUEST VALIDATION: Parse and validate JSON input (and ?tz=, before anything is written)
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return
+	}
+	view, ok := presentation(c, h.clock.Now())
+	if !ok {
+		return
+	}
+
+	// 2. AUTHENTICATION: User verified by the UserAuth middleware
+	userID := c.GetString(middleware.UserIDKey)
+	if userID == "" {
+		c.Error(apperrors.ErrAuthRequired)
+		return
+	}
+
+	// 3. BUSINESS LOGIC: Delegate to service layer
+	transfers, err := h.transferService.InitiateBulkTransfer(userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// 4. SUCCESS RESPONSE: All transfers share the sender's shard, so one token covers them
+	data := make([]TransferView, len(transfers))
+	for i, transfer := range transfers {
+		data[i] = presentTransfer(transfer, view)
+	}
+	response := gin.H{
+		"success": true,
+		"message": fmt.Sprintf("%d transfers initiated successfully", len(transfers)),
+		"data":    data,
+	}
+	if token := h.transferService.ConsistencyToken(transfers[len(transfers)-1]); token != "" {
+		c.Header(consistencyTokenHeader, token)
+		response["consistency_token"] = token
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+// PreviewClaimEmail - HTTP handler rendering the claim email for the sender's inputs without sending it
+func (h *TransferHandler) PreviewClaimEmail(c *gin.Context) {
+	var req models.EmailPreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return
+	}
+
+	userID := c.GetString(middleware.UserIDKey)
+	if userID == "" {
+		c.Error(apperrors.ErrAuthRequired)
+		return
+	}
+
+	rendered, err := h.transferService.PreviewClaimEmail(userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"subject": rendered.Subject,
+			"html":    rendered.HTML,
+			"text":    rendered.Text,
+		},
 	})
 }
 
-// GetTransfers - HTTP handler to get user's transfer history
+// ListEmailThemes - HTTP handler listing the claim email themes senders can choose
+func (h *TransferHandler) ListEmailThemes(c *gin.Context) {
+	themes := make([]services.EmailTheme, 0)
+	for _, name := range services.EmailThemeNames() {
+		theme, _ := services.LookupEmailTheme(name)
+		themes = append(themes, theme)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    themes,
+	})
+}
+
+// enqueueTransfer - Queue-backed initiation: 202 Accepted with a pollable job
+func (h *TransferHandler) enqueueTransfer(c *gin.Context, userID string, req models.TransferRequest) {
+	job, err := h.initiationQueue.Enqueue(userID, req)
+	if err != nil {
+		c.Header("Retry-After", "5")
+		c.Error(err)
+		return
+	}
+
+	c.Header("Preference-Applied", "respond-async")
+	c.Header("Location", "/transfer/jobs/"+job.ID)
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"message": "Transfer initiation queued",
+		"data":    job,
+	})
+}
+
+// GetInitiationJob - HTTP handler polling an async initiation job
+func (h *TransferHandler) GetInitiationJob(c *gin.Context) {
+	job, err := h.initiationQueue.Get(c.Param("jobId"), c.GetString(middleware.UserIDKey))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    job,
+	})
+}
+
+// prefersAsync - RFC 7240 Prefer: respond-async
+func prefersAsync(c *gin.Context) bool {
+	for _, preference := range strings.Split(c.GetHeader("Prefer"), ",") {
+		if strings.EqualFold(strings.TrimSpace(preference), "respond-async") {
+			return true
+		}
+	}
+	return false
+}
+
+// bindOptionalJSON - Binds a JSON body that may be left out. The body is read whenever there is one,
+// including chunked requests whose length is unknown (-1); an empty body binds nothing
+func bindOptionalJSON(c *gin.Context, obj interface{}) error {
+	if c.Request.Body == nil || c.Request.Body == http.NoBody {
+		return nil
+	}
+	if err := c.ShouldBindJSON(obj); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// GetTransfers - HTTP handler to get one page of a user's transfer history
+// (?limit=&offset=&cursor= plus status, from, to, min_points, max_points, receiver_email, sort filters)
 func (h *TransferHandler) GetTransfers(c *gin.Context) {
-	userID := c.Param("userId") // Extract user ID from URL path
+	userID, ok := requireSelf(c) // Senders only see their own history
+	if !ok {
+		return
+	}
+
+	var (
+		page   models.PageRequest
+		filter models.TransferFilter
+	)
+	if err := c.ShouldBindQuery(&page); err != nil {
+		c.Error(apperrors.Invalid("Invalid pagination parameters", err))
+		return
+	}
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(apperrors.Invalid("Invalid filter parameters", err))
+		return
+	}
+	view, ok := presentation(c, h.clock.Now())
+	if !ok {
+		return
+	}
+
+	// READ-YOUR-WRITES: Token from a previous mutation (header or ?consistency_token=)
+	token := c.GetHeader(consistencyTokenHeader)
+	if token == "" {
+		token = c.Query("consistency_token")
+	}
 
-	transfers, err := h.transferService.GetUserTransfers(userID)
+	result, err := h.transferService.GetUserTransfers(userID, token, filter, page)
+	if errors.Is(err, services.ErrInvalidCursor) || errors.Is(err, services.ErrInvalidFilter) {
+		c.Error(err)
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to fetch transfers",
-		})
+		c.Error(apperrors.ErrInternal.WithMessage("Failed to fetch transfers"))
+		return
+	}
+
+	// LINKS: Cursors replace the offset, which was already applied to reach this page
+	var links []pageLink
+	if result.NextCursor != "" {
+		links = append(links, pageLink{rel: "next", params: map[string]string{"cursor": result.NextCursor, "offset": ""}})
+	}
+	if result.PrevCursor != "" {
+		links = append(links, pageLink{rel: "prev", params: map[string]string{"cursor": result.PrevCursor, "offset": ""}})
+	}
+	setPageLinks(c, links...)
+	setCursorHeaders(c, result.NextCursor, result.PrevCursor)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       presentTransfers(result.Transfers, view),
+		"pagination": result,
+	})
+}
+
+// UpdateTransferStatus - HTTP handler for trusted-service status changes with reason codes
+func (h *TransferHandler) UpdateTransferStatus(c *gin.Context) {
+	var req models.StatusChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return
+	}
+	view, ok := presentation(c, h.clock.Now())
+	if !ok {
+		return
+	}
+
+	transfer, err := h.transferService.ChangeStatus(c.Param("id"), req, c.GetString(middleware.ServiceNameKey), c.ClientIP())
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    transfers,
+		"message": "Transfer status updated",
+		"data":    presentTransfer(transfer, view),
 	})
 }
 
-// CompleteTransfer - HTTP handler for completing transfer (Saga Pattern step)
+// RecordEmailBounce - HTTP handler for bounces relayed from the email provider's webhook (trusted services)
+func (h *TransferHandler) RecordEmailBounce(c *gin.Context) {
+	var req models.EmailBounceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return
+	}
+
+	bounced, err := h.transferService.RecordEmailBounce(req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    gin.H{"transfers_flagged": bounced},
+	})
+}
+
+// GetTransfer - HTTP handler returning one of the requesting sender's transfers
+func (h *TransferHandler) GetTransfer(c *gin.Context) {
+	// AUTHENTICATION: Only the sender may read it here (verified by UserAuth); operators use /admin
+	userID := c.GetString(middleware.UserIDKey)
+	if userID == "" {
+		c.Error(apperrors.ErrAuthRequired)
+		return
+	}
+	h.respondWithTransfer(c, userID)
+}
+
+// GetTransferAdmin - HTTP handler returning any transfer (admin router, no ownership check)
+func (h *TransferHandler) GetTransferAdmin(c *gin.Context) {
+	h.respondWithTransfer(c, "")
+}
+
+// ExplainTransfer - HTTP handler evaluating why a transfer is stuck and what can be done next (admin router)
+func (h *TransferHandler) ExplainTransfer(c *gin.Context) {
+	explanation, err := h.transferService.ExplainTransfer(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    explanation,
+	})
+}
+
+// respondWithTransfer - Shared lookup/response for the sender and admin views
+func (h *TransferHandler) respondWithTransfer(c *gin.Context, senderID string) {
+	view, ok := presentation(c, h.clock.Now())
+	if !ok {
+		return
+	}
+	transfer, err := h.transferService.GetTransfer(c.Param("id"), senderID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    presentTransfer(transfer, view),
+	})
+}
+
+// GetTransferChain - HTTP handler listing the reissues, reversals and forwards linked to a transfer
+func (h *TransferHandler) GetTransferChain(c *gin.Context) {
+	view, ok := presentation(c, h.clock.Now())
+	if !ok {
+		return
+	}
+	chain, err := h.transferService.GetTransferChain(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    presentTransfers(chain, view),
+	})
+}
+
+// DeferClaim - HTTP handler letting the receiver save a claim for later (extends expiry once)
+func (h *TransferHandler) DeferClaim(c *gin.Context) {
+	transfer, err := h.transferService.DeferClaim(c.Param("token"), c.ClientIP())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Claim saved for later",
+		"data": gin.H{
+			"transfer_id": transfer.ID,
+			"expires_at":  transfer.ExpiresAt,
+			"deferred_at": transfer.DeferredAt,
+		},
+	})
+}
+
+// ExtendTransfer - HTTP handler letting the sender push a pending transfer's expiry forward
+func (h *TransferHandler) ExtendTransfer(c *gin.Context) {
+	// 1. AUTHENTICATION: Only the sender may extend (verified by UserAuth)
+	userID := c.GetString(middleware.UserIDKey)
+	if userID == "" {
+		c.Error(apperrors.ErrAuthRequired)
+		return
+	}
+
+	// 2. INPUT VALIDATION
+	var req models.ExtendTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return
+	}
+
+	// 3. BUSINESS LOGIC: Delegate to service layer
+	transfer, err := h.transferService.ExtendExpiration(c.Param("id"), userID, req, c.ClientIP())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Transfer expiry extended",
+		"data": gin.H{
+			"transfer_id": transfer.ID,
+			"expires_at":  transfer.ExpiresAt,
+		},
+	})
+}
+
+// ForwardClaim - HTTP handler letting the receiver regift an unclaimed transfer
+func (h *TransferHandler) ForwardClaim(c *gin.Context) {
+	var req models.ForwardTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return
+	}
+
+	child, err := h.transferService.ForwardClaim(c.Param("token"), req, c.ClientIP())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Transfer forwarded",
+		"data": gin.H{
+			"transfer_id":        child.ID,
+			"parent_transfer_id": child.ParentTransferID,
+			"receiver_email":     child.ReceiverEmail,
+			"expires_at":         child.ExpiresAt,
+		},
+	})
+}
+
+// DeclineClaim - HTTP handler letting the receiver turn a gift down (invalidates the claim link)
+func (h *TransferHandler) DeclineClaim(c *gin.Context) {
+	var req models.DeclineTransferRequest
+	if err := bindOptionalJSON(c, &req); err != nil {
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return
+	}
+
+	transfer, err := h.transferService.DeclineClaim(c.Param("token"), req, c.ClientIP())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Transfer declined",
+		"data": gin.H{
+			"transfer_id": transfer.ID,
+			"status":      transfer.Status,
+		},
+	})
+}
+
+// CompleteTransfer - HTTP handler for completing transfer (Saga Pattern step); trusted callers only (see routes)
 func (h *TransferHandler) CompleteTransfer(c *gin.Context) {
 	transferID := c.Param("id") // Extract transfer ID from URL path
 
+	// Optional body: passphrase for protected claims
+	var req models.CompleteTransferRequest
+	if err := bindOptionalJSON(c, &req); err != nil {
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return
+	}
+	view, ok := presentation(c, h.clock.Now())
+	if !ok {
+		return
+	}
+
 	// Delegate to service layer for business logic
-	err := h.transferService.CompleteTransfer(transferID)
+	transfer, replayed, err := h.transferService.CompleteTransfer(transferID, req.Passphrase, middleware.BearerToken(c), c.Request.UserAgent())
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
+	message := "Transfer completed successfully"
+	if replayed {
+		message = "Transfer already completed"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": message,
+		"data":    presentTransfer(transfer, view),
+	})
+}
+
+// ClaimTransfer - HTTP handler completing a transfer by the claim link token (receiver flow)
+func (h *TransferHandler) ClaimTransfer(c *gin.Context) {
+	// Optional body: passphrase for protected claims
+	var req models.CompleteTransferRequest
+	if err := bindOptionalJSON(c, &req); err != nil {
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return
+	}
+
+	transfer, replayed, err := h.transferService.ClaimTransfer(c.Param("token"), req.Passphrase, middleware.BearerToken(c), c.Request.UserAgent())
+	if err != nil {
+		if errors.Is(err, services.ErrClaimRateLimited) {
+			middleware.SetRetryAfter(c, h.throttle.RetryAfter())
+		}
+		c.Error(err)
+		return
+	}
+
+	message := "Transfer claimed successfully"
+	if replayed {
+		message = "Transfer already claimed"
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Transfer completed successfully",
+		"message": message,
+		"data": gin.H{
+			"transfer_id":  transfer.ID,
+			"points":       transfer.ConvertedPoints,
+			"program":      transfer.TargetProgram,
+			"sender_email": transfer.SenderEmail,
+			"status":       transfer.Status,
+		},
 	})
 }
@@ -0,0 +1,113 @@
+// DESIGN PATTERN: Presenter Pattern (API view models)
+package handlers
+
+import (
+	"sender-service/apperrors"
+	"sender-service/i18n"
+	"sender-service/models"
+	"time"
+	_ "time/tzdata" // ?tz= works in images without a zoneinfo database
+
+	"github.com/gin-gonic/gin"
+)
+
+// errInvalidTimeZone - ?tz= is not an IANA zone name
+var errInvalidTimeZone = apperrors.New(apperrors.ErrInvalidInput, "invalid_time_zone", "tz must be an IANA time zone such as Europe/Paris")
+
+// TransferView - Transfer as returned by the API: machine status plus localized label, timestamps in UTC
+type TransferView struct {
+	models.Transfer
+	StatusLabel      string           `json:"status_label"`                 // Localized, human-readable status (Accept-Language)
+	ExpiresInSeconds *int64           `json:"expires_in_seconds,omitempty"` // Seconds left to claim (pending transfers only; 0 once lapsed)
+	Display          *TransferDisplay `json:"display,omitempty"`            // Localized timestamps (only with ?tz=)
+}
+
+// TransferDisplay - Timestamps rendered for people in the requested zone and the response language
+type TransferDisplay struct {
+	TimeZone    string `json:"time_zone"`               // Zone the strings are rendered in (?tz=)
+	CreatedAt   string `json:"created_at"`              // e.g. "2 mars 2026 à 14:05 CET" (fr, Europe/Paris)
+	UpdatedAt   string `json:"updated_at"`              // Last change
+	ExpiresAt   string `json:"expires_at"`              // Claim deadline
+	EmailSentAt string `json:"email_sent_at,omitempty"` // Claim notification delivered
+}
+
+// transferPresentation - Per-request presenter settings
+type transferPresentation struct {
+	locale   string         // Negotiated response language
+	location *time.Location // ?tz= zone (nil = no display strings)
+	now      time.Time      // Reference for expires_in_seconds
+}
+
+// requestLocale - Negotiates the response locale and advertises it via Content-Language
+func requestLocale(c *gin.Context) string {
+	locale := i18n.Negotiate(c.GetHeader("Accept-Language"))
+	c.Header("Content-Language", locale)
+	return locale
+}
+
+// presentation - Reads the response language and ?tz=; an unknown zone is answered with 400.
+// Call it before any state change so a bad zone never follows a successful write.
+func presentation(c *gin.Context, now time.Time) (transferPresentation, bool) {
+	view := transferPresentation{locale: requestLocale(c), now: now}
+	if tz := c.Query("tz"); tz != "" {
+		location, err := time.LoadLocation(tz)
+		if err != nil || tz == "Local" {
+			c.Error(errInvalidTimeZone)
+			return view, false
+		}
+		view.location = location
+	}
+	return view, true
+}
+
+// presentTransfer - Builds the API view of one transfer
+func presentTransfer(transfer *models.Transfer, view transferPresentation) TransferView {
+	result := TransferView{
+		Transfer:    utcTransfer(*transfer),
+		StatusLabel: i18n.StatusLabel(view.locale, string(transfer.Status)),
+	}
+	if transfer.Status == models.TransferStatusPending {
+		remaining := int64(transfer.ExpiresAt.Sub(view.now) / time.Second)
+		if remaining < 0 {
+			remaining = 0
+		}
+		result.ExpiresInSeconds = &remaining
+	}
+	if view.location != nil {
+		display := &TransferDisplay{
+			TimeZone:  view.location.String(),
+			CreatedAt: i18n.FormatDateTimeIn(view.locale, transfer.CreatedAt, view.location),
+			UpdatedAt: i18n.FormatDateTimeIn(view.locale, transfer.UpdatedAt, view.location),
+			ExpiresAt: i18n.FormatDateTimeIn(view.locale, transfer.ExpiresAt, view.location),
+		}
+		if transfer.EmailSentAt != nil {
+			display.EmailSentAt = i18n.FormatDateTimeIn(view.locale, *transfer.EmailSentAt, view.location)
+		}
+		result.Display = display
+	}
+	return result
+}
+
+// presentTransfers - Builds API views for a list of transfers
+func presentTransfers(transfers []models.Transfer, view transferPresentation) []TransferView {
+	views := make([]TransferView, 0, len(transfers))
+	for i := range transfers {
+		views = append(views, presentTransfer(&transfers[i], view))
+	}
+	return views
+}
+
+// utcTransfer - Copy of the transfer with every timestamp in UTC (the database driver returns local time)
+func utcTransfer(transfer models.Transfer) models.Transfer {
+	transfer.CreatedAt = transfer.CreatedAt.UTC()
+	transfer.UpdatedAt = transfer.UpdatedAt.UTC()
+	transfer.ExpiresAt = transfer.ExpiresAt.UTC()
+	for _, field := range []**time.Time{&transfer.PointsMutatedAt, &transfer.EmailSentAt, &transfer.EscheatableAt,
+		&transfer.NudgeAt, &transfer.NudgedAt, &transfer.DeferredAt, &transfer.EmailOpenedAt, &transfer.LinkClickedAt} {
+		if *field != nil {
+			utc := (*field).UTC()
+			*field = &utc
+		}
+	}
+	return transfer
+}
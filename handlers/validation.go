@@ -0,0 +1,28 @@
+// DESIGN PATTERN: Adapter (Go struct fields -> API field names in validation errors)
+package handlers
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// FIELD NAMES: Validation errors name the JSON key or query parameter clients sent, not the Go field
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		engine.RegisterTagNameFunc(apiFieldName)
+	}
+}
+
+// apiFieldName - json name, else form (query) name, else the Go field name
+func apiFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
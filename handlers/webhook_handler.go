@@ -0,0 +1,104 @@
+// DESIGN PATTERN: Controller Pattern + Request Handler
+package handlers
+
+import (
+	"net/http"
+	"sender-service/apperrors"
+	"sender-service/models"
+	"sender-service/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler - Handles admin HTTP requests for integrator webhook subscriptions
+type WebhookHandler struct {
+	webhookService *services.WebhookService // Composition: HAS-A business service
+}
+
+// NewWebhookHandler - Factory method with dependency injection
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+// ListWebhooks - HTTP handler listing subscriptions with their templates
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	subscriptions, err := h.webhookService.List()
+	if err != nil {
+		c.Error(apperrors.ErrInternal.WithMessage("Failed to fetch webhook subscriptions"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    subscriptions,
+	})
+}
+
+// CreateWebhook - HTTP handler registering a subscription
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req models.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return
+	}
+
+	subscription, err := h.webhookService.Create(req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    subscription,
+	})
+}
+
+// UpdateWebhook - HTTP handler replacing a subscription (URL, event types, template, active)
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	var req models.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperrors.Invalid("Invalid request data", err))
+		return
+	}
+
+	subscription, err := h.webhookService.Update(c.Param("id"), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    subscription,
+	})
+}
+
+// ListDeliveries - HTTP handler listing a subscription's deliveries (?status=pending|delivered|failed&limit=100)
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	deliveries, err := h.webhookService.Deliveries(c.Param("id"), c.Query("status"), limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    deliveries,
+	})
+}
+
+// DeleteWebhook - HTTP handler removing a subscription
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	if err := h.webhookService.Delete(c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Webhook subscription deleted",
+	})
+}
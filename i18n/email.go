@@ -0,0 +1,155 @@
+// DESIGN PATTERN: Registry Pattern (claim email catalogs) + Formatter (localized dates)
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// emailCatalogs - Claim email text per locale, merged into catalogs. Entries are trusted markup
+// (the email template escapes the arguments, not the entry); %-verbs take the arguments in order.
+var emailCatalogs = map[string]map[string]string{
+	"en": {
+		"claim.subject":       "You've Received Virtual Points!",
+		"claim.hello":         "Hello <strong>%s</strong>,",
+		"claim.received":      `Great news! You have received <span class="points">%d virtual points</span> from <strong>%s</strong>.`,
+		"claim.bundle":        "Included in this gift:",
+		"claim.bundle_points": "%d points",
+		"claim.badge":         "Badge:",
+		"claim.links":         "Links shared by the sender:",
+		"claim.button":        "Claim Your Points Now",
+		"claim.passphrase":    "<strong>Passphrase required:</strong> the sender protected this transfer. Ask them for the passphrase to claim it.",
+		"claim.hint":          "<strong>Hint:</strong> %s",
+		"claim.expiry":        "<strong>Important:</strong> This link expires on %s.",
+		"claim.signup":        "If you don't have an account yet, you'll be able to create one after clicking the link.",
+		"claim.account_email": "<strong>Email:</strong> Make sure to use <strong>%s</strong> when creating your account.",
+		"claim.promo_from":    "From Virtual Points",
+		"claim.learn_more":    "Learn more",
+		"claim.regards":       "Best regards,<br><strong>Virtual Points Team</strong>",
+		"claim.automated":     "This is an automated message, please do not reply to this email.",
+
+		"format.datetime": "January 2, 2006 at 15:04 MST",
+	},
+	"es": {
+		"claim.subject":       "¡Has recibido puntos virtuales!",
+		"claim.hello":         "Hola <strong>%s</strong>:",
+		"claim.received":      `¡Buenas noticias! Has recibido <span class="points">%d puntos virtuales</span> de <strong>%s</strong>.`,
+		"claim.bundle":        "Incluido en este regalo:",
+		"claim.bundle_points": "%d puntos",
+		"claim.badge":         "Insignia:",
+		"claim.links":         "Enlaces compartidos por el remitente:",
+		"claim.button":        "Reclama tus puntos ahora",
+		"claim.passphrase":    "<strong>Se requiere una frase de acceso:</strong> el remitente protegió esta transferencia. Pídele la frase de acceso para reclamarla.",
+		"claim.hint":          "<strong>Pista:</strong> %s",
+		"claim.expiry":        "<strong>Importante:</strong> este enlace caduca el %s.",
+		"claim.signup":        "Si todavía no tienes una cuenta, podrás crearla después de hacer clic en el enlace.",
+		"claim.account_email": "<strong>Correo electrónico:</strong> asegúrate de usar <strong>%s</strong> al crear tu cuenta.",
+		"claim.promo_from":    "De Virtual Points",
+		"claim.learn_more":    "Más información",
+		"claim.regards":       "Saludos cordiales,<br><strong>El equipo de Virtual Points</strong>",
+		"claim.automated":     "Este es un mensaje automático; por favor, no respondas a este correo.",
+
+		"theme.classic.headline":     "¡Has recibido puntos virtuales!",
+		"theme.celebration.headline": "¡A celebrar! ¡Aquí están tus puntos!",
+		"theme.thank_you.headline":   "¡Gracias! Has recibido puntos",
+
+		"format.datetime": "2 de January de 2006, 15:04 MST",
+
+		"month.1": "enero", "month.2": "febrero", "month.3": "marzo", "month.4": "abril",
+		"month.5": "mayo", "month.6": "junio", "month.7": "julio", "month.8": "agosto",
+		"month.9": "septiembre", "month.10": "octubre", "month.11": "noviembre", "month.12": "diciembre",
+	},
+	"fr": {
+		"claim.subject":       "Vous avez reçu des points virtuels !",
+		"claim.hello":         "Bonjour <strong>%s</strong>,",
+		"claim.received":      `Bonne nouvelle ! Vous avez reçu <span class="points">%d points virtuels</span> de la part de <strong>%s</strong>.`,
+		"claim.bundle":        "Inclus dans ce cadeau :",
+		"claim.bundle_points": "%d points",
+		"claim.badge":         "Badge :",
+		"claim.links":         "Liens partagés par l'expéditeur :",
+		"claim.button":        "Réclamer mes points",
+		"claim.passphrase":    "<strong>Phrase secrète requise :</strong> l'expéditeur a protégé ce transfert. Demandez-lui la phrase secrète pour le réclamer.",
+		"claim.hint":          "<strong>Indice :</strong> %s",
+		"claim.expiry":        "<strong>Important :</strong> ce lien expire le %s.",
+		"claim.signup":        "Si vous n'avez pas encore de compte, vous pourrez en créer un après avoir cliqué sur le lien.",
+		"claim.account_email": "<strong>E-mail :</strong> veillez à utiliser <strong>%s</strong> lors de la création de votre compte.",
+		"claim.promo_from":    "De la part de Virtual Points",
+		"claim.learn_more":    "En savoir plus",
+		"claim.regards":       "Cordialement,<br><strong>L'équipe Virtual Points</strong>",
+		"claim.automated":     "Ceci est un message automatique, merci de ne pas y répondre.",
+
+		"theme.classic.headline":     "Vous avez reçu des points virtuels !",
+		"theme.celebration.headline": "C'est la fête ! Vos points sont arrivés !",
+		"theme.thank_you.headline":   "Merci ! Vous avez reçu des points",
+
+		"format.datetime": "2 January 2006 à 15:04 MST",
+
+		"month.1": "janvier", "month.2": "février", "month.3": "mars", "month.4": "avril",
+		"month.5": "mai", "month.6": "juin", "month.7": "juillet", "month.8": "août",
+		"month.9": "septembre", "month.10": "octobre", "month.11": "novembre", "month.12": "décembre",
+	},
+	"de": {
+		"claim.subject":       "Sie haben virtuelle Punkte erhalten!",
+		"claim.hello":         "Hallo <strong>%s</strong>,",
+		"claim.received":      `Gute Nachrichten! Sie haben <span class="points">%d virtuelle Punkte</span> von <strong>%s</strong> erhalten.`,
+		"claim.bundle":        "In diesem Geschenk enthalten:",
+		"claim.bundle_points": "%d Punkte",
+		"claim.badge":         "Abzeichen:",
+		"claim.links":         "Vom Absender geteilte Links:",
+		"claim.button":        "Jetzt Punkte einlösen",
+		"claim.passphrase":    "<strong>Passphrase erforderlich:</strong> Der Absender hat diese Übertragung geschützt. Fragen Sie ihn nach der Passphrase, um sie einzulösen.",
+		"claim.hint":          "<strong>Hinweis:</strong> %s",
+		"claim.expiry":        "<strong>Wichtig:</strong> Dieser Link läuft am %s ab.",
+		"claim.signup":        "Falls Sie noch kein Konto haben, können Sie nach dem Klick auf den Link eines erstellen.",
+		"claim.account_email": "<strong>E-Mail:</strong> Verwenden Sie beim Erstellen Ihres Kontos unbedingt <strong>%s</strong>.",
+		"claim.promo_from":    "Von Virtual Points",
+		"claim.learn_more":    "Mehr erfahren",
+		"claim.regards":       "Viele Grüße,<br><strong>Ihr Virtual Points Team</strong>",
+		"claim.automated":     "Dies ist eine automatisch erstellte Nachricht, bitte antworten Sie nicht auf diese E-Mail.",
+
+		"theme.classic.headline":     "Sie haben virtuelle Punkte erhalten!",
+		"theme.celebration.headline": "Zeit zu feiern! Ihre Punkte sind da!",
+		"theme.thank_you.headline":   "Danke! Sie haben Punkte erhalten",
+
+		"format.datetime": "2. January 2006, 15:04 MST",
+
+		"month.1": "Januar", "month.2": "Februar", "month.3": "März", "month.4": "April",
+		"month.5": "Mai", "month.6": "Juni", "month.7": "Juli", "month.8": "August",
+		"month.9": "September", "month.10": "Oktober", "month.11": "November", "month.12": "Dezember",
+	},
+}
+
+func init() {
+	for locale, messages := range emailCatalogs {
+		for key, message := range messages {
+			catalogs[locale][key] = message
+		}
+	}
+}
+
+// Lookup - Message of a key in exactly this locale (no English fallback)
+func Lookup(locale, key string) (string, bool) {
+	message, ok := catalogs[locale][key]
+	return message, ok
+}
+
+// Format - Translates a key and fills its %-verbs with args
+func Format(locale, key string, args ...interface{}) string {
+	return fmt.Sprintf(T(locale, key), args...)
+}
+
+// FormatDateTime - UTC date and time in the locale's layout, with its month names
+func FormatDateTime(locale string, t time.Time) string {
+	return FormatDateTimeIn(locale, t, time.UTC)
+}
+
+// FormatDateTimeIn - Date and time in the given zone, in the locale's layout, with its month names
+func FormatDateTimeIn(locale string, t time.Time, loc *time.Location) string {
+	t = t.In(loc)
+	formatted := t.Format(T(locale, "format.datetime"))
+	if month, ok := Lookup(locale, fmt.Sprintf("month.%d", t.Month())); ok {
+		formatted = strings.Replace(formatted, t.Month().String(), month, 1)
+	}
+	return formatted
+}
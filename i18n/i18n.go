@@ -0,0 +1,134 @@
+// DESIGN PATTERN: Registry Pattern (message catalogs) + Content Negotiation
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale - Fallback when nothing in Accept-Language is supported
+const DefaultLocale = "en"
+
+// catalogs - Message catalogs keyed by locale, then message key
+var catalogs = map[string]map[string]string{
+	"en": {
+		"status.pending":   "Waiting to be claimed",
+		"status.completed": "Claimed",
+		"status.expired":   "Expired",
+		"status.cancelled": "Cancelled",
+		"status.failed":    "Failed",
+		"status.on_hold":   "On hold",
+		"status.forwarded": "Forwarded",
+		"status.declined":  "Declined",
+	},
+	"es": {
+		"status.pending":   "Pendiente de reclamar",
+		"status.completed": "Reclamado",
+		"status.expired":   "Caducado",
+		"status.cancelled": "Cancelado",
+		"status.failed":    "Fallido",
+		"status.on_hold":   "En espera",
+		"status.forwarded": "Reenviado",
+		"status.declined":  "Rechazado",
+	},
+	"fr": {
+		"status.pending":   "En attente de réclamation",
+		"status.completed": "Réclamé",
+		"status.expired":   "Expiré",
+		"status.cancelled": "Annulé",
+		"status.failed":    "Échoué",
+		"status.on_hold":   "En attente de vérification",
+		"status.forwarded": "Transféré",
+		"status.declined":  "Refusé",
+	},
+	"de": {
+		"status.pending":   "Wartet auf Einlösung",
+		"status.completed": "Eingelöst",
+		"status.expired":   "Abgelaufen",
+		"status.cancelled": "Storniert",
+		"status.failed":    "Fehlgeschlagen",
+		"status.on_hold":   "Angehalten",
+		"status.forwarded": "Weitergeleitet",
+		"status.declined":  "Abgelehnt",
+	},
+}
+
+// Supported - Locales with a catalog
+func Supported() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// T - Translates a key, falling back to English and finally to the key itself
+func T(locale, key string) string {
+	if message, ok := catalogs[locale][key]; ok {
+		return message
+	}
+	if message, ok := catalogs[DefaultLocale][key]; ok {
+		return message
+	}
+	return key
+}
+
+// StatusLabel - Human-readable label for a transfer status code
+func StatusLabel(locale, status string) string {
+	return T(locale, "status."+status)
+}
+
+// Negotiate - Picks the best supported locale from an Accept-Language header (RFC 9110 q-values)
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		locale  string
+		quality float64
+	}
+
+	candidates := []candidate{}
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		candidates = append(candidates, candidate{locale: tag, quality: quality})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].quality > candidates[j].quality })
+
+	for _, c := range candidates {
+		if c.quality <= 0 {
+			continue
+		}
+		if locale := Match(c.locale); locale != "" {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// Match - Supported locale for a language tag ("es-MX" -> "es"), or "" when unsupported
+func Match(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if _, ok := catalogs[tag]; ok {
+		return tag
+	}
+	base := strings.SplitN(tag, "-", 2)[0]
+	if _, ok := catalogs[base]; ok {
+		return base
+	}
+	return ""
+}
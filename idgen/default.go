@@ -0,0 +1,11 @@
+//go:build !deterministic
+
+package idgen
+
+// Deterministic - Reports whether the binary was built with the deterministic test flag
+const Deterministic = false
+
+// New - Factory method: crypto/rand generator (seed is only honoured in deterministic builds)
+func New(seed int64, tokenBytes int) Generator {
+	return NewRandomGenerator(tokenBytes)
+}
@@ -0,0 +1,50 @@
+//go:build deterministic
+
+// DESIGN PATTERN: Strategy Pattern (reproducible identifiers for integration tests)
+package idgen
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+)
+
+// Deterministic - Reports whether the binary was built with the deterministic test flag
+const Deterministic = true
+
+// New - Factory method: seeded sequence generator (build with -tags deterministic)
+func New(seed int64, tokenBytes int) Generator {
+	return NewSequence(seed)
+}
+
+// Sequence - Reproducible generator: counters per prefix for IDs, seeded PRNG for tokens
+type Sequence struct {
+	mu       sync.Mutex
+	counters map[string]int // Next number per prefix
+	random   *rand.Rand     // Seeded token source
+}
+
+// NewSequence - Factory method; the same seed always yields the same IDs and tokens
+func NewSequence(seed int64) *Sequence {
+	return &Sequence{
+		counters: map[string]int{},
+		random:   rand.New(rand.NewSource(seed)),
+	}
+}
+
+// NewID - Sequential identifier, e.g. transfer_000001
+func (s *Sequence) NewID(prefix string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counters[prefix]++
+	return fmt.Sprintf("%s_%06d", prefix, s.counters[prefix])
+}
+
+// NewToken - Token drawn from the seeded source
+func (s *Sequence) NewToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return fmt.Sprintf("token_%016x", s.random.Uint64())
+}
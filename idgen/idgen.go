@@ -0,0 +1,53 @@
+// DESIGN PATTERN: Strategy Pattern (identifier generation) + Factory Method
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// Generator - Produces entity identifiers and claim tokens
+type Generator interface {
+	NewID(prefix string) string // e.g. NewID("transfer") -> transfer_9f86d081884c7d659a2feaa0c55ad015
+	NewToken() string           // Claim token sent to the receiver
+}
+
+// Token sizes in random bytes
+const (
+	idBytes       = 16 // 128-bit identifiers
+	MinTokenBytes = 16 // Shortest claim token accepted from configuration
+)
+
+// RandomGenerator - Production generator backed by crypto/rand (unguessable IDs and tokens)
+type RandomGenerator struct {
+	tokenBytes int // Random bytes per claim token
+}
+
+// NewRandomGenerator - Factory method; token lengths below MinTokenBytes are raised to it
+func NewRandomGenerator(tokenBytes int) *RandomGenerator {
+	if tokenBytes < MinTokenBytes {
+		tokenBytes = MinTokenBytes
+	}
+	return &RandomGenerator{tokenBytes: tokenBytes}
+}
+
+// NewID - Prefixed 128-bit random identifier
+func (g *RandomGenerator) NewID(prefix string) string {
+	return fmt.Sprintf("%s_%s", prefix, hex.EncodeToString(randomBytes(idBytes)))
+}
+
+// NewToken - URL-safe random claim token
+func (g *RandomGenerator) NewToken() string {
+	return base64.RawURLEncoding.EncodeToString(randomBytes(g.tokenBytes))
+}
+
+// randomBytes - Reads from the OS CSPRNG; a failing CSPRNG is unrecoverable
+func randomBytes(n int) []byte {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		panic(fmt.Sprintf("idgen: crypto/rand unavailable: %v", err))
+	}
+	return buf
+}
@@ -0,0 +1,172 @@
+// DESIGN PATTERN: Value Object (key-ID-versioned secrets) + Key Rotation (primary writes, all read)
+package keyring
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownKey = errors.New("no configured key with this key ID")
+	ErrNoKeys     = errors.New("keyring is empty")
+	ErrDecrypt    = errors.New("ciphertext does not decrypt with its key")
+)
+
+// Key - One secret and the ID it is published under (e.g. "2024-06")
+type Key struct {
+	ID     string // Key ID (JWT "kid", webhook signature label, encrypted row marker)
+	Secret []byte // Raw secret as configured
+}
+
+// Keyring - Keys active during a rotation: the primary (first) key signs and encrypts, every key
+// still verifies and decrypts until it is removed from configuration
+type Keyring struct {
+	keys []Key // Primary first
+}
+
+// New - Keyring from keys in priority order (duplicate IDs keep the first)
+func New(keys ...Key) *Keyring {
+	r := &Keyring{}
+	for _, key := range keys {
+		if _, exists := r.Key(key.ID); !exists {
+			r.keys = append(r.keys, key)
+		}
+	}
+	return r
+}
+
+// Parse - Keyring from "kid:secret" entries, primary first (e.g. KEYS=2024-06:newsecret,2024-01:oldsecret)
+func Parse(entries []string) (*Keyring, error) {
+	keys := make([]Key, 0, len(entries))
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		id, secret, ok := strings.Cut(entry, ":")
+		id, secret = strings.TrimSpace(id), strings.TrimSpace(secret)
+		if !ok || id == "" || secret == "" {
+			return nil, errors.New("entries must be kid:secret")
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("key ID %q is listed twice", id)
+		}
+		seen[id] = true
+		keys = append(keys, Key{ID: id, Secret: []byte(secret)})
+	}
+	return New(keys...), nil
+}
+
+// Empty - Whether no key is configured (nil keyrings are empty)
+func (r *Keyring) Empty() bool {
+	return r == nil || len(r.keys) == 0
+}
+
+// Primary - Key used for new signatures and ciphertexts
+func (r *Keyring) Primary() (Key, error) {
+	if r.Empty() {
+		return Key{}, ErrNoKeys
+	}
+	return r.keys[0], nil
+}
+
+// Key - Key with the given ID
+func (r *Keyring) Key(id string) (Key, bool) {
+	if r == nil {
+		return Key{}, false
+	}
+	for _, key := range r.keys {
+		if key.ID == id {
+			return key, true
+		}
+	}
+	return Key{}, false
+}
+
+// Keys - All active keys, primary first
+func (r *Keyring) Keys() []Key {
+	if r == nil {
+		return nil
+	}
+	return append([]Key(nil), r.keys...)
+}
+
+// candidates - The named key when configured, otherwise every key (tokens minted before the issuer
+// started sending key IDs, or naming a kid this service calls differently)
+func (r *Keyring) candidates(id string) []Key {
+	if key, ok := r.Key(id); ok {
+		return []Key{key}
+	}
+	return r.Keys()
+}
+
+// MAC - HMAC-SHA256 of data under one key
+func MAC(key Key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key.Secret)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// VerifyMAC - Whether an HMAC-SHA256 was produced by the named key (or, when the ID is unknown or
+// empty, by any active key); returns the key that matched
+func (r *Keyring) VerifyMAC(id string, data, signature []byte) (string, bool) {
+	for _, key := range r.candidates(id) {
+		if hmac.Equal(MAC(key, data), signature) {
+			return key.ID, true
+		}
+	}
+	return "", false
+}
+
+// Seal - Encrypts with the primary key (AES-256-GCM, random nonce prepended; the key ID is
+// authenticated so a ciphertext cannot be relabelled)
+func (r *Keyring) Seal(plaintext []byte) (string, []byte, error) {
+	key, err := r.Primary()
+	if err != nil {
+		return "", nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, err
+	}
+	return key.ID, aead.Seal(nonce, nonce, plaintext, []byte(key.ID)), nil
+}
+
+// Open - Decrypts a ciphertext sealed under the named key
+func (r *Keyring) Open(id string, ciphertext []byte) ([]byte, error) {
+	key, ok := r.Key(id)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, id)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, []byte(id))
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// newAEAD - AES-256-GCM keyed by a SHA-256 derivation of the secret (secrets of any length, and
+// never the same bytes as the key's HMAC use)
+func newAEAD(key Key) (cipher.AEAD, error) {
+	derived := sha256.Sum256(append([]byte("sender-service/encryption\x00"), key.Secret...))
+	block, err := aes.NewCipher(derived[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,35 @@
+// DESIGN PATTERN: Chain of Responsibility (Gin middleware) + Guard Clause
+package middleware
+
+import (
+	"crypto/subtle"
+	"sender-service/apperrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errAdminDisabled     = apperrors.New(apperrors.ErrForbidden, "admin_api_disabled", "Admin API is disabled")
+	errAdminAuthRequired = apperrors.New(apperrors.ErrUnauthorized, "admin_auth_required", "Admin authentication required")
+)
+
+// AdminAuth - Guards operational endpoints with a shared admin key (X-Admin-Key header)
+func AdminAuth(apiKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Admin API is disabled entirely unless a key is configured
+		if apiKey == "" {
+			c.Error(errAdminDisabled)
+			c.Abort()
+			return
+		}
+
+		provided := c.GetHeader("X-Admin-Key")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(apiKey)) != 1 {
+			c.Error(errAdminAuthRequired)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,26 @@
+// DESIGN PATTERN: Chain of Responsibility (Gin middleware) + Guard Clause
+package middleware
+
+import "github.com/gin-gonic/gin"
+
+// CaptchaVerifier - Checks a CAPTCHA token with its provider
+type CaptchaVerifier interface {
+	VerifyCaptcha(token, remoteIP string) error
+}
+
+// Captcha - Requires a verified CAPTCHA token from X-Captcha-Token, or the captcha_token query
+// parameter for links opened in a browser
+func Captcha(verifier CaptchaVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader("X-Captcha-Token")
+		if token == "" {
+			token = c.Query("captcha_token")
+		}
+		if err := verifier.VerifyCaptcha(token, c.ClientIP()); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,38 @@
+// DESIGN PATTERN: Chain of Responsibility (Gin middleware) + Rate Limiter
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClaimLimiter - Counts claim link requests per client IP
+type ClaimLimiter interface {
+	AllowIP(ip string) error
+	RetryAfter() time.Duration
+}
+
+// ClaimRateLimit - Rejects claim attempts and previews with 429 + Retry-After once the client IP
+// exceeds its hourly budget (mount it on claim-token routes only)
+func ClaimRateLimit(limiter ClaimLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := limiter.AllowIP(c.ClientIP()); err != nil {
+			SetRetryAfter(c, limiter.RetryAfter())
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// SetRetryAfter - Retry-After header in whole seconds (at least 1)
+func SetRetryAfter(c *gin.Context, wait time.Duration) {
+	seconds := int(wait.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Header("Retry-After", strconv.Itoa(seconds))
+}
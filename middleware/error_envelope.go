@@ -0,0 +1,75 @@
+// DESIGN PATTERN: Chain of Responsibility (Gin middleware) + Central Error Mapping
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sender-service/apperrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorStatuses - Category -> HTTP status; every domain error refines exactly one category
+var errorStatuses = []struct {
+	category *apperrors.Error
+	status   int
+}{
+	{apperrors.ErrInvalidInput, http.StatusBadRequest},
+	{apperrors.ErrUnauthorized, http.StatusUnauthorized},
+	{apperrors.ErrForbidden, http.StatusForbidden},
+	{apperrors.ErrNotFound, http.StatusNotFound},
+	{apperrors.ErrConflict, http.StatusConflict},
+	{apperrors.ErrGone, http.StatusGone},
+	{apperrors.ErrUnprocessable, http.StatusUnprocessableEntity},
+	{apperrors.ErrLocked, http.StatusLocked},
+	{apperrors.ErrTooManyRequests, http.StatusTooManyRequests},
+	{apperrors.ErrUpstream, http.StatusBadGateway},
+	{apperrors.ErrUnavailable, http.StatusServiceUnavailable},
+}
+
+// ErrorEnvelope - Renders the error a handler or guard recorded with c.Error as
+// {"success": false, "code", "message", "details"} with the status of its category
+func ErrorEnvelope() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		// Handlers that already answered (or recorded nothing) are left alone
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		status, body := ErrorResponse(c.Errors.Last().Err)
+		c.JSON(status, body)
+	}
+}
+
+// ErrorResponse - Status and envelope for an error (unclassified errors are 500 internal_error).
+// Internal errors are answered with a generic message; the detail only goes to the server log
+func ErrorResponse(err error) (int, gin.H) {
+	domain := apperrors.From(err)
+
+	status := http.StatusInternalServerError
+	for _, mapping := range errorStatuses {
+		if errors.Is(domain, mapping.category) {
+			status = mapping.status
+			break
+		}
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		fmt.Printf("Internal error (%s): %v\n", domain.Code, err)
+		message = apperrors.ErrInternal.Message
+	}
+
+	body := gin.H{
+		"success": false,
+		"code":    domain.Code,
+		"message": message,
+		"error":   message, // Same text under the pre-envelope key for older clients
+	}
+	if domain.Details != nil {
+		body["details"] = domain.Details
+	}
+	return status, body
+}
@@ -0,0 +1,46 @@
+// DESIGN PATTERN: Chain of Responsibility (Gin middleware) + Guard Clause
+package middleware
+
+import (
+	"crypto/subtle"
+	"sender-service/apperrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errIntegrationDisabled   = apperrors.New(apperrors.ErrForbidden, "integration_api_disabled", "Integration API is disabled")
+	errIntegrationKeyMissing = apperrors.New(apperrors.ErrUnauthorized, "integration_key_required", "Integration API key required")
+)
+
+// IntegrationNameKey - Context key holding the authenticated integration
+const IntegrationNameKey = "integration_name"
+
+// IntegrationAuth - Guards the polling feed with per-integration API keys (X-API-Key)
+func IntegrationAuth(apiKeys map[string]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Feed is disabled entirely unless at least one integration key is configured
+		if len(apiKeys) == 0 {
+			c.Error(errIntegrationDisabled)
+			c.Abort()
+			return
+		}
+
+		// Compare against every key so timing does not reveal which integrations exist
+		provided := []byte(c.GetHeader("X-API-Key"))
+		matched := ""
+		for name, key := range apiKeys {
+			if subtle.ConstantTimeCompare(provided, []byte(key)) == 1 {
+				matched = name
+			}
+		}
+		if len(provided) == 0 || matched == "" {
+			c.Error(errIntegrationKeyMissing)
+			c.Abort()
+			return
+		}
+
+		c.Set(IntegrationNameKey, matched)
+		c.Next()
+	}
+}
@@ -0,0 +1,34 @@
+// DESIGN PATTERN: Chain of Responsibility (Gin middleware) + Circuit-Breaker-style Load Shedding
+package middleware
+
+import (
+	"sender-service/apperrors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errShedding - Initiations rejected while dependencies are degraded
+var errShedding = apperrors.New(apperrors.ErrUnavailable, "load_shedding", "Service is temporarily shedding load, please retry later")
+
+// HealthChecker - Reports whether downstream dependencies are degraded
+type HealthChecker interface {
+	Degraded() bool
+}
+
+// LoadShedding - Rejects new work with 503 + Retry-After while dependencies are degraded.
+// Mount it only on routes that start new sagas (initiations) so claims/completions keep flowing.
+func LoadShedding(health HealthChecker, retryAfter time.Duration) gin.HandlerFunc {
+	retryAfterSeconds := strconv.Itoa(int(retryAfter.Seconds()))
+
+	return func(c *gin.Context) {
+		if health.Degraded() {
+			c.Header("Retry-After", retryAfterSeconds)
+			c.Error(errShedding)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
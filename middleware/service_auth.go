@@ -0,0 +1,49 @@
+// DESIGN PATTERN: Chain of Responsibility (Gin middleware) + Guard Clause
+package middleware
+
+import (
+	"crypto/subtle"
+	"sender-service/apperrors"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errInternalDisabled    = apperrors.New(apperrors.ErrForbidden, "internal_api_disabled", "Internal API is disabled")
+	errServiceAuthRequired = apperrors.New(apperrors.ErrUnauthorized, "service_auth_required", "Service authentication required")
+	errServiceNotAllowed   = apperrors.New(apperrors.ErrForbidden, "service_not_allowed", "Service is not allowed to call this endpoint")
+)
+
+// ServiceNameKey - Context key holding the authenticated trusted service
+const ServiceNameKey = "service_name"
+
+// ServiceAuth - Guards internal endpoints with per-service keys (X-Service-Name + X-Service-Key);
+// with allowed services listed, only those may call (any keyed service otherwise)
+func ServiceAuth(serviceKeys map[string]string, allowed ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Internal API is disabled entirely unless at least one service key is configured
+		if len(serviceKeys) == 0 {
+			c.Error(errInternalDisabled)
+			c.Abort()
+			return
+		}
+
+		name := c.GetHeader("X-Service-Name")
+		expected, ok := serviceKeys[name]
+		provided := c.GetHeader("X-Service-Key")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(expected)) != 1 {
+			c.Error(errServiceAuthRequired)
+			c.Abort()
+			return
+		}
+		if len(allowed) > 0 && !slices.Contains(allowed, name) {
+			c.Error(errServiceNotAllowed)
+			c.Abort()
+			return
+		}
+
+		c.Set(ServiceNameKey, name)
+		c.Next()
+	}
+}
@@ -0,0 +1,71 @@
+// DESIGN PATTERN: Chain of Responsibility (Gin middleware) + Guard Clause
+package middleware
+
+import (
+	"sender-service/apperrors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Context keys holding the authenticated user
+const (
+	UserIDKey    = "user_id"    // Subject of the verified access token
+	UserRolesKey = "user_roles" // []string roles granted by the Auth Service
+)
+
+// UserTokenVerifier - Validates an Auth Service access token (JWT) and names its user
+type UserTokenVerifier interface {
+	VerifyUserToken(raw string) (userID string, roles []string, err error)
+}
+
+// UserAuth - Identifies the caller from "Authorization: Bearer <jwt>". With allowIDHeader, requests
+// without a token may still identify themselves with X-User-ID (local development only).
+func UserAuth(verifier UserTokenVerifier, allowIDHeader bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := BearerToken(c)
+		if token == "" {
+			if userID := c.GetHeader("X-User-ID"); allowIDHeader && userID != "" {
+				c.Set(UserIDKey, userID)
+				c.Set(UserRolesKey, []string{})
+				c.Next()
+				return
+			}
+			c.Header("WWW-Authenticate", `Bearer realm="sender-service"`)
+			c.Error(apperrors.ErrAuthRequired)
+			c.Abort()
+			return
+		}
+
+		userID, roles, err := verifier.VerifyUserToken(token)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer realm="sender-service", error="invalid_token"`)
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Set(UserIDKey, userID)
+		c.Set(UserRolesKey, roles)
+		c.Next()
+	}
+}
+
+// HasRole - Whether the authenticated user was granted a role
+func HasRole(c *gin.Context, role string) bool {
+	for _, granted := range c.GetStringSlice(UserRolesKey) {
+		if granted == role {
+			return true
+		}
+	}
+	return false
+}
+
+// BearerToken - Token from "Authorization: Bearer <token>" ("" when absent)
+func BearerToken(c *gin.Context) string {
+	scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,17 @@
+// DESIGN PATTERN: Entity Pattern (append-only audit trail)
+package models
+
+import "time"
+
+// TransferAuditLog - One recorded status change of a transfer
+type TransferAuditLog struct {
+	ID         string         `json:"id" gorm:"primaryKey"`              // Audit entry ID
+	TransferID string         `json:"transfer_id" gorm:"not null;index"` // Transfer that changed
+	FromStatus TransferStatus `json:"from_status" gorm:"not null"`       // Status before the change
+	ToStatus   TransferStatus `json:"to_status" gorm:"not null"`         // Status after the change
+	ReasonCode string         `json:"reason_code" gorm:"not null"`       // Machine reason code
+	Actor      string         `json:"actor" gorm:"not null"`             // Trusted service (or user) that made the change
+	ClientIP   string         `json:"client_ip,omitempty"`               // Real caller IP (behind trusted proxies)
+	Note       string         `json:"note,omitempty" gorm:"type:text"`   // Optional context
+	CreatedAt  time.Time      `json:"created_at"`                        // When the change was recorded
+}
@@ -0,0 +1,23 @@
+// DESIGN PATTERN: Composite Pattern (bundle of award items) + Value Object
+package models
+
+// Bundle item types
+const (
+	BundleItemPoints  = "points"  // Points award (summed into the transfer amount)
+	BundleItemBadge   = "badge"   // Recognition badge
+	BundleItemMessage = "message" // Message-only item (e.g. a citation)
+)
+
+// BundleItem - One award inside a transfer bundle
+type BundleItem struct {
+	Type    string `json:"type" binding:"required,oneof=points badge message"` // points, badge or message
+	Points  int    `json:"points,omitempty" binding:"min=0"`                   // Points items only
+	BadgeID string `json:"badge_id,omitempty" binding:"max=64"`                // Badge items only
+	Title   string `json:"title,omitempty" binding:"max=100"`                  // Badge name or item heading
+	Message string `json:"message,omitempty" binding:"max=500"`                // Message text (scanned like the gift message)
+}
+
+// TransferMetadata - Structured extras stored as JSON alongside the transfer
+type TransferMetadata struct {
+	Bundle []BundleItem `json:"bundle,omitempty"` // Award items rendered together in one email
+}
@@ -0,0 +1,11 @@
+// DESIGN PATTERN: Entity Pattern (fixed-window counters throttling claim link use)
+package models
+
+import "time"
+
+// ClaimRateCounter - Claim attempts and previews from one client IP or for one receiver address within one hour
+type ClaimRateCounter struct {
+	Key         string    `json:"key" gorm:"primaryKey"`                // "ip:" or "email:" + SHA-256 of the value (no addresses stored)
+	WindowStart time.Time `json:"window_start" gorm:"primaryKey;index"` // Start of the UTC hour counted
+	Count       int       `json:"count" gorm:"not null"`                // Requests in the window
+}
@@ -0,0 +1,18 @@
+// DESIGN PATTERN: Entity Pattern + Data Transfer Object (DTO)
+package models
+
+import "time"
+
+// MarketingConsent - Recorded opt-in/opt-out for promotional content, keyed by email
+type MarketingConsent struct {
+	Email     string    `json:"email" gorm:"primaryKey"` // Lower-cased address (receivers may not be registered)
+	Consented bool      `json:"consented"`               // Promotional content allowed
+	Source    string    `json:"source" gorm:"not null"`  // Where it was recorded: claim_link or a trusted service name
+	CreatedAt time.Time `json:"created_at"`              // First recorded
+	UpdatedAt time.Time `json:"updated_at"`              // Last change
+}
+
+// ConsentRequest - DTO for recording marketing consent
+type ConsentRequest struct {
+	Consented *bool `json:"consented" binding:"required"` // Explicit true/false
+}
@@ -0,0 +1,42 @@
+// DESIGN PATTERN: Dead Letter Channel (Entity)
+package models
+
+import "time"
+
+// Dead-letter kinds (the subsystem that gave up on the message)
+const (
+	DeadLetterKindEmail   = "email"   // Claim/notification email that could not be sent
+	DeadLetterKindEvent   = "event"   // Outbox event the relay could not deliver
+	DeadLetterKindWebhook = "webhook" // Webhook delivery that exhausted its retries
+	DeadLetterKindCredit  = "credit"  // Receiver credit of an auto-completed transfer that Auth Service rejected
+	DeadLetterKindOutbox  = "outbox"  // Transfer outbox message (email, notification, event) the dispatcher gave up on
+)
+
+// Dead-letter lifecycle states
+const (
+	DeadLetterOpen      = "open"      // Awaiting operator action
+	DeadLetterRetried   = "retried"   // Successfully re-driven
+	DeadLetterDiscarded = "discarded" // Dropped deliberately by an operator
+)
+
+// DeadLetter - Message that exhausted automatic retries and needs operator attention
+type DeadLetter struct {
+	ID             string              `json:"id" gorm:"primaryKey"`                             // Primary key
+	Kind           string              `json:"kind" gorm:"not null;index"`                       // email, event, webhook
+	ReferenceID    string              `json:"reference_id" gorm:"not null;index"`               // Transfer ID, outbox event ID, delivery ID
+	Payload        string              `json:"payload" gorm:"type:text"`                         // Original message (for inspection)
+	Reason         string              `json:"reason" gorm:"not null"`                           // Why it was dead-lettered
+	Attempts       int                 `json:"attempts"`                                         // Total attempts, automatic and manual
+	AttemptHistory []DeadLetterAttempt `json:"attempt_history" gorm:"serializer:json;type:text"` // Per-attempt outcomes
+	Status         string              `json:"status" gorm:"default:open;index"`                 // open, retried, discarded
+	ResolvedAt     *time.Time          `json:"resolved_at"`                                      // When retried or discarded
+	CreatedAt      time.Time           `json:"created_at"`                                       // Creation timestamp
+	UpdatedAt      time.Time           `json:"updated_at"`                                       // Last update timestamp
+}
+
+// DeadLetterAttempt - One delivery attempt recorded in the history
+type DeadLetterAttempt struct {
+	At     time.Time `json:"at"`     // When the attempt happened
+	Error  string    `json:"error"`  // Failure message (empty on success)
+	Manual bool      `json:"manual"` // Triggered by an operator retry
+}
@@ -0,0 +1,28 @@
+// DESIGN PATTERN: Entity (index of archived email copies) + Query Object
+package models
+
+import "time"
+
+// ArchivedEmail - Index row for one archived copy of a sent email; the message itself (headers + body)
+// lives in the configured archive store under StorageKey
+type ArchivedEmail struct {
+	ID         string     `json:"id" gorm:"primaryKey"`                              // email_<hex>
+	Recipient  string     `json:"recipient" gorm:"not null;index"`                   // To address
+	Template   string     `json:"template" gorm:"not null;index"`                    // Template the email was rendered from
+	Subject    string     `json:"subject"`                                           // Subject line as sent
+	StorageKey string     `json:"storage_key" gorm:"not null"`                       // Object key / relative path in the archive store
+	KeyID      string     `json:"key_id,omitempty" gorm:"not null;default:'';index"` // Encryption key of the stored copy ("" = plaintext)
+	Size       int        `json:"size"`                                              // Bytes of the message as sent
+	SHA256     string     `json:"sha256"`                                            // Digest of the message as sent (checked on retrieval, after decryption)
+	SentAt     time.Time  `json:"sent_at" gorm:"not null;index"`                     // When the provider accepted the email
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" gorm:"index"`                 // End of retention (nil = kept forever)
+}
+
+// EmailArchiveQuery - Query parameters filtering the archive listing
+type EmailArchiveQuery struct {
+	Recipient string `form:"recipient" binding:"omitempty,email"`     // Exact recipient address
+	Template  string `form:"template" binding:"max=64"`               // Template name
+	From      string `form:"from"`                                    // Sent at or after (YYYY-MM-DD or RFC 3339)
+	To        string `form:"to"`                                      // Sent before (YYYY-MM-DD or RFC 3339)
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=200"` // Page size (default 50)
+}
@@ -0,0 +1,18 @@
+// DESIGN PATTERN: Entity Pattern
+package models
+
+import "time"
+
+// EmailSendCount - Emails delivered through one SMTP provider on one UTC day
+type EmailSendCount struct {
+	Provider  string    `json:"provider" gorm:"primaryKey"` // SMTP host
+	Day       string    `json:"day" gorm:"primaryKey"`      // UTC date, YYYY-MM-DD
+	Sent      int       `json:"sent" gorm:"not null"`       // Successful deliveries
+	Deferred  int       `json:"deferred" gorm:"not null"`   // Non-urgent emails held back near quota
+	UpdatedAt time.Time `json:"updated_at"`                 // Last change
+}
+
+// EmailTestRequest - Body of POST /admin/email/test
+type EmailTestRequest struct {
+	To string `json:"to" binding:"required,email"` // Address the test message is sent to
+}
@@ -0,0 +1,12 @@
+// DESIGN PATTERN: Data Transfer Object (bulk re-queue of failed email jobs)
+package models
+
+// EmailRequeueRequest - Body of POST /admin/emails/requeue (empty filters match every job)
+type EmailRequeueRequest struct {
+	Status   string `json:"status" binding:"omitempty,oneof=failed retrying"` // failed (dead-lettered, default) or retrying
+	From     string `json:"from"`                                             // Queued at or after (YYYY-MM-DD or RFC 3339)
+	To       string `json:"to"`                                               // Queued before (YYYY-MM-DD or RFC 3339)
+	Provider string `json:"provider" binding:"max=255"`                       // Provider of the latest attempt (SMTP or API host, as in EMAIL_RATE_LIMITS)
+	Limit    int    `json:"limit" binding:"omitempty,min=1,max=5000"`         // Jobs per call (default 500)
+	DryRun   bool   `json:"dry_run"`                                          // List the matching jobs without re-queueing them
+}
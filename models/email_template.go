@@ -0,0 +1,37 @@
+// DESIGN PATTERN: Entity (versioned, per-tenant email template override)
+package models
+
+import "time"
+
+// EmailTemplateOverride - One uploaded version of a custom subject/body replacing a built-in email
+// for a tenant ("" = every tenant); at most one version per tenant and template is active
+type EmailTemplateOverride struct {
+	ID          uint       `json:"id" gorm:"primaryKey;autoIncrement"`                                       // Row identifier
+	Tenant      string     `json:"tenant" gorm:"not null;default:'';uniqueIndex:idx_email_template_version"` // White-label tenant ("" = deployment default)
+	Name        string     `json:"name" gorm:"not null;uniqueIndex:idx_email_template_version"`              // Built-in template it replaces (e.g. transfer_claim)
+	Version     int        `json:"version" gorm:"not null;uniqueIndex:idx_email_template_version"`           // 1, 2, 3... per tenant and template
+	Subject     string     `json:"subject" gorm:"type:text"`                                                 // text/template subject
+	HTML        string     `json:"html" gorm:"type:text"`                                                    // html/template body
+	Active      bool       `json:"active" gorm:"not null;default:false"`                                     // Used for sends (only after a clean lint)
+	ActivatedAt *time.Time `json:"activated_at,omitempty"`                                                   // Last activation
+	CreatedAt   time.Time  `json:"created_at"`                                                               // Upload time
+}
+
+// EmailTemplateUpload - DTO for linting or uploading a template
+type EmailTemplateUpload struct {
+	Subject string `json:"subject" binding:"required,max=200"` // text/template subject
+	HTML    string `json:"html" binding:"required,max=100000"` // html/template body
+}
+
+// EmailTemplateScope - Query parameters selecting whose templates an admin call touches
+type EmailTemplateScope struct {
+	Tenant  string `form:"tenant" binding:"max=64"`           // White-label tenant ("" = deployment default)
+	Version int    `form:"version" binding:"omitempty,min=1"` // Version to activate (default latest)
+}
+
+// TemplateLintReport - Result of validating a template before it may be activated
+type TemplateLintReport struct {
+	Valid    bool     `json:"valid"`              // No errors (warnings do not block activation)
+	Errors   []string `json:"errors,omitempty"`   // Parse/render failures, missing required variables, scripts
+	Warnings []string `json:"warnings,omitempty"` // Suspicious but allowed
+}
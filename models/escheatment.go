@@ -0,0 +1,20 @@
+// DESIGN PATTERN: Data Transfer Object (DTO) - Reporting read model
+package models
+
+import "time"
+
+// Escheatment reporting periods
+const (
+	EscheatmentPeriodMonth   = "month"
+	EscheatmentPeriodQuarter = "quarter"
+	EscheatmentPeriodYear    = "year"
+)
+
+// EscheatmentRow - Unclaimed points aggregated per sender and period
+type EscheatmentRow struct {
+	SenderID    string    `json:"sender_id"`    // Sender who still owns the points
+	SenderEmail string    `json:"sender_email"` // Sender contact
+	Period      time.Time `json:"period"`       // Start of the month/quarter/year the transfers expired in
+	Transfers   int       `json:"transfers"`    // Unclaimed transfer count
+	Points      int       `json:"points"`       // Unclaimed points total
+}
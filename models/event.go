@@ -0,0 +1,95 @@
+// DESIGN PATTERN: Domain Event + Data Transfer Object (DTO) Pattern
+package models
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Domain event types published by the sender service
+const (
+	EventTransferInitiated     = "transfer.initiated"      // New transfer created, claim email pending
+	EventTransferCompleted     = "transfer.completed"      // Receiver claimed, points deducted from sender
+	EventTransferFailed        = "transfer.failed"         // Saga aborted (e.g. insufficient points at claim time)
+	EventTransferStatusChanged = "transfer.status_changed" // Trusted service changed the status with a reason code
+	EventTransferClaimDeferred = "transfer.claim_deferred" // Receiver saved the claim for later (expiry extended once)
+)
+
+// DomainEvent - Versioned envelope for every event published to consumers
+type DomainEvent struct {
+	ID          string          `json:"id"`                 // Unique event identifier
+	Type        string          `json:"type"`               // Event type (e.g. transfer.initiated)
+	Version     int             `json:"version"`            // Schema version of Data
+	AggregateID string          `json:"aggregate_id"`       // Transfer ID the event belongs to
+	Sequence    int             `json:"sequence,omitempty"` // Per-aggregate order assigned by the outbox
+	OccurredAt  time.Time       `json:"occurred_at"`        // When the event happened
+	Data        json.RawMessage `json:"data"`               // Event-specific payload
+}
+
+// SchemaName - Identifier of the schema that validates this event (e.g. transfer.initiated.v1)
+func (e *DomainEvent) SchemaName() string {
+	return SchemaName(e.Type, e.Version)
+}
+
+// SchemaName - Builds the canonical schema identifier for an event type and version
+func SchemaName(eventType string, version int) string {
+	return eventType + ".v" + strconv.Itoa(version)
+}
+
+// TransferInitiatedData - Payload of transfer.initiated (v1)
+type TransferInitiatedData struct {
+	TransferID    string    `json:"transfer_id"`
+	SenderID      string    `json:"sender_id"`
+	ReceiverEmail string    `json:"receiver_email"`
+	Points        int       `json:"points"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// TransferCompletedData - Payload of transfer.completed (v1)
+type TransferCompletedData struct {
+	TransferID  string    `json:"transfer_id"`
+	SenderID    string    `json:"sender_id"`
+	Points      int       `json:"points"`
+	CompletedAt time.Time `json:"completed_at"`
+
+	SourceProgram   string  `json:"source_program,omitempty"`   // Added in v1 (optional)
+	TargetProgram   string  `json:"target_program,omitempty"`   // Added in v1 (optional)
+	ConversionRate  float64 `json:"conversion_rate,omitempty"`  // Added in v1 (optional)
+	ConvertedPoints int     `json:"converted_points,omitempty"` // Added in v1 (optional)
+
+	Engagement *TransferEngagement `json:"engagement,omitempty"` // Added in v1 (optional)
+}
+
+// TransferEngagement - Receiver funnel attached to transfer.completed (fields absent when not tracked)
+type TransferEngagement struct {
+	EmailOpenedAt *time.Time `json:"email_opened_at,omitempty"` // First claim email open
+	LinkClickedAt *time.Time `json:"link_clicked_at,omitempty"` // First claim link click
+	ClaimDevice   string     `json:"claim_device,omitempty"`    // mobile, tablet or desktop
+}
+
+// TransferFailedData - Payload of transfer.failed (v1)
+type TransferFailedData struct {
+	TransferID string `json:"transfer_id"`
+	SenderID   string `json:"sender_id"`
+	Points     int    `json:"points"`
+	Reason     string `json:"reason"`
+}
+
+// TransferClaimDeferredData - Payload of transfer.claim_deferred (v1)
+type TransferClaimDeferredData struct {
+	TransferID string    `json:"transfer_id"`
+	SenderID   string    `json:"sender_id"`
+	DeferredAt time.Time `json:"deferred_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// TransferStatusChangedData - Payload of transfer.status_changed (v1)
+type TransferStatusChangedData struct {
+	TransferID string         `json:"transfer_id"`
+	SenderID   string         `json:"sender_id"`
+	FromStatus TransferStatus `json:"from_status"`
+	ToStatus   TransferStatus `json:"to_status"`
+	ReasonCode string         `json:"reason_code"`
+	Actor      string         `json:"actor"`
+}
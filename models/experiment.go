@@ -0,0 +1,13 @@
+// DESIGN PATTERN: Data Transfer Object (DTO) - Experiment reporting
+package models
+
+// CohortConversion - Claim outcomes of one experiment variant
+type CohortConversion struct {
+	Variant        string  `json:"variant"`
+	Transfers      int64   `json:"transfers"`       // Transfers tagged with the variant
+	Completed      int64   `json:"completed"`       // Claimed
+	Expired        int64   `json:"expired"`         // Claim window ended
+	Pending        int64   `json:"pending"`         // Still open
+	Nudged         int64   `json:"nudged"`          // Sender was reminded
+	ConversionRate float64 `json:"conversion_rate"` // Completed / Transfers
+}
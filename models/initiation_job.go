@@ -0,0 +1,27 @@
+// DESIGN PATTERN: Producer-Consumer Pattern (persisted job entity)
+package models
+
+import "time"
+
+// Initiation job states
+const (
+	InitiationJobQueued     = "queued"     // Waiting for a worker on any instance
+	InitiationJobProcessing = "processing" // Claimed by a worker (claimed again once INITIATION_JOB_LEASE passes)
+	InitiationJobSucceeded  = "succeeded"  // Transfer created
+	InitiationJobFailed     = "failed"     // Initiation rejected or interrupted too often
+)
+
+// InitiationJob - Asynchronous transfer initiation, stored so it survives restarts and can be polled on any replica
+type InitiationJob struct {
+	ID               string           `json:"id" gorm:"primaryKey"`                                   // Job identifier returned with 202
+	SenderID         string           `json:"-" gorm:"not null;index"`                                // Owner (only they may poll it)
+	Request          *TransferRequest `json:"-" gorm:"serializer:json;type:text"`                     // Original request (cleared once finished: it carries the passphrase)
+	IdempotencyKey   string           `json:"-" gorm:"not null"`                                      // Request key, or one derived from the job so a re-run replays its transfer
+	Status           string           `json:"status" gorm:"not null;index:idx_initiation_job_status"` // queued, processing, succeeded, failed
+	Attempts         int              `json:"-"`                                                      // Times a worker claimed the job
+	TransferID       string           `json:"transfer_id,omitempty"`                                  // Created transfer on success
+	ConsistencyToken string           `json:"consistency_token,omitempty"`                            // Read-your-writes token for GET /transfers/:userId
+	Error            string           `json:"error,omitempty"`                                        // Failure reason
+	CreatedAt        time.Time        `json:"created_at"`                                             // Enqueue time
+	UpdatedAt        time.Time        `json:"updated_at" gorm:"index:idx_initiation_job_status"`      // Last state change (claim time while processing)
+}
@@ -0,0 +1,43 @@
+// DESIGN PATTERN: Data Transfer Object (polling feed for no-code integrations)
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Integration triggers exposed to polling tools (Zapier, IFTTT, Make)
+const (
+	TriggerTransferClaimed = "transfer.claimed" // transfer.completed: receiver claimed the gift
+	TriggerTransferExpired = "transfer.expired" // transfer.status_changed to expired: claim window ended
+)
+
+// Integration feed page sizes
+const (
+	DefaultIntegrationPageSize = 50  // Limit when the tool sends none
+	MaxIntegrationPageSize     = 100 // Largest limit accepted
+)
+
+// IntegrationFeedRequest - Query parameters of GET /integrations/events
+type IntegrationFeedRequest struct {
+	Since   string `form:"since"`                                   // next_cursor (or an event's cursor) from a previous poll; empty starts at the oldest event
+	Limit   int    `form:"limit" binding:"omitempty,min=1,max=100"` // Events per poll (default 50)
+	Trigger string `form:"trigger" binding:"omitempty,oneof=transfer.claimed transfer.expired"`
+}
+
+// IntegrationEvent - One trigger occurrence in a flat, tool-friendly shape
+type IntegrationEvent struct {
+	Cursor     string          `json:"cursor"`      // Stable position; doubles as the dedupe ID for polling tools
+	EventID    string          `json:"event_id"`    // Underlying domain event ID (same as webhook X-Event-ID)
+	Trigger    string          `json:"trigger"`     // transfer.claimed or transfer.expired
+	TransferID string          `json:"transfer_id"` // Transfer the event belongs to
+	OccurredAt time.Time       `json:"occurred_at"` // When it happened
+	Data       json.RawMessage `json:"data"`        // Domain event payload (see /schemas)
+}
+
+// IntegrationFeed - One poll's worth of events, oldest first
+type IntegrationFeed struct {
+	Events     []IntegrationEvent `json:"events"`      // Matching events after Since
+	NextCursor string             `json:"next_cursor"` // Pass as ?since= next time (unchanged when nothing new)
+	HasMore    bool               `json:"has_more"`    // More events are ready; poll again immediately
+}
@@ -0,0 +1,30 @@
+// DESIGN PATTERN: Entity Pattern (run history of scheduled jobs)
+package models
+
+import "time"
+
+// Job run outcomes
+const (
+	JobRunSucceeded = "succeeded" // The job returned without error
+	JobRunFailed    = "failed"    // The job returned an error (retried at its next scheduled time)
+)
+
+// JobLastRun - Start of the latest run of a cluster-wide job on any instance; written under the job's
+// advisory lock so replicas skip a period another instance already covered
+type JobLastRun struct {
+	Job       string    `json:"job" gorm:"primaryKey"`      // Job name, e.g. sender_digest
+	StartedAt time.Time `json:"started_at" gorm:"not null"` // When the latest run started
+	Instance  string    `json:"instance"`                   // Host that ran it
+}
+
+// JobRun - One execution of a scheduled job on one instance
+type JobRun struct {
+	ID         string    `json:"id" gorm:"primaryKey"`                                                 // Primary key
+	Job        string    `json:"job" gorm:"not null;index:idx_job_runs_job_started,priority:1"`        // Job name, e.g. expiration_sweep
+	Instance   string    `json:"instance"`                                                             // Host that ran it
+	Status     string    `json:"status" gorm:"not null"`                                               // succeeded, failed
+	Error      string    `json:"error,omitempty"`                                                      // Failure returned by the job
+	StartedAt  time.Time `json:"started_at" gorm:"not null;index:idx_job_runs_job_started,priority:2"` // Run start
+	FinishedAt time.Time `json:"finished_at"`                                                          // Run end
+	DurationMS int64     `json:"duration_ms"`                                                          // FinishedAt - StartedAt
+}
@@ -0,0 +1,22 @@
+// DESIGN PATTERN: Entity Pattern
+package models
+
+import "time"
+
+// Notification types
+const (
+	NotificationTransferReceived = "transfer_received" // Pending gift waiting to be claimed
+)
+
+// Notification - In-app notification shown to registered users on login
+type Notification struct {
+	ID         string     `json:"id" gorm:"primaryKey"`               // Notification ID
+	UserID     string     `json:"user_id" gorm:"not null;index"`      // Recipient (Auth Service user ID)
+	Type       string     `json:"type" gorm:"not null"`               // e.g. transfer_received
+	Title      string     `json:"title" gorm:"not null"`              // Short headline
+	Body       string     `json:"body" gorm:"type:text"`              // Notification text
+	TransferID string     `json:"transfer_id,omitempty" gorm:"index"` // Related transfer
+	ClaimURL   string     `json:"claim_url,omitempty"`                // Deep link into the claim flow
+	ReadAt     *time.Time `json:"read_at,omitempty"`                  // Set when marked read
+	CreatedAt  time.Time  `json:"created_at"`                         // Creation timestamp
+}
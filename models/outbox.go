@@ -0,0 +1,28 @@
+// DESIGN PATTERN: Transactional Outbox Pattern (Entity)
+package models
+
+import "time"
+
+// Outbox delivery states
+const (
+	OutboxPending   = "pending"   // Waiting for the relay
+	OutboxDelivered = "delivered" // Acknowledged by the sink (at-least-once marker)
+	OutboxDead      = "dead"      // Retries exhausted, dead-lettered (blocks later events of the aggregate)
+	OutboxDiscarded = "discarded" // Dropped by an operator from the dead-letter API
+)
+
+// OutboxEvent - Persisted domain event awaiting delivery by the outbox relay
+type OutboxEvent struct {
+	ID            uint       `json:"id" gorm:"primaryKey;autoIncrement"`                                // Global delivery order
+	EventID       string     `json:"event_id" gorm:"uniqueIndex;not null"`                              // DomainEvent.ID (consumer dedupe key)
+	EventType     string     `json:"event_type" gorm:"not null;index"`                                  // e.g. transfer.completed
+	AggregateID   string     `json:"aggregate_id" gorm:"not null;uniqueIndex:idx_outbox_aggregate_seq"` // Transfer ID
+	Sequence      int        `json:"sequence" gorm:"not null;uniqueIndex:idx_outbox_aggregate_seq"`     // Per-aggregate order (1, 2, 3...)
+	Payload       string     `json:"payload" gorm:"type:text;not null"`                                 // Serialized DomainEvent envelope
+	Status        string     `json:"status" gorm:"default:pending;index"`                               // pending, delivered, dead, discarded
+	Attempts      int        `json:"attempts" gorm:"default:0"`                                         // Delivery attempts so far
+	LastError     string     `json:"last_error"`                                                        // Most recent delivery error
+	NextAttemptAt time.Time  `json:"next_attempt_at"`                                                   // Earliest time of the next attempt
+	DeliveredAt   *time.Time `json:"delivered_at"`                                                      // When the sink acknowledged the event
+	CreatedAt     time.Time  `json:"created_at"`                                                        // Creation timestamp
+}
@@ -0,0 +1,28 @@
+// DESIGN PATTERN: Entity Pattern + Data Transfer Object (DTO)
+package models
+
+import "time"
+
+// Digest frequencies
+const (
+	DigestNone   = "none"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// NotificationPreference - Per-user opt-ins for non-transactional notifications
+type NotificationPreference struct {
+	UserID           string     `json:"user_id" gorm:"primaryKey"`                  // Auth Service user ID
+	Email            string     `json:"email" gorm:"not null"`                      // Where digests are sent
+	DigestFrequency  string     `json:"digest_frequency" gorm:"default:none;index"` // none, daily, weekly
+	LastDigestAt     *time.Time `json:"last_digest_at"`                             // Last digest sent
+	StaleNudgeOptOut bool       `json:"stale_nudge_opt_out"`                        // No reminders about unclaimed transfers
+	CreatedAt        time.Time  `json:"created_at"`                                 // Creation timestamp
+	UpdatedAt        time.Time  `json:"updated_at"`                                 // Last update timestamp
+}
+
+// PreferenceRequest - DTO for updating notification preferences
+type PreferenceRequest struct {
+	DigestFrequency  string `json:"digest_frequency" binding:"omitempty,oneof=none daily weekly"` // Digest opt-in (unchanged when empty)
+	StaleNudgeOptOut *bool  `json:"stale_nudge_opt_out"`                                          // Stale transfer reminders (unchanged when null)
+}
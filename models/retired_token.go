@@ -0,0 +1,12 @@
+// DESIGN PATTERN: Entity Pattern (tombstones of claim tokens no transfer row holds any more)
+package models
+
+import "time"
+
+// RetiredClaimToken - Hash of a claim token replaced by rotation or removed with its transfer; it stays
+// taken forever so a link that was once sent can never resolve to a different transfer
+type RetiredClaimToken struct {
+	TokenHash  string    `json:"-" gorm:"primaryKey"`                       // SHA-256 (hex) of the retired token
+	TransferID string    `json:"transfer_id" gorm:"index"`                  // Transfer that held it
+	RetiredAt  time.Time `json:"retired_at" gorm:"not null;autoCreateTime"` // When it stopped resolving
+}
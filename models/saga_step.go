@@ -0,0 +1,35 @@
+// DESIGN PATTERN: Saga Pattern (Compensation Log Entity)
+package models
+
+import "time"
+
+// Saga steps (the compensating action recorded)
+const (
+	SagaStepRefundSender = "refund_sender" // Re-credit points deducted for a transfer that did not complete
+)
+
+// Saga step lifecycle states
+const (
+	SagaStepPending   = "pending"   // Not yet applied (or last attempt failed)
+	SagaStepSucceeded = "succeeded" // Compensation applied downstream
+	SagaStepFailed    = "failed"    // Automatic retries exhausted; waiting for an operator
+)
+
+// SagaStep - Compensating action taken when a saga fails after its commitment step
+type SagaStep struct {
+	ID             string     `json:"id" gorm:"primaryKey"`                // Primary key
+	TransferID     string     `json:"transfer_id" gorm:"not null;index"`   // Transfer whose saga failed
+	Step           string     `json:"step" gorm:"not null"`                // refund_sender
+	UserID         string     `json:"user_id" gorm:"not null"`             // Account being compensated
+	Points         int        `json:"points"`                              // Points returned
+	DeductionKey   string     `json:"deduction_key" gorm:"index"`          // Idempotency key of the deduction being undone
+	IdempotencyKey string     `json:"idempotency_key" gorm:"uniqueIndex"`  // Key sent to Auth Service (retries replay it)
+	Balance        *int       `json:"balance"`                             // Target balance, fixed on the first attempt that reads it
+	Status         string     `json:"status" gorm:"default:pending;index"` // pending, succeeded, failed
+	Attempts       int        `json:"attempts"`                            // Total attempts, automatic and manual
+	LastError      string     `json:"last_error"`                          // Most recent failure
+	Cause          string     `json:"cause"`                               // Why the saga had to compensate
+	CompletedAt    *time.Time `json:"completed_at"`                        // When compensation succeeded
+	CreatedAt      time.Time  `json:"created_at"`                          // Creation timestamp
+	UpdatedAt      time.Time  `json:"updated_at"`                          // Last update timestamp
+}
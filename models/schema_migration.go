@@ -0,0 +1,17 @@
+// DESIGN PATTERN: Value Object (schema version marker)
+package models
+
+import "time"
+
+// SchemaVersion - Version of the table layout this build expects; bump whenever a persisted model
+// gains, loses or renames a column so startup can tell old and new databases apart
+const SchemaVersion = 22
+
+// ShardModels - Tables on each transfer shard (everything routed by the transfer's sender)
+var ShardModels = []interface{}{&Transfer{}, &TransferOutboxMessage{}, &RetiredClaimToken{}}
+
+// SchemaMigration - One schema version applied to a database (highest row wins)
+type SchemaMigration struct {
+	Version   int       `json:"version" gorm:"primaryKey;autoIncrement:false"` // Schema version
+	AppliedAt time.Time `json:"applied_at"`                                    // When this version was first migrated
+}
@@ -1,34 +1,197 @@
 // DESIGN PATTERN: Data Transfer Object (DTO) + Entity Pattern
 package models
 
-import "time"
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"time"
+)
+
+// Claim notification delivery states (Transfer.EmailStatus)
+const (
+	EmailStatusQueued  = "queued"  // Claim notification waiting in the outbox (including retries)
+	EmailStatusSent    = "sent"    // Handed to the email provider (or the receiver's preferred channel)
+	EmailStatusFailed  = "failed"  // Attempts exhausted; the notification is dead-lettered
+	EmailStatusBounced = "bounced" // The provider reported a bounce for the receiver's address after sending
+)
 
 // Transfer - Entity representing a points transfer in the system
 type Transfer struct {
-	ID            string    `json:"id" gorm:"primaryKey"`                 // Primary key
-	SenderID      string    `json:"sender_id" gorm:"not null;index"`      // Sender user ID with index
-	SenderEmail   string    `json:"sender_email" gorm:"not null"`         // Sender's email
-	ReceiverEmail string    `json:"receiver_email" gorm:"not null;index"` // Receiver email with index
-	ReceiverName  string    `json:"receiver_name" gorm:"not null"`        // Receiver's name
-	Points        int       `json:"points" gorm:"not null"`               // Points amount
-	Status        string    `json:"status" gorm:"default:pending"`        // Transfer lifecycle: pending, completed, expired, cancelled
-	Token         string    `json:"token" gorm:"uniqueIndex;not null"`    // Unique claim token
-	ExpiresAt     time.Time `json:"expires_at" gorm:"not null"`           // Claim expiration time
-	CreatedAt     time.Time `json:"created_at"`                           // Creation timestamp
-	UpdatedAt     time.Time `json:"updated_at"`                           // Last update timestamp
+	ID                    string            `json:"id" gorm:"primaryKey"`                                                            // Primary key
+	SenderID              string            `json:"sender_id" gorm:"not null;index;uniqueIndex:idx_transfer_idempotency,priority:1"` // Sender user ID with index
+	SenderEmail           string            `json:"sender_email" gorm:"not null"`                                                    // Sender's email
+	ReceiverEmail         string            `json:"receiver_email" gorm:"not null;index"`                                            // Receiver email with index
+	ReceiverName          string            `json:"receiver_name" gorm:"not null"`                                                   // Receiver's name
+	Points                int               `json:"points" gorm:"not null"`                                                          // Points amount
+	SourceProgram         string            `json:"source_program" gorm:"not null;default:''"`                                       // Program the sender spends
+	TargetProgram         string            `json:"target_program" gorm:"not null;default:''"`                                       // Program the receiver is credited in
+	ConversionRate        float64           `json:"conversion_rate,omitempty"`                                                       // Rate applied at completion
+	ConvertedPoints       int               `json:"converted_points,omitempty"`                                                      // Target points credited at completion
+	PointsMutationKey     string            `json:"points_mutation_key,omitempty" gorm:"index"`                                      // Idempotency-Key sent to Auth Service for the deduction
+	PointsMutationBalance int               `json:"-"`                                                                               // Balance requested with that key (replayed verbatim on retry)
+	PointsMutatedAt       *time.Time        `json:"points_mutated_at,omitempty"`                                                     // When Auth Service acknowledged the deduction
+	Message               string            `json:"message,omitempty" gorm:"type:text"`                                              // Optional gift message (scanned)
+	Links                 []string          `json:"links,omitempty" gorm:"serializer:json;type:text"`                                // Optional attached URLs (scanned)
+	Metadata              *TransferMetadata `json:"metadata,omitempty" gorm:"serializer:json;type:text"`                             // Bundle items and other structured extras
+	Status                TransferStatus    `json:"status" gorm:"default:pending"`                                                   // Lifecycle state (see transfer_status.go)
+	PreviousStatus        TransferStatus    `json:"previous_status,omitempty"`                                                       // Status before the last transition (auditing)
+	NotificationChannel   string            `json:"notification_channel,omitempty"`                                                  // Channel the claim notification went out on
+	NotificationDeferred  bool              `json:"notification_deferred" gorm:"not null;default:false;index"`                       // Claim notification waiting for the email provider to recover
+	EmailStatus           string            `json:"email_status,omitempty"`                                                          // Claim notification delivery: queued, sent, failed, bounced ("" = none, e.g. auto-completed)
+	EmailSentAt           *time.Time        `json:"email_sent_at,omitempty"`                                                         // When the claim notification was delivered
+	ReceiverID            string            `json:"receiver_id,omitempty"`                                                           // Registered receiver (auto-completed transfers)
+	AutoCompleted         bool              `json:"auto_completed"`                                                                  // Completed without a claim link
+	Canary                bool              `json:"canary,omitempty" gorm:"not null;default:false"`                                  // Synthetic self-test transfer (no events, no points moved)
+	PassphraseHash        string            `json:"-"`                                                                               // bcrypt hash of the claim passphrase
+	PassphraseHint        string            `json:"passphrase_hint,omitempty"`                                                       // Hint shown in the claim email
+	Theme                 string            `json:"theme,omitempty"`                                                                 // Claim email theme ("" = classic)
+	Tenant                string            `json:"tenant,omitempty"`                                                                // Sender's white-label tenant (claim email branding)
+	CallbackURL           string            `json:"callback_url,omitempty"`                                                          // Receives this transfer's signed events (allowlisted host)
+	Locale                string            `json:"locale,omitempty" gorm:"size:10"`                                                 // Receiver's language for the claim email ("" = English)
+	IdempotencyKey        *string           `json:"-" gorm:"uniqueIndex:idx_transfer_idempotency,priority:2"`                        // Client Idempotency-Key (NULL when none or released)
+	IdempotencyHash       string            `json:"-"`                                                                               // Fingerprint of the request the key was first used with
+	PassphraseAttempts    int               `json:"-" gorm:"not null;default:0"`                                                     // Failed passphrase checks
+	Token                 string            `json:"-" gorm:"-"`                                                                      // Raw claim token, only on the instance that issued it (never stored)
+	TokenHash             string            `json:"-" gorm:"uniqueIndex;not null"`                                                   // SHA-256 (hex) of the claim token
+	ExpiresAt             time.Time         `json:"expires_at" gorm:"not null"`                                                      // Claim expiration time
+	EscheatableAt         *time.Time        `json:"escheatable_at,omitempty" gorm:"index"`                                           // Flagged for unclaimed-property reporting
+	NudgeAt               *time.Time        `json:"nudge_at,omitempty"`                                                              // Experiment-chosen reminder time (nil = global fraction)
+	NudgedAt              *time.Time        `json:"nudged_at,omitempty"`                                                             // Sender reminded about the unclaimed transfer
+	Cohorts               map[string]string `json:"cohorts,omitempty" gorm:"serializer:json;type:text"`                              // Experiment -> variant assigned at initiation
+	DeferredAt            *time.Time        `json:"deferred_at,omitempty"`                                                           // Receiver saved the claim for later (once)
+	EmailOpenedAt         *time.Time        `json:"email_opened_at,omitempty"`                                                       // First claim email open (tracking pixel)
+	LinkClickedAt         *time.Time        `json:"link_clicked_at,omitempty"`                                                       // First claim link click (tracked redirect)
+	ClaimDevice           string            `json:"claim_device,omitempty"`                                                          // mobile, tablet or desktop (claim request's User-Agent)
+	Kind                  string            `json:"kind" gorm:"default:original;index"`                                              // original, reissue, reversal, forward
+	Region                string            `json:"region,omitempty"`                                                                // Storage region (sender's region at initiation; empty = default)
+	ParentTransferID      string            `json:"parent_transfer_id,omitempty" gorm:"index"`                                       // Transfer this one derives from (see Kind)
+	ForwardedToID         string            `json:"forwarded_to_id,omitempty"`                                                       // Child transfer that superseded this one
+	CreatedAt             time.Time         `json:"created_at"`                                                                      // Creation timestamp
+	UpdatedAt             time.Time         `json:"updated_at"`                                                                      // Last update timestamp
 }
 
 // TransferRequest - DTO for transfer creation API input
 type TransferRequest struct {
+	ReceiverEmail  string       `json:"receiver_email" binding:"required,email"`                 // Must be valid email
+	ReceiverName   string       `json:"receiver_name" binding:"required,min=2"`                  // Min 2 characters
+	Points         int          `json:"points" binding:"required_without=Items,omitempty,min=1"` // Must be positive (derived from Items for bundles)
+	Items          []BundleItem `json:"items" binding:"omitempty,max=10,dive"`                   // Optional bundle of points/badge/message items
+	Message        string       `json:"message" binding:"max=500"`                               // Optional gift message
+	Links          []string     `json:"links" binding:"omitempty,max=5,dive,url"`                // Optional attached URLs
+	Passphrase     string       `json:"passphrase" binding:"omitempty,min=6,max=72"`             // Optional claim passphrase (shared out-of-band, at most 72 bytes)
+	SourceProgram  string       `json:"source_program" binding:"max=50"`                         // Defaults to the service's program
+	TargetProgram  string       `json:"target_program" binding:"max=50"`                         // Defaults to the source program
+	PassphraseHint string       `json:"passphrase_hint" binding:"max=100"`                       // Optional hint included in the email
+	Theme          string       `json:"theme" binding:"max=30"`                                  // Claim email theme (see GET /emails/themes)
+	CallbackURL    string       `json:"callback_url" binding:"omitempty,url,max=500"`            // Optional per-transfer event callback (host must be allowlisted)
+	Locale         string       `json:"locale" binding:"max=35"`                                 // Receiver's language tag, e.g. "es" or "fr-CA" (English fallback)
+	IdempotencyKey string       `json:"-"`                                                       // From the Idempotency-Key header (set by the handler)
+}
+
+// EmailPreviewRequest - DTO for rendering the claim email a receiver would get, without sending it
+type EmailPreviewRequest struct {
+	ReceiverName   string       `json:"receiver_name" binding:"max=100"`                         // Defaults to a placeholder
+	ReceiverEmail  string       `json:"receiver_email" binding:"omitempty,email"`                // Defaults to a placeholder
+	Points         int          `json:"points" binding:"required_without=Items,omitempty,min=1"` // Derived from Items for bundles
+	Items          []BundleItem `json:"items" binding:"omitempty,max=10,dive"`                   // Optional bundle items
+	Message        string       `json:"message" binding:"max=500"`                               // Gift message
+	Links          []string     `json:"links" binding:"omitempty,max=5,dive,url"`                // Attached URLs
+	Protected      bool         `json:"protected"`                                               // Show the passphrase notice
+	PassphraseHint string       `json:"passphrase_hint" binding:"max=100"`                       // Hint shown with the notice
+	Theme          string       `json:"theme" binding:"max=30"`                                  // Claim email theme
+	Locale         string       `json:"locale" binding:"max=35"`                                 // Receiver's language tag (English fallback)
+}
+
+// BulkTransferRequest - DTO for sending points to several receivers at once
+type BulkTransferRequest struct {
+	Transfers []BulkTransferEntry `json:"transfers" binding:"required,min=1,max=100,dive"` // One entry per receiver
+}
+
+// BulkTransferEntry - One receiver of a bulk transfer
+type BulkTransferEntry struct {
 	ReceiverEmail string `json:"receiver_email" binding:"required,email"` // Must be valid email
 	ReceiverName  string `json:"receiver_name" binding:"required,min=2"`  // Min 2 characters
 	Points        int    `json:"points" binding:"required,min=1"`         // Must be positive
 }
 
+// ForwardTransferRequest - DTO for a receiver forwarding an unclaimed gift
+type ForwardTransferRequest struct {
+	ReceiverEmail string `json:"receiver_email" binding:"required,email"` // New receiver
+	ReceiverName  string `json:"receiver_name" binding:"required,min=2"`  // New receiver's name
+	Passphrase    string `json:"passphrase"`                              // Required when the transfer is passphrase-protected
+}
+
+// DeclineTransferRequest - DTO for a receiver declining a gift
+type DeclineTransferRequest struct {
+	Note string `json:"note" binding:"max=200"` // Optional message passed to the sender
+}
+
+// ExtendTransferRequest - DTO for a sender pushing a pending transfer's expiry forward
+type ExtendTransferRequest struct {
+	ExpiresAt time.Time `json:"expires_at" binding:"required"` // New expiry (RFC 3339), later than the current one
+}
+
+// EmailBounceRequest - DTO for a bounce reported by the email provider (relayed by a trusted service)
+type EmailBounceRequest struct {
+	Email  string `json:"email" binding:"required,email"` // Address that bounced
+	Reason string `json:"reason" binding:"max=500"`       // Provider's bounce reason (logged)
+}
+
+// CompleteTransferRequest - DTO for the completion API input
+type CompleteTransferRequest struct {
+	Passphrase string `json:"passphrase"` // Required when the transfer is passphrase-protected
+}
+
+// Bundle - Award items of a bundle transfer (nil for plain transfers)
+func (t *Transfer) Bundle() []BundleItem {
+	if t.Metadata == nil {
+		return nil
+	}
+	return t.Metadata.Bundle
+}
+
+// SetClaimToken - Issues a claim token: the raw value stays in memory for the outgoing notification,
+// only its hash is persisted
+func (t *Transfer) SetClaimToken(token string) {
+	t.Token = token
+	t.TokenHash = HashClaimToken(token)
+}
+
+// MatchesClaimToken - Constant-time check of a presented claim token against the stored hash
+func (t *Transfer) MatchesClaimToken(token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(HashClaimToken(token)), []byte(t.TokenHash)) == 1
+}
+
+// HashClaimToken - Stored form of a claim token (tokens are high-entropy, so an unsalted hash suffices)
+func HashClaimToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// PassphraseProtected - Whether completion requires the claim passphrase
+func (t *Transfer) PassphraseProtected() bool {
+	return t.PassphraseHash != ""
+}
+
+// Engagement - Receiver funnel recorded before the claim (nil when nothing was tracked)
+func (t *Transfer) Engagement() *TransferEngagement {
+	if t.EmailOpenedAt == nil && t.LinkClickedAt == nil && t.ClaimDevice == "" {
+		return nil
+	}
+	return &TransferEngagement{EmailOpenedAt: t.EmailOpenedAt, LinkClickedAt: t.LinkClickedAt, ClaimDevice: t.ClaimDevice}
+}
+
 // User - External user model (from Auth Service) for service integration
 type User struct {
 	ID     string `json:"id"`     // User identifier
 	Email  string `json:"email"`  // User email
 	Name   string `json:"name"`   // User name
 	Points int    `json:"points"` // Current points balance
+
+	NotificationChannel string `json:"notification_channel,omitempty"` // Preferred channel: email, sms, in_app
+	Phone               string `json:"phone,omitempty"`                // For SMS notifications
+	Region              string `json:"region,omitempty"`               // Data residency region (e.g. eu, us)
+	Team                string `json:"team,omitempty"`                 // Sender's team (routes chat notifications)
+	Tenant              string `json:"tenant,omitempty"`               // White-label tenant (selects email branding)
 }
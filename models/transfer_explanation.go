@@ -0,0 +1,42 @@
+// DESIGN PATTERN: Data Transfer Object (DTO) for the operator runbook view of one transfer
+package models
+
+import "time"
+
+// Explanation finding severities
+const (
+	FindingInfo     = "info"     // Context; nothing is wrong
+	FindingWarning  = "warning"  // Degraded or retrying; expected to resolve on its own
+	FindingBlocking = "blocking" // The transfer cannot progress without someone acting
+)
+
+// TransferExplanation - Why a transfer is in its current state and what can move it on
+// (GET /admin/transfers/:id/explain)
+type TransferExplanation struct {
+	TransferID  string             `json:"transfer_id"`  // Transfer explained
+	Status      TransferStatus     `json:"status"`       // Current status
+	Terminal    bool               `json:"terminal"`     // The state machine allows no further status change
+	Stuck       bool               `json:"stuck"`        // At least one blocking finding
+	Summary     string             `json:"summary"`      // First blocking finding, or what the transfer is waiting for
+	Findings    []ExplainFinding   `json:"findings"`     // Evaluated rules, most severe first
+	NextActions []TransferAction   `json:"next_actions"` // Operations allowed from here
+	ExplainedAt time.Time          `json:"explained_at"` // When the evaluation ran
+	History     []TransferAuditLog `json:"history"`      // Recorded status changes, oldest first
+}
+
+// ExplainFinding - One rule evaluated against the transfer
+type ExplainFinding struct {
+	Code      string `json:"code"`                // Machine code, e.g. notification_dead_lettered, insufficient_points
+	Severity  string `json:"severity"`            // info, warning, blocking
+	Message   string `json:"message"`             // e.g. "email dead-lettered after 5 attempts: ..."
+	Reference string `json:"reference,omitempty"` // Dead letter, saga step or child transfer involved
+}
+
+// TransferAction - One operation that is allowed from the transfer's current state
+type TransferAction struct {
+	Action      string            `json:"action"`         // change_status, retry_dead_letter, retry_saga_step, ...
+	Method      string            `json:"method"`         // HTTP method
+	Path        string            `json:"path"`           // Endpoint to call (internal or public router)
+	Body        map[string]string `json:"body,omitempty"` // Request body to send
+	Description string            `json:"description"`    // What the action does
+}
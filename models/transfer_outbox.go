@@ -0,0 +1,51 @@
+// DESIGN PATTERN: Transactional Outbox Pattern (Entity, stored next to the transfer)
+package models
+
+import "time"
+
+// Transfer outbox message kinds (what the dispatcher does with the row)
+const (
+	OutboxMessageEvent             = "event"              // Domain event handed to the event outbox relay
+	OutboxMessageClaimNotification = "claim_notification" // Claim invitation on the receiver's preferred channel
+	OutboxMessageEmail             = "email"              // Pre-rendered email to a single recipient
+	OutboxMessageAudit             = "audit"              // Audit entry copied to the audit trail on the primary database
+)
+
+// Transfer outbox message states
+const (
+	OutboxMessagePending   = "pending"   // Waiting for the dispatcher (or for its retry time)
+	OutboxMessageSent      = "sent"      // Delivered
+	OutboxMessageDead      = "dead"      // Attempts exhausted, dead-lettered (blocks later events of the transfer)
+	OutboxMessageDiscarded = "discarded" // Dropped by an operator from the dead-letter API
+)
+
+// TransferOutboxMessage - Side effect of a transfer change, written in the same shard transaction
+// as the transfer so it survives a crash; the dispatcher delivers it afterwards
+type TransferOutboxMessage struct {
+	ID            uint       `json:"id" gorm:"primaryKey;autoIncrement"`  // Dispatch order within the shard
+	Kind          string     `json:"kind" gorm:"not null"`                // event, claim_notification, email
+	TransferID    string     `json:"transfer_id" gorm:"not null;index"`   // Transfer the side effect belongs to
+	Payload       string     `json:"payload" gorm:"type:text"`            // Event envelope, OutboxClaimNotification or OutboxEmail (JSON)
+	Status        string     `json:"status" gorm:"default:pending;index"` // pending, sent, dead
+	Attempts      int        `json:"attempts" gorm:"default:0"`           // Delivery attempts so far
+	LastError     string     `json:"last_error"`                          // Most recent delivery error
+	Provider      string     `json:"provider,omitempty"`                  // Email provider of the latest attempt (EmailProviderKey; email-bound kinds only)
+	NextAttemptAt time.Time  `json:"next_attempt_at"`                     // Earliest time of the next attempt
+	SentAt        *time.Time `json:"sent_at"`                             // When delivery succeeded
+	CreatedAt     time.Time  `json:"created_at"`                          // Creation timestamp
+}
+
+// OutboxClaimNotification - Payload of a claim notification message: the raw claim token exists only
+// here until the invitation is delivered (the payload is cleared once sent or discarded)
+type OutboxClaimNotification struct {
+	Token string `json:"token"` // Raw claim token for the links
+}
+
+// OutboxEmail - Payload of an email outbox message (rendered when the change was committed)
+type OutboxEmail struct {
+	To       string `json:"to"`       // Recipient
+	Template string `json:"template"` // Template name (quota class, logging)
+	Subject  string `json:"subject"`  // Rendered subject
+	HTML     string `json:"html"`     // Rendered body
+	Text     string `json:"text"`     // Rendered plain-text alternative (empty in rows queued before it existed)
+}
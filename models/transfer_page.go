@@ -0,0 +1,47 @@
+// DESIGN PATTERN: Data Transfer Object (paged, filtered history)
+package models
+
+import "time"
+
+// Transfer history page sizes
+const (
+	DefaultPageSize = 50  // Limit when the client sends none
+	MaxPageSize     = 200 // Largest limit accepted
+)
+
+// Transfer history sort orders (ties broken by ID in the same direction)
+const (
+	SortNewest     = "newest"      // created_at DESC (default)
+	SortOldest     = "oldest"      // created_at ASC
+	SortPointsDesc = "points_desc" // points DESC
+	SortPointsAsc  = "points_asc"  // points ASC
+)
+
+// PageRequest - Query parameters for a page of transfer history (cursor and offset may be combined)
+type PageRequest struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=200"` // Transfers per page (default 50)
+	Offset int    `form:"offset" binding:"omitempty,min=0"`        // Transfers to skip (past the cursor, if any)
+	Cursor string `form:"cursor"`                                  // next_cursor or prev_cursor of an earlier response (same filters and sort)
+}
+
+// TransferFilter - Query parameters narrowing and ordering transfer history (zero values are ignored)
+type TransferFilter struct {
+	Status        TransferStatus `form:"status"`                                                              // Exact status
+	From          time.Time      `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`                        // Created at or after (RFC 3339)
+	To            time.Time      `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`                          // Created before (RFC 3339)
+	MinPoints     int            `form:"min_points" binding:"omitempty,min=1"`                                // At least this many points
+	MaxPoints     int            `form:"max_points" binding:"omitempty,min=1"`                                // At most this many points
+	ReceiverEmail string         `form:"receiver_email" binding:"omitempty,email"`                            // Receiver (case-insensitive)
+	Sort          string         `form:"sort" binding:"omitempty,oneof=newest oldest points_desc points_asc"` // Order (default newest)
+}
+
+// TransferPage - One page of a sender's transfers in the requested order
+type TransferPage struct {
+	Transfers  []Transfer `json:"-"`                     // Page contents
+	Total      int64      `json:"total"`                 // Sender's transfers matching the filters
+	Limit      int        `json:"limit"`                 // Effective page size
+	Offset     int        `json:"offset"`                // Effective offset
+	Sort       string     `json:"sort"`                  // Effective order
+	NextCursor string     `json:"next_cursor,omitempty"` // Resume point; empty on the last page
+	PrevCursor string     `json:"prev_cursor,omitempty"` // Page before this one; empty on the first page
+}
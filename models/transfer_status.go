@@ -0,0 +1,113 @@
+// DESIGN PATTERN: State Pattern (transition table) + Value Object (reason codes)
+package models
+
+import (
+	"fmt"
+	"sender-service/apperrors"
+)
+
+// ErrInvalidTransition - Returned when the state machine rejects a status change
+var ErrInvalidTransition = apperrors.New(apperrors.ErrConflict, "invalid_transition", "status transition not allowed")
+
+// TransferStatus - Lifecycle state of a transfer; change it only through Transfer.TransitionTo
+type TransferStatus string
+
+// Transfer statuses
+const (
+	TransferStatusPending   TransferStatus = "pending"   // Waiting for the receiver to claim
+	TransferStatusOnHold    TransferStatus = "on_hold"   // Paused by a trusted service (e.g. fraud review)
+	TransferStatusCompleted TransferStatus = "completed" // Claimed, points deducted
+	TransferStatusFailed    TransferStatus = "failed"    // Saga aborted
+	TransferStatusExpired   TransferStatus = "expired"   // Claim window elapsed
+	TransferStatusCancelled TransferStatus = "cancelled" // Withdrawn before completion
+	TransferStatusForwarded TransferStatus = "forwarded" // Superseded by a child transfer the receiver forwarded
+	TransferStatusDeclined  TransferStatus = "declined"  // Receiver turned the gift down
+)
+
+// Transfer kinds - How a transfer relates to its ParentTransferID
+const (
+	TransferKindOriginal = "original" // Created by a sender (no parent)
+	TransferKindReissue  = "reissue"  // Re-offer of an expired/failed parent
+	TransferKindReversal = "reversal" // Compensates a completed parent
+	TransferKindForward  = "forward"  // Receiver regifted the parent
+)
+
+// Machine reason codes accepted on trusted status changes
+const (
+	ReasonFraudSuspected    = "fraud_suspected"
+	ReasonFraudCleared      = "fraud_cleared"
+	ReasonFraudConfirmed    = "fraud_confirmed"
+	ReasonReceiverDeclined  = "receiver_declined"
+	ReasonClaimWindowEnded  = "claim_window_ended"
+	ReasonComplianceHold    = "compliance_hold"
+	ReasonComplianceClear   = "compliance_cleared"
+	ReasonDuplicate         = "duplicate"
+	ReasonReceiverForwarded = "receiver_forwarded" // Set by the forwarding flow, not accepted on trusted changes
+)
+
+// transferTransitions - Every legal status change; all other statuses are terminal
+var transferTransitions = map[TransferStatus][]TransferStatus{
+	TransferStatusPending: {
+		TransferStatusCompleted, TransferStatusExpired, TransferStatusCancelled, TransferStatusFailed,
+		TransferStatusOnHold, TransferStatusForwarded, TransferStatusDeclined,
+	},
+	TransferStatusOnHold: {TransferStatusPending, TransferStatusFailed, TransferStatusCancelled},
+}
+
+// transitionReasons - Reason codes valid for each target status on trusted changes. Targets without
+// reasons (completed, forwarded, declined) are reachable only through their own flows.
+var transitionReasons = map[TransferStatus][]string{
+	TransferStatusOnHold:    {ReasonFraudSuspected, ReasonComplianceHold},
+	TransferStatusPending:   {ReasonFraudCleared, ReasonComplianceClear},
+	TransferStatusFailed:    {ReasonFraudConfirmed, ReasonDuplicate},
+	TransferStatusExpired:   {ReasonClaimWindowEnded},
+	TransferStatusCancelled: {ReasonReceiverDeclined, ReasonFraudConfirmed, ReasonDuplicate},
+}
+
+// CanTransition - Whether the state machine allows from -> to
+func CanTransition(from, to TransferStatus) bool {
+	return contains(transferTransitions[from], to)
+}
+
+// NextStatuses - Statuses the state machine allows from the given one (empty for terminal statuses)
+func NextStatuses(from TransferStatus) []TransferStatus {
+	return transferTransitions[from]
+}
+
+// TransitionReasons - Reason codes a trusted status change to the target may carry (empty when the
+// target is only reachable through its own flow)
+func TransitionReasons(to TransferStatus) []string {
+	return transitionReasons[to]
+}
+
+// ValidReason - Whether a reason code may accompany a change to the target status
+func ValidReason(to TransferStatus, reason string) bool {
+	return contains(transitionReasons[to], reason)
+}
+
+// TransitionTo - Moves the transfer to a new status, remembering the previous one for auditing
+func (t *Transfer) TransitionTo(to TransferStatus) error {
+	if !CanTransition(t.Status, to) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, t.Status, to)
+	}
+	t.PreviousStatus = t.Status
+	t.Status = to
+	return nil
+}
+
+// contains - Linear membership check for the small tables above
+func contains[T comparable](values []T, value T) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusChangeRequest - DTO for trusted-service status changes
+type StatusChangeRequest struct {
+	Status     TransferStatus `json:"status" binding:"required"`      // Target status
+	ReasonCode string         `json:"reason_code" binding:"required"` // Machine reason code
+	Note       string         `json:"note" binding:"max=500"`         // Optional free-text context
+}
@@ -0,0 +1,28 @@
+// DESIGN PATTERN: Publisher-Subscriber Pattern (per-subscription delivery record)
+package models
+
+import "time"
+
+// Webhook delivery lifecycle states
+const (
+	WebhookDeliveryPending   = "pending"   // Waiting for its first or next attempt
+	WebhookDeliveryDelivered = "delivered" // Subscriber answered 2xx
+	WebhookDeliveryFailed    = "failed"    // Retries exhausted (dead-lettered) or subscription removed
+)
+
+// WebhookDelivery - One event's delivery to one subscription, retried on its own schedule
+type WebhookDelivery struct {
+	ID             string     `json:"id" gorm:"primaryKey"`                                             // Primary key
+	SubscriptionID string     `json:"subscription_id" gorm:"not null;uniqueIndex:idx_webhook_delivery"` // Subscription delivered to
+	EventID        string     `json:"event_id" gorm:"not null;uniqueIndex:idx_webhook_delivery"`        // Event delivered (one row per subscription and event)
+	EventType      string     `json:"event_type" gorm:"not null"`                                       // e.g. transfer.completed
+	EventSchema    string     `json:"event_schema" gorm:"not null"`                                     // X-Event-Schema header value
+	Payload        []byte     `json:"-"`                                                                // Event envelope (templated again on every attempt)
+	Status         string     `json:"status" gorm:"default:pending;index"`                              // pending, delivered, failed
+	Attempts       int        `json:"attempts"`                                                         // Attempts so far
+	LastError      string     `json:"last_error,omitempty"`                                             // Most recent failure
+	NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"index"`                                     // When a pending delivery is retried
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`                                           // When the subscriber accepted it
+	CreatedAt      time.Time  `json:"created_at"`                                                       // First attempt
+	UpdatedAt      time.Time  `json:"updated_at"`                                                       // Last change
+}
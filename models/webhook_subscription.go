@@ -0,0 +1,26 @@
+// DESIGN PATTERN: Publisher-Subscriber Pattern (subscriber registration entity)
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookSubscription - Integrator endpoint receiving domain events, optionally reshaped by a template
+type WebhookSubscription struct {
+	ID         string    `json:"id" gorm:"primaryKey"`                         // Subscription ID
+	URL        string    `json:"url" gorm:"not null"`                          // Endpoint POSTed to
+	EventTypes []string  `json:"event_types" gorm:"serializer:json;type:text"` // Types delivered (empty = all)
+	Template   string    `json:"template,omitempty" gorm:"type:text"`          // Payload template (empty = full envelope)
+	Active     bool      `json:"active" gorm:"not null;default:true"`          // Paused subscriptions receive nothing
+	CreatedAt  time.Time `json:"created_at"`                                   // Registration time
+	UpdatedAt  time.Time `json:"updated_at"`                                   // Last change
+}
+
+// WebhookSubscriptionRequest - DTO for registering or replacing a subscription
+type WebhookSubscriptionRequest struct {
+	URL        string          `json:"url" binding:"required,url"`                    // Endpoint POSTed to
+	EventTypes []string        `json:"event_types" binding:"omitempty,dive,required"` // Types delivered (empty = all)
+	Template   json.RawMessage `json:"template"`                                      // Payload template (null/absent = full envelope)
+	Active     *bool           `json:"active"`                                        // Defaults to true
+}
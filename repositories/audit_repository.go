@@ -0,0 +1,36 @@
+// DESIGN PATTERN: Repository Pattern (append-only audit trail)
+package repositories
+
+import (
+	"sender-service/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AuditRepository - Data access for transfer audit entries
+type AuditRepository struct {
+	db *gorm.DB // Composition: HAS-A database connection
+}
+
+// NewAuditRepository - Factory method for repository
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Create - Appends an audit entry
+func (r *AuditRepository) Create(entry *models.TransferAuditLog) error {
+	return r.db.Create(entry).Error
+}
+
+// CreateOnce - Appends an audit entry unless its ID is already recorded (outbox redelivery)
+func (r *AuditRepository) CreateOnce(entry *models.TransferAuditLog) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(entry).Error
+}
+
+// FindByTransferID - Audit trail of one transfer, oldest first
+func (r *AuditRepository) FindByTransferID(transferID string) ([]models.TransferAuditLog, error) {
+	var entries []models.TransferAuditLog
+	err := r.db.Where("transfer_id = ?", transferID).Order("created_at ASC").Find(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,52 @@
+// DESIGN PATTERN: Repository Pattern - Claim latency aggregation
+package repositories
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// claimedAtSQL - When a transfer was claimed (deduction time, falling back to the last update)
+const claimedAtSQL = "COALESCE(points_mutated_at, updated_at)"
+
+// ClaimLatencyCounts - Created->completed latency of completed transfers, bucketed by upper bounds
+type ClaimLatencyCounts struct {
+	Total      int64   // Completed transfers in range
+	SumSeconds float64 // Sum of latencies (for the mean)
+	Buckets    []int64 // Cumulative count per bound (latency <= bound)
+}
+
+// ClaimLatencyBuckets - Aggregates claim latency for transfers completed in [from, to) on every shard
+func (r *TransferRepository) ClaimLatencyBuckets(bounds []time.Duration, from, to time.Time) (*ClaimLatencyCounts, error) {
+	// SELECT COUNT(*), SUM(secs), SUM(CASE WHEN secs <= b THEN 1 ELSE 0 END)... over completed transfers
+	columns := []string{"COUNT(*)::bigint", "COALESCE(SUM(secs), 0)::float8"}
+	for _, bound := range bounds {
+		columns = append(columns, fmt.Sprintf("COALESCE(SUM(CASE WHEN secs <= %d THEN 1 ELSE 0 END), 0)::bigint", int64(bound.Seconds())))
+	}
+	query := "SELECT " + strings.Join(columns, ", ") + " FROM (" +
+		"SELECT EXTRACT(EPOCH FROM (" + claimedAtSQL + " - created_at)) AS secs FROM transfers " +
+		"WHERE status = ? AND NOT canary AND " + claimedAtSQL + " >= ? AND " + claimedAtSQL + " < ?) AS latencies"
+
+	counts := &ClaimLatencyCounts{Buckets: make([]int64, len(bounds))}
+	for _, shard := range r.shards {
+		var total int64
+		var sum float64
+		buckets := make([]int64, len(bounds))
+		dest := []interface{}{&total, &sum}
+		for i := range buckets {
+			dest = append(dest, &buckets[i])
+		}
+
+		if err := shard.Raw(query, "completed", from, to).Row().Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		counts.Total += total
+		counts.SumSeconds += sum
+		for i := range buckets {
+			counts.Buckets[i] += buckets[i]
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,37 @@
+// DESIGN PATTERN: Repository Pattern + Upsert counters
+package repositories
+
+import (
+	"sender-service/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ClaimRateRepository - Persists hourly claim attempt counters (shared by every instance)
+type ClaimRateRepository struct {
+	db *gorm.DB // Composition: HAS-A database connection
+}
+
+// NewClaimRateRepository - Factory method for repository
+func NewClaimRateRepository(db *gorm.DB) *ClaimRateRepository {
+	return &ClaimRateRepository{db: db}
+}
+
+// Increment - Atomically counts one request and returns the window's new total
+func (r *ClaimRateRepository) Increment(key string, window time.Time) (int, error) {
+	row := models.ClaimRateCounter{Key: key, WindowStart: window, Count: 1}
+	// POSTGRES: INSERT ... ON CONFLICT (key, window_start) DO UPDATE SET count = count + 1 RETURNING count
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}, {Name: "window_start"}},
+		DoUpdates: clause.Set{{Column: clause.Column{Name: "count"}, Value: gorm.Expr("claim_rate_counters.count + 1")}},
+	}, clause.Returning{Columns: []clause.Column{{Name: "count"}}}).Create(&row).Error
+	return row.Count, err
+}
+
+// DeleteBefore - Drops windows that started before the cutoff
+func (r *ClaimRateRepository) DeleteBefore(cutoff time.Time) (int64, error) {
+	result := r.db.Where("window_start < ?", cutoff).Delete(&models.ClaimRateCounter{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,44 @@
+// DESIGN PATTERN: One-off Data Migration (runs before AutoMigrate, idempotent)
+package repositories
+
+import (
+	"sender-service/models"
+
+	"gorm.io/gorm"
+)
+
+// HashLegacyClaimTokens - Moves a database from plaintext claim tokens (transfers.token) to hashes
+// (transfers.token_hash). Invitations still queued keep their raw token in the outbox payload so they
+// can be delivered. Returns the transfers converted (0 once the old column is gone).
+func HashLegacyClaimTokens(db *gorm.DB) (int64, error) {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.Transfer{}) || !migrator.HasColumn(&models.Transfer{}, "token") {
+		return 0, nil
+	}
+
+	var converted int64
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// 1. HASH: Same SHA-256 hex encoding as models.HashClaimToken
+		if err := tx.Exec("ALTER TABLE transfers ADD COLUMN IF NOT EXISTS token_hash text").Error; err != nil {
+			return err
+		}
+		result := tx.Exec("UPDATE transfers SET token_hash = encode(sha256(convert_to(token, 'UTF8')), 'hex') WHERE COALESCE(token_hash, '') = ''")
+		if result.Error != nil {
+			return result.Error
+		}
+		converted = result.RowsAffected
+
+		// 2. QUEUED INVITATIONS: The dispatcher reads the raw token from the payload from now on
+		if tx.Migrator().HasTable(&models.TransferOutboxMessage{}) {
+			if err := tx.Exec(`UPDATE transfer_outbox_messages m SET payload = json_build_object('token', t.token)::text
+				FROM transfers t WHERE m.transfer_id = t.id AND m.kind = ? AND m.status IN (?, ?) AND COALESCE(m.payload, '') = ''`,
+				models.OutboxMessageClaimNotification, models.OutboxMessagePending, models.OutboxMessageDead).Error; err != nil {
+				return err
+			}
+		}
+
+		// 3. DROP: Nothing else may read the plaintext again
+		return tx.Exec("ALTER TABLE transfers DROP COLUMN token").Error
+	})
+	return converted, err
+}
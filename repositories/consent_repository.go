@@ -0,0 +1,30 @@
+// DESIGN PATTERN: Repository Pattern + CRUD Operations
+package repositories
+
+import (
+	"sender-service/models"
+
+	"gorm.io/gorm"
+)
+
+// ConsentRepository - Abstracts database operations for marketing consent
+type ConsentRepository struct {
+	db *gorm.DB // Composition: HAS-A database connection
+}
+
+// NewConsentRepository - Factory method for repository
+func NewConsentRepository(db *gorm.DB) *ConsentRepository {
+	return &ConsentRepository{db: db}
+}
+
+// FindByEmail - Recorded consent for an address
+func (r *ConsentRepository) FindByEmail(email string) (*models.MarketingConsent, error) {
+	var consent models.MarketingConsent
+	err := r.db.Where("email = ?", email).First(&consent).Error
+	return &consent, err
+}
+
+// Save - Inserts or updates consent
+func (r *ConsentRepository) Save(consent *models.MarketingConsent) error {
+	return r.db.Save(consent).Error
+}
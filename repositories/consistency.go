@@ -0,0 +1,81 @@
+// DESIGN PATTERN: Read-Your-Writes Consistency (replica routing by WAL position)
+package repositories
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sender-service/models"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// consistencyTokenVersion - Prefix allowing the token format to evolve
+const consistencyTokenVersion = "v1"
+
+// ConsistencyToken - Opaque WAL position of the transfer's shard after a write ("" when no replica is configured)
+func (r *TransferRepository) ConsistencyToken(transfer *models.Transfer) (string, error) {
+	shard := r.shardIndexFor(transfer.Region, transfer.SenderID)
+	if r.replicaFor(shard) == nil {
+		return "", nil
+	}
+
+	var lsn string
+	// POSTGRES: Current write-ahead log position on the primary
+	if err := r.shards[shard].Raw("SELECT pg_current_wal_lsn()::text").Scan(&lsn).Error; err != nil {
+		return "", err
+	}
+
+	raw := fmt.Sprintf("%s:%d:%s", consistencyTokenVersion, shard, lsn)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw)), nil
+}
+
+// readerFor - Replica when it has replayed past the token's WAL position (or no token given), primary otherwise
+func (r *TransferRepository) readerFor(shard int, token string) *gorm.DB {
+	replica := r.replicaFor(shard)
+	if replica == nil {
+		return r.shards[shard]
+	}
+	if token == "" {
+		return replica // No read-your-writes requirement: eventual consistency is fine
+	}
+
+	tokenShard, lsn, ok := parseConsistencyToken(token)
+	if !ok || tokenShard != shard {
+		return r.shards[shard] // Unknown token: be safe and read the primary
+	}
+
+	var caughtUp bool
+	// POSTGRES: Has the replica replayed the caller's write?
+	err := replica.Raw("SELECT COALESCE(pg_last_wal_replay_lsn() >= ?::pg_lsn, false)", lsn).Scan(&caughtUp).Error
+	if err != nil || !caughtUp {
+		return r.shards[shard]
+	}
+	return replica
+}
+
+// replicaFor - Replica of a shard, if configured
+func (r *TransferRepository) replicaFor(shard int) *gorm.DB {
+	if shard < len(r.replicas) {
+		return r.replicas[shard]
+	}
+	return nil
+}
+
+// parseConsistencyToken - Decodes v1:<shard>:<lsn>
+func parseConsistencyToken(token string) (int, string, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, "", false
+	}
+	parts := strings.SplitN(string(raw), ":", 3)
+	if len(parts) != 3 || parts[0] != consistencyTokenVersion {
+		return 0, "", false
+	}
+	shard, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, "", false
+	}
+	return shard, parts[2], true
+}
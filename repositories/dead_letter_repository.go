@@ -0,0 +1,68 @@
+// DESIGN PATTERN: Repository Pattern + CRUD Operations
+package repositories
+
+import (
+	"sender-service/models"
+
+	"gorm.io/gorm"
+)
+
+// DeadLetterRepository - Abstracts database operations for dead-lettered messages
+type DeadLetterRepository struct {
+	db *gorm.DB // Composition: HAS-A database connection
+}
+
+// NewDeadLetterRepository - Factory method for repository
+func NewDeadLetterRepository(db *gorm.DB) *DeadLetterRepository {
+	return &DeadLetterRepository{db: db}
+}
+
+// Create - Persists a new dead letter
+func (r *DeadLetterRepository) Create(deadLetter *models.DeadLetter) error {
+	return r.db.Create(deadLetter).Error
+}
+
+// List - Finds dead letters filtered by kind and status (empty filters match all)
+func (r *DeadLetterRepository) List(kind, status string, limit int) ([]models.DeadLetter, error) {
+	var deadLetters []models.DeadLetter
+	query := r.db.Order("created_at DESC").Limit(limit)
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&deadLetters).Error
+	return deadLetters, err
+}
+
+// FindByTransfer - Dead letters of one transfer: referenced by its ID (credits) or by one of its
+// outbox messages (<transfer ID>/<message ID>), oldest first
+func (r *DeadLetterRepository) FindByTransfer(transferID string) ([]models.DeadLetter, error) {
+	var deadLetters []models.DeadLetter
+	err := r.db.Where("reference_id = ? OR reference_id LIKE ?", transferID, transferID+"/%").
+		Order("created_at ASC").
+		Find(&deadLetters).Error
+	return deadLetters, err
+}
+
+// FindOpenByReference - Unresolved dead letter of a kind for one message
+func (r *DeadLetterRepository) FindOpenByReference(kind, referenceID string) (*models.DeadLetter, error) {
+	var deadLetter models.DeadLetter
+	err := r.db.Where("kind = ? AND reference_id = ? AND status = ?", kind, referenceID, models.DeadLetterOpen).
+		Order("created_at DESC").
+		First(&deadLetter).Error
+	return &deadLetter, err
+}
+
+// FindByID - Finds a dead letter by identifier
+func (r *DeadLetterRepository) FindByID(id string) (*models.DeadLetter, error) {
+	var deadLetter models.DeadLetter
+	err := r.db.Where("id = ?", id).First(&deadLetter).Error
+	return &deadLetter, err
+}
+
+// Update - Saves dead letter changes
+func (r *DeadLetterRepository) Update(deadLetter *models.DeadLetter) error {
+	return r.db.Save(deadLetter).Error
+}
@@ -0,0 +1,102 @@
+// DESIGN PATTERN: Repository Pattern + CRUD Operations
+package repositories
+
+import (
+	"sender-service/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// EmailArchiveFilter - Listing criteria (zero values match everything)
+type EmailArchiveFilter struct {
+	Recipient string    // Exact recipient address
+	Template  string    // Template name
+	From      time.Time // Sent at or after
+	To        time.Time // Sent before
+	Limit     int       // Maximum rows
+}
+
+// EmailArchiveRepository - Abstracts database operations for the archived email index
+type EmailArchiveRepository struct {
+	db *gorm.DB // Composition: HAS-A database connection
+}
+
+// NewEmailArchiveRepository - Factory method for repository
+func NewEmailArchiveRepository(db *gorm.DB) *EmailArchiveRepository {
+	return &EmailArchiveRepository{db: db}
+}
+
+// Create - Indexes a stored copy
+func (r *EmailArchiveRepository) Create(email *models.ArchivedEmail) error {
+	return r.db.Create(email).Error
+}
+
+// FindByID - One archived email
+func (r *EmailArchiveRepository) FindByID(id string) (*models.ArchivedEmail, error) {
+	var email models.ArchivedEmail
+	err := r.db.Where("id = ?", id).First(&email).Error
+	return &email, err
+}
+
+// Find - Archived emails matching the filter, newest first
+func (r *EmailArchiveRepository) Find(filter EmailArchiveFilter) ([]models.ArchivedEmail, error) {
+	var emails []models.ArchivedEmail
+	// GORM: SELECT * FROM archived_emails WHERE ... ORDER BY sent_at DESC LIMIT ?
+	query := r.db.Model(&models.ArchivedEmail{})
+	if filter.Recipient != "" {
+		query = query.Where("recipient = ?", filter.Recipient)
+	}
+	if filter.Template != "" {
+		query = query.Where("template = ?", filter.Template)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("sent_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("sent_at < ?", filter.To)
+	}
+	err := query.Order("sent_at DESC").Limit(filter.Limit).Find(&emails).Error
+	return emails, err
+}
+
+// FindExpired - Copies past their retention, oldest first
+func (r *EmailArchiveRepository) FindExpired(now time.Time, limit int) ([]models.ArchivedEmail, error) {
+	var emails []models.ArchivedEmail
+	// GORM: SELECT * FROM archived_emails WHERE expires_at <= ? ORDER BY expires_at LIMIT ?
+	err := r.db.Where("expires_at <= ?", now).Order("expires_at").Limit(limit).Find(&emails).Error
+	return emails, err
+}
+
+// FindNotKey - Copies not encrypted with the given key (plaintext included), by ID after a cursor
+func (r *EmailArchiveRepository) FindNotKey(keyID, afterID string, limit int) ([]models.ArchivedEmail, error) {
+	var emails []models.ArchivedEmail
+	// GORM: SELECT * FROM archived_emails WHERE key_id <> ? AND id > ? ORDER BY id LIMIT ? (keyset pagination)
+	err := r.db.Where("key_id <> ? AND id > ?", keyID, afterID).Order("id ASC").Limit(limit).Find(&emails).Error
+	return emails, err
+}
+
+// CountByKey - Copies per encryption key ("" = plaintext)
+func (r *EmailArchiveRepository) CountByKey() (map[string]int64, error) {
+	var rows []struct {
+		KeyID string
+		Count int64
+	}
+	// GORM: SELECT key_id, COUNT(*) AS count FROM archived_emails GROUP BY key_id
+	err := r.db.Model(&models.ArchivedEmail{}).Select("key_id, COUNT(*) AS count").Group("key_id").Scan(&rows).Error
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.KeyID] = row.Count
+	}
+	return counts, err
+}
+
+// UpdateStorage - Points a row at its re-encrypted copy
+func (r *EmailArchiveRepository) UpdateStorage(email *models.ArchivedEmail) error {
+	return r.db.Model(email).Updates(map[string]interface{}{"storage_key": email.StorageKey, "key_id": email.KeyID}).Error
+}
+
+// Delete - Drops an index row (after its copy was removed from the store)
+func (r *EmailArchiveRepository) Delete(email *models.ArchivedEmail) error {
+	return r.db.Delete(email).Error
+}
@@ -0,0 +1,60 @@
+// DESIGN PATTERN: Repository Pattern + Upsert counters
+package repositories
+
+import (
+	"sender-service/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EmailQuotaRepository - Persists daily send counts per SMTP provider
+type EmailQuotaRepository struct {
+	db *gorm.DB // Composition: HAS-A database connection
+}
+
+// NewEmailQuotaRepository - Factory method for repository
+func NewEmailQuotaRepository(db *gorm.DB) *EmailQuotaRepository {
+	return &EmailQuotaRepository{db: db}
+}
+
+// IncrementSent - Atomically counts one delivery
+func (r *EmailQuotaRepository) IncrementSent(provider, day string) error {
+	return r.increment(provider, day, "sent")
+}
+
+// IncrementDeferred - Atomically counts one held-back email
+func (r *EmailQuotaRepository) IncrementDeferred(provider, day string) error {
+	return r.increment(provider, day, "deferred")
+}
+
+// increment - INSERT ... ON CONFLICT (provider, day) DO UPDATE SET column = column + 1
+func (r *EmailQuotaRepository) increment(provider, day, column string) error {
+	row := models.EmailSendCount{Provider: provider, Day: day}
+	if column == "sent" {
+		row.Sent = 1
+	} else {
+		row.Deferred = 1
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "provider"}, {Name: "day"}},
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: column}, Value: gorm.Expr("email_send_counts." + column + " + 1")},
+			{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("excluded.updated_at")},
+		},
+	}).Create(&row).Error
+}
+
+// Find - Counts for one provider and day (zero row when nothing was sent yet)
+func (r *EmailQuotaRepository) Find(provider, day string) (*models.EmailSendCount, error) {
+	row := models.EmailSendCount{Provider: provider, Day: day}
+	err := r.db.Where("provider = ? AND day = ?", provider, day).Limit(1).Find(&row).Error
+	return &row, err
+}
+
+// FindDay - Counts for every provider on a day
+func (r *EmailQuotaRepository) FindDay(day string) ([]models.EmailSendCount, error) {
+	var rows []models.EmailSendCount
+	err := r.db.Where("day = ?", day).Order("provider").Find(&rows).Error
+	return rows, err
+}
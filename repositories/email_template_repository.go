@@ -0,0 +1,86 @@
+// DESIGN PATTERN: Repository Pattern + CRUD Operations
+package repositories
+
+import (
+	"sender-service/models"
+
+	"gorm.io/gorm"
+)
+
+// EmailTemplateRepository - Abstracts database operations for versioned email template overrides
+type EmailTemplateRepository struct {
+	db *gorm.DB // Composition: HAS-A database connection
+}
+
+// NewEmailTemplateRepository - Factory method for repository
+func NewEmailTemplateRepository(db *gorm.DB) *EmailTemplateRepository {
+	return &EmailTemplateRepository{db: db}
+}
+
+// CreateVersion - Stores an upload as the next version of its tenant's template
+func (r *EmailTemplateRepository) CreateVersion(override *models.EmailTemplateOverride) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		// GORM: SELECT COALESCE(MAX(version), 0) FROM email_template_overrides WHERE tenant = ? AND name = ?
+		if err := tx.Model(&models.EmailTemplateOverride{}).
+			Where("tenant = ? AND name = ?", override.Tenant, override.Name).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&last).Error; err != nil {
+			return err
+		}
+
+		override.Version = last + 1
+		override.Active = false
+		return tx.Create(override).Error
+	})
+}
+
+// ListVersions - Every version of one tenant's template, newest first
+func (r *EmailTemplateRepository) ListVersions(tenant, name string) ([]models.EmailTemplateOverride, error) {
+	var overrides []models.EmailTemplateOverride
+	// GORM: SELECT * FROM email_template_overrides WHERE tenant = ? AND name = ? ORDER BY version DESC
+	err := r.db.Where("tenant = ? AND name = ?", tenant, name).Order("version DESC").Find(&overrides).Error
+	return overrides, err
+}
+
+// FindVersion - One version (0 = latest)
+func (r *EmailTemplateRepository) FindVersion(tenant, name string, version int) (*models.EmailTemplateOverride, error) {
+	var override models.EmailTemplateOverride
+	query := r.db.Where("tenant = ? AND name = ?", tenant, name)
+	if version > 0 {
+		query = query.Where("version = ?", version)
+	}
+	err := query.Order("version DESC").First(&override).Error
+	return &override, err
+}
+
+// FindActive - Overrides currently used for sends, across tenants
+func (r *EmailTemplateRepository) FindActive() ([]models.EmailTemplateOverride, error) {
+	var overrides []models.EmailTemplateOverride
+	// GORM: SELECT * FROM email_template_overrides WHERE active = true ORDER BY tenant, name
+	err := r.db.Where("active = ?", true).Order("tenant, name").Find(&overrides).Error
+	return overrides, err
+}
+
+// Activate - Makes one version the active one for its tenant and template (others are deactivated)
+func (r *EmailTemplateRepository) Activate(override *models.EmailTemplateOverride) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.EmailTemplateOverride{}).
+			Where("tenant = ? AND name = ? AND id <> ?", override.Tenant, override.Name, override.ID).
+			Update("active", false).Error; err != nil {
+			return err
+		}
+		return tx.Model(override).Updates(map[string]interface{}{
+			"active":       true,
+			"activated_at": override.ActivatedAt,
+		}).Error
+	})
+}
+
+// Deactivate - Turns off a tenant's template (versions are kept); affected reports whether one was active
+func (r *EmailTemplateRepository) Deactivate(tenant, name string) (affected bool, err error) {
+	result := r.db.Model(&models.EmailTemplateOverride{}).
+		Where("tenant = ? AND name = ? AND active = ?", tenant, name, true).
+		Update("active", false)
+	return result.RowsAffected > 0, result.Error
+}
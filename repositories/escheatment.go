@@ -0,0 +1,54 @@
+// DESIGN PATTERN: Repository Pattern - Escheatment reporting queries
+package repositories
+
+import (
+	"sender-service/models"
+	"sort"
+	"time"
+)
+
+// unclaimedStatuses - Transfers whose points never left the sender
+var unclaimedStatuses = []string{"pending", "expired"}
+
+// FlagEscheatable - Stamps unclaimed transfers that expired before the cutoff (all shards)
+func (r *TransferRepository) FlagEscheatable(cutoff, now time.Time) (int64, error) {
+	var flagged int64
+	for _, shard := range r.shards {
+		// GORM: UPDATE transfers SET escheatable_at = ? WHERE status IN (...) AND expires_at < ? AND escheatable_at IS NULL AND NOT canary
+		result := shard.Model(&models.Transfer{}).
+			Where("status IN ? AND expires_at < ? AND escheatable_at IS NULL AND NOT canary", unclaimedStatuses, cutoff).
+			Update("escheatable_at", now)
+		if result.Error != nil {
+			return flagged, result.Error
+		}
+		flagged += result.RowsAffected
+	}
+	return flagged, nil
+}
+
+// EscheatmentReport - Flagged transfers that expired in [from, to), grouped by sender and period
+func (r *TransferRepository) EscheatmentReport(period string, from, to time.Time) ([]models.EscheatmentRow, error) {
+	rows := []models.EscheatmentRow{}
+	for _, shard := range r.shards {
+		var shardRows []models.EscheatmentRow
+		// Senders never span shards, so per-shard groups can simply be concatenated
+		err := shard.Model(&models.Transfer{}).
+			Select("sender_id, sender_email, date_trunc(?, expires_at) AS period, COUNT(*) AS transfers, SUM(points) AS points", period).
+			Where("escheatable_at IS NOT NULL AND status IN ?", unclaimedStatuses).
+			Where("expires_at >= ? AND expires_at < ?", from, to).
+			Group("sender_id, sender_email, period").
+			Scan(&shardRows).Error
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, shardRows...)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if !rows[i].Period.Equal(rows[j].Period) {
+			return rows[i].Period.Before(rows[j].Period)
+		}
+		return rows[i].SenderID < rows[j].SenderID
+	})
+	return rows, nil
+}
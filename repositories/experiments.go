@@ -0,0 +1,52 @@
+// DESIGN PATTERN: Repository Pattern - Experiment cohort reporting
+package repositories
+
+import (
+	"sender-service/models"
+	"sort"
+	"time"
+)
+
+// CohortConversion - Outcomes per variant of one experiment for transfers created in [from, to)
+func (r *TransferRepository) CohortConversion(experiment string, from, to time.Time) ([]models.CohortConversion, error) {
+	totals := map[string]*models.CohortConversion{}
+	for _, shard := range r.shards {
+		var shardRows []models.CohortConversion
+		// Cohorts are stored as JSON text: {"expiry": "72h", ...}
+		err := shard.Model(&models.Transfer{}).
+			Select(`cohorts::jsonb ->> ? AS variant,
+				COUNT(*) AS transfers,
+				SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END) AS completed,
+				SUM(CASE WHEN status = 'expired' THEN 1 ELSE 0 END) AS expired,
+				SUM(CASE WHEN status = 'pending' THEN 1 ELSE 0 END) AS pending,
+				SUM(CASE WHEN nudged_at IS NOT NULL THEN 1 ELSE 0 END) AS nudged`, experiment).
+			Where("cohorts IS NOT NULL AND cohorts <> '' AND cohorts::jsonb ->> ? IS NOT NULL", experiment).
+			Where("created_at >= ? AND created_at < ?", from, to).
+			Group("variant").
+			Scan(&shardRows).Error
+		if err != nil {
+			return nil, err
+		}
+
+		// Variants appear on every shard, so merge by name
+		for _, row := range shardRows {
+			total, ok := totals[row.Variant]
+			if !ok {
+				total = &models.CohortConversion{Variant: row.Variant}
+				totals[row.Variant] = total
+			}
+			total.Transfers += row.Transfers
+			total.Completed += row.Completed
+			total.Expired += row.Expired
+			total.Pending += row.Pending
+			total.Nudged += row.Nudged
+		}
+	}
+
+	rows := make([]models.CohortConversion, 0, len(totals))
+	for _, total := range totals {
+		rows = append(rows, *total)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Variant < rows[j].Variant })
+	return rows, nil
+}
@@ -0,0 +1,95 @@
+// DESIGN PATTERN: Plugin Pattern (GORM callbacks) + Observer Pattern
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// healthStartKey - Statement setting holding the query start time
+const healthStartKey = "health:started_at"
+
+// HealthObserver - Receives the latency and outcome of every database operation
+type HealthObserver interface {
+	Observe(dependency string, latency time.Duration, err error)
+}
+
+// HealthPlugin - GORM plugin feeding query latency/errors to a HealthObserver
+type HealthPlugin struct {
+	dependency string         // Name reported to the observer
+	observer   HealthObserver // Receives samples
+}
+
+// NewHealthPlugin - Factory method; register with db.Use(plugin)
+func NewHealthPlugin(dependency string, observer HealthObserver) *HealthPlugin {
+	return &HealthPlugin{dependency: dependency, observer: observer}
+}
+
+// Name - GORM plugin identifier
+func (p *HealthPlugin) Name() string {
+	return "health_observer"
+}
+
+// Initialize - Hooks before/after callbacks on every operation type
+func (p *HealthPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("health:before_create", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:create").Register("health:after_create", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("health:before_query", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("gorm:query").Register("health:after_query", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("health:before_update", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("health:after_update", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("health:before_delete", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:delete").Register("health:after_delete", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("health:before_row", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Row().After("gorm:row").Register("health:after_row", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().Before("gorm:raw").Register("health:before_raw", p.before); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("gorm:raw").Register("health:after_raw", p.after)
+}
+
+// before - Stamps the start time on the statement
+func (p *HealthPlugin) before(db *gorm.DB) {
+	db.InstanceSet(healthStartKey, time.Now())
+}
+
+// after - Reports latency and outcome (record-not-found is a healthy answer)
+func (p *HealthPlugin) after(db *gorm.DB) {
+	value, ok := db.InstanceGet(healthStartKey)
+	if !ok {
+		return
+	}
+	started, ok := value.(time.Time)
+	if !ok {
+		return
+	}
+
+	err := db.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	p.observer.Observe(p.dependency, time.Since(started), err)
+}
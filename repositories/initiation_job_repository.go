@@ -0,0 +1,86 @@
+// DESIGN PATTERN: Repository Pattern + Competing Consumers (SELECT ... FOR UPDATE SKIP LOCKED)
+package repositories
+
+import (
+	"sender-service/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InitiationJobRepository - Abstracts persistence of queued asynchronous initiations
+type InitiationJobRepository struct {
+	db *gorm.DB // Composition: HAS-A database connection
+}
+
+// NewInitiationJobRepository - Factory method for repository
+func NewInitiationJobRepository(db *gorm.DB) *InitiationJobRepository {
+	return &InitiationJobRepository{db: db}
+}
+
+// Create - Persists a newly queued job
+func (r *InitiationJobRepository) Create(job *models.InitiationJob) error {
+	return r.db.Create(job).Error
+}
+
+// FindByID - Finds a job by identifier
+func (r *InitiationJobRepository) FindByID(id string) (*models.InitiationJob, error) {
+	var job models.InitiationJob
+	err := r.db.Where("id = ?", id).First(&job).Error
+	return &job, err
+}
+
+// CountQueued - Jobs waiting for a worker on any instance
+func (r *InitiationJobRepository) CountQueued() (int64, error) {
+	var count int64
+	err := r.db.Model(&models.InitiationJob{}).Where("status = ?", models.InitiationJobQueued).Count(&count).Error
+	return count, err
+}
+
+// ClaimNext - Oldest queued job, or a processing one whose worker went quiet before staleBefore, marked
+// processing for this worker (nil when there is nothing to do). Workers on every instance compete for
+// rows; SKIP LOCKED keeps them from waiting on, or taking, the same job
+func (r *InitiationJobRepository) ClaimNext(now, staleBefore time.Time) (*models.InitiationJob, error) {
+	var claimed *models.InitiationJob
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var jobs []models.InitiationJob
+		// GORM: SELECT * FROM initiation_jobs WHERE status = 'queued' OR (status = 'processing' AND updated_at < ?)
+		//       ORDER BY created_at LIMIT 1 FOR UPDATE SKIP LOCKED
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND updated_at < ?)", models.InitiationJobQueued, models.InitiationJobProcessing, staleBefore).
+			Order("created_at ASC").
+			Limit(1).
+			Find(&jobs).Error; err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		job := &jobs[0]
+		job.Status = models.InitiationJobProcessing
+		job.Attempts++
+		job.UpdatedAt = now
+		if err := tx.Save(job).Error; err != nil {
+			return err
+		}
+		claimed = job
+		return nil
+	})
+	return claimed, err
+}
+
+// Update - Saves a job's state change
+func (r *InitiationJobRepository) Update(job *models.InitiationJob) error {
+	return r.db.Save(job).Error
+}
+
+// PurgeFinished - Deletes succeeded and failed jobs last changed before the cutoff
+func (r *InitiationJobRepository) PurgeFinished(before time.Time) (int64, error) {
+	// GORM: DELETE FROM initiation_jobs WHERE status IN ('succeeded', 'failed') AND updated_at < ?
+	result := r.db.Where("status IN ? AND updated_at < ?",
+		[]string{models.InitiationJobSucceeded, models.InitiationJobFailed}, before).
+		Delete(&models.InitiationJob{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,92 @@
+// DESIGN PATTERN: Repository Pattern (append-only job run history)
+package repositories
+
+import (
+	"errors"
+	"sender-service/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// jobLockNamespace - First key of the per-job advisory locks (the second is hashtext of the job name)
+const jobLockNamespace = 7201722
+
+// JobRunRepository - Persists the run history of scheduled jobs
+type JobRunRepository struct {
+	db *gorm.DB // Composition: HAS-A database connection
+}
+
+// NewJobRunRepository - Factory method for repository
+func NewJobRunRepository(db *gorm.DB) *JobRunRepository {
+	return &JobRunRepository{db: db}
+}
+
+// RunIfDue - Runs fn inside a transaction holding the job's advisory lock when due accepts the start of the
+// job's latest run on any instance (nil when it never ran). The new start is recorded in the same
+// transaction, so no other replica runs the job again within the period; a failed run rolls it back and
+// leaves the job due. ran reports whether fn ran.
+func (r *JobRunRepository) RunIfDue(job, instance string, now time.Time, due func(last *time.Time) bool, fn func() error) (ran bool, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		// POSTGRES: Transaction-scoped advisory lock, released automatically on commit/rollback
+		acquired := false
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?, hashtext(?))", jobLockNamespace, job).Scan(&acquired).Error; err != nil {
+			return err
+		}
+		if !acquired {
+			return nil
+		}
+
+		// PERIOD CHECK: Another replica may already have run this period
+		var latest models.JobLastRun
+		var last *time.Time
+		err := tx.Where("job = ?", job).Take(&latest).Error
+		switch {
+		case err == nil:
+			last = &latest.StartedAt
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+		if !due(last) {
+			return nil
+		}
+
+		mark := &models.JobLastRun{Job: job, StartedAt: now, Instance: instance}
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(mark).Error; err != nil {
+			return err
+		}
+		ran = true
+		return fn()
+	})
+	return ran, err
+}
+
+// Create - Records one finished run
+func (r *JobRunRepository) Create(run *models.JobRun) error {
+	return r.db.Create(run).Error
+}
+
+// FindRecent - Latest runs of one job, newest first
+func (r *JobRunRepository) FindRecent(job string, limit int) ([]models.JobRun, error) {
+	var runs []models.JobRun
+	err := r.db.Where("job = ?", job).Order("started_at DESC").Limit(limit).Find(&runs).Error
+	return runs, err
+}
+
+// FindLatest - Most recent run per job (jobs that never ran are absent)
+func (r *JobRunRepository) FindLatest() (map[string]models.JobRun, error) {
+	var runs []models.JobRun
+	err := r.db.Raw(`SELECT DISTINCT ON (job) * FROM job_runs ORDER BY job, started_at DESC`).Scan(&runs).Error
+	latest := make(map[string]models.JobRun, len(runs))
+	for _, run := range runs {
+		latest[run.Job] = run
+	}
+	return latest, err
+}
+
+// DeleteBefore - Drops runs started before cutoff, returning how many were removed
+func (r *JobRunRepository) DeleteBefore(cutoff time.Time) (int64, error) {
+	result := r.db.Where("started_at < ?", cutoff).Delete(&models.JobRun{})
+	return result.RowsAffected, result.Error
+}
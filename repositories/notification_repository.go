@@ -0,0 +1,54 @@
+// DESIGN PATTERN: Repository Pattern + CRUD Operations
+package repositories
+
+import (
+	"sender-service/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationRepository - Abstracts database operations for in-app notifications
+type NotificationRepository struct {
+	db *gorm.DB // Composition: HAS-A database connection
+}
+
+// NewNotificationRepository - Factory method for repository
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// Create - Persists a new notification
+func (r *NotificationRepository) Create(notification *models.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+// FindByUserID - A user's notifications, newest first (optionally unread only)
+func (r *NotificationRepository) FindByUserID(userID string, unreadOnly bool, limit int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	query := r.db.Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	err := query.Order("created_at DESC").Limit(limit).Find(&notifications).Error
+	return notifications, err
+}
+
+// MarkRead - Marks one of the user's notifications as read (false when it does not exist)
+func (r *NotificationRepository) MarkRead(userID, notificationID string, at time.Time) (bool, error) {
+	// GORM: UPDATE notifications SET read_at = ? WHERE id = ? AND user_id = ? AND read_at IS NULL
+	result := r.db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", notificationID, userID).
+		Update("read_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	// Already read is still success; only a missing notification is not
+	var count int64
+	err := r.db.Model(&models.Notification{}).Where("id = ? AND user_id = ?", notificationID, userID).Count(&count).Error
+	return count > 0, err
+}
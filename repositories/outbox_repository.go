@@ -0,0 +1,159 @@
+// DESIGN PATTERN: Repository Pattern + Transactional Outbox
+package repositories
+
+import (
+	"sender-service/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// outboxRelayLockKey - Advisory lock key ensuring a single relay delivers at a time (preserves ordering)
+const outboxRelayLockKey = 7201720
+
+// OutboxRepository - Abstracts persistence of outbox events
+type OutboxRepository struct {
+	db *gorm.DB // Composition: HAS-A database connection
+}
+
+// NewOutboxRepository - Factory method for repository
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Append - Stores an event and assigns the next per-aggregate sequence number
+func (r *OutboxRepository) Append(event *models.OutboxEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		// GORM: SELECT COALESCE(MAX(sequence), 0) FROM outbox_events WHERE aggregate_id = ?
+		if err := tx.Model(&models.OutboxEvent{}).
+			Where("aggregate_id = ?", event.AggregateID).
+			Select("COALESCE(MAX(sequence), 0)").
+			Scan(&last).Error; err != nil {
+			return err
+		}
+
+		event.Sequence = last + 1
+		event.Status = models.OutboxPending
+		return tx.Create(event).Error
+	})
+}
+
+// EventExists - Whether an event ID is already in the outbox
+func (r *OutboxRepository) EventExists(eventID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.OutboxEvent{}).Where("event_id = ?", eventID).Count(&count).Error
+	return count > 0, err
+}
+
+// WithRelayLock - Runs fn inside a transaction holding the relay advisory lock; acquired reports whether fn ran
+func (r *OutboxRepository) WithRelayLock(fn func(repo *OutboxRepository) error) (acquired bool, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		// POSTGRES: Transaction-scoped advisory lock, released automatically on commit/rollback
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", outboxRelayLockKey).Scan(&acquired).Error; err != nil {
+			return err
+		}
+		if !acquired {
+			return nil
+		}
+		return fn(&OutboxRepository{db: tx})
+	})
+	return acquired, err
+}
+
+// FetchPendingBatch - Oldest undelivered events in global order (includes not-yet-due ones so ordering can be enforced)
+func (r *OutboxRepository) FetchPendingBatch(limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	// GORM: SELECT * FROM outbox_events WHERE status = 'pending' ORDER BY id LIMIT ?
+	err := r.db.Where("status = ?", models.OutboxPending).
+		Order("id ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// MarkDelivered - Records the at-least-once delivery marker for a batch of events
+func (r *OutboxRepository) MarkDelivered(ids []uint, deliveredAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	// GORM: UPDATE outbox_events SET status = 'delivered', delivered_at = ? WHERE id IN (?)
+	return r.db.Model(&models.OutboxEvent{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"status":       models.OutboxDelivered,
+			"delivered_at": deliveredAt,
+			"attempts":     gorm.Expr("attempts + 1"),
+			"last_error":   "",
+		}).Error
+}
+
+// MarkFailed - Records a failed attempt and schedules the next one
+func (r *OutboxRepository) MarkFailed(id uint, lastError string, nextAttemptAt time.Time) error {
+	// GORM: UPDATE outbox_events SET attempts = attempts + 1, last_error = ?, next_attempt_at = ? WHERE id = ?
+	return r.db.Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		}).Error
+}
+
+// MarkDead - Moves an event to the dead state after its final failed attempt
+func (r *OutboxRepository) MarkDead(id uint, lastError string) error {
+	return r.db.Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     models.OutboxDead,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"last_error": lastError,
+		}).Error
+}
+
+// DeadAggregateIDs - Aggregates with a dead event; their later events must wait to keep ordering
+func (r *OutboxRepository) DeadAggregateIDs() ([]string, error) {
+	var ids []string
+	// GORM: SELECT DISTINCT aggregate_id FROM outbox_events WHERE status = 'dead'
+	err := r.db.Model(&models.OutboxEvent{}).
+		Where("status = ?", models.OutboxDead).
+		Distinct("aggregate_id").
+		Pluck("aggregate_id", &ids).Error
+	return ids, err
+}
+
+// FindByID - Finds an outbox event by its delivery sequence ID
+func (r *OutboxRepository) FindByID(id uint) (*models.OutboxEvent, error) {
+	var event models.OutboxEvent
+	err := r.db.Where("id = ?", id).First(&event).Error
+	return &event, err
+}
+
+// Requeue - Returns a dead event to the pending queue with a fresh retry budget
+func (r *OutboxRepository) Requeue(id uint, now time.Time) error {
+	return r.db.Model(&models.OutboxEvent{}).
+		Where("id = ? AND status = ?", id, models.OutboxDead).
+		Updates(map[string]interface{}{
+			"status":          models.OutboxPending,
+			"attempts":        0,
+			"next_attempt_at": now,
+		}).Error
+}
+
+// Discard - Drops a dead event so the aggregate's later events can flow again
+func (r *OutboxRepository) Discard(id uint) error {
+	return r.db.Model(&models.OutboxEvent{}).
+		Where("id = ? AND status = ?", id, models.OutboxDead).
+		Update("status", models.OutboxDiscarded).Error
+}
+
+// FetchSince - Events of the given types after an outbox ID in global order, created no later than settledBefore
+func (r *OutboxRepository) FetchSince(afterID uint, eventTypes []string, settledBefore time.Time, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	// GORM: SELECT * FROM outbox_events WHERE id > ? AND event_type IN (?) AND created_at <= ? ORDER BY id LIMIT ?
+	err := r.db.Where("id > ? AND event_type IN ? AND created_at <= ?", afterID, eventTypes, settledBefore).
+		Order("id ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
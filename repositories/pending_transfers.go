@@ -0,0 +1,109 @@
+// DESIGN PATTERN: Repository Pattern - Pending transfer lifecycle queries (reminders, expiry)
+package repositories
+
+import (
+	"sender-service/models"
+	"time"
+)
+
+// FindStalePending - Pending, un-nudged transfers past their nudge_at, or past `fraction` of their lifetime (all shards)
+func (r *TransferRepository) FindStalePending(fraction float64, now time.Time, limit int) ([]models.Transfer, error) {
+	var transfers []models.Transfer
+	for _, shard := range r.shards {
+		var shardTransfers []models.Transfer
+		// GORM: SELECT * FROM transfers WHERE status = 'pending' AND nudged_at IS NULL AND expires_at > ?
+		//       AND COALESCE(nudge_at, created_at + (expires_at - created_at) * ?) <= ? ORDER BY expires_at LIMIT ?
+		err := shard.Where("status = ? AND nudged_at IS NULL AND expires_at > ?", "pending", now).
+			Where("COALESCE(nudge_at, created_at + (expires_at - created_at) * ?) <= ?", fraction, now).
+			Order("expires_at").
+			Limit(limit).
+			Find(&shardTransfers).Error
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, shardTransfers...)
+	}
+	return transfers, nil
+}
+
+// FindExpiredPending - Pending transfers whose claim window has ended, oldest first (all shards)
+func (r *TransferRepository) FindExpiredPending(now time.Time, limit int) ([]models.Transfer, error) {
+	var transfers []models.Transfer
+	for _, shard := range r.shards {
+		var shardTransfers []models.Transfer
+		// GORM: SELECT * FROM transfers WHERE status = 'pending' AND expires_at <= ? ORDER BY expires_at LIMIT ?
+		err := shard.Where("status = ? AND expires_at <= ?", "pending", now).
+			Order("expires_at").
+			Limit(limit).
+			Find(&shardTransfers).Error
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, shardTransfers...)
+	}
+	return transfers, nil
+}
+
+// MarkNudged - Single-column update so a transfer is nudged at most once
+func (r *TransferRepository) MarkNudged(transfer *models.Transfer, now time.Time) error {
+	// GORM: UPDATE transfers SET nudged_at = ? WHERE id = ?
+	return r.shardFor(transfer).Model(&models.Transfer{ID: transfer.ID}).
+		Update("nudged_at", now).Error
+}
+
+// MarkEngagement - Single-column update keeping the first open/click (column is email_opened_at or link_clicked_at)
+func (r *TransferRepository) MarkEngagement(transfer *models.Transfer, column string, at time.Time) error {
+	// GORM: UPDATE transfers SET <column> = ? WHERE id = ? AND <column> IS NULL
+	return r.shardFor(transfer).Model(&models.Transfer{}).
+		Where("id = ? AND "+column+" IS NULL", transfer.ID).
+		Update(column, at).Error
+}
+
+// MarkNotificationDeferred - Single-column update flagging (or clearing) a claim notification held back by the email circuit
+func (r *TransferRepository) MarkNotificationDeferred(transfer *models.Transfer, deferred bool) error {
+	// GORM: UPDATE transfers SET notification_deferred = ? WHERE id = ?
+	return r.shardFor(transfer).Model(&models.Transfer{ID: transfer.ID}).
+		Update("notification_deferred", deferred).Error
+}
+
+// UpdateEmailStatus - Single-column update recording a newly queued claim notification
+func (r *TransferRepository) UpdateEmailStatus(transfer *models.Transfer) error {
+	// GORM: UPDATE transfers SET email_status = ? WHERE id = ?
+	return r.shardFor(transfer).Model(&models.Transfer{ID: transfer.ID}).
+		Update("email_status", transfer.EmailStatus).Error
+}
+
+// MarkEmailBounced - Flags pending transfers whose claim email to the address was sent as bounced (all shards)
+func (r *TransferRepository) MarkEmailBounced(email string) (int64, error) {
+	var total int64
+	for _, shard := range r.shards {
+		// GORM: UPDATE transfers SET email_status = 'bounced' WHERE LOWER(receiver_email) = LOWER(?)
+		//       AND status = 'pending' AND email_status = 'sent'
+		result := shard.Model(&models.Transfer{}).
+			Scopes(scopeReceiverEmail(email)).
+			Where("status = ? AND email_status = ?", models.TransferStatusPending, models.EmailStatusSent).
+			Update("email_status", models.EmailStatusBounced)
+		if result.Error != nil {
+			return total, result.Error
+		}
+		total += result.RowsAffected
+	}
+	return total, nil
+}
+
+// CountNotificationDeferred - Pending transfers whose claim notification is deferred (all shards)
+func (r *TransferRepository) CountNotificationDeferred() (int64, error) {
+	var total int64
+	for _, shard := range r.shards {
+		var count int64
+		// GORM: SELECT count(*) FROM transfers WHERE status = 'pending' AND notification_deferred = true
+		err := shard.Model(&models.Transfer{}).
+			Where("status = ? AND notification_deferred = ?", "pending", true).
+			Count(&count).Error
+		if err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
@@ -0,0 +1,41 @@
+// DESIGN PATTERN: Repository Pattern + CRUD Operations
+package repositories
+
+import (
+	"sender-service/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PreferenceRepository - Abstracts database operations for notification preferences
+type PreferenceRepository struct {
+	db *gorm.DB // Composition: HAS-A database connection
+}
+
+// NewPreferenceRepository - Factory method for repository
+func NewPreferenceRepository(db *gorm.DB) *PreferenceRepository {
+	return &PreferenceRepository{db: db}
+}
+
+// FindByUserID - Finds a user's preferences
+func (r *PreferenceRepository) FindByUserID(userID string) (*models.NotificationPreference, error) {
+	var preference models.NotificationPreference
+	err := r.db.Where("user_id = ?", userID).First(&preference).Error
+	return &preference, err
+}
+
+// Save - Inserts or updates preferences
+func (r *PreferenceRepository) Save(preference *models.NotificationPreference) error {
+	return r.db.Save(preference).Error
+}
+
+// FindDueForDigest - Users on a frequency whose last digest is older than the cutoff
+func (r *PreferenceRepository) FindDueForDigest(frequency string, cutoff time.Time) ([]models.NotificationPreference, error) {
+	var preferences []models.NotificationPreference
+	// GORM: SELECT * FROM notification_preferences WHERE digest_frequency = ? AND (last_digest_at IS NULL OR last_digest_at <= ?)
+	err := r.db.Where("digest_frequency = ?", frequency).
+		Where("last_digest_at IS NULL OR last_digest_at <= ?", cutoff).
+		Find(&preferences).Error
+	return preferences, err
+}
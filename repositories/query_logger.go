@@ -0,0 +1,230 @@
+// DESIGN PATTERN: Decorator Pattern (GORM logger) + Observer Pattern (query metrics)
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// QueryStats - Per-query-type counters exposed on the admin API
+type QueryStats struct {
+	Count     int64         `json:"count"`      // Queries executed
+	Errors    int64         `json:"errors"`     // Queries that failed (not-found excluded)
+	Slow      int64         `json:"slow"`       // Queries above the slow threshold
+	TotalTime time.Duration `json:"total_time"` // Cumulative latency
+	MaxTime   time.Duration `json:"max_time"`   // Worst latency seen
+}
+
+// slowQueryLog - Structured slow-query record (one JSON object per line)
+type slowQueryLog struct {
+	Level     string  `json:"level"`
+	Message   string  `json:"msg"`
+	Caller    string  `json:"caller"`     // Repository method that issued the query
+	QueryType string  `json:"query_type"` // SELECT, INSERT, UPDATE, DELETE, OTHER
+	ElapsedMs float64 `json:"elapsed_ms"`
+	Threshold string  `json:"threshold"`
+	Rows      int64   `json:"rows"`
+	SQL       string  `json:"sql"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// QueryLogger - GORM logger emitting structured slow-query logs and per-type metrics
+type QueryLogger struct {
+	mu            sync.Mutex
+	stats         map[string]*QueryStats // Keyed by query type
+	slowThreshold time.Duration          // Queries slower than this are logged
+	level         logger.LogLevel        // GORM log level for Info/Warn/Error messages
+}
+
+// NewQueryLogger - Factory method; pass as gorm.Config{Logger: ...}
+func NewQueryLogger(slowThreshold time.Duration, level string) *QueryLogger {
+	return &QueryLogger{
+		stats:         map[string]*QueryStats{},
+		slowThreshold: slowThreshold,
+		level:         parseLogLevel(level),
+	}
+}
+
+// LogMode - Returns a copy sharing the same metrics at a different level
+func (l *QueryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &levelledQueryLogger{QueryLogger: l, level: level}
+}
+
+// Info - GORM info messages
+func (l *QueryLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	l.printf(l.level, logger.Info, "info", msg, args...)
+}
+
+// Warn - GORM warnings
+func (l *QueryLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	l.printf(l.level, logger.Warn, "warn", msg, args...)
+}
+
+// Error - GORM errors
+func (l *QueryLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	l.printf(l.level, logger.Error, "error", msg, args...)
+}
+
+// Trace - Called after every statement: records metrics and logs slow queries
+func (l *QueryLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	l.trace(l.level, begin, fc, err)
+}
+
+// Snapshot - Copy of the per-query-type counters
+func (l *QueryLogger) Snapshot() map[string]QueryStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	snapshot := make(map[string]QueryStats, len(l.stats))
+	for queryType, stats := range l.stats {
+		snapshot[queryType] = *stats
+	}
+	return snapshot
+}
+
+// trace - Shared by the base logger and LogMode copies
+func (l *QueryLogger) trace(level logger.LogLevel, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+	queryType := classifyQuery(sql)
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+	slow := l.slowThreshold > 0 && elapsed > l.slowThreshold
+
+	// 1. METRICS: Always counted, regardless of log level
+	l.mu.Lock()
+	stats, ok := l.stats[queryType]
+	if !ok {
+		stats = &QueryStats{}
+		l.stats[queryType] = stats
+	}
+	stats.Count++
+	stats.TotalTime += elapsed
+	if elapsed > stats.MaxTime {
+		stats.MaxTime = elapsed
+	}
+	if failed {
+		stats.Errors++
+	}
+	if slow {
+		stats.Slow++
+	}
+	l.mu.Unlock()
+
+	// 2. STRUCTURED LOGS: Slow queries at warn, failures at error
+	if level == logger.Silent || (!slow && !failed) {
+		return
+	}
+	if !failed && level < logger.Warn {
+		return
+	}
+
+	entry := slowQueryLog{
+		Level:     "warn",
+		Message:   "slow query",
+		Caller:    repositoryCaller(),
+		QueryType: queryType,
+		ElapsedMs: float64(elapsed.Microseconds()) / 1000,
+		Threshold: l.slowThreshold.String(),
+		Rows:      rows,
+		SQL:       sql,
+	}
+	if failed {
+		entry.Level = "error"
+		entry.Message = "query failed"
+		entry.Error = err.Error()
+	}
+	line, _ := json.Marshal(entry)
+	log.Println(string(line))
+}
+
+// printf - Level-filtered plain message
+func (l *QueryLogger) printf(configured, required logger.LogLevel, level, msg string, args ...interface{}) {
+	if configured >= required {
+		log.Printf("[gorm "+level+"] "+msg, args...)
+	}
+}
+
+// levelledQueryLogger - LogMode view of a QueryLogger (shares metrics)
+type levelledQueryLogger struct {
+	*QueryLogger
+	level logger.LogLevel
+}
+
+// LogMode - Switches level again
+func (l *levelledQueryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &levelledQueryLogger{QueryLogger: l.QueryLogger, level: level}
+}
+
+// Info - GORM info messages
+func (l *levelledQueryLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	l.printf(l.level, logger.Info, "info", msg, args...)
+}
+
+// Warn - GORM warnings
+func (l *levelledQueryLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	l.printf(l.level, logger.Warn, "warn", msg, args...)
+}
+
+// Error - GORM errors
+func (l *levelledQueryLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	l.printf(l.level, logger.Error, "error", msg, args...)
+}
+
+// Trace - Uses this view's level
+func (l *levelledQueryLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	l.trace(l.level, begin, fc, err)
+}
+
+// classifyQuery - Query type from the leading SQL keyword
+func classifyQuery(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "OTHER"
+	}
+	switch keyword := strings.ToUpper(fields[0]); keyword {
+	case "SELECT", "INSERT", "UPDATE", "DELETE":
+		return keyword
+	case "WITH":
+		return "SELECT"
+	}
+	return "OTHER"
+}
+
+// repositoryCaller - First repository method on the stack (e.g. TransferRepository.FindByID)
+func repositoryCaller() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, "sender-service/repositories.") &&
+			!strings.HasSuffix(frame.File, "query_logger.go") && !strings.HasSuffix(frame.File, "health_plugin.go") {
+			name := strings.TrimPrefix(frame.Function, "sender-service/repositories.")
+			return strings.NewReplacer("(*", "", ")", "").Replace(name)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// parseLogLevel - silent, error, warn or info (warn by default)
+func parseLogLevel(level string) logger.LogLevel {
+	switch strings.ToLower(level) {
+	case "silent":
+		return logger.Silent
+	case "error":
+		return logger.Error
+	case "info":
+		return logger.Info
+	}
+	return logger.Warn
+}
@@ -0,0 +1,83 @@
+// DESIGN PATTERN: Factory Pattern + Data Residency Routing (region -> shard group)
+package repositories
+
+import (
+	"sender-service/models"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// RegionStore - Transfer databases of one region (replicas aligned with shards, nil = read primary)
+type RegionStore struct {
+	Shards   []*gorm.DB // Shards holding the region's transfers (sharded by sender ID within the region)
+	Replicas []*gorm.DB // Optional read replica per shard
+}
+
+// NewRegionalTransferRepository - Factory method routing each transfer to its sender's region, then to a
+// shard within that region. Regions are flattened into one shard list so scans cover every region.
+func NewRegionalTransferRepository(defaultRegion string, stores map[string]RegionStore) *TransferRepository {
+	r := &TransferRepository{regions: map[string][]int{}, defaultRegion: defaultRegion}
+	for _, region := range sortedRegions(stores) {
+		store := stores[region]
+		for i, shard := range store.Shards {
+			r.regions[region] = append(r.regions[region], len(r.shards))
+			r.shards = append(r.shards, shard)
+			var replica *gorm.DB
+			if i < len(store.Replicas) {
+				replica = store.Replicas[i]
+			}
+			r.replicas = append(r.replicas, replica)
+		}
+	}
+	return r
+}
+
+// ResolveRegion - Region a sender's transfers are stored in (unconfigured or empty regions use the default)
+func (r *TransferRepository) ResolveRegion(region string) string {
+	if _, ok := r.regions[region]; ok {
+		return region
+	}
+	return r.defaultRegion
+}
+
+// Regions - Configured storage regions
+func (r *TransferRepository) Regions() []string {
+	regions := make([]string, 0, len(r.regions))
+	for region := range r.regions {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// shardIndexFor - Global shard index owning a sender's transfers in a region
+func (r *TransferRepository) shardIndexFor(region, senderID string) int {
+	group := r.regions[r.ResolveRegion(region)]
+	return group[ShardIndex(senderID, len(group))]
+}
+
+// shardFor - Connection owning the transfer (its stored region, then its sender)
+func (r *TransferRepository) shardFor(transfer *models.Transfer) *gorm.DB {
+	return r.shards[r.shardIndexFor(transfer.Region, transfer.SenderID)]
+}
+
+// senderShards - The sender's owning shard in every region (only the region of record holds
+// new transfers, but a sender whose profile region changed keeps older rows elsewhere)
+func (r *TransferRepository) senderShards(senderID string) []int {
+	indexes := make([]int, 0, len(r.regions))
+	for _, region := range r.Regions() {
+		indexes = append(indexes, r.shardIndexFor(region, senderID))
+	}
+	return indexes
+}
+
+// sortedRegions - Deterministic region order so shard numbering is stable across restarts
+func sortedRegions(stores map[string]RegionStore) []string {
+	regions := make([]string, 0, len(stores))
+	for region := range stores {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
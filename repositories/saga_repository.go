@@ -0,0 +1,70 @@
+// DESIGN PATTERN: Repository Pattern + CRUD Operations
+package repositories
+
+import (
+	"sender-service/models"
+
+	"gorm.io/gorm"
+)
+
+// SagaRepository - Abstracts database operations for saga compensation steps
+type SagaRepository struct {
+	db *gorm.DB // Composition: HAS-A database connection
+}
+
+// NewSagaRepository - Factory method for repository
+func NewSagaRepository(db *gorm.DB) *SagaRepository {
+	return &SagaRepository{db: db}
+}
+
+// Create - Persists a new saga step
+func (r *SagaRepository) Create(step *models.SagaStep) error {
+	return r.db.Create(step).Error
+}
+
+// List - Finds saga steps filtered by status (empty matches all)
+func (r *SagaRepository) List(status string, limit int) ([]models.SagaStep, error) {
+	var steps []models.SagaStep
+	query := r.db.Order("created_at DESC").Limit(limit)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&steps).Error
+	return steps, err
+}
+
+// FindByTransfer - Compensations recorded for one transfer, oldest first
+func (r *SagaRepository) FindByTransfer(transferID string) ([]models.SagaStep, error) {
+	var steps []models.SagaStep
+	err := r.db.Where("transfer_id = ?", transferID).Order("created_at ASC").Find(&steps).Error
+	return steps, err
+}
+
+// FindByID - Finds a saga step by identifier
+func (r *SagaRepository) FindByID(id string) (*models.SagaStep, error) {
+	var step models.SagaStep
+	err := r.db.Where("id = ?", id).First(&step).Error
+	return &step, err
+}
+
+// FindPending - Oldest compensations still waiting to be applied
+func (r *SagaRepository) FindPending(limit int) ([]models.SagaStep, error) {
+	var steps []models.SagaStep
+	err := r.db.Where("status = ?", models.SagaStepPending).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&steps).Error
+	return steps, err
+}
+
+// ExistsForDeduction - Whether a deduction key has already been compensated (or is being)
+func (r *SagaRepository) ExistsForDeduction(deductionKey string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.SagaStep{}).Where("deduction_key = ?", deductionKey).Count(&count).Error
+	return count > 0, err
+}
+
+// Update - Saves saga step changes
+func (r *SagaRepository) Update(step *models.SagaStep) error {
+	return r.db.Save(step).Error
+}
@@ -0,0 +1,105 @@
+// DESIGN PATTERN: Specification Pattern (expected schema vs live database)
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"sender-service/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrSchemaDrift - Returned when drift is found and the deployment refuses to start on it
+var ErrSchemaDrift = errors.New("database schema drift detected")
+
+// SchemaDrift - One difference between the models and the live database
+type SchemaDrift struct {
+	Table   string // Affected table (empty for version drift)
+	Column  string // Affected column (empty for table/version drift)
+	Problem string // Human-readable description
+}
+
+// String - Log-friendly form
+func (d SchemaDrift) String() string {
+	switch {
+	case d.Column != "":
+		return fmt.Sprintf("%s.%s: %s", d.Table, d.Column, d.Problem)
+	case d.Table != "":
+		return fmt.Sprintf("%s: %s", d.Table, d.Problem)
+	}
+	return d.Problem
+}
+
+// RecordSchemaVersion - Notes that the database was migrated to version (idempotent)
+func RecordSchemaVersion(db *gorm.DB, version int, now time.Time) error {
+	if err := db.AutoMigrate(&models.SchemaMigration{}); err != nil {
+		return err
+	}
+	// GORM: INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?) ON CONFLICT DO NOTHING
+	return db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.SchemaMigration{Version: version, AppliedAt: now}).Error
+}
+
+// CheckSchema - Compares the recorded schema version and every model's tables/columns with the database
+func CheckSchema(db *gorm.DB, version int, entities ...interface{}) ([]SchemaDrift, error) {
+	var drift []SchemaDrift
+	migrator := db.Migrator()
+
+	// 1. VERSION: Older databases need migrating; newer ones belong to a later build
+	recorded := 0
+	if migrator.HasTable(&models.SchemaMigration{}) {
+		// GORM: SELECT COALESCE(MAX(version), 0) FROM schema_migrations
+		if err := db.Model(&models.SchemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&recorded).Error; err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case recorded == 0:
+		drift = append(drift, SchemaDrift{Problem: fmt.Sprintf("no schema version recorded (expected %d)", version)})
+	case recorded < version:
+		drift = append(drift, SchemaDrift{Problem: fmt.Sprintf("database is at schema version %d, this build expects %d", recorded, version)})
+	case recorded > version:
+		drift = append(drift, SchemaDrift{Problem: fmt.Sprintf("database is at schema version %d, newer than this build (%d)", recorded, version)})
+	}
+
+	// 2. STRUCTURE: Every mapped column must exist; unknown columns usually mean another build migrated
+	for _, entity := range entities {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(entity); err != nil {
+			return nil, err
+		}
+		table := stmt.Schema.Table
+		if !migrator.HasTable(table) {
+			drift = append(drift, SchemaDrift{Table: table, Problem: "table missing"})
+			continue
+		}
+
+		columns, err := migrator.ColumnTypes(table)
+		if err != nil {
+			return nil, err
+		}
+		live := make(map[string]bool, len(columns))
+		for _, column := range columns {
+			live[column.Name()] = true
+		}
+
+		expected := make(map[string]bool, len(stmt.Schema.Fields))
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" || field.IgnoreMigration {
+				continue
+			}
+			expected[field.DBName] = true
+			if !live[field.DBName] {
+				drift = append(drift, SchemaDrift{Table: table, Column: field.DBName, Problem: "column missing"})
+			}
+		}
+		for _, column := range columns {
+			if !expected[column.Name()] {
+				drift = append(drift, SchemaDrift{Table: table, Column: column.Name(), Problem: "column not mapped by any model"})
+			}
+		}
+	}
+	return drift, nil
+}
@@ -0,0 +1,52 @@
+// DESIGN PATTERN: Repository Pattern - Per-sender aggregates backing the transfer limits
+package repositories
+
+import (
+	"sender-service/models"
+	"time"
+)
+
+// committedStatuses - Transfers whose points moved or may still move (forwarded originals stand in
+// for their children)
+var committedStatuses = []models.TransferStatus{
+	models.TransferStatusPending, models.TransferStatusOnHold, models.TransferStatusCompleted, models.TransferStatusForwarded,
+}
+
+// SumPointsSince - Points the sender committed in transfers created at or after since (sender-initiated
+// kinds only; canary transfers excluded)
+func (r *TransferRepository) SumPointsSince(senderID string, since time.Time) (int64, error) {
+	var total int64
+	for _, shard := range r.senderShards(senderID) {
+		var sum int64
+		// GORM: SELECT COALESCE(SUM(points), 0) FROM transfers WHERE sender_id = ? AND created_at >= ?
+		//       AND status IN (...) AND kind IN ('original', 'reissue') AND NOT canary
+		err := r.shards[shard].Model(&models.Transfer{}).
+			Select("COALESCE(SUM(points), 0)").
+			Where("sender_id = ? AND created_at >= ? AND status IN ? AND kind IN ? AND NOT canary",
+				senderID, since, committedStatuses, []string{models.TransferKindOriginal, models.TransferKindReissue}).
+			Scan(&sum).Error
+		if err != nil {
+			return 0, err
+		}
+		total += sum
+	}
+	return total, nil
+}
+
+// CountPending - The sender's transfers still waiting to be claimed (pending or on hold)
+func (r *TransferRepository) CountPending(senderID string) (int64, error) {
+	var total int64
+	for _, shard := range r.senderShards(senderID) {
+		var count int64
+		// GORM: SELECT count(*) FROM transfers WHERE sender_id = ? AND status IN ('pending', 'on_hold') AND NOT canary
+		err := r.shards[shard].Model(&models.Transfer{}).
+			Where("sender_id = ? AND status IN ? AND NOT canary",
+				senderID, []models.TransferStatus{models.TransferStatusPending, models.TransferStatusOnHold}).
+			Count(&count).Error
+		if err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
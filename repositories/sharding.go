@@ -0,0 +1,14 @@
+// DESIGN PATTERN: Sharding (hash-modulo routing on sender ID; changing the shard count moves most senders)
+package repositories
+
+import "hash/fnv"
+
+// ShardIndex - Shard that owns a sender's transfers (FNV-1a of the sender ID modulo shard count)
+func ShardIndex(senderID string, shardCount int) int {
+	if shardCount <= 1 {
+		return 0
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(senderID))
+	return int(hash.Sum32() % uint32(shardCount))
+}
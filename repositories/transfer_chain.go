@@ -0,0 +1,55 @@
+// DESIGN PATTERN: Repository Pattern - Parent/child transfer chains (reissues, reversals, forwards)
+package repositories
+
+import "sender-service/models"
+
+// maxChainDepth - Guards chain walks against cycles from bad data
+const maxChainDepth = 100
+
+// FindChildren - Transfers derived from the given one (all shards)
+func (r *TransferRepository) FindChildren(parentID string) ([]models.Transfer, error) {
+	var children []models.Transfer
+	for _, shard := range r.shards {
+		var shardChildren []models.Transfer
+		// GORM: SELECT * FROM transfers WHERE parent_transfer_id = ? ORDER BY created_at
+		if err := shard.Where("parent_transfer_id = ?", parentID).Order("created_at").Find(&shardChildren).Error; err != nil {
+			return nil, err
+		}
+		children = append(children, shardChildren...)
+	}
+	return children, nil
+}
+
+// FindRoot - Follows parent links up to the original transfer
+func (r *TransferRepository) FindRoot(transferID string) (*models.Transfer, error) {
+	transfer, err := r.FindByID(transferID)
+	if err != nil {
+		return nil, err
+	}
+	for depth := 0; transfer.ParentTransferID != "" && depth < maxChainDepth; depth++ {
+		parent, err := r.FindByID(transfer.ParentTransferID)
+		if err != nil {
+			return nil, err
+		}
+		transfer = parent
+	}
+	return transfer, nil
+}
+
+// FindChain - Every transfer linked to the given one, root first, then breadth-first by creation
+func (r *TransferRepository) FindChain(transferID string) ([]models.Transfer, error) {
+	root, err := r.FindRoot(transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := []models.Transfer{*root}
+	for i := 0; i < len(chain) && len(chain) < maxChainDepth; i++ {
+		children, err := r.FindChildren(chain[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, children...)
+	}
+	return chain, nil
+}
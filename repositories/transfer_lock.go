@@ -0,0 +1,55 @@
+// DESIGN PATTERN: Unit of Work (shard transactions) + Pessimistic Locking (SELECT ... FOR UPDATE)
+package repositories
+
+import (
+	"sender-service/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Transaction - Runs fn in a transaction on the shard owning the transfer. The repository passed to fn
+// writes through that transaction; use it for every read and write inside fn. Returning an error rolls back.
+func (r *TransferRepository) Transaction(owner *models.Transfer, fn func(tx *TransferRepository) error) error {
+	index := r.shardIndexFor(owner.Region, owner.SenderID)
+	return r.shards[index].Transaction(func(tx *gorm.DB) error {
+		return fn(r.withTx(index, tx))
+	})
+}
+
+// withTx - Copy of the repository whose shard `index` is bound to the transaction (no replicas)
+func (r *TransferRepository) withTx(index int, tx *gorm.DB) *TransferRepository {
+	bound := *r
+	bound.shards = append([]*gorm.DB(nil), r.shards...)
+	bound.shards[index] = tx
+	bound.replicas = nil
+	return &bound
+}
+
+// FindByIDForUpdate - Re-reads a located transfer and locks the row until the transaction ends
+func (r *TransferRepository) FindByIDForUpdate(located *models.Transfer) (*models.Transfer, error) {
+	var transfer models.Transfer
+	// GORM: SELECT * FROM transfers WHERE id = ? LIMIT 1 FOR UPDATE
+	err := r.shardFor(located).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", located.ID).First(&transfer).Error
+	return &transfer, err
+}
+
+// LockByID - Runs fn in a transaction holding the transfer's row lock, so concurrent status
+// transitions of the same transfer queue up instead of racing
+func (r *TransferRepository) LockByID(transferID string, fn func(locked *TransferRepository, transfer *models.Transfer) error) error {
+	// 1. ROUTING: Find the owning shard (IDs are not shard-routable)
+	located, err := r.FindByID(transferID)
+	if err != nil {
+		return err
+	}
+
+	// 2. LOCK: Re-read the row under FOR UPDATE inside the shard transaction
+	return r.Transaction(located, func(tx *TransferRepository) error {
+		transfer, err := tx.FindByIDForUpdate(located)
+		if err != nil {
+			return err
+		}
+		return fn(tx, transfer)
+	})
+}
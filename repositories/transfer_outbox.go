@@ -0,0 +1,320 @@
+// DESIGN PATTERN: Repository Pattern + Transactional Outbox (shard-local, committed with the transfer)
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"sender-service/models"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrCrossShardBatch - Returned when a batch mixes transfers owned by different shards
+var ErrCrossShardBatch = errors.New("batch transfers must share one shard")
+
+// transferOutboxLockKey - Advisory lock key ensuring a single dispatcher per shard (preserves ordering)
+const transferOutboxLockKey = 7201721
+
+// TransferOutbox - One shard's outbox, bound to the dispatcher's transaction
+type TransferOutbox struct {
+	db *gorm.DB // Composition: HAS-A shard transaction
+}
+
+// CreateWithOutbox - Inserts a transfer and its side effects in one shard transaction
+func (r *TransferRepository) CreateWithOutbox(transfer *models.Transfer, messages ...*models.TransferOutboxMessage) error {
+	return r.Transaction(transfer, func(tx *TransferRepository) error {
+		if err := tx.Create(transfer); err != nil {
+			return err
+		}
+		for _, message := range messages {
+			if err := tx.AppendOutbox(transfer, message); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CreateBatch - Inserts several transfers of one sender and their side effects in one shard transaction
+// (outbox is keyed by transfer ID); nothing is stored if any row fails
+func (r *TransferRepository) CreateBatch(transfers []*models.Transfer, outbox map[string][]*models.TransferOutboxMessage) error {
+	if len(transfers) == 0 {
+		return nil
+	}
+	// A batch commits on one shard, so every transfer must route to the first one's shard
+	owner := r.shardIndexFor(transfers[0].Region, transfers[0].SenderID)
+	for _, transfer := range transfers[1:] {
+		if r.shardIndexFor(transfer.Region, transfer.SenderID) != owner {
+			return ErrCrossShardBatch
+		}
+	}
+
+	return r.Transaction(transfers[0], func(tx *TransferRepository) error {
+		// GORM: INSERT INTO transfers (...) VALUES (...), (...), ...
+		if err := tx.shards[owner].Create(transfers).Error; err != nil {
+			return err
+		}
+		for _, transfer := range transfers {
+			for _, message := range outbox[transfer.ID] {
+				if err := tx.AppendOutbox(transfer, message); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// AppendOutbox - Stores a side effect on the transfer's shard (joins the transaction of a tx-bound repository)
+func (r *TransferRepository) AppendOutbox(transfer *models.Transfer, message *models.TransferOutboxMessage) error {
+	message.TransferID = transfer.ID
+	message.Status = models.OutboxMessagePending
+	return r.shardFor(transfer).Create(message).Error
+}
+
+// FindOutbox - Every outbox message of one transfer in dispatch order (payloads included; callers
+// must not expose claim notification payloads)
+func (r *TransferRepository) FindOutbox(transfer *models.Transfer) ([]models.TransferOutboxMessage, error) {
+	var messages []models.TransferOutboxMessage
+	err := r.shardFor(transfer).Where("transfer_id = ?", transfer.ID).Order("id ASC").Find(&messages).Error
+	return messages, err
+}
+
+// OutboxQueueCounts - Outbox messages of some kinds by delivery state, summed over every shard
+type OutboxQueueCounts struct {
+	Queued   int64      // Pending and not yet attempted
+	Retrying int64      // Pending after at least one failed attempt
+	Dead     int64      // Attempts exhausted (dead-lettered)
+	Oldest   *time.Time // Creation time of the oldest pending message (nil when none)
+}
+
+// CountOutboxQueue - Aggregates the outbox messages of the given kinds on every shard
+func (r *TransferRepository) CountOutboxQueue(kinds []string) (*OutboxQueueCounts, error) {
+	// POSTGRES: SELECT COUNT(*) FILTER (...), ..., MIN(created_at) FILTER (...) FROM transfer_outbox_messages WHERE kind IN (...)
+	query := "SELECT COUNT(*) FILTER (WHERE status = @pending AND attempts = 0), " +
+		"COUNT(*) FILTER (WHERE status = @pending AND attempts > 0), " +
+		"COUNT(*) FILTER (WHERE status = @dead), " +
+		"MIN(created_at) FILTER (WHERE status = @pending) " +
+		"FROM transfer_outbox_messages WHERE kind IN @kinds"
+	args := map[string]interface{}{"pending": models.OutboxMessagePending, "dead": models.OutboxMessageDead, "kinds": kinds}
+
+	counts := &OutboxQueueCounts{}
+	for _, shard := range r.shards {
+		var queued, retrying, dead int64
+		var oldest sql.NullTime
+		if err := shard.Raw(query, args).Row().Scan(&queued, &retrying, &dead, &oldest); err != nil {
+			return nil, err
+		}
+		counts.Queued += queued
+		counts.Retrying += retrying
+		counts.Dead += dead
+		if oldest.Valid && (counts.Oldest == nil || oldest.Time.Before(*counts.Oldest)) {
+			counts.Oldest = &oldest.Time
+		}
+	}
+	return counts, nil
+}
+
+// FindRetryingOutbox - Pending messages of the given kinds that have failed at least once, next retry first
+// (payloads are not loaded: claim notifications carry the raw claim token)
+func (r *TransferRepository) FindRetryingOutbox(kinds []string, limit int) ([]models.TransferOutboxMessage, error) {
+	var retrying []models.TransferOutboxMessage
+	for _, shard := range r.shards {
+		var messages []models.TransferOutboxMessage
+		// GORM: SELECT (all but payload) FROM transfer_outbox_messages WHERE status = 'pending' AND attempts > 0
+		//       AND kind IN (...) ORDER BY next_attempt_at LIMIT ?
+		err := shard.Omit("payload").
+			Where("status = ? AND attempts > 0 AND kind IN ?", models.OutboxMessagePending, kinds).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&messages).Error
+		if err != nil {
+			return nil, err
+		}
+		retrying = append(retrying, messages...)
+	}
+
+	sort.Slice(retrying, func(i, j int) bool { return retrying[i].NextAttemptAt.Before(retrying[j].NextAttemptAt) })
+	if len(retrying) > limit {
+		retrying = retrying[:limit]
+	}
+	return retrying, nil
+}
+
+// OutboxRequeueFilter - Outbox messages selected for a bulk re-queue (zero values are ignored)
+type OutboxRequeueFilter struct {
+	Kinds    []string  // Message kinds (required)
+	Retrying bool      // Pending messages that failed at least once instead of dead ones
+	From     time.Time // Created at or after
+	To       time.Time // Created before
+	Provider string    // Email provider of the latest attempt
+	Limit    int       // Messages returned
+}
+
+// FindOutboxForRequeue - Messages matching the filter on every shard, oldest first (payloads are not
+// loaded: claim notifications carry the raw claim token)
+func (r *TransferRepository) FindOutboxForRequeue(filter OutboxRequeueFilter) ([]models.TransferOutboxMessage, error) {
+	status := models.OutboxMessageDead
+	if filter.Retrying {
+		status = models.OutboxMessagePending
+	}
+
+	var matched []models.TransferOutboxMessage
+	for _, shard := range r.shards {
+		// GORM: SELECT (all but payload) FROM transfer_outbox_messages WHERE status = ? AND kind IN (...)
+		//       [AND attempts > 0] [AND created_at >= ?] [AND created_at < ?] [AND provider = ?]
+		//       ORDER BY created_at, id LIMIT ?
+		query := shard.Omit("payload").Where("status = ? AND kind IN ?", status, filter.Kinds)
+		if filter.Retrying {
+			query = query.Where("attempts > 0")
+		}
+		if !filter.From.IsZero() {
+			query = query.Where("created_at >= ?", filter.From)
+		}
+		if !filter.To.IsZero() {
+			query = query.Where("created_at < ?", filter.To)
+		}
+		if filter.Provider != "" {
+			query = query.Where("provider = ?", filter.Provider)
+		}
+		var messages []models.TransferOutboxMessage
+		if err := query.Order("created_at ASC, id ASC").Limit(filter.Limit).Find(&messages).Error; err != nil {
+			return nil, err
+		}
+		matched = append(matched, messages...)
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].CreatedAt.Before(matched[j].CreatedAt) })
+	if len(matched) > filter.Limit {
+		matched = matched[:filter.Limit]
+	}
+	return matched, nil
+}
+
+// RescheduleOutbox - Gives a retrying message a fresh retry budget and makes it due now
+func (r *TransferRepository) RescheduleOutbox(transferID string, messageID uint, now time.Time) error {
+	transfer, err := r.FindByID(transferID)
+	if err != nil {
+		return err
+	}
+	return r.shardFor(transfer).Model(&models.TransferOutboxMessage{}).
+		Where("id = ? AND status = ? AND attempts > 0", messageID, models.OutboxMessagePending).
+		Updates(map[string]interface{}{"attempts": 0, "next_attempt_at": now}).Error
+}
+
+// RequeueOutbox - Returns a dead message to the pending queue with a fresh retry budget (a failed
+// claim notification shows as queued again)
+func (r *TransferRepository) RequeueOutbox(transferID string, messageID uint, now time.Time) error {
+	err := r.updateDeadOutbox(transferID, messageID, map[string]interface{}{
+		"status":          models.OutboxMessagePending,
+		"attempts":        0,
+		"next_attempt_at": now,
+	})
+	if err != nil {
+		return err
+	}
+	transfer, err := r.FindByID(transferID)
+	if err != nil {
+		return err
+	}
+	// GORM: UPDATE transfers SET email_status = 'queued' WHERE id = ? AND email_status = 'failed'
+	//       AND EXISTS (SELECT 1 FROM transfer_outbox_messages WHERE id = ? AND kind = 'claim_notification')
+	return r.shardFor(transfer).Model(&models.Transfer{}).
+		Where("id = ? AND email_status = ?", transferID, models.EmailStatusFailed).
+		Where("EXISTS (SELECT 1 FROM transfer_outbox_messages WHERE id = ? AND kind = ?)", messageID, models.OutboxMessageClaimNotification).
+		Update("email_status", models.EmailStatusQueued).Error
+}
+
+// DiscardOutbox - Drops a dead message so the transfer's later events can flow again (a claim
+// notification's raw token is erased with it)
+func (r *TransferRepository) DiscardOutbox(transferID string, messageID uint) error {
+	return r.updateDeadOutbox(transferID, messageID, map[string]interface{}{
+		"status":  models.OutboxMessageDiscarded,
+		"payload": gorm.Expr("CASE WHEN kind = ? THEN '' ELSE payload END", models.OutboxMessageClaimNotification),
+	})
+}
+
+// updateDeadOutbox - Updates a dead message on the shard owning its transfer
+func (r *TransferRepository) updateDeadOutbox(transferID string, messageID uint, updates map[string]interface{}) error {
+	transfer, err := r.FindByID(transferID)
+	if err != nil {
+		return err
+	}
+	return r.shardFor(transfer).Model(&models.TransferOutboxMessage{}).
+		Where("id = ? AND status = ?", messageID, models.OutboxMessageDead).
+		Updates(updates).Error
+}
+
+// ForEachOutbox - Runs fn per shard inside a transaction holding that shard's dispatcher lock
+// (shards being dispatched by another instance are skipped this round)
+func (r *TransferRepository) ForEachOutbox(fn func(outbox *TransferOutbox) error) error {
+	for _, shard := range r.shards {
+		err := shard.Transaction(func(tx *gorm.DB) error {
+			var acquired bool
+			// POSTGRES: Transaction-scoped advisory lock, released automatically on commit/rollback
+			if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", transferOutboxLockKey).Scan(&acquired).Error; err != nil {
+				return err
+			}
+			if !acquired {
+				return nil
+			}
+			return fn(&TransferOutbox{db: tx})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FetchPending - Oldest undelivered messages (includes not-yet-due ones so event ordering can be enforced);
+// held-back kinds are left out so they cannot crowd deliverable messages out of the batch
+func (o *TransferOutbox) FetchPending(limit int, heldKinds ...string) ([]models.TransferOutboxMessage, error) {
+	var messages []models.TransferOutboxMessage
+	// GORM: SELECT * FROM transfer_outbox_messages WHERE status = 'pending' [AND kind NOT IN (...)] ORDER BY id LIMIT ?
+	query := o.db.Where("status = ?", models.OutboxMessagePending)
+	if len(heldKinds) > 0 {
+		query = query.Where("kind NOT IN ?", heldKinds)
+	}
+	err := query.Order("id ASC").
+		Limit(limit).
+		Find(&messages).Error
+	return messages, err
+}
+
+// FlagDeferredNotifications - Marks pending transfers whose claim notification is still queued as notification_deferred
+func (o *TransferOutbox) FlagDeferredNotifications() (int64, error) {
+	// GORM: UPDATE transfers SET notification_deferred = true WHERE status = 'pending' AND notification_deferred = false
+	//       AND id IN (SELECT transfer_id FROM transfer_outbox_messages WHERE status = 'pending' AND kind = 'claim_notification')
+	queued := o.db.Model(&models.TransferOutboxMessage{}).
+		Select("transfer_id").
+		Where("status = ? AND kind = ?", models.OutboxMessagePending, models.OutboxMessageClaimNotification)
+	result := o.db.Model(&models.Transfer{}).
+		Where("status = ? AND notification_deferred = ? AND id IN (?)", "pending", false, queued).
+		Update("notification_deferred", true)
+	return result.RowsAffected, result.Error
+}
+
+// DeadEventTransferIDs - Transfers with a dead event message; their later events must wait
+func (o *TransferOutbox) DeadEventTransferIDs() ([]string, error) {
+	var ids []string
+	err := o.db.Model(&models.TransferOutboxMessage{}).
+		Where("status = ? AND kind = ?", models.OutboxMessageDead, models.OutboxMessageEvent).
+		Distinct("transfer_id").
+		Pluck("transfer_id", &ids).Error
+	return ids, err
+}
+
+// Save - Persists the outcome of a delivery attempt
+func (o *TransferOutbox) Save(message *models.TransferOutboxMessage) error {
+	return o.db.Save(message).Error
+}
+
+// SetEmailStatus - Records the claim notification outcome on its transfer, in the dispatcher's transaction
+func (o *TransferOutbox) SetEmailStatus(transferID, status string, sentAt *time.Time) error {
+	// GORM: UPDATE transfers SET email_status = ?, email_sent_at = ? WHERE id = ?
+	return o.db.Model(&models.Transfer{ID: transferID}).
+		Updates(map[string]interface{}{"email_status": status, "email_sent_at": sentAt}).Error
+}
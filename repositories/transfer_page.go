@@ -0,0 +1,275 @@
+// DESIGN PATTERN: Repository Pattern (composable scopes + keyset pagination across shards)
+package repositories
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/models"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvalidCursor - Returned when a pagination cursor cannot be decoded or belongs to another sort
+var ErrInvalidCursor = apperrors.New(apperrors.ErrInvalidInput, "invalid_cursor", "invalid pagination cursor")
+
+// historySort - Column and direction behind a public sort name
+type historySort struct {
+	column     string // created_at or points
+	descending bool
+}
+
+// historySorts - Supported orders
+var historySorts = map[string]historySort{
+	models.SortNewest:     {column: "created_at", descending: true},
+	models.SortOldest:     {column: "created_at"},
+	models.SortPointsDesc: {column: "points", descending: true},
+	models.SortPointsAsc:  {column: "points"},
+}
+
+// cursorBefore - Marker on cursors that page backwards (prev_cursor)
+const cursorBefore = "before"
+
+// pageCursor - Position of a page boundary: (sort key, id) in the page's order
+type pageCursor struct {
+	Sort     string      // Sort the cursor was issued for
+	Value    interface{} // time.Time for created_at, int for points
+	ID       string      // Tiebreaker
+	Backward bool        // Page ends just before this transfer (prev_cursor) instead of starting after it
+}
+
+// encodeCursor - Opaque cursor for the transfer a page ended on (or, backward, started on)
+func encodeCursor(sortName string, transfer *models.Transfer, backward bool) string {
+	value := transfer.CreatedAt.UTC().Format(time.RFC3339Nano)
+	if historySorts[sortName].column == "points" {
+		value = strconv.Itoa(transfer.Points)
+	}
+	raw := sortName + "|" + value + "|" + transfer.ID
+	if backward {
+		raw += "|" + cursorBefore
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor - Reverses encodeCursor, rejecting cursors issued for a different sort
+func decodeCursor(sortName, cursor string) (*pageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) < 3 || len(parts) > 4 || parts[0] != sortName || parts[2] == "" {
+		return nil, ErrInvalidCursor
+	}
+	if len(parts) == 4 && parts[3] != cursorBefore {
+		return nil, ErrInvalidCursor
+	}
+
+	decoded := &pageCursor{Sort: parts[0], ID: parts[2], Backward: len(parts) == 4}
+	if historySorts[sortName].column == "points" {
+		points, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		decoded.Value = points
+	} else {
+		createdAt, err := time.Parse(time.RFC3339Nano, parts[1])
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		decoded.Value = createdAt
+	}
+	return decoded, nil
+}
+
+// filterScopes - The filter as composable GORM scopes (each narrows the query independently)
+func filterScopes(senderID string, filter models.TransferFilter) []func(*gorm.DB) *gorm.DB {
+	scopes := []func(*gorm.DB) *gorm.DB{scopeSender(senderID)}
+	if filter.Status != "" {
+		scopes = append(scopes, scopeStatus(filter.Status))
+	}
+	if !filter.From.IsZero() || !filter.To.IsZero() {
+		scopes = append(scopes, scopeCreatedBetween(filter.From, filter.To))
+	}
+	if filter.MinPoints > 0 || filter.MaxPoints > 0 {
+		scopes = append(scopes, scopePointsBetween(filter.MinPoints, filter.MaxPoints))
+	}
+	if filter.ReceiverEmail != "" {
+		scopes = append(scopes, scopeReceiverEmail(filter.ReceiverEmail))
+	}
+	return scopes
+}
+
+// scopeSender - WHERE sender_id = ?
+func scopeSender(senderID string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("sender_id = ?", senderID)
+	}
+}
+
+// scopeStatus - WHERE status = ?
+func scopeStatus(status models.TransferStatus) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("status = ?", status)
+	}
+}
+
+// scopeCreatedBetween - WHERE created_at >= from AND created_at < to (either bound optional)
+func scopeCreatedBetween(from, to time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if !from.IsZero() {
+			db = db.Where("created_at >= ?", from)
+		}
+		if !to.IsZero() {
+			db = db.Where("created_at < ?", to)
+		}
+		return db
+	}
+}
+
+// scopePointsBetween - WHERE points >= min AND points <= max (either bound optional)
+func scopePointsBetween(min, max int) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if min > 0 {
+			db = db.Where("points >= ?", min)
+		}
+		if max > 0 {
+			db = db.Where("points <= ?", max)
+		}
+		return db
+	}
+}
+
+// scopeReceiverEmail - WHERE LOWER(receiver_email) = LOWER(?)
+func scopeReceiverEmail(email string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("LOWER(receiver_email) = LOWER(?)", email)
+	}
+}
+
+// scopeAfter - Keyset condition: rows strictly after the cursor in the given order
+func scopeAfter(order historySort, after *pageCursor) func(*gorm.DB) *gorm.DB {
+	comparison := ">"
+	if order.descending {
+		comparison = "<"
+	}
+	condition := fmt.Sprintf("%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?)", order.column, comparison)
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(condition, after.Value, after.Value, after.ID)
+	}
+}
+
+// FindBySenderID - One page of a sender's filtered transfers in the requested order (replica-aware,
+// see ConsistencyToken)
+func (r *TransferRepository) FindBySenderID(senderID, consistencyToken string, filter models.TransferFilter, page models.PageRequest) (*models.TransferPage, error) {
+	sortName := filter.Sort
+	if sortName == "" {
+		sortName = models.SortNewest
+	}
+	order, ok := historySorts[sortName]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort %q", sortName)
+	}
+	scopes := filterScopes(senderID, filter)
+
+	var after *pageCursor
+	if page.Cursor != "" {
+		decoded, err := decodeCursor(sortName, page.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = decoded
+	}
+
+	// BACKWARD: A prev_cursor walks the reversed order from the cursor, then flips the page back
+	scan := order
+	backward := after != nil && after.Backward
+	if backward {
+		scan.descending = !order.descending
+	}
+	direction := "ASC"
+	if scan.descending {
+		direction = "DESC"
+	}
+
+	// 1. SCATTER: Each shard returns its first offset+limit+1 matching rows past the cursor; one
+	// extra row tells whether another page exists
+	result := &models.TransferPage{Limit: page.Limit, Offset: page.Offset, Sort: sortName}
+	var transfers []models.Transfer
+	for _, shard := range r.senderShards(senderID) {
+		reader := r.readerFor(shard, consistencyToken)
+
+		// GORM: SELECT count(*) FROM transfers WHERE sender_id = ? AND <filters>
+		var count int64
+		if err := reader.Model(&models.Transfer{}).Scopes(scopes...).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		result.Total += count
+
+		// GORM: SELECT * FROM transfers WHERE sender_id = ? AND <filters> [AND (<key>, id) past cursor]
+		//       ORDER BY <key> <dir>, id <dir> LIMIT ?
+		query := reader.Scopes(scopes...)
+		if after != nil {
+			query = query.Scopes(scopeAfter(scan, after))
+		}
+		var shardTransfers []models.Transfer
+		err := query.Order(order.column + " " + direction + ", id " + direction).
+			Limit(page.Offset + page.Limit + 1).
+			Find(&shardTransfers).Error
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, shardTransfers...)
+	}
+
+	// 2. GATHER: Merge in the scan order, skip the offset, keep one page
+	sortTransfers(transfers, scan)
+	if page.Offset >= len(transfers) {
+		result.Transfers = []models.Transfer{}
+		return result, nil
+	}
+	transfers = transfers[page.Offset:]
+	more := len(transfers) > page.Limit
+	if more {
+		transfers = transfers[:page.Limit]
+	}
+
+	// 3. CURSORS: Rows exist past the far end when the extra row came back, and before the near end
+	// whenever the page was reached through a cursor or an offset
+	earlier := after != nil || page.Offset > 0
+	if backward {
+		sortTransfers(transfers, order)
+		more, earlier = earlier, more
+	}
+	if more {
+		result.NextCursor = encodeCursor(sortName, &transfers[len(transfers)-1], false)
+	}
+	if earlier {
+		result.PrevCursor = encodeCursor(sortName, &transfers[0], true)
+	}
+	result.Transfers = transfers
+	return result, nil
+}
+
+// sortTransfers - Orders merged shard results exactly as the per-shard ORDER BY did
+func sortTransfers(transfers []models.Transfer, order historySort) {
+	ascending := func(a, b *models.Transfer) bool {
+		if order.column == "points" && a.Points != b.Points {
+			return a.Points < b.Points
+		}
+		if order.column == "created_at" && !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	}
+	sort.SliceStable(transfers, func(i, j int) bool {
+		if order.descending {
+			return ascending(&transfers[j], &transfers[i])
+		}
+		return ascending(&transfers[i], &transfers[j])
+	})
+}
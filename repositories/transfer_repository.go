@@ -1,62 +1,205 @@
-// DESIGN PATTERN: Repository Pattern + CRUD Operations
+// DESIGN PATTERN: Repository Pattern + CRUD Operations + Shard Routing
 package repositories
 
 import (
+	"errors"
 	"sender-service/models"
+	"sort"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Unique indexes holding drawn identifiers (GORM's default names)
+const (
+	transferPrimaryKey = "transfers_pkey"
+	transferTokenIndex = "idx_transfers_token_hash"
 )
 
 // TransferRepository - Abstracts all database operations for Transfer entity
 type TransferRepository struct {
-	db *gorm.DB // Composition: HAS-A database connection
+	shards        []*gorm.DB       // Composition: HAS-A connection per shard, all regions (one entry when unsharded)
+	replicas      []*gorm.DB       // Optional read replica per shard (see consistency.go)
+	regions       map[string][]int // Region -> indexes into shards (see regions.go)
+	defaultRegion string           // Region for senders without a configured one
 }
 
 // NewTransferRepository - Factory method for repository
 func NewTransferRepository(db *gorm.DB) *TransferRepository {
-	return &TransferRepository{db: db}
+	return NewShardedTransferRepository([]*gorm.DB{db})
+}
+
+// NewShardedTransferRepository - Factory method routing transfers across shards by sender ID
+func NewShardedTransferRepository(shards []*gorm.DB) *TransferRepository {
+	return NewRegionalTransferRepository("", map[string]RegionStore{"": {Shards: shards}})
+}
+
+// findAcrossShards - Scatter lookup for queries that cannot be routed (ID, token)
+func (r *TransferRepository) findAcrossShards(query string, args ...interface{}) (*models.Transfer, error) {
+	var transfer models.Transfer
+	for _, shard := range r.shards {
+		err := shard.Where(query, args...).First(&transfer).Error
+		if err == nil {
+			return &transfer, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return &transfer, err
+		}
+	}
+	return &transfer, gorm.ErrRecordNotFound
 }
 
 // Create - Persists new transfer to database
 func (r *TransferRepository) Create(transfer *models.Transfer) error {
 	// GORM: INSERT INTO transfers (...) VALUES (...)
-	return r.db.Create(transfer).Error
+	return r.shardFor(transfer).Create(transfer).Error
 }
 
-// FindBySenderID - Finds all transfers for a specific sender
-func (r *TransferRepository) FindBySenderID(senderID string) ([]models.Transfer, error) {
+// FindForDigest - Sender's transfers updated since a point in time, plus everything still pending
+func (r *TransferRepository) FindForDigest(senderID string, since time.Time) ([]models.Transfer, error) {
 	var transfers []models.Transfer
-	// GORM: SELECT * FROM transfers WHERE sender_id = ? ORDER BY created_at DESC
-	err := r.db.Where("sender_id = ?", senderID).
-		Order("created_at DESC").
-		Find(&transfers).Error
-	return transfers, err
+	for _, shard := range r.senderShards(senderID) {
+		var shardTransfers []models.Transfer
+		// GORM: SELECT * FROM transfers WHERE sender_id = ? AND (updated_at >= ? OR status = 'pending') ORDER BY created_at DESC
+		err := r.shards[shard].Where("sender_id = ?", senderID).
+			Where("updated_at >= ? OR status = ?", since, "pending").
+			Order("created_at DESC").
+			Find(&shardTransfers).Error
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, shardTransfers...)
+	}
+	sortNewestFirst(transfers)
+	return transfers, nil
 }
 
-// FindByToken - Finds transfer by unique claim token
+// sortNewestFirst - Restores created_at DESC (then id DESC) order after merging per-region results
+func sortNewestFirst(transfers []models.Transfer) {
+	sort.SliceStable(transfers, func(i, j int) bool {
+		if !transfers[i].CreatedAt.Equal(transfers[j].CreatedAt) {
+			return transfers[i].CreatedAt.After(transfers[j].CreatedAt)
+		}
+		return transfers[i].ID > transfers[j].ID
+	})
+}
+
+// FindByToken - Finds transfer by unique claim token (looked up by its hash; the raw token is never stored)
 func (r *TransferRepository) FindByToken(token string) (*models.Transfer, error) {
-	var transfer models.Transfer
-	// GORM: SELECT * FROM transfers WHERE token = ? LIMIT 1
-	err := r.db.Where("token = ?", token).First(&transfer).Error
-	return &transfer, err
+	// GORM: SELECT * FROM transfers WHERE token_hash = ? LIMIT 1 (on each shard)
+	return r.findAcrossShards("token_hash = ?", models.HashClaimToken(token))
 }
 
 // Update - Updates transfer entity in database
 func (r *TransferRepository) Update(transfer *models.Transfer) error {
 	// GORM: UPDATE transfers SET ... WHERE id = ?
-	return r.db.Save(transfer).Error
+	return r.shardFor(transfer).Save(transfer).Error
 }
 
-// Delete - Removes transfer from database (for rollback scenarios)
+// UpdateNotificationChannel - Single-column update so concurrent saga steps are not overwritten
+func (r *TransferRepository) UpdateNotificationChannel(transfer *models.Transfer) error {
+	// GORM: UPDATE transfers SET notification_channel = ? WHERE id = ?
+	return r.shardFor(transfer).Model(&models.Transfer{ID: transfer.ID}).
+		Update("notification_channel", transfer.NotificationChannel).Error
+}
+
+// Delete - Removes transfer from database (for rollback scenarios); its claim token is retired with it
 func (r *TransferRepository) Delete(transfer *models.Transfer) error {
-	// GORM: DELETE FROM transfers WHERE id = ?
-	return r.db.Delete(transfer).Error
+	return r.Transaction(transfer, func(tx *TransferRepository) error {
+		if err := tx.RetireClaimToken(transfer); err != nil {
+			return err
+		}
+		// GORM: DELETE FROM transfers WHERE id = ?
+		return tx.shardFor(transfer).Delete(transfer).Error
+	})
+}
+
+// RetireClaimToken - Records the transfer's current token hash as permanently taken (call before rotating it)
+func (r *TransferRepository) RetireClaimToken(transfer *models.Transfer) error {
+	if transfer.TokenHash == "" {
+		return nil
+	}
+	// GORM: INSERT INTO retired_claim_tokens (...) VALUES (...) ON CONFLICT DO NOTHING
+	return r.shardFor(transfer).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.RetiredClaimToken{TokenHash: transfer.TokenHash, TransferID: transfer.ID}).Error
+}
+
+// IDExists - Whether a transfer ID is already taken on any shard
+func (r *TransferRepository) IDExists(transferID string) (bool, error) {
+	return r.existsAcrossShards("id = ?", transferID)
+}
+
+// TokenExists - Whether a claim token is already taken on any shard, by a transfer or as a retired token
+func (r *TransferRepository) TokenExists(token string) (bool, error) {
+	hash := models.HashClaimToken(token)
+	if taken, err := r.existsAcrossShards("token_hash = ?", hash); err != nil || taken {
+		return taken, err
+	}
+	for _, shard := range r.shards {
+		var count int64
+		// GORM: SELECT count(*) FROM retired_claim_tokens WHERE token_hash = ?
+		if err := shard.Model(&models.RetiredClaimToken{}).Where("token_hash = ?", hash).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IdentifierCollision - Whether an insert lost a race on the transfer ID or claim token unique index
+// (another instance drew the same value after the uniqueness check); other violations are not collisions
+func IdentifierCollision(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return false
+	}
+	return pgErr.ConstraintName == transferPrimaryKey || pgErr.ConstraintName == transferTokenIndex
+}
+
+// existsAcrossShards - COUNT-based probe for uniqueness checks
+func (r *TransferRepository) existsAcrossShards(query string, args ...interface{}) (bool, error) {
+	for _, shard := range r.shards {
+		var count int64
+		// GORM: SELECT count(*) FROM transfers WHERE ...
+		if err := shard.Model(&models.Transfer{}).Where(query, args...).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 // FindByID - Finds transfer by unique identifier (for Saga completion)
 func (r *TransferRepository) FindByID(transferID string) (*models.Transfer, error) {
-	var transfer models.Transfer
-	// GORM: SELECT * FROM transfers WHERE id = ? LIMIT 1
-	err := r.db.Where("id = ?", transferID).First(&transfer).Error
-	return &transfer, err
+	// GORM: SELECT * FROM transfers WHERE id = ? LIMIT 1 (on each shard)
+	return r.findAcrossShards("id = ?", transferID)
+}
+
+// FindByIdempotencyKey - Sender's transfer created with a client Idempotency-Key (any region)
+func (r *TransferRepository) FindByIdempotencyKey(senderID, key string) (*models.Transfer, error) {
+	for _, shard := range r.senderShards(senderID) {
+		var transfer models.Transfer
+		// GORM: SELECT * FROM transfers WHERE sender_id = ? AND idempotency_key = ? LIMIT 1
+		err := r.shards[shard].Where("sender_id = ? AND idempotency_key = ?", senderID, key).First(&transfer).Error
+		if err == nil {
+			return &transfer, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ReleaseIdempotencyKey - Frees an expired key so the sender may reuse it for a new transfer
+func (r *TransferRepository) ReleaseIdempotencyKey(transfer *models.Transfer) error {
+	// GORM: UPDATE transfers SET idempotency_key = NULL WHERE id = ?
+	return r.shardFor(transfer).Model(&models.Transfer{}).Where("id = ?", transfer.ID).
+		Update("idempotency_key", nil).Error
 }
@@ -0,0 +1,56 @@
+// DESIGN PATTERN: Repository Pattern - Transfer volume aggregation
+package repositories
+
+import (
+	"time"
+)
+
+// VolumeBucket - Transfers initiated in one time bucket
+type VolumeBucket struct {
+	Start           time.Time // Bucket start (UTC)
+	Transfers       int64     // Transfers created
+	Points          int64     // Points in those transfers
+	Completed       int64     // Of those, completed so far
+	CompletedPoints int64     // Points of the completed ones
+}
+
+// VolumeByDay - Daily initiation volume for transfers created in [from, to) on every shard
+func (r *TransferRepository) VolumeByDay(from, to time.Time) (map[time.Time]*VolumeBucket, error) {
+	// GORM: SELECT date_trunc('day', created_at AT TIME ZONE 'UTC'), COUNT(*), SUM(points), ... GROUP BY 1
+	query := "SELECT date_trunc('day', created_at AT TIME ZONE 'UTC') AS day, COUNT(*)::bigint, " +
+		"COALESCE(SUM(points), 0)::bigint, " +
+		"COALESCE(SUM(CASE WHEN status = ? THEN 1 ELSE 0 END), 0)::bigint, " +
+		"COALESCE(SUM(CASE WHEN status = ? THEN points ELSE 0 END), 0)::bigint " +
+		"FROM transfers WHERE created_at >= ? AND created_at < ? AND NOT canary GROUP BY 1"
+
+	buckets := map[time.Time]*VolumeBucket{}
+	for _, shard := range r.shards {
+		rows, err := shard.Raw(query, "completed", "completed", from, to).Rows()
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var day time.Time
+			var row VolumeBucket
+			if err := rows.Scan(&day, &row.Transfers, &row.Points, &row.Completed, &row.CompletedPoints); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+
+			bucket, ok := buckets[day]
+			if !ok {
+				bucket = &VolumeBucket{Start: day}
+				buckets[day] = bucket
+			}
+			bucket.Transfers += row.Transfers
+			bucket.Points += row.Points
+			bucket.Completed += row.Completed
+			bucket.CompletedPoints += row.CompletedPoints
+		}
+		if err := rows.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return buckets, nil
+}
@@ -0,0 +1,60 @@
+// DESIGN PATTERN: Repository Pattern + CRUD Operations
+package repositories
+
+import (
+	"sender-service/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveryRepository - Abstracts database operations for per-subscription webhook deliveries
+type WebhookDeliveryRepository struct {
+	db *gorm.DB // Composition: HAS-A database connection
+}
+
+// NewWebhookDeliveryRepository - Factory method for repository
+func NewWebhookDeliveryRepository(db *gorm.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+// FindByEvent - The delivery of one event to one subscription
+func (r *WebhookDeliveryRepository) FindByEvent(subscriptionID, eventID string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.Where("subscription_id = ? AND event_id = ?", subscriptionID, eventID).First(&delivery).Error
+	return &delivery, err
+}
+
+// FindByID - Finds a delivery by identifier
+func (r *WebhookDeliveryRepository) FindByID(id string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.Where("id = ?", id).First(&delivery).Error
+	return &delivery, err
+}
+
+// FindDue - Oldest pending deliveries whose retry time has come
+func (r *WebhookDeliveryRepository) FindDue(now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	// GORM: SELECT * FROM webhook_deliveries WHERE status = 'pending' AND next_attempt_at <= ? ORDER BY next_attempt_at LIMIT ?
+	err := r.db.Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// ListBySubscription - A subscription's deliveries, newest first, filtered by status (empty matches all)
+func (r *WebhookDeliveryRepository) ListBySubscription(subscriptionID, status string, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	query := r.db.Where("subscription_id = ?", subscriptionID).Order("created_at DESC").Limit(limit)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&deliveries).Error
+	return deliveries, err
+}
+
+// Save - Inserts or updates a delivery
+func (r *WebhookDeliveryRepository) Save(delivery *models.WebhookDelivery) error {
+	return r.db.Save(delivery).Error
+}
@@ -0,0 +1,56 @@
+// DESIGN PATTERN: Repository Pattern + CRUD Operations
+package repositories
+
+import (
+	"sender-service/models"
+
+	"gorm.io/gorm"
+)
+
+// WebhookRepository - Abstracts database operations for webhook subscriptions
+type WebhookRepository struct {
+	db *gorm.DB // Composition: HAS-A database connection
+}
+
+// NewWebhookRepository - Factory method for repository
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// Create - Persists a new subscription
+func (r *WebhookRepository) Create(subscription *models.WebhookSubscription) error {
+	return r.db.Create(subscription).Error
+}
+
+// List - All subscriptions, oldest first
+func (r *WebhookRepository) List() ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	// GORM: SELECT * FROM webhook_subscriptions ORDER BY created_at
+	err := r.db.Order("created_at").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// FindActive - Subscriptions currently receiving events
+func (r *WebhookRepository) FindActive() ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	// GORM: SELECT * FROM webhook_subscriptions WHERE active = true ORDER BY created_at
+	err := r.db.Where("active = ?", true).Order("created_at").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// FindByID - Finds one subscription
+func (r *WebhookRepository) FindByID(id string) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	err := r.db.Where("id = ?", id).First(&subscription).Error
+	return &subscription, err
+}
+
+// Update - Saves all fields of a subscription
+func (r *WebhookRepository) Update(subscription *models.WebhookSubscription) error {
+	return r.db.Save(subscription).Error
+}
+
+// Delete - Removes a subscription
+func (r *WebhookRepository) Delete(id string) error {
+	return r.db.Where("id = ?", id).Delete(&models.WebhookSubscription{}).Error
+}
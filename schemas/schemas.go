@@ -0,0 +1,198 @@
+// DESIGN PATTERN: Registry Pattern + Specification Pattern (JSON Schema validation)
+package schemas
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Embedded JSON Schema definitions, one file per event type and version
+//
+//go:embed *.json
+var files embed.FS
+
+// Schema - Subset of JSON Schema (draft 2020-12) used by the published event contracts
+type Schema struct {
+	Type                 string             `json:"type"`                           // object, string, integer, number, boolean, array
+	Required             []string           `json:"required"`                       // Required object properties
+	Properties           map[string]*Schema `json:"properties"`                     // Object property schemas
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"` // Reject unknown properties when false
+	Items                *Schema            `json:"items"`                          // Array item schema
+	Enum                 []interface{}      `json:"enum"`                           // Allowed values
+	Minimum              *float64           `json:"minimum"`                        // Inclusive numeric minimum
+	MinLength            *int               `json:"minLength"`                      // Minimum string length
+	Format               string             `json:"format"`                         // date-time, email
+}
+
+// registry - Parsed schemas and their raw definitions keyed by name (e.g. transfer.initiated.v1)
+var registry = map[string]struct {
+	raw    json.RawMessage
+	schema *Schema
+}{}
+
+func init() {
+	entries, err := files.ReadDir(".")
+	if err != nil {
+		panic(fmt.Sprintf("schemas: failed to read embedded definitions: %v", err))
+	}
+
+	for _, entry := range entries {
+		raw, err := files.ReadFile(entry.Name())
+		if err != nil {
+			panic(fmt.Sprintf("schemas: failed to read %s: %v", entry.Name(), err))
+		}
+
+		var schema Schema
+		if err := json.Unmarshal(raw, &schema); err != nil {
+			panic(fmt.Sprintf("schemas: invalid definition %s: %v", entry.Name(), err))
+		}
+
+		name := strings.TrimSuffix(entry.Name(), path.Ext(entry.Name()))
+		registry[name] = struct {
+			raw    json.RawMessage
+			schema *Schema
+		}{raw: raw, schema: &schema}
+	}
+}
+
+// Names - Lists all registered schema names in stable order
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get - Returns the raw JSON Schema definition served to consumers
+func Get(name string) (json.RawMessage, bool) {
+	entry, ok := registry[name]
+	return entry.raw, ok
+}
+
+// Validate - Checks a JSON document against the named schema
+func Validate(name string, document []byte) error {
+	entry, ok := registry[name]
+	if !ok {
+		return fmt.Errorf("no schema registered for %s", name)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(document, &value); err != nil {
+		return fmt.Errorf("payload is not valid JSON: %v", err)
+	}
+
+	return entry.schema.validate("$", value)
+}
+
+// validate - Recursively validates a decoded JSON value
+func (s *Schema) validate(at string, value interface{}) error {
+	if s.Type != "" && !matchesType(s.Type, value) {
+		return fmt.Errorf("%s: expected %s", at, s.Type)
+	}
+
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		return fmt.Errorf("%s: value %v is not one of %v", at, value, s.Enum)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, field := range s.Required {
+			if _, ok := v[field]; !ok {
+				return fmt.Errorf("%s.%s: is required", at, field)
+			}
+		}
+		for field, fieldValue := range v {
+			propSchema, ok := s.Properties[field]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					return fmt.Errorf("%s.%s: additional property not allowed", at, field)
+				}
+				continue
+			}
+			if err := propSchema.validate(at+"."+field, fieldValue); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				if err := s.Items.validate(fmt.Sprintf("%s[%d]", at, i), item); err != nil {
+					return err
+				}
+			}
+		}
+	case string:
+		if s.MinLength != nil && len(v) < *s.MinLength {
+			return fmt.Errorf("%s: shorter than %d characters", at, *s.MinLength)
+		}
+		if err := checkFormat(s.Format, v); err != nil {
+			return fmt.Errorf("%s: %v", at, err)
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			return fmt.Errorf("%s: %v is below minimum %v", at, v, *s.Minimum)
+		}
+	}
+
+	return nil
+}
+
+// matchesType - JSON type check for decoded values
+func matchesType(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == float64(int64(n))
+	case "null":
+		return value == nil
+	}
+	return false
+}
+
+// inEnum - Compares decoded values with enum members
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+// checkFormat - Validates supported string formats
+func checkFormat(format, value string) error {
+	switch format {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339Nano, value); err != nil {
+			return fmt.Errorf("%q is not an RFC 3339 date-time", value)
+		}
+	case "email":
+		if _, err := mail.ParseAddress(value); err != nil {
+			return fmt.Errorf("%q is not a valid email address", value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// DESIGN PATTERN: Gateway Pattern (service-to-service integration)
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/models"
+	"time"
+)
+
+// idempotencyKeyHeader - Lets Auth Service de-duplicate retried points mutations
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// pointsMutationAttempts - Tries per mutation; retries reuse the idempotency key so they cannot double-debit
+const pointsMutationAttempts = 3
+
+// AuthGateway - What the service needs from the Auth Service (embedders may substitute their own)
+type AuthGateway interface {
+	GetUser(userID string) (*models.User, error)
+	FindUserByEmail(email string) (*models.User, error)
+	UpdateUserPoints(userID string, points int, idempotencyKey string) error
+}
+
+// AuthClient - Gateway to the Auth Service; every call feeds the health monitor
+type AuthClient struct {
+	baseURL string         // Auth Service base URL
+	client  *http.Client   // Shared HTTP client
+	monitor *HealthMonitor // Composition: HAS-A downstream health observer
+	clock   clock.Clock    // Composition: HAS-A time source (latency measurement)
+}
+
+// NewAuthClient - Factory method with dependency injection
+func NewAuthClient(cfg *config.Config, monitor *HealthMonitor, clk clock.Clock) *AuthClient {
+	return &AuthClient{
+		baseURL: cfg.AuthService,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		monitor: monitor,
+		clock:   clk,
+	}
+}
+
+// GetUser - Service-to-service call to Auth Service
+func (a *AuthClient) GetUser(userID string) (*models.User, error) {
+	req, err := http.NewRequest("GET", a.baseURL+"/users/"+userID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("user not found")
+	}
+
+	var response struct {
+		Success bool         `json:"success"`
+		Data    *models.User `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil || !response.Success {
+		return nil, errors.New("failed to get user data")
+	}
+
+	return response.Data, nil
+}
+
+// FindUserByEmail - Looks up a registered user by email (nil, nil when none exists)
+func (a *AuthClient) FindUserByEmail(email string) (*models.User, error) {
+	req, err := http.NewRequest("GET", a.baseURL+"/users/lookup?email="+url.QueryEscape(email), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("failed to look up user")
+	}
+
+	var response struct {
+		Success bool         `json:"success"`
+		Data    *models.User `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil || !response.Success {
+		return nil, errors.New("failed to get user data")
+	}
+
+	return response.Data, nil
+}
+
+// UpdateUserPoints - Service-to-service call to update user points (exactly-once via idempotencyKey)
+func (a *AuthClient) UpdateUserPoints(userID string, points int, idempotencyKey string) error {
+	requestBody := map[string]int{"points": points}
+	jsonData, _ := json.Marshal(requestBody)
+
+	var lastErr error
+	for attempt := 1; attempt <= pointsMutationAttempts; attempt++ {
+		req, err := http.NewRequest("PUT", a.baseURL+"/users/"+userID+"/points",
+			bytes.NewBuffer(jsonData))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(idempotencyKeyHeader, idempotencyKey)
+
+		resp, err := a.do(req)
+		if err != nil {
+			// Timeout or transport error: the debit may have landed, so only the same key may retry
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return nil
+		case resp.StatusCode >= 500:
+			lastErr = errors.New("auth service error: " + resp.Status)
+			continue
+		default:
+			return errors.New("failed to update points")
+		}
+	}
+
+	return lastErr
+}
+
+// do - Executes a request and reports latency/outcome to the health monitor (4xx counts as healthy)
+func (a *AuthClient) do(req *http.Request) (*http.Response, error) {
+	start := a.clock.Now()
+	resp, err := a.client.Do(req)
+
+	observed := err
+	if err == nil && resp.StatusCode >= 500 {
+		observed = errors.New(resp.Status)
+	}
+	a.monitor.Observe(DependencyAuth, a.clock.Now().Sub(start), observed)
+
+	return resp, err
+}
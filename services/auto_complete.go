@@ -0,0 +1,56 @@
+// DESIGN PATTERN: Saga Pattern (short-circuited for registered receivers)
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sender-service/models"
+)
+
+// autoCompleteReceiver - Registered receiver the transfer can be completed for instantly (nil = claim-link flow)
+func (s *TransferService) autoCompleteReceiver(transfer *models.Transfer) *models.User {
+	// Passphrase-protected transfers are deliberately gated by the sender
+	if !s.config.Claims.AutoComplete || transfer.PassphraseProtected() {
+		return nil
+	}
+
+	// RECEIVER LOOKUP: Only emails that belong to a registered user
+	receiver, err := s.auth.FindUserByEmail(transfer.ReceiverEmail)
+	if err != nil || receiver == nil {
+		return nil
+	}
+	return receiver
+}
+
+// tryAutoComplete - Completes the transfer immediately for a registered receiver.
+// Returns false (leaving the normal claim-link flow in charge) when the transfer is still pending.
+func (s *TransferService) tryAutoComplete(transfer *models.Transfer, receiver *models.User) bool {
+	// 1. DEDUCTION: Same saga step a claim would run
+	if err := s.finalize(transfer); err != nil {
+		fmt.Printf("Auto-complete of %s failed, falling back to claim link: %v\n", transfer.ID, err)
+		return transfer.Status != models.TransferStatusPending // e.g. failed for insufficient points
+	}
+
+	// 2. INSTANT CREDIT: Receiver gets the converted points right away
+	if err := s.creditReceiver(transfer, receiver); err != nil {
+		fmt.Printf("Failed to credit receiver for %s: %v\n", transfer.ID, err)
+		s.deadLetters.Record(models.DeadLetterKindCredit, transfer.ID, receiver.ID, err.Error(), 1,
+			[]models.DeadLetterAttempt{{At: s.clock.Now(), Error: err.Error()}})
+	}
+
+	// 3. NOTIFICATION: "Points received" instead of a claim invitation (delivered by the outbox dispatcher)
+	s.queueEmail(transfer, transfer.ReceiverEmail, TemplatePointsReceived, s.emailService.PointsReceivedData(transfer))
+
+	return true
+}
+
+// creditReceiver - Adds the converted points to the receiver's balance (idempotent per transfer)
+func (s *TransferService) creditReceiver(transfer *models.Transfer, receiver *models.User) error {
+	transfer.ReceiverID = receiver.ID
+	transfer.AutoCompleted = true
+	if err := s.transferRepo.Update(transfer); err != nil {
+		return errors.New("failed to record receiver")
+	}
+
+	return s.auth.UpdateUserPoints(receiver.ID, receiver.Points+transfer.ConvertedPoints, "credit-"+transfer.ID)
+}
@@ -0,0 +1,92 @@
+// DESIGN PATTERN: Batch Processing (all-or-nothing initiation) + Transactional Outbox
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/models"
+	"strings"
+)
+
+// ErrDuplicateReceiver - A bulk request names the same receiver twice
+var ErrDuplicateReceiver = apperrors.New(apperrors.ErrInvalidInput, "duplicate_receiver", "duplicate receiver")
+
+// InitiateBulkTransfer - Creates one pending transfer per entry in a single transaction; the sender's
+// balance must cover the sum, and claim notifications go out through the outbox dispatcher
+func (s *TransferService) InitiateBulkTransfer(senderID string, req models.BulkTransferRequest) ([]*models.Transfer, error) {
+	transfers, err := s.initiateBulk(senderID, req)
+	s.observeOutcome(OperationInitiate, err)
+	return transfers, err
+}
+
+// initiateBulk - Creates the batch (InitiateBulkTransfer counts the outcome)
+func (s *TransferService) initiateBulk(senderID string, req models.BulkTransferRequest) ([]*models.Transfer, error) {
+	// 1. SERVICE INTEGRATION: Get sender details from Auth Service
+	sender, err := s.auth.GetUser(senderID)
+	if err != nil {
+		return nil, ErrSenderUnavailable
+	}
+
+	// 2. BUSINESS VALIDATION: The balance must cover every entry together, not each one alone
+	total := 0
+	seen := make(map[string]bool, len(req.Transfers))
+	for i, entry := range req.Transfers {
+		receiver := strings.ToLower(entry.ReceiverEmail)
+		if seen[receiver] {
+			return nil, ErrDuplicateReceiver.WithMessage(fmt.Sprintf("entry %d: duplicate receiver %s", i+1, entry.ReceiverEmail))
+		}
+		seen[receiver] = true
+		total += entry.Points
+	}
+	if sender.Points < total {
+		return nil, apperrors.ErrInsufficientPoints.WithMessage(fmt.Sprintf("insufficient points: %d needed for %d transfers, %d available", total, len(req.Transfers), sender.Points))
+	}
+	if err := s.checkSenderLimits(senderID, total, len(req.Transfers)); err != nil {
+		return nil, err
+	}
+
+	// 3. ENTITY CREATION: Each entry passes the same rules and hooks as a single transfer
+	transfers := make([]*models.Transfer, 0, len(req.Transfers))
+	for i, entry := range req.Transfers {
+		single := models.TransferRequest{
+			ReceiverEmail: entry.ReceiverEmail,
+			ReceiverName:  entry.ReceiverName,
+			Points:        entry.Points,
+			SourceProgram: s.conversions.DefaultProgram(),
+			TargetProgram: s.conversions.DefaultProgram(),
+		}
+		if err := s.validateRules(sender, single); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		if err := s.hooks.BeforeInitiate(sender, &single); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+
+		transfer, err := s.newTransfer(sender, senderID, single, &ScannedContent{}, nil, "")
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	// 4. PERSISTENCE: All rows or none (TRANSACTIONAL OUTBOX keeps the invitations with them)
+	err = s.createFresh(transfers, func() error {
+		// OUTBOX: initiated event plus claim notification, dispatched asynchronously after commit
+		outbox := make(map[string][]*models.TransferOutboxMessage, len(transfers))
+		for _, transfer := range transfers {
+			outbox[transfer.ID] = append(s.initiatedMessages(transfer), s.claimNotificationMessage(transfer))
+		}
+		return s.transferRepo.CreateBatch(transfers, outbox)
+	})
+	if err != nil {
+		fmt.Printf("Failed to create bulk transfer for %s: %v\n", senderID, err)
+		return nil, errors.New("failed to create transfers")
+	}
+	for _, transfer := range transfers {
+		s.volume.ObserveInitiated(transfer.Points)
+	}
+
+	//  SAGA PATTERN: As with single transfers, points move only when each receiver claims
+	return transfers, nil
+}
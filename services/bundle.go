@@ -0,0 +1,65 @@
+// DESIGN PATTERN: Composite Pattern (bundle validation and scanning)
+package services
+
+import (
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/models"
+)
+
+// ErrInvalidBundle - Bundle items that cannot form a gift (messages name the offending item)
+var ErrInvalidBundle = apperrors.New(apperrors.ErrInvalidInput, "invalid_bundle", "invalid bundle")
+
+// bundlePoints - Validates bundle items and returns the points total they add up to
+func bundlePoints(items []models.BundleItem, requested int) (int, error) {
+	total := 0
+	for i, item := range items {
+		switch item.Type {
+		case models.BundleItemPoints:
+			if item.Points < 1 {
+				return 0, ErrInvalidBundle.WithMessage(fmt.Sprintf("item %d: points items need at least 1 point", i+1))
+			}
+			total += item.Points
+		case models.BundleItemBadge:
+			if item.BadgeID == "" && item.Title == "" {
+				return 0, ErrInvalidBundle.WithMessage(fmt.Sprintf("item %d: badge items need a badge_id or title", i+1))
+			}
+		case models.BundleItemMessage:
+			if item.Message == "" {
+				return 0, ErrInvalidBundle.WithMessage(fmt.Sprintf("item %d: message items need a message", i+1))
+			}
+		}
+		if item.Type != models.BundleItemPoints && item.Points != 0 {
+			return 0, ErrInvalidBundle.WithMessage(fmt.Sprintf("item %d: only points items may carry points", i+1))
+		}
+	}
+
+	// The claim saga moves points, so every bundle carries at least one points item
+	if total < 1 {
+		return 0, ErrInvalidBundle.WithMessage("bundles must include at least one points item")
+	}
+	if requested != 0 && requested != total {
+		return 0, ErrInvalidBundle.WithMessage(fmt.Sprintf("points (%d) must equal the sum of bundle points items (%d)", requested, total))
+	}
+	return total, nil
+}
+
+// scanBundle - Runs every bundle message through the content scanner and builds the stored metadata
+func (s *TransferService) scanBundle(items []models.BundleItem) (*models.TransferMetadata, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	scanned := make([]models.BundleItem, len(items))
+	for i, item := range items {
+		if item.Message != "" {
+			content, err := s.scanner.Scan(item.Message, nil)
+			if err != nil {
+				return nil, err
+			}
+			item.Message = content.Message
+		}
+		scanned[i] = item
+	}
+	return &models.TransferMetadata{Bundle: scanned}, nil
+}
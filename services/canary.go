@@ -0,0 +1,262 @@
+// DESIGN PATTERN: Synthetic Transaction (end-to-end self-test) + Null Object (dry-run Auth gateway) + Scheduled Job
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/idgen"
+	"sender-service/models"
+	"sync"
+	"time"
+)
+
+// Canary run stages, in order
+const (
+	CanaryStageInitiate = "initiate" // Transfer accepted and persisted with its claim notification
+	CanaryStageDelivery = "delivery" // Claim email found in the canary mailbox
+	CanaryStageComplete = "complete" // Claim link redeemed and the transfer completed
+)
+
+// canaryRunHistory - Recent runs kept for the metrics endpoint
+const canaryRunHistory = 50
+
+// ErrCanaryEmailMissing - The claim email did not reach the canary mailbox in time
+var ErrCanaryEmailMissing = errors.New("claim email not found in the canary mailbox")
+
+// CanaryRun - Outcome of one synthetic transfer
+type CanaryRun struct {
+	ID          string                   `json:"id"`                     // canary_<hex>, also the marker searched for in the mailbox
+	TransferID  string                   `json:"transfer_id,omitempty"`  // Synthetic transfer (flagged canary)
+	StartedAt   time.Time                `json:"started_at"`             // When the run began
+	Duration    time.Duration            `json:"duration"`               // End to end (ns)
+	Stages      map[string]time.Duration `json:"stages"`                 // Completed stage -> time taken (ns)
+	Healthy     bool                     `json:"healthy"`                // Every stage succeeded
+	FailedStage string                   `json:"failed_stage,omitempty"` // First stage that failed
+	Error       string                   `json:"error,omitempty"`        // Why it failed
+}
+
+// CanaryStatus - End-to-end health as seen by the canary
+type CanaryStatus struct {
+	Enabled             bool        `json:"enabled"`                   // CANARY_INTERVAL > 0
+	Healthy             bool        `json:"healthy"`                   // Last run succeeded
+	Alerting            bool        `json:"alerting"`                  // Consecutive failures reached CANARY_ALERT_AFTER
+	ConsecutiveFailures int         `json:"consecutive_failures"`      // Failed runs since the last success
+	Runs                int         `json:"runs"`                      // Runs since process start
+	Failures            int         `json:"failures"`                  // Failed runs since process start
+	SuccessRate         float64     `json:"success_rate"`              // Over the recent runs
+	LastSuccessAt       *time.Time  `json:"last_success_at,omitempty"` // When a run last passed
+	Recent              []CanaryRun `json:"recent"`                    // Newest first
+}
+
+// CanaryService - Periodically sends a real transfer through initiation, email delivery and claiming
+type CanaryService struct {
+	transfers *TransferService // Canary copy: dry-run Auth gateway, flagged transfers
+	mailbox   CanaryMailbox    // Strategy: HAS-A mailbox reader (IMAP or API)
+	clock     clock.Clock      // Composition: HAS-A time source
+	ids       idgen.Generator  // Composition: HAS-A ID generator
+	config    config.CanaryConfig
+
+	mu            sync.Mutex  // Guards the fields below
+	runs          []CanaryRun // Newest first, at most canaryRunHistory
+	total         int         // Runs since process start
+	failures      int         // Failed runs since process start
+	consecutive   int         // Failed runs since the last success
+	alerting      bool        // Alert raised and not yet recovered
+	lastSuccessAt *time.Time  // Last passing run
+}
+
+// NewCanaryService - Factory method; the transfer service is copied onto a dry-run Auth gateway so the
+// canary never reads or moves real points
+func NewCanaryService(transfers *TransferService, mailbox CanaryMailbox, clk clock.Clock, ids idgen.Generator, cfg *config.Config) *CanaryService {
+	sender := models.User{
+		ID:     cfg.Canary.SenderID,
+		Email:  "canary-sender+" + cfg.Canary.SenderID + "@invalid", // Never the receiver (self-transfers are rejected)
+		Name:   "Sender Service Canary",
+		Points: math.MaxInt32,
+	}
+	return &CanaryService{
+		transfers: transfers.Canary(&canaryAuth{sender: sender}),
+		mailbox:   mailbox,
+		clock:     clk,
+		ids:       ids,
+		config:    cfg.Canary,
+	}
+}
+
+// Enabled - Whether the canary is scheduled
+func (s *CanaryService) Enabled() bool {
+	return s.config.Interval > 0
+}
+
+// Run - Scheduler job: one synthetic transfer, recorded and alerted on
+func (s *CanaryService) Run(ctx context.Context) error {
+	started := s.clock.Now()
+	run := CanaryRun{ID: s.ids.NewID("canary"), StartedAt: started, Stages: map[string]time.Duration{}}
+
+	stage, err := s.probe(ctx, &run)
+	run.Duration = s.clock.Now().Sub(started)
+	run.Healthy = err == nil
+	if err != nil {
+		run.FailedStage, run.Error = stage, err.Error()
+	}
+	s.record(run)
+
+	if err != nil {
+		return fmt.Errorf("canary %s failed at %s: %w", run.ID, stage, err)
+	}
+	return nil
+}
+
+// probe - Runs the stages in order; returns the failing stage
+func (s *CanaryService) probe(ctx context.Context, run *CanaryRun) (string, error) {
+	// 1. INITIATE: Through the full pipeline (validation, scanning, outbox), marker in the gift message
+	stageStart := s.clock.Now()
+	transfer, err := s.transfers.InitiateTransfer(s.config.SenderID, models.TransferRequest{
+		ReceiverEmail: s.config.Mailbox,
+		ReceiverName:  "Canary",
+		Points:        s.config.Points,
+		Message:       "Automated delivery check " + run.ID,
+	})
+	if err != nil {
+		return CanaryStageInitiate, err
+	}
+	run.TransferID = transfer.ID
+	run.Stages[CanaryStageInitiate] = s.clock.Now().Sub(stageStart)
+
+	// 2. DELIVERY: The outbox dispatcher sends the claim email; poll the mailbox for the marker
+	stageStart = s.clock.Now()
+	if err := s.awaitDelivery(ctx, run.ID); err != nil {
+		return CanaryStageDelivery, err
+	}
+	run.Stages[CanaryStageDelivery] = s.clock.Now().Sub(stageStart)
+
+	// 3. COMPLETE: Redeem the claim token like the landing page would
+	stageStart = s.clock.Now()
+	completed, _, err := s.transfers.ClaimTransfer(transfer.Token, "", "", "sender-service-canary")
+	if err != nil {
+		return CanaryStageComplete, err
+	}
+	if completed.Status != models.TransferStatusCompleted {
+		return CanaryStageComplete, fmt.Errorf("transfer is %s after claiming", completed.Status)
+	}
+	run.Stages[CanaryStageComplete] = s.clock.Now().Sub(stageStart)
+	return "", nil
+}
+
+// awaitDelivery - Polls the mailbox until the marker arrives or CANARY_DELIVERY_TIMEOUT passes
+func (s *CanaryService) awaitDelivery(ctx context.Context, marker string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.config.DeliveryTimeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		found, err := s.mailbox.Received(ctx, marker)
+		if err == nil && found {
+			return nil
+		}
+		if err != nil {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return fmt.Errorf("%w (last mailbox error: %v)", ErrCanaryEmailMissing, lastErr)
+			}
+			return ErrCanaryEmailMissing
+		case <-time.After(s.config.PollInterval):
+		}
+	}
+}
+
+// record - Keeps the run, updates counters and raises/clears the alert
+func (s *CanaryService) record(run CanaryRun) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.runs = append([]CanaryRun{run}, s.runs...)
+	if len(s.runs) > canaryRunHistory {
+		s.runs = s.runs[:canaryRunHistory]
+	}
+	s.total++
+
+	if run.Healthy {
+		if s.alerting {
+			fmt.Printf("RECOVERED: canary transfer %s passed end to end after %d failed run(s)\n", run.TransferID, s.consecutive)
+		}
+		s.consecutive, s.alerting = 0, false
+		finishedAt := run.StartedAt.Add(run.Duration)
+		s.lastSuccessAt = &finishedAt
+		return
+	}
+
+	s.failures++
+	s.consecutive++
+	if !s.alerting && s.consecutive >= s.config.AlertAfter {
+		s.alerting = true
+		fmt.Printf("ALERT: canary failed %d consecutive run(s); latest failed at %s: %s\n", s.consecutive, run.FailedStage, run.Error)
+	}
+}
+
+// Status - End-to-end health for the metrics endpoint
+func (s *CanaryService) Status() CanaryStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := CanaryStatus{
+		Enabled:             s.Enabled(),
+		Healthy:             len(s.runs) > 0 && s.runs[0].Healthy,
+		Alerting:            s.alerting,
+		ConsecutiveFailures: s.consecutive,
+		Runs:                s.total,
+		Failures:            s.failures,
+		LastSuccessAt:       s.lastSuccessAt,
+		Recent:              append([]CanaryRun{}, s.runs...),
+	}
+	if len(s.runs) > 0 {
+		passed := 0
+		for _, run := range s.runs {
+			if run.Healthy {
+				passed++
+			}
+		}
+		status.SuccessRate = float64(passed) / float64(len(s.runs))
+	}
+	return status
+}
+
+// Canary - Copy of the service for synthetic transfers: Auth calls go to the given dry-run gateway,
+// claims need no assertion, and new transfers are flagged so no event or live metric counts them
+func (s *TransferService) Canary(auth AuthGateway) *TransferService {
+	canary := *s
+	canary.auth = auth
+	canary.assertions = s.assertions.Optional()
+	canary.canary = true
+	return &canary
+}
+
+// canaryAuth - Dry-run Auth Service for the canary sender: a synthetic user with ample points, no
+// registered receivers (so the claim link is always emailed), and deductions acknowledged unapplied
+type canaryAuth struct {
+	sender models.User // Synthetic sender
+}
+
+// GetUser - The synthetic sender
+func (a *canaryAuth) GetUser(userID string) (*models.User, error) {
+	sender := a.sender
+	return &sender, nil
+}
+
+// FindUserByEmail - No registered receivers (nil, nil)
+func (a *canaryAuth) FindUserByEmail(email string) (*models.User, error) {
+	return nil, nil
+}
+
+// UpdateUserPoints - Acknowledged without touching any balance
+func (a *canaryAuth) UpdateUserPoints(userID string, points int, idempotencyKey string) error {
+	return nil
+}
@@ -0,0 +1,182 @@
+// DESIGN PATTERN: Strategy Pattern (IMAP / HTTP API mailbox readers) for the canary
+package services
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sender-service/config"
+	"strings"
+	"time"
+)
+
+// CanaryMailbox - Reads the canary mailbox back to confirm the claim email arrived
+type CanaryMailbox interface {
+	Received(ctx context.Context, marker string) (bool, error) // Whether a message containing marker arrived
+}
+
+// NewCanaryMailbox - Factory method selecting the reader from configuration (nil when the canary is off)
+func NewCanaryMailbox(cfg *config.Config) (CanaryMailbox, error) {
+	canary := cfg.Canary
+	if canary.Interval <= 0 {
+		return nil, nil
+	}
+	if canary.Mailbox == "" {
+		return nil, fmt.Errorf("CANARY_INTERVAL is set but CANARY_MAILBOX is empty")
+	}
+	switch canary.Verify {
+	case "imap":
+		if canary.IMAPAddr == "" || canary.IMAPUser == "" || canary.IMAPPassword == "" {
+			return nil, fmt.Errorf("CANARY_VERIFY=imap needs CANARY_IMAP_ADDR, CANARY_IMAP_USER and CANARY_IMAP_PASSWORD")
+		}
+		return &IMAPMailbox{addr: canary.IMAPAddr, user: canary.IMAPUser, password: canary.IMAPPassword}, nil
+	case "api":
+		if canary.APIURL == "" {
+			return nil, fmt.Errorf("CANARY_VERIFY=api needs CANARY_MAILBOX_API_URL")
+		}
+		return &APIMailbox{url: canary.APIURL, token: canary.APIToken, client: &http.Client{Timeout: 10 * time.Second}}, nil
+	}
+	return nil, fmt.Errorf("unknown CANARY_VERIFY %q", canary.Verify)
+}
+
+// IMAPMailbox - Searches the INBOX over IMAP (implicit TLS) and deletes the messages it found, so the
+// canary mailbox does not grow
+type IMAPMailbox struct {
+	addr     string // host:port
+	user     string // Login
+	password string // Password / app password
+}
+
+// Received - LOGIN, SELECT INBOX, UID SEARCH BODY <marker>, then flag the hits deleted and EXPUNGE
+func (m *IMAPMailbox) Received(ctx context.Context, marker string) (bool, error) {
+	// 1. CONNECT: Bounded by the caller's deadline
+	dialer := &tls.Dialer{NetDialer: &net.Dialer{Timeout: 10 * time.Second}}
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	session := &imapSession{reader: bufio.NewReader(conn), writer: conn}
+	if _, err := session.reader.ReadString('\n'); err != nil { // Server greeting
+		return false, err
+	}
+
+	// 2. SEARCH: Message bodies containing the run's marker
+	if _, err := session.command("LOGIN " + imapQuote(m.user) + " " + imapQuote(m.password)); err != nil {
+		return false, err
+	}
+	defer session.command("LOGOUT")
+	if _, err := session.command("SELECT INBOX"); err != nil {
+		return false, err
+	}
+	lines, err := session.command("UID SEARCH BODY " + imapQuote(marker))
+	if err != nil {
+		return false, err
+	}
+	var uids []string
+	for _, line := range lines {
+		if rest, ok := strings.CutPrefix(line, "* SEARCH"); ok {
+			uids = append(uids, strings.Fields(rest)...)
+		}
+	}
+	if len(uids) == 0 {
+		return false, nil
+	}
+
+	// 3. CLEAN UP: Best-effort; the delivery is confirmed either way
+	if _, err := session.command("UID STORE " + strings.Join(uids, ",") + ` +FLAGS.SILENT (\Deleted)`); err == nil {
+		session.command("EXPUNGE")
+	}
+	return true, nil
+}
+
+// imapSession - Minimal tagged command/response exchange (no literals are ever sent)
+type imapSession struct {
+	reader *bufio.Reader
+	writer net.Conn
+	tag    int
+}
+
+// command - Sends one tagged command and collects untagged lines until its completion
+func (s *imapSession) command(command string) ([]string, error) {
+	s.tag++
+	tag := fmt.Sprintf("c%d", s.tag)
+	if _, err := fmt.Fprintf(s.writer, "%s %s\r\n", tag, command); err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if status, ok := strings.CutPrefix(line, tag+" "); ok {
+			if !strings.HasPrefix(status, "OK") {
+				verb, _, _ := strings.Cut(command, " ")
+				return nil, fmt.Errorf("IMAP %s failed: %s", verb, status)
+			}
+			return lines, nil
+		}
+		lines = append(lines, line)
+	}
+}
+
+// imapQuote - IMAP quoted string
+func imapQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// APIMailbox - Searches a mailbox HTTP API (Mailpit-compatible: GET <url>?query=<marker> answering
+// {"messages_count": n} or {"count": n})
+type APIMailbox struct {
+	url    string       // Search endpoint
+	token  string       // Bearer token (optional)
+	client *http.Client // Shared HTTP client
+}
+
+// Received - Whether the search reports at least one message
+func (m *APIMailbox) Received(ctx context.Context, marker string) (bool, error) {
+	target, err := url.Parse(m.url)
+	if err != nil {
+		return false, err
+	}
+	query := target.Query()
+	query.Set("query", marker)
+	target.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return false, err
+	}
+	if m.token != "" {
+		req.Header.Set("Authorization", "Bearer "+m.token)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("mailbox API responded with status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		MessagesCount int `json:"messages_count"`
+		Count         int `json:"count"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("invalid mailbox API response: %v", err)
+	}
+	return result.MessagesCount > 0 || result.Count > 0, nil
+}
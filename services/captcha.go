@@ -0,0 +1,121 @@
+// DESIGN PATTERN: Gateway Pattern (reCAPTCHA / Turnstile siteverify) + Strategy Pattern (fail open vs closed)
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sender-service/apperrors"
+	"sender-service/config"
+	"strings"
+)
+
+var (
+	ErrCaptchaRequired    = apperrors.New(apperrors.ErrForbidden, "captcha_required", "a CAPTCHA token is required (X-Captcha-Token)")
+	ErrCaptchaFailed      = apperrors.New(apperrors.ErrForbidden, "captcha_failed", "CAPTCHA verification failed")
+	ErrCaptchaUnavailable = apperrors.New(apperrors.ErrUnavailable, "captcha_unavailable", "CAPTCHA verification is unavailable, please try again later")
+)
+
+// captchaVerifyURLs - Public siteverify endpoints per provider
+var captchaVerifyURLs = map[string]string{
+	"recaptcha": "https://www.google.com/recaptcha/api/siteverify",
+	"turnstile": "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+}
+
+// CaptchaVerifier - Checks the token a frontend widget produced with the provider, server-side
+type CaptchaVerifier struct {
+	provider  string       // recaptcha or turnstile
+	verifyURL string       // Siteverify endpoint
+	secret    string       // Provider secret key
+	minScore  float64      // Lowest accepted reCAPTCHA v3 score (0 = any)
+	action    string       // Expected action (empty = any)
+	failOpen  bool         // Accept when the provider is unreachable
+	client    *http.Client // Shared HTTP client
+}
+
+// NewCaptchaVerifier - Factory method; nil when CAPTCHA_PROVIDER is empty (no check)
+func NewCaptchaVerifier(cfg *config.Config) (*CaptchaVerifier, error) {
+	captcha := cfg.Captcha
+	if captcha.Provider == "" {
+		return nil, nil
+	}
+	verifyURL, known := captchaVerifyURLs[captcha.Provider]
+	if !known {
+		return nil, fmt.Errorf("unknown CAPTCHA_PROVIDER %q (recaptcha or turnstile)", captcha.Provider)
+	}
+	if captcha.Secret == "" {
+		return nil, fmt.Errorf("CAPTCHA_PROVIDER=%s needs CAPTCHA_SECRET", captcha.Provider)
+	}
+	if captcha.VerifyURL != "" {
+		verifyURL = captcha.VerifyURL
+	}
+	return &CaptchaVerifier{
+		provider:  captcha.Provider,
+		verifyURL: verifyURL,
+		secret:    captcha.Secret,
+		minScore:  captcha.MinScore,
+		action:    captcha.Action,
+		failOpen:  captcha.FailOpen,
+		client:    &http.Client{Timeout: captcha.Timeout},
+	}, nil
+}
+
+// VerifyCaptcha - Validates one token for the client IP (satisfies middleware.CaptchaVerifier)
+func (v *CaptchaVerifier) VerifyCaptcha(token, remoteIP string) error {
+	if strings.TrimSpace(token) == "" {
+		return ErrCaptchaRequired
+	}
+
+	// 1. SITEVERIFY: Both providers take the same form and answer with the same core fields
+	result, err := v.siteverify(token, remoteIP)
+	if err != nil {
+		if v.failOpen {
+			fmt.Printf("CAPTCHA check skipped (%s unreachable, fail-open): %v\n", v.provider, err)
+			return nil
+		}
+		fmt.Printf("CAPTCHA check failed (%s unreachable): %v\n", v.provider, err)
+		return ErrCaptchaUnavailable
+	}
+
+	// 2. VERDICT: Success, plus the score and action when the provider reports them
+	switch {
+	case !result.Success:
+		return ErrCaptchaFailed.WithMessage("CAPTCHA verification failed: " + strings.Join(result.ErrorCodes, ", "))
+	case result.Score != nil && *result.Score < v.minScore:
+		return ErrCaptchaFailed.WithMessage(fmt.Sprintf("CAPTCHA score %.2f is below %.2f", *result.Score, v.minScore))
+	case v.action != "" && result.Action != "" && result.Action != v.action:
+		return ErrCaptchaFailed.WithMessage(fmt.Sprintf("CAPTCHA token was issued for action %q", result.Action))
+	}
+	return nil
+}
+
+// captchaResult - Siteverify response (score/action: reCAPTCHA v3 and Turnstile only)
+type captchaResult struct {
+	Success    bool     `json:"success"`
+	Score      *float64 `json:"score"`
+	Action     string   `json:"action"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// siteverify - POSTs secret, response and remoteip as a form
+func (v *CaptchaVerifier) siteverify(token, remoteIP string) (*captchaResult, error) {
+	form := url.Values{"secret": {v.secret}, "response": {token}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	resp, err := v.client.PostForm(v.verifyURL, form)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s siteverify responded with status %d", v.provider, resp.StatusCode)
+	}
+
+	var result captchaResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode %s siteverify response: %v", v.provider, err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,224 @@
+// DESIGN PATTERN: Decorator Pattern (event sink) + Template Method (per-event chat messages) + Adapter (Slack/Teams)
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sender-service/config"
+	"sender-service/models"
+	texttemplate "text/template"
+	"time"
+)
+
+// Chat providers
+const (
+	ChatProviderSlack = "slack" // Slack incoming webhook ({"text"})
+	ChatProviderTeams = "teams" // Microsoft Teams incoming webhook (MessageCard)
+)
+
+// defaultChatTemplates - Message per event type when a channel enables it without its own template
+var defaultChatTemplates = map[string]string{
+	models.EventTransferInitiated:     `{{sender .}} sent a {{.Data.points}}-point gift :gift:`,
+	models.EventTransferCompleted:     `A {{.Data.points}}-point gift from {{sender .}} was claimed :tada:`,
+	models.EventTransferFailed:        `A {{.Data.points}}-point transfer from {{sender .}} failed: {{.Data.reason}}`,
+	models.EventTransferStatusChanged: `A transfer from {{sender .}} is now {{.Data.to_status}} ({{.Data.reason_code}})`,
+	models.EventTransferClaimDeferred: `A gift from {{sender .}} was saved to claim later`,
+}
+
+// chatTemplateFuncs - Helpers available in channel templates
+var chatTemplateFuncs = texttemplate.FuncMap{
+	// sender - Sender's name from the Auth Service, or the sender ID when unavailable
+	"sender": func(data ChatMessageData) string {
+		if data.Sender.Name != "" {
+			return data.Sender.Name
+		}
+		senderID, _ := data.Data["sender_id"].(string)
+		return senderID
+	},
+}
+
+// ChatChannel - One Slack/Teams webhook from CHAT_CHANNELS
+type ChatChannel struct {
+	Name     string            `json:"name"`     // Label used in logs
+	Provider string            `json:"provider"` // slack or teams
+	URL      string            `json:"url"`      // Incoming webhook URL
+	Teams    []string          `json:"teams"`    // Sender teams (Auth Service profile); empty = every sender
+	Events   map[string]string `json:"events"`   // Enabled event type -> template ("" = default); empty = all, default templates
+
+	templates map[string]*texttemplate.Template // Parsed Events (or defaults)
+}
+
+// ChatMessageData - Data available to chat templates
+type ChatMessageData struct {
+	Event      string                 // Event type (e.g. transfer.completed)
+	TransferID string                 // Transfer the event belongs to
+	OccurredAt time.Time              // When it happened
+	Data       map[string]interface{} // Event payload fields (e.g. .Data.points, .Data.sender_id)
+	Sender     models.User            // Sender profile (zero when the Auth Service is unavailable)
+}
+
+// ChatSink - Delivers each event to the wrapped sink, then posts it to matching chat channels.
+// Chat posts are best-effort: failures are logged, never retried, so people do not see duplicates
+// and a broken webhook cannot hold up the ordered relay
+type ChatSink struct {
+	next     EventSink      // Decorated sink (webhooks, configured endpoint or log)
+	auth     AuthGateway    // Sender name and team lookup
+	channels []*ChatChannel // Parsed CHAT_CHANNELS
+	client   *http.Client   // Shared HTTP client
+}
+
+// NewChatSink - Factory method parsing CHAT_CHANNELS and decorating the configured sink
+// (returns next unchanged when no channels are configured)
+func NewChatSink(next EventSink, auth AuthGateway, cfg *config.Config) (EventSink, error) {
+	channels, err := ParseChatChannels(cfg.Notifications.ChatChannels)
+	if err != nil {
+		return nil, err
+	}
+	if len(channels) == 0 {
+		return next, nil
+	}
+	return &ChatSink{next: next, auth: auth, channels: channels, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+// ParseChatChannels - Validates the CHAT_CHANNELS JSON array and compiles every template ("" = none)
+func ParseChatChannels(raw string) ([]*ChatChannel, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var channels []*ChatChannel
+	if err := json.Unmarshal([]byte(raw), &channels); err != nil {
+		return nil, fmt.Errorf("invalid chat channels definition: %v", err)
+	}
+
+	for i, channel := range channels {
+		// 1. SHAPE: Provider and URL are required
+		if channel.Name == "" {
+			channel.Name = fmt.Sprintf("channel-%d", i+1)
+		}
+		if channel.Provider != ChatProviderSlack && channel.Provider != ChatProviderTeams {
+			return nil, fmt.Errorf("chat channel %s: provider must be slack or teams", channel.Name)
+		}
+		if channel.URL == "" {
+			return nil, fmt.Errorf("chat channel %s: url is required", channel.Name)
+		}
+
+		// 2. TOGGLES: No events means every event with its default message
+		events := channel.Events
+		if len(events) == 0 {
+			events = make(map[string]string, len(defaultChatTemplates))
+			for eventType := range defaultChatTemplates {
+				events[eventType] = ""
+			}
+		}
+
+		// 3. TEMPLATES: Parsed now so a typo fails startup, not the first claim
+		channel.templates = make(map[string]*texttemplate.Template, len(events))
+		for eventType, text := range events {
+			if _, ok := eventVersions[eventType]; !ok {
+				return nil, fmt.Errorf("chat channel %s: %w: %s", channel.Name, ErrUnknownEventType, eventType)
+			}
+			if text == "" {
+				text = defaultChatTemplates[eventType]
+			}
+			tmpl, err := texttemplate.New(eventType).Funcs(chatTemplateFuncs).Parse(text)
+			if err != nil {
+				return nil, fmt.Errorf("chat channel %s: template for %s: %v", channel.Name, eventType, err)
+			}
+			channel.templates[eventType] = tmpl
+		}
+	}
+	return channels, nil
+}
+
+// Deliver - Wrapped sink first (its failure retries the event), then best-effort chat posts
+func (s *ChatSink) Deliver(event *models.DomainEvent, payload []byte) error {
+	if err := s.next.Deliver(event, payload); err != nil {
+		return err
+	}
+
+	var data ChatMessageData
+	senderLoaded := false
+	for _, channel := range s.channels {
+		tmpl, ok := channel.templates[event.Type]
+		if !ok {
+			continue
+		}
+
+		// 1. CONTEXT: Decode the payload and look the sender up once per event
+		if !senderLoaded {
+			data = s.messageData(event)
+			senderLoaded = true
+		}
+		if !channel.coversTeam(data.Sender.Team) {
+			continue
+		}
+
+		// 2. RENDER + POST: Logged on failure, the event is still delivered
+		var text bytes.Buffer
+		if err := tmpl.Execute(&text, data); err != nil {
+			fmt.Printf("Failed to render chat message for %s on %s: %v\n", event.ID, channel.Name, err)
+			continue
+		}
+		if err := s.post(channel, text.String()); err != nil {
+			fmt.Printf("Failed to post %s to chat channel %s: %v\n", event.ID, channel.Name, err)
+		}
+	}
+	return nil
+}
+
+// messageData - Template data for an event (sender profile left empty if the lookup fails)
+func (s *ChatSink) messageData(event *models.DomainEvent) ChatMessageData {
+	data := ChatMessageData{Event: event.Type, TransferID: event.AggregateID, OccurredAt: event.OccurredAt}
+	if err := json.Unmarshal(event.Data, &data.Data); err != nil {
+		fmt.Printf("Failed to decode %s for chat: %v\n", event.ID, err)
+	}
+	if senderID, _ := data.Data["sender_id"].(string); senderID != "" {
+		if sender, err := s.auth.GetUser(senderID); err == nil {
+			data.Sender = *sender
+		}
+	}
+	return data
+}
+
+// coversTeam - Whether a channel posts for senders of this team (team-scoped channels skip unknown teams)
+func (c *ChatChannel) coversTeam(team string) bool {
+	if len(c.Teams) == 0 {
+		return true
+	}
+	for _, t := range c.Teams {
+		if t == team {
+			return true
+		}
+	}
+	return false
+}
+
+// post - Sends text in the provider's incoming-webhook format
+func (s *ChatSink) post(channel *ChatChannel, text string) error {
+	var body interface{} = map[string]string{"text": text}
+	if channel.Provider == ChatProviderTeams {
+		body = map[string]string{
+			"@type":    "MessageCard",
+			"@context": "https://schema.org/extensions",
+			"text":     text,
+		}
+	}
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(channel.URL, "application/json", bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return errors.New("chat webhook responded with status " + resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+// DESIGN PATTERN: Strategy Pattern (HS256/RS256 signature check) + Claims-Based Authorization
+package services
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/keyring"
+	"strings"
+	"time"
+)
+
+// Claim assertion errors
+var (
+	ErrAssertionRequired = apperrors.New(apperrors.ErrUnauthorized, "assertion_required", "signed claim assertion required")
+	ErrAssertionInvalid  = apperrors.New(apperrors.ErrUnauthorized, "assertion_invalid", "claim assertion is invalid")
+	ErrAssertionExpired  = apperrors.New(apperrors.ErrUnauthorized, "assertion_expired", "claim assertion has expired")
+	ErrAssertionMismatch = apperrors.New(apperrors.ErrForbidden, "assertion_mismatch", "claim assertion does not match this transfer")
+)
+
+// ClaimAssertion - Verified claims of a JWT minted by the Auth Service when the receiver signs up or claims
+type ClaimAssertion struct {
+	Issuer     string       `json:"iss"`                   // Minting service
+	Audience   claimStrings `json:"aud"`                   // Intended recipient (this service)
+	ReceiverID string       `json:"sub"`                   // Authenticated receiver
+	ClaimToken string       `json:"claim_token"`           // Claim link token the receiver holds
+	TransferID string       `json:"transfer_id,omitempty"` // Optional: pins the assertion to one transfer
+	IssuedAt   int64        `json:"iat"`                   // Unix seconds
+	ExpiresAt  int64        `json:"exp"`                   // Unix seconds
+}
+
+// claimStrings - JWT claims like "aud" or "roles" that are either a string or an array of strings
+type claimStrings []string
+
+// UnmarshalJSON - Accepts both encodings
+func (a *claimStrings) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = claimStrings{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// contains - Whether the claim lists the given value
+func (a claimStrings) contains(value string) bool {
+	for _, entry := range a {
+		if entry == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ClaimAssertionVerifier - Checks signature, issuer, audience and lifetime of claim assertions
+type ClaimAssertionVerifier struct {
+	secrets   *keyring.Keyring        // HS256 keys by kid (empty disables HS256)
+	publicKey *rsa.PublicKey          // RS256 key (nil disables RS256)
+	config    config.AssertionsConfig // Expected issuer/audience, lifetime limits
+	clock     clock.Clock             // Composition: HAS-A time source
+}
+
+// NewClaimAssertionVerifier - Factory method loading the configured keys (fails on an unreadable key)
+func NewClaimAssertionVerifier(cfg *config.Config, clk clock.Clock) (*ClaimAssertionVerifier, error) {
+	secrets, err := hmacKeyring(cfg.Assertions.Secret, cfg.Assertions.Secrets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CLAIM_ASSERTION_SECRETS: %v", err)
+	}
+	v := &ClaimAssertionVerifier{secrets: secrets, config: cfg.Assertions, clock: clk}
+	if cfg.Assertions.PublicKeyPath != "" {
+		key, err := loadRSAPublicKey(cfg.Assertions.PublicKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		v.publicKey = key
+	}
+	if cfg.Assertions.Required && v.secrets.Empty() && v.publicKey == nil {
+		return nil, errors.New("claim assertions are required but no CLAIM_ASSERTION_SECRET(S) or CLAIM_ASSERTION_PUBLIC_KEY is set")
+	}
+	return v, nil
+}
+
+// Optional - Copy accepting completions without an assertion (signed ones are still fully verified)
+func (v *ClaimAssertionVerifier) Optional() *ClaimAssertionVerifier {
+	optional := *v
+	optional.config.Required = false
+	return &optional
+}
+
+// Verify - Parses and validates a compact JWT; an empty assertion is accepted only when not required
+func (v *ClaimAssertionVerifier) Verify(raw string) (*ClaimAssertion, error) {
+	if raw == "" {
+		if v.config.Required {
+			return nil, ErrAssertionRequired
+		}
+		return nil, nil
+	}
+
+	// 1. STRUCTURE: header.payload.signature
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrAssertionInvalid)
+	}
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: bad header", ErrAssertionInvalid)
+	}
+
+	// 2. SIGNATURE: Only algorithms with a configured key ("none" is never accepted)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: bad signature encoding", ErrAssertionInvalid)
+	}
+	if err := v.verifySignature(header.Algorithm, header.KeyID, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	// 3. CLAIMS: Issued for this service by the expected issuer
+	var assertion ClaimAssertion
+	if err := decodeSegment(parts[1], &assertion); err != nil {
+		return nil, fmt.Errorf("%w: bad payload", ErrAssertionInvalid)
+	}
+	if v.config.Issuer != "" && assertion.Issuer != v.config.Issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrAssertionInvalid)
+	}
+	if v.config.Audience != "" && !assertion.Audience.contains(v.config.Audience) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrAssertionInvalid)
+	}
+	if assertion.ReceiverID == "" || assertion.ClaimToken == "" {
+		return nil, fmt.Errorf("%w: missing receiver or claim token", ErrAssertionInvalid)
+	}
+
+	// 4. LIFETIME: Short-lived and currently valid
+	now := v.clock.Now()
+	issuedAt, expiresAt := time.Unix(assertion.IssuedAt, 0), time.Unix(assertion.ExpiresAt, 0)
+	if assertion.ExpiresAt == 0 || assertion.IssuedAt == 0 {
+		return nil, fmt.Errorf("%w: missing iat/exp", ErrAssertionInvalid)
+	}
+	if expiresAt.Sub(issuedAt) > v.config.MaxTTL {
+		return nil, fmt.Errorf("%w: lifetime exceeds %s", ErrAssertionInvalid, v.config.MaxTTL)
+	}
+	if issuedAt.After(now.Add(v.config.Leeway)) {
+		return nil, fmt.Errorf("%w: issued in the future", ErrAssertionInvalid)
+	}
+	if !now.Before(expiresAt.Add(v.config.Leeway)) {
+		return nil, ErrAssertionExpired
+	}
+	return &assertion, nil
+}
+
+// verifySignature - Strategy per algorithm
+func (v *ClaimAssertionVerifier) verifySignature(algorithm, keyID, signingInput string, signature []byte) error {
+	switch {
+	case algorithm == "HS256" && !v.secrets.Empty():
+		if _, ok := v.secrets.VerifyMAC(keyID, []byte(signingInput), signature); !ok {
+			return fmt.Errorf("%w: bad signature", ErrAssertionInvalid)
+		}
+		return nil
+	case algorithm == "RS256" && v.publicKey != nil:
+		digest := sha256.Sum256([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(v.publicKey, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("%w: bad signature", ErrAssertionInvalid)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unsupported algorithm %q", ErrAssertionInvalid, algorithm)
+}
+
+// hmacKeyring - Versioned HS256 secrets plus the unversioned legacy secret, which verifies tokens
+// without a kid (or naming an unlisted one) until the issuer has switched to versioned keys
+func hmacKeyring(legacy string, versioned []string) (*keyring.Keyring, error) {
+	keys, err := keyring.Parse(versioned)
+	if err != nil {
+		return nil, err
+	}
+	if legacy != "" {
+		keys = keyring.New(append(keys.Keys(), keyring.Key{Secret: []byte(legacy)})...)
+	}
+	return keys, nil
+}
+
+// decodeSegment - base64url (unpadded) JSON segment
+func decodeSegment(segment string, target interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, target)
+}
+
+// loadRSAPublicKey - Reads a PKIX ("PUBLIC KEY") or PKCS#1 ("RSA PUBLIC KEY") PEM file
+func loadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read claim assertion public key: %v", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("claim assertion public key is not PEM encoded")
+	}
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid claim assertion public key: %v", err)
+	}
+	key, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("claim assertion public key is not RSA")
+	}
+	return key, nil
+}
@@ -0,0 +1,89 @@
+// DESIGN PATTERN: Service Layer - Receiver declines a gift
+package services
+
+import (
+	"errors"
+	"sender-service/apperrors"
+	"sender-service/models"
+	"sender-service/repositories"
+)
+
+var ErrClaimNotDeclinable = apperrors.New(apperrors.ErrConflict, "claim_not_declinable", "only pending, unexpired claims can be declined")
+
+// DeclineClaim - Moves a pending transfer to declined, invalidates its claim link and tells the sender
+func (s *TransferService) DeclineClaim(token string, req models.DeclineTransferRequest, clientIP string) (*models.Transfer, error) {
+	located, err := s.transferRepo.FindByToken(token)
+	if err != nil {
+		return nil, transferLookupError(err)
+	}
+	if err := s.throttleReceiver(located); err != nil {
+		return nil, err
+	}
+
+	// 1. TOKEN ROTATION: The old link stops resolving once the decline commits
+	rotated, err := s.generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. STATE CHANGE: Under the row lock so a concurrent claim or expiry cannot slip in; the audit
+	// entry, event and sender email commit in the same transaction
+	var transfer *models.Transfer
+	now := s.clock.Now()
+	err = s.transferRepo.LockByID(located.ID, func(locked *repositories.TransferRepository, current *models.Transfer) error {
+		if current.Status != models.TransferStatusPending || !current.MatchesClaimToken(token) || !now.Before(current.ExpiresAt) {
+			return ErrClaimNotDeclinable
+		}
+		if err := s.settleDeduction(current, "receiver declined"); err != nil {
+			return err
+		}
+		if err := current.TransitionTo(models.TransferStatusDeclined); err != nil {
+			return ErrClaimNotDeclinable
+		}
+		// RETIREMENT: The link that was sent stays taken, so it can never be reissued to another transfer
+		if err := locked.RetireClaimToken(current); err != nil {
+			return errors.New("failed to decline transfer")
+		}
+		current.SetClaimToken(rotated) // Never sent anywhere: the declined transfer has no live link
+		if err := locked.Update(current); err != nil {
+			return errors.New("failed to decline transfer")
+		}
+		transfer = current
+
+		// 3. AUDIT + EVENTS: Same trail as any other status change
+		tx := *s
+		tx.transferRepo = locked
+		tx.audit(transfer, &models.TransferAuditLog{
+			ID:         s.ids.NewID("audit"),
+			TransferID: transfer.ID,
+			FromStatus: models.TransferStatusPending,
+			ToStatus:   models.TransferStatusDeclined,
+			ReasonCode: models.ReasonReceiverDeclined,
+			Actor:      "receiver",
+			ClientIP:   clientIP,
+			Note:       req.Note,
+			CreatedAt:  now,
+		})
+		tx.publish(models.EventTransferStatusChanged, transfer, models.TransferStatusChangedData{
+			TransferID: transfer.ID,
+			SenderID:   transfer.SenderID,
+			FromStatus: models.TransferStatusPending,
+			ToStatus:   models.TransferStatusDeclined,
+			ReasonCode: models.ReasonReceiverDeclined,
+			Actor:      "receiver",
+		})
+
+		// 4. OBSERVER PATTERN: Let the sender know the points stay with them
+		tx.queueEmail(transfer, transfer.SenderEmail, TemplateTransferDeclined, TransferDeclinedEmailData{
+			ReceiverName:  transfer.ReceiverName,
+			ReceiverEmail: transfer.ReceiverEmail,
+			Points:        transfer.Points,
+			Note:          req.Note,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
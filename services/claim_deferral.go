@@ -0,0 +1,89 @@
+// DESIGN PATTERN: Service Layer - Receiver "save for later"
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/models"
+	"sender-service/repositories"
+	"time"
+)
+
+var (
+	ErrAlreadyDeferred     = apperrors.New(apperrors.ErrConflict, "claim_already_deferred", "claim has already been deferred once")
+	ErrClaimNotDeferrable  = apperrors.New(apperrors.ErrConflict, "claim_not_deferrable", "only pending, unexpired claims can be deferred")
+	ErrClaimDeferralClosed = apperrors.New(apperrors.ErrForbidden, "claim_deferral_disabled", "claim deferral is disabled")
+)
+
+// ReasonReceiverDeferred - Audit reason for a receiver-initiated expiry extension
+const ReasonReceiverDeferred = "receiver_deferred"
+
+// DeferClaim - Extends a pending claim's expiry once, audits it and informs the sender
+func (s *TransferService) DeferClaim(token, clientIP string) (*models.Transfer, error) {
+	if s.config.Claims.DeferralExtension <= 0 {
+		return nil, ErrClaimDeferralClosed
+	}
+
+	located, err := s.transferRepo.FindByToken(token)
+	if err != nil {
+		return nil, transferLookupError(err)
+	}
+	if err := s.throttleReceiver(located); err != nil {
+		return nil, err
+	}
+
+	// 1-2. VALIDATION + PERSISTENCE: Under the row lock so a concurrent claim or expiry cannot be
+	// overwritten by this save; the audit entry and event commit with it
+	var (
+		transfer       *models.Transfer
+		previousExpiry time.Time
+	)
+	now := s.clock.Now()
+	err = s.transferRepo.LockByID(located.ID, func(locked *repositories.TransferRepository, current *models.Transfer) error {
+		// One deferral per transfer, and only before it expires
+		if current.DeferredAt != nil {
+			return ErrAlreadyDeferred
+		}
+		if current.Status != models.TransferStatusPending || !current.MatchesClaimToken(token) || !now.Before(current.ExpiresAt) {
+			return ErrClaimNotDeferrable
+		}
+
+		// Push the expiry out and record when the receiver deferred
+		previousExpiry = current.ExpiresAt
+		current.ExpiresAt = current.ExpiresAt.Add(s.config.Claims.DeferralExtension)
+		current.DeferredAt = &now
+		if err := locked.Update(current); err != nil {
+			return errors.New("failed to defer claim")
+		}
+		transfer = current
+
+		// 3. AUDIT: Status is unchanged; the reason code records the deferral
+		tx := *s
+		tx.transferRepo = locked
+		tx.audit(transfer, &models.TransferAuditLog{
+			ID:         s.ids.NewID("audit"),
+			TransferID: transfer.ID,
+			FromStatus: transfer.Status,
+			ToStatus:   transfer.Status,
+			ReasonCode: ReasonReceiverDeferred,
+			Actor:      "receiver",
+			ClientIP:   clientIP,
+			Note:       fmt.Sprintf("expiry extended from %s", previousExpiry.UTC().Format("2006-01-02T15:04:05Z")),
+			CreatedAt:  now,
+		})
+
+		// 4. EVENTS: Senders see the deferral in their digest; consumers get an event
+		tx.publish(models.EventTransferClaimDeferred, transfer, models.TransferClaimDeferredData{
+			TransferID: transfer.ID,
+			SenderID:   transfer.SenderID,
+			DeferredAt: now,
+			ExpiresAt:  transfer.ExpiresAt,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
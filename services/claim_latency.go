@@ -0,0 +1,125 @@
+// DESIGN PATTERN: Service Layer + Histogram metric (claim latency analytics)
+package services
+
+import (
+	"sender-service/clock"
+	"sender-service/repositories"
+	"sync"
+	"time"
+)
+
+// claimLatencyBounds - Histogram bucket upper bounds, chosen around the 24h claim window
+var claimLatencyBounds = []time.Duration{
+	5 * time.Minute, 15 * time.Minute, time.Hour, 3 * time.Hour, 6 * time.Hour,
+	12 * time.Hour, 24 * time.Hour, 48 * time.Hour, 72 * time.Hour, 7 * 24 * time.Hour,
+}
+
+// LatencyBucket - Cumulative count of claims at or below an upper bound
+type LatencyBucket struct {
+	LE      string  `json:"le"`      // Upper bound, e.g. "1h0m0s" or "+Inf"
+	Seconds float64 `json:"seconds"` // Upper bound in seconds (0 for +Inf)
+	Count   int64   `json:"count"`   // Claims with latency <= bound
+}
+
+// ClaimLatencyHistogram - Created->completed latency distribution
+type ClaimLatencyHistogram struct {
+	From        *time.Time      `json:"from,omitempty"` // Report range (nil for live metrics)
+	To          *time.Time      `json:"to,omitempty"`
+	Count       int64           `json:"count"`        // Completed transfers observed
+	MeanSeconds float64         `json:"mean_seconds"` // Average latency
+	P50         string          `json:"p50"`          // Bucket bound containing the median
+	P90         string          `json:"p90"`          // Bucket bound containing the 90th percentile
+	Buckets     []LatencyBucket `json:"buckets"`      // Cumulative buckets (Prometheus-style)
+}
+
+// ClaimLatencyService - Live histogram of claims since start plus historical reports from the database
+type ClaimLatencyService struct {
+	transferRepo *repositories.TransferRepository // Composition: HAS-A transfer store
+	clock        clock.Clock                      // Composition: HAS-A time source
+	mu           sync.Mutex                       // Guards the live counters
+	count        int64                            // Live: observed claims
+	sumSeconds   float64                          // Live: summed latency
+	buckets      []int64                          // Live: cumulative counts per bound
+}
+
+// NewClaimLatencyService - Factory method with dependency injection
+func NewClaimLatencyService(transferRepo *repositories.TransferRepository, clk clock.Clock) *ClaimLatencyService {
+	return &ClaimLatencyService{
+		transferRepo: transferRepo,
+		clock:        clk,
+		buckets:      make([]int64, len(claimLatencyBounds)),
+	}
+}
+
+// Observe - Records one claim's created->completed latency
+func (s *ClaimLatencyService) Observe(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.count++
+	s.sumSeconds += latency.Seconds()
+	for i, bound := range claimLatencyBounds {
+		if latency <= bound {
+			s.buckets[i]++
+		}
+	}
+}
+
+// Live - Histogram of claims completed since the process started
+func (s *ClaimLatencyService) Live() *ClaimLatencyHistogram {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return buildHistogram(s.count, s.sumSeconds, append([]int64(nil), s.buckets...))
+}
+
+// Report - Histogram of transfers completed in [from, to) (defaults: last 30 days)
+func (s *ClaimLatencyService) Report(from, to time.Time) (*ClaimLatencyHistogram, error) {
+	if to.IsZero() {
+		to = s.clock.Now()
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -30)
+	}
+	if !from.Before(to) {
+		return nil, ErrInvalidReportRange
+	}
+
+	counts, err := s.transferRepo.ClaimLatencyBuckets(claimLatencyBounds, from, to)
+	if err != nil {
+		return nil, err
+	}
+	histogram := buildHistogram(counts.Total, counts.SumSeconds, counts.Buckets)
+	histogram.From, histogram.To = &from, &to
+	return histogram, nil
+}
+
+// buildHistogram - Adds the +Inf bucket, mean and bucket-resolution percentiles
+func buildHistogram(count int64, sumSeconds float64, cumulative []int64) *ClaimLatencyHistogram {
+	histogram := &ClaimLatencyHistogram{Count: count, Buckets: make([]LatencyBucket, 0, len(cumulative)+1)}
+	if count > 0 {
+		histogram.MeanSeconds = sumSeconds / float64(count)
+	}
+	for i, bound := range claimLatencyBounds {
+		histogram.Buckets = append(histogram.Buckets, LatencyBucket{LE: bound.String(), Seconds: bound.Seconds(), Count: cumulative[i]})
+	}
+	histogram.Buckets = append(histogram.Buckets, LatencyBucket{LE: "+Inf", Count: count})
+
+	histogram.P50 = percentileBound(histogram.Buckets, count, 0.5)
+	histogram.P90 = percentileBound(histogram.Buckets, count, 0.9)
+	return histogram
+}
+
+// percentileBound - First bucket whose cumulative count reaches the quantile ("" without data)
+func percentileBound(buckets []LatencyBucket, count int64, quantile float64) string {
+	if count == 0 {
+		return ""
+	}
+	target := int64(quantile * float64(count))
+	for _, bucket := range buckets {
+		if bucket.Count >= target && bucket.Count > 0 {
+			return bucket.LE
+		}
+	}
+	return "+Inf"
+}
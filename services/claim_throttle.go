@@ -0,0 +1,80 @@
+// DESIGN PATTERN: Rate Limiter (fixed hourly windows per client IP and per receiver address)
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/repositories"
+	"strings"
+	"time"
+)
+
+// ErrClaimRateLimited - Too many claim attempts or previews from one IP or for one receiver this hour
+var ErrClaimRateLimited = apperrors.New(apperrors.ErrTooManyRequests, "claim_rate_limited", "too many claim attempts, please try again later")
+
+// claimRateWindow - Length of one counting window
+const claimRateWindow = time.Hour
+
+// ClaimThrottle - Slows down claim token guessing, both from one client and against one known receiver
+type ClaimThrottle struct {
+	repo     *repositories.ClaimRateRepository // Composition: HAS-A shared counters
+	clock    clock.Clock                       // Composition: HAS-A time source
+	perIP    int                               // CLAIM_RATE_LIMIT_PER_IP (0 = unlimited)
+	perEmail int                               // CLAIM_RATE_LIMIT_PER_EMAIL (0 = unlimited)
+}
+
+// NewClaimThrottle - Factory method with dependency injection
+func NewClaimThrottle(repo *repositories.ClaimRateRepository, clk clock.Clock, cfg *config.Config) *ClaimThrottle {
+	return &ClaimThrottle{repo: repo, clock: clk, perIP: cfg.Claims.RateLimitPerIP, perEmail: cfg.Claims.RateLimitPerEmail}
+}
+
+// AllowIP - Counts a claim attempt or preview from the client IP; ErrClaimRateLimited once over the limit
+func (t *ClaimThrottle) AllowIP(ip string) error {
+	return t.allow("ip", ip, t.perIP)
+}
+
+// AllowEmail - Counts a claim attempt or preview of a link addressed to the receiver; ErrClaimRateLimited once over the limit
+func (t *ClaimThrottle) AllowEmail(email string) error {
+	return t.allow("email", strings.ToLower(strings.TrimSpace(email)), t.perEmail)
+}
+
+// RetryAfter - Time until the current window closes and the counters start over
+func (t *ClaimThrottle) RetryAfter() time.Duration {
+	now := t.clock.Now().UTC()
+	return now.Truncate(claimRateWindow).Add(claimRateWindow).Sub(now)
+}
+
+// Purge - Drops closed windows (scheduled as claim_rate_purge)
+func (t *ClaimThrottle) Purge(ctx context.Context) error {
+	removed, err := t.repo.DeleteBefore(t.clock.Now().UTC().Truncate(claimRateWindow))
+	if err != nil {
+		return err
+	}
+	if removed > 0 {
+		fmt.Printf("Claim throttle: purged %d expired counter(s)\n", removed)
+	}
+	return nil
+}
+
+// allow - Increments the value's counter for this hour; counting failures let the request through
+// (the claim itself still needs the database, so an outage is not a bypass)
+func (t *ClaimThrottle) allow(kind, value string, limit int) error {
+	if limit <= 0 || value == "" {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(value))
+	count, err := t.repo.Increment(kind+":"+hex.EncodeToString(sum[:]), t.clock.Now().UTC().Truncate(claimRateWindow))
+	if err != nil {
+		fmt.Printf("Claim throttle: failed to count %s request: %v\n", kind, err)
+		return nil
+	}
+	if count > limit {
+		return ErrClaimRateLimited
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// DESIGN PATTERN: Service Layer
+package services
+
+import (
+	"errors"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/models"
+	"sender-service/repositories"
+	"strings"
+)
+
+// ConsentSourceClaimLink - Consent given by the receiver from the claim link
+const ConsentSourceClaimLink = "claim_link"
+
+// PromoBlock - Promotional content appended to claim emails for consenting receivers only
+type PromoBlock struct {
+	Headline string // Short title
+	Body     string // Plain text, escaped by the template
+	URL      string // Call-to-action link
+}
+
+// ConsentService - Records marketing consent and decides whether promotional content may be shown
+type ConsentService struct {
+	consentRepo *repositories.ConsentRepository // Composition: HAS-A consent store
+	clock       clock.Clock                     // Composition: HAS-A time source
+	promo       *PromoBlock                     // Configured promo (nil = none)
+}
+
+// NewConsentService - Factory method with dependency injection
+func NewConsentService(consentRepo *repositories.ConsentRepository, clk clock.Clock, cfg *config.Config) *ConsentService {
+	service := &ConsentService{consentRepo: consentRepo, clock: clk}
+	if cfg.Promo.Headline != "" {
+		service.promo = &PromoBlock{Headline: cfg.Promo.Headline, Body: cfg.Promo.Body, URL: cfg.Promo.URL}
+	}
+	return service
+}
+
+// Get - Recorded consent (defaults to no consent)
+func (s *ConsentService) Get(email string) *models.MarketingConsent {
+	email = strings.ToLower(strings.TrimSpace(email))
+	consent, err := s.consentRepo.FindByEmail(email)
+	if err != nil {
+		return &models.MarketingConsent{Email: email}
+	}
+	return consent
+}
+
+// Record - Stores an explicit opt-in or opt-out
+func (s *ConsentService) Record(email string, consented bool, source string) (*models.MarketingConsent, error) {
+	consent := s.Get(email)
+	consent.Consented = consented
+	consent.Source = source
+	if consent.CreatedAt.IsZero() {
+		consent.CreatedAt = s.clock.Now()
+	}
+	consent.UpdatedAt = s.clock.Now()
+
+	if err := s.consentRepo.Save(consent); err != nil {
+		return nil, errors.New("failed to save consent")
+	}
+	return consent, nil
+}
+
+// PromoFor - Promo block for a recipient, or nil without recorded consent (transactional email stays clean)
+func (s *ConsentService) PromoFor(email string) *PromoBlock {
+	if s.promo == nil || !s.Get(email).Consented {
+		return nil
+	}
+	return s.promo
+}
@@ -0,0 +1,174 @@
+// DESIGN PATTERN: Gateway Pattern + Strategy Pattern (block vs strip policy)
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sender-service/apperrors"
+	"sender-service/config"
+	"strings"
+	"time"
+)
+
+// Content scan policies for flagged URLs
+const (
+	ContentScanBlock = "block" // Reject the transfer
+	ContentScanStrip = "strip" // Remove flagged URLs and keep the rest of the message
+)
+
+// strippedLinkPlaceholder - Replaces flagged URLs inside the message
+const strippedLinkPlaceholder = "[link removed]"
+
+var (
+	ErrUnsafeContent      = apperrors.New(apperrors.ErrInvalidInput, "unsafe_content", "message contains links flagged as unsafe")
+	ErrContentScanOffline = apperrors.New(apperrors.ErrUnavailable, "content_scan_unavailable", "content scanning is unavailable, please try again later")
+)
+
+// urlPattern - Finds http(s) and bare www. links inside free text
+var urlPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"']+`)
+
+// URLReputationChecker - Strategy for looking up URL verdicts
+type URLReputationChecker interface {
+	Check(urls []string) (map[string]bool, error) // URL -> flagged
+}
+
+// HTTPURLReputationChecker - Gateway to an external URL reputation service
+type HTTPURLReputationChecker struct {
+	endpoint string       // Reputation service URL
+	apiKey   string       // Sent as X-API-Key when set
+	client   *http.Client // Shared HTTP client
+}
+
+// NewHTTPURLReputationChecker - Factory method for the HTTP reputation gateway
+func NewHTTPURLReputationChecker(endpoint, apiKey string, timeout time.Duration) *HTTPURLReputationChecker {
+	return &HTTPURLReputationChecker{endpoint: endpoint, apiKey: apiKey, client: &http.Client{Timeout: timeout}}
+}
+
+// Check - POSTs {"urls": [...]} and expects {"results": [{"url", "verdict"}]}; anything but "clean" is flagged
+func (c *HTTPURLReputationChecker) Check(urls []string) (map[string]bool, error) {
+	body, _ := json.Marshal(map[string][]string{"urls": urls})
+	req, err := http.NewRequest("POST", c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("url reputation service responded with status %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Results []struct {
+			URL     string `json:"url"`
+			Verdict string `json:"verdict"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode url reputation response: %v", err)
+	}
+
+	verdicts := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		verdicts[url] = true // Unanswered URLs are treated as flagged
+	}
+	for _, result := range response.Results {
+		verdicts[result.URL] = !strings.EqualFold(result.Verdict, "clean")
+	}
+	return verdicts, nil
+}
+
+// ScannedContent - Sender content after the scan policy was applied
+type ScannedContent struct {
+	Message string   // Message with flagged URLs stripped (strip policy)
+	Links   []string // Links that passed the scan
+	Flagged []string // URLs the reputation service flagged
+}
+
+// ContentScanner - Screens sender messages and links before they reach receivers' inboxes
+type ContentScanner struct {
+	checker  URLReputationChecker // Strategy: reputation lookup (nil disables scanning)
+	policy   string               // block or strip
+	failOpen bool                 // Deliver unscanned content when the service is down
+}
+
+// NewContentScanner - Factory method; scanning is disabled when no endpoint is configured
+func NewContentScanner(cfg *config.Config) *ContentScanner {
+	scanner := &ContentScanner{policy: cfg.ContentScan.Policy, failOpen: cfg.ContentScan.FailOpen}
+	if cfg.ContentScan.Endpoint != "" {
+		scanner.checker = NewHTTPURLReputationChecker(cfg.ContentScan.Endpoint, cfg.ContentScan.APIKey, cfg.ContentScan.Timeout)
+	}
+	return scanner
+}
+
+// Scan - Checks every URL in the message plus attached links and applies the policy
+func (s *ContentScanner) Scan(message string, links []string) (*ScannedContent, error) {
+	// 1. EXTRACTION: Collect unique URLs from free text and metadata
+	urls := uniqueURLs(append(urlPattern.FindAllString(message, -1), links...))
+	content := &ScannedContent{Message: message, Links: links}
+	if s.checker == nil || len(urls) == 0 {
+		return content, nil
+	}
+
+	// 2. REPUTATION LOOKUP: Fail closed unless configured otherwise
+	verdicts, err := s.checker.Check(urls)
+	if err != nil {
+		fmt.Printf("URL reputation check failed: %v\n", err)
+		if s.failOpen {
+			return content, nil
+		}
+		return nil, ErrContentScanOffline
+	}
+
+	for _, url := range urls {
+		if verdicts[url] {
+			content.Flagged = append(content.Flagged, url)
+		}
+	}
+	if len(content.Flagged) == 0 {
+		return content, nil
+	}
+
+	// 3. POLICY: Block outright, or strip only the flagged URLs
+	if s.policy != ContentScanStrip {
+		return nil, ErrUnsafeContent
+	}
+
+	content.Message = urlPattern.ReplaceAllStringFunc(message, func(url string) string {
+		if verdicts[url] {
+			return strippedLinkPlaceholder
+		}
+		return url
+	})
+	content.Links = nil
+	for _, link := range links {
+		if !verdicts[link] {
+			content.Links = append(content.Links, link)
+		}
+	}
+	return content, nil
+}
+
+// uniqueURLs - De-duplicates while keeping first-seen order
+func uniqueURLs(urls []string) []string {
+	seen := make(map[string]bool, len(urls))
+	unique := make([]string, 0, len(urls))
+	for _, url := range urls {
+		if !seen[url] {
+			seen[url] = true
+			unique = append(unique, url)
+		}
+	}
+	return unique
+}
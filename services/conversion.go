@@ -0,0 +1,112 @@
+// DESIGN PATTERN: Value Object + Registry Pattern (point program conversion rates)
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/config"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrUnsupportedConversion = apperrors.New(apperrors.ErrInvalidInput, "unsupported_conversion", "transfers between these point programs are not supported")
+
+// ConversionRate - "From source points = To target points" (e.g. 2 loyalty = 1 reward)
+type ConversionRate struct {
+	Source string  `json:"source_program"` // Program the sender spends
+	Target string  `json:"target_program"` // Program the receiver is credited in
+	From   int     `json:"from"`           // Source points per unit
+	To     int     `json:"to"`             // Target points per unit
+	Rate   float64 `json:"rate"`           // To / From, for display
+}
+
+// Convert - Target points for a source amount (rounded down)
+func (r ConversionRate) Convert(points int) int {
+	return points * r.To / r.From
+}
+
+// ConversionTable - Configured rates between point programs
+type ConversionTable struct {
+	defaultProgram string                    // Program used when a request names none
+	rates          map[string]ConversionRate // Keyed by source>target
+}
+
+// NewConversionTable - Factory method parsing POINT_CONVERSION_RATES ("loyalty>reward=2:1,...")
+func NewConversionTable(cfg *config.Config) *ConversionTable {
+	table := &ConversionTable{defaultProgram: cfg.Programs.Default, rates: map[string]ConversionRate{}}
+	for _, entry := range cfg.Programs.ConversionRates {
+		rate, err := parseConversionRate(entry)
+		if err != nil {
+			fmt.Printf("Warning: ignoring conversion rate %q: %v\n", entry, err)
+			continue
+		}
+		table.rates[rate.Source+">"+rate.Target] = rate
+	}
+	return table
+}
+
+// DefaultProgram - Program assumed when a request names none
+func (t *ConversionTable) DefaultProgram() string {
+	return t.defaultProgram
+}
+
+// Resolve - Rate for a program pair; same-program transfers convert 1:1
+func (t *ConversionTable) Resolve(source, target string) (ConversionRate, error) {
+	if source == target {
+		return ConversionRate{Source: source, Target: target, From: 1, To: 1, Rate: 1}, nil
+	}
+	rate, ok := t.rates[source+">"+target]
+	if !ok {
+		return ConversionRate{}, ErrUnsupportedConversion
+	}
+	return rate, nil
+}
+
+// Rates - Configured cross-program rates in stable order
+func (t *ConversionTable) Rates() []ConversionRate {
+	rates := make([]ConversionRate, 0, len(t.rates))
+	for _, rate := range t.rates {
+		rates = append(rates, rate)
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].Source != rates[j].Source {
+			return rates[i].Source < rates[j].Source
+		}
+		return rates[i].Target < rates[j].Target
+	})
+	return rates
+}
+
+// parseConversionRate - Parses "source>target=from:to"
+func parseConversionRate(entry string) (ConversionRate, error) {
+	pair, ratio, ok := strings.Cut(entry, "=")
+	if !ok {
+		return ConversionRate{}, errors.New("expected source>target=from:to")
+	}
+	source, target, ok := strings.Cut(pair, ">")
+	if !ok || strings.TrimSpace(source) == "" || strings.TrimSpace(target) == "" {
+		return ConversionRate{}, errors.New("expected source>target")
+	}
+	fromText, toText, ok := strings.Cut(ratio, ":")
+	if !ok {
+		return ConversionRate{}, errors.New("expected from:to ratio")
+	}
+	from, err := strconv.Atoi(strings.TrimSpace(fromText))
+	if err != nil || from <= 0 {
+		return ConversionRate{}, errors.New("from must be a positive integer")
+	}
+	to, err := strconv.Atoi(strings.TrimSpace(toText))
+	if err != nil || to <= 0 {
+		return ConversionRate{}, errors.New("to must be a positive integer")
+	}
+
+	return ConversionRate{
+		Source: strings.TrimSpace(source),
+		Target: strings.TrimSpace(target),
+		From:   from,
+		To:     to,
+		Rate:   float64(to) / float64(from),
+	}, nil
+}
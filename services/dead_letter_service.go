@@ -0,0 +1,273 @@
+// DESIGN PATTERN: Dead Letter Channel + Strategy Pattern (per-kind re-drive)
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/idgen"
+	"sender-service/models"
+	"sender-service/repositories"
+	"strconv"
+)
+
+// Dead-letter management errors
+var (
+	ErrDeadLetterNotFound  = apperrors.New(apperrors.ErrNotFound, "dead_letter_not_found", "dead letter not found")
+	ErrDeadLetterResolved  = apperrors.New(apperrors.ErrConflict, "dead_letter_resolved", "dead letter already resolved")
+	ErrNoDeadLetterRedrive = apperrors.New(apperrors.ErrConflict, "dead_letter_not_redrivable", "no re-drive handler registered for this kind")
+)
+
+// DeadLetterHandler - Strategy used to re-drive or drop a dead letter of a given kind
+type DeadLetterHandler interface {
+	Retry(deadLetter *models.DeadLetter) error   // Re-attempt delivery
+	Discard(deadLetter *models.DeadLetter) error // Release resources held by the message
+}
+
+// DeadLetterService - Records dead letters and lets operators retry or discard them
+type DeadLetterService struct {
+	repo     *repositories.DeadLetterRepository // Composition: HAS-A repository
+	handlers map[string]DeadLetterHandler       // Re-drive strategies keyed by kind
+	clock    clock.Clock                        // Composition: HAS-A time source
+	ids      idgen.Generator                    // Composition: HAS-A ID generator
+}
+
+// NewDeadLetterService - Factory method with dependency injection
+func NewDeadLetterService(repo *repositories.DeadLetterRepository, clk clock.Clock, ids idgen.Generator) *DeadLetterService {
+	return &DeadLetterService{repo: repo, handlers: map[string]DeadLetterHandler{}, clock: clk, ids: ids}
+}
+
+// Register - Plugs in the re-drive strategy for a kind (email, event, webhook)
+func (s *DeadLetterService) Register(kind string, handler DeadLetterHandler) {
+	s.handlers[kind] = handler
+}
+
+// Record - Stores a message that exhausted its automatic retries
+func (s *DeadLetterService) Record(kind, referenceID, payload, reason string, attempts int, history []models.DeadLetterAttempt) error {
+	deadLetter := &models.DeadLetter{
+		ID:             s.ids.NewID("dl"),
+		Kind:           kind,
+		ReferenceID:    referenceID,
+		Payload:        payload,
+		Reason:         reason,
+		Attempts:       attempts,
+		AttemptHistory: history,
+		Status:         models.DeadLetterOpen,
+	}
+	if err := s.repo.Create(deadLetter); err != nil {
+		return fmt.Errorf("failed to record %s dead letter for %s: %v", kind, referenceID, err)
+	}
+	fmt.Printf("Dead-lettered %s %s: %s\n", kind, referenceID, reason)
+	return nil
+}
+
+// List - Dead letters filtered by kind and status
+func (s *DeadLetterService) List(kind, status string, limit int) ([]models.DeadLetter, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return s.repo.List(kind, status, limit)
+}
+
+// ForTransfer - Every dead letter of one transfer (credits and outbox messages)
+func (s *DeadLetterService) ForTransfer(transferID string) ([]models.DeadLetter, error) {
+	return s.repo.FindByTransfer(transferID)
+}
+
+// Get - Single dead letter with its attempt history
+func (s *DeadLetterService) Get(id string) (*models.DeadLetter, error) {
+	deadLetter, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrDeadLetterNotFound
+	}
+	return deadLetter, nil
+}
+
+// OpenFor - Unresolved dead letter recorded for a message, if any
+func (s *DeadLetterService) OpenFor(kind, referenceID string) (*models.DeadLetter, error) {
+	deadLetter, err := s.repo.FindOpenByReference(kind, referenceID)
+	if err != nil {
+		return nil, ErrDeadLetterNotFound
+	}
+	return deadLetter, nil
+}
+
+// Retry - Re-drives an open dead letter; the outcome is appended to its attempt history
+func (s *DeadLetterService) Retry(id string) (*models.DeadLetter, error) {
+	deadLetter, handler, err := s.open(id)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt := models.DeadLetterAttempt{At: s.clock.Now(), Manual: true}
+	retryErr := handler.Retry(deadLetter)
+	if retryErr != nil {
+		attempt.Error = retryErr.Error()
+		deadLetter.Reason = retryErr.Error()
+	} else {
+		now := s.clock.Now()
+		deadLetter.Status = models.DeadLetterRetried
+		deadLetter.ResolvedAt = &now
+	}
+	deadLetter.Attempts++
+	deadLetter.AttemptHistory = append(deadLetter.AttemptHistory, attempt)
+
+	if err := s.repo.Update(deadLetter); err != nil {
+		return nil, err
+	}
+	if retryErr != nil {
+		return deadLetter, apperrors.ErrUpstream.WithMessage(fmt.Sprintf("retry failed: %v", retryErr))
+	}
+	return deadLetter, nil
+}
+
+// Discard - Drops an open dead letter without re-driving it
+func (s *DeadLetterService) Discard(id string) (*models.DeadLetter, error) {
+	deadLetter, handler, err := s.open(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := handler.Discard(deadLetter); err != nil {
+		return nil, fmt.Errorf("discard failed: %v", err)
+	}
+
+	now := s.clock.Now()
+	deadLetter.Status = models.DeadLetterDiscarded
+	deadLetter.ResolvedAt = &now
+	if err := s.repo.Update(deadLetter); err != nil {
+		return nil, err
+	}
+	return deadLetter, nil
+}
+
+// open - Loads an unresolved dead letter and its handler
+func (s *DeadLetterService) open(id string) (*models.DeadLetter, DeadLetterHandler, error) {
+	deadLetter, err := s.Get(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if deadLetter.Status != models.DeadLetterOpen {
+		return nil, nil, ErrDeadLetterResolved
+	}
+	handler, ok := s.handlers[deadLetter.Kind]
+	if !ok {
+		return nil, nil, ErrNoDeadLetterRedrive
+	}
+	return deadLetter, handler, nil
+}
+
+// EmailDeadLetterHandler - Re-sends the claim email for a dead-lettered transfer
+type EmailDeadLetterHandler struct {
+	transferRepo *repositories.TransferRepository // Composition: HAS-A repository
+	emailService *EmailService                    // Composition: HAS-A email service
+}
+
+// NewEmailDeadLetterHandler - Factory method with dependency injection
+func NewEmailDeadLetterHandler(transferRepo *repositories.TransferRepository, emailService *EmailService) *EmailDeadLetterHandler {
+	return &EmailDeadLetterHandler{transferRepo: transferRepo, emailService: emailService}
+}
+
+// Retry - Sends the claim email again if the transfer is still claimable
+func (h *EmailDeadLetterHandler) Retry(deadLetter *models.DeadLetter) error {
+	transfer, err := h.transferRepo.FindByID(deadLetter.ReferenceID)
+	if err != nil {
+		return errors.New("transfer not found")
+	}
+	if transfer.Status != models.TransferStatusPending {
+		return fmt.Errorf("transfer is %s, claim email no longer relevant", transfer.Status)
+	}
+	return h.emailService.SendTransferEmail(transfer)
+}
+
+// Discard - Nothing to release for emails
+func (h *EmailDeadLetterHandler) Discard(deadLetter *models.DeadLetter) error {
+	return nil
+}
+
+// EventDeadLetterHandler - Re-queues or drops dead outbox events
+type EventDeadLetterHandler struct {
+	outbox *repositories.OutboxRepository // Composition: HAS-A outbox store
+	clock  clock.Clock                    // Composition: HAS-A time source
+}
+
+// NewEventDeadLetterHandler - Factory method with dependency injection
+func NewEventDeadLetterHandler(outbox *repositories.OutboxRepository, clk clock.Clock) *EventDeadLetterHandler {
+	return &EventDeadLetterHandler{outbox: outbox, clock: clk}
+}
+
+// Retry - Puts the event back in the outbox; the relay delivers it (and unblocks its aggregate)
+func (h *EventDeadLetterHandler) Retry(deadLetter *models.DeadLetter) error {
+	id, err := strconv.ParseUint(deadLetter.ReferenceID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid outbox reference %s", deadLetter.ReferenceID)
+	}
+	return h.outbox.Requeue(uint(id), h.clock.Now())
+}
+
+// Discard - Drops the event so later events of the same transfer can flow
+func (h *EventDeadLetterHandler) Discard(deadLetter *models.DeadLetter) error {
+	id, err := strconv.ParseUint(deadLetter.ReferenceID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid outbox reference %s", deadLetter.ReferenceID)
+	}
+	return h.outbox.Discard(uint(id))
+}
+
+// CreditDeadLetterHandler - Re-applies the receiver credit of an auto-completed transfer
+type CreditDeadLetterHandler struct {
+	transferRepo *repositories.TransferRepository // Composition: HAS-A repository
+	auth         AuthGateway                      // Composition: HAS-A Auth Service gateway
+}
+
+// NewCreditDeadLetterHandler - Factory method with dependency injection
+func NewCreditDeadLetterHandler(transferRepo *repositories.TransferRepository, auth AuthGateway) *CreditDeadLetterHandler {
+	return &CreditDeadLetterHandler{transferRepo: transferRepo, auth: auth}
+}
+
+// Retry - Same idempotency key as the original credit, so a credit that did land is not repeated
+func (h *CreditDeadLetterHandler) Retry(deadLetter *models.DeadLetter) error {
+	transfer, err := h.transferRepo.FindByID(deadLetter.ReferenceID)
+	if err != nil {
+		return errors.New("transfer not found")
+	}
+	receiver, err := h.auth.GetUser(deadLetter.Payload)
+	if err != nil {
+		return errors.New("failed to get receiver details")
+	}
+	return h.auth.UpdateUserPoints(receiver.ID, receiver.Points+transfer.ConvertedPoints, "credit-"+transfer.ID)
+}
+
+// Discard - Credit was settled outside the service
+func (h *CreditDeadLetterHandler) Discard(deadLetter *models.DeadLetter) error {
+	return nil
+}
+
+// WebhookDeadLetterHandler - Re-opens a webhook delivery that exhausted its retries
+type WebhookDeadLetterHandler struct {
+	deliveries *repositories.WebhookDeliveryRepository // Composition: HAS-A delivery log
+	clock      clock.Clock                             // Composition: HAS-A time source
+}
+
+// NewWebhookDeadLetterHandler - Factory method with dependency injection
+func NewWebhookDeadLetterHandler(deliveries *repositories.WebhookDeliveryRepository, clk clock.Clock) *WebhookDeadLetterHandler {
+	return &WebhookDeadLetterHandler{deliveries: deliveries, clock: clk}
+}
+
+// Retry - Puts the delivery back on the retry schedule with a fresh attempt budget
+func (h *WebhookDeadLetterHandler) Retry(deadLetter *models.DeadLetter) error {
+	delivery, err := h.deliveries.FindByID(deadLetter.ReferenceID)
+	if err != nil {
+		return errors.New("webhook delivery not found")
+	}
+	delivery.Status = models.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = h.clock.Now()
+	return h.deliveries.Save(delivery)
+}
+
+// Discard - The delivery stays failed; the subscriber never receives the event
+func (h *WebhookDeadLetterHandler) Discard(deadLetter *models.DeadLetter) error {
+	return nil
+}
@@ -0,0 +1,107 @@
+// DESIGN PATTERN: Service Layer + Scheduled Job
+package services
+
+import (
+	"context"
+	"fmt"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/models"
+	"sender-service/repositories"
+	"time"
+)
+
+// digestPeriods - Lookback window per digest frequency
+var digestPeriods = map[string]time.Duration{
+	models.DigestDaily:  24 * time.Hour,
+	models.DigestWeekly: 7 * 24 * time.Hour,
+}
+
+// DigestService - Emails opted-in senders a summary of their recent transfers
+type DigestService struct {
+	preferenceRepo *repositories.PreferenceRepository // Composition: HAS-A preference store
+	transferRepo   *repositories.TransferRepository   // Composition: HAS-A transfer store
+	emailService   *EmailService                      // Composition: HAS-A email service
+	clock          clock.Clock                        // Composition: HAS-A time source
+	expiringWindow time.Duration                      // "Expiring soon" horizon
+}
+
+// NewDigestService - Factory method with dependency injection
+func NewDigestService(preferenceRepo *repositories.PreferenceRepository,
+	transferRepo *repositories.TransferRepository,
+	emailService *EmailService,
+	clk clock.Clock,
+	cfg *config.Config) *DigestService {
+	return &DigestService{
+		preferenceRepo: preferenceRepo,
+		transferRepo:   transferRepo,
+		emailService:   emailService,
+		clock:          clk,
+		expiringWindow: cfg.Digest.ExpiringSoonWindow,
+	}
+}
+
+// Run - Scheduler job: sends every digest that is due
+func (s *DigestService) Run(ctx context.Context) error {
+	for frequency, period := range digestPeriods {
+		now := s.clock.Now()
+		due, err := s.preferenceRepo.FindDueForDigest(frequency, now.Add(-period))
+		if err != nil {
+			return err
+		}
+
+		for i := range due {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err := s.send(&due[i], frequency, now.Add(-period), now); err != nil {
+				fmt.Printf("Failed to send %s digest to %s: %v\n", frequency, due[i].UserID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// send - Builds and delivers one digest, then records when it was sent
+func (s *DigestService) send(preference *models.NotificationPreference, frequency string, since, now time.Time) error {
+	transfers, err := s.transferRepo.FindForDigest(preference.UserID, since)
+	if err != nil {
+		return err
+	}
+
+	data := DigestEmailData{Period: frequency}
+	for _, transfer := range transfers {
+		item := DigestItem{ReceiverName: transfer.ReceiverName, ReceiverEmail: transfer.ReceiverEmail, Points: transfer.Points, Deferred: transfer.DeferredAt != nil}
+		switch {
+		case transfer.Status == models.TransferStatusCompleted:
+			item.When = transfer.UpdatedAt.UTC().Format("2006-01-02 15:04 MST")
+			data.Claimed = append(data.Claimed, item)
+		case transfer.Status == models.TransferStatusPending && transfer.ExpiresAt.Sub(now) <= s.expiringWindow:
+			item.When = transfer.ExpiresAt.UTC().Format("2006-01-02 15:04 MST")
+			data.ExpiringSoon = append(data.ExpiringSoon, item)
+		case transfer.Status == models.TransferStatusPending:
+			item.When = transfer.ExpiresAt.UTC().Format("2006-01-02 15:04 MST")
+			data.Pending = append(data.Pending, item)
+		}
+	}
+
+	// WINDOW: Advanced before sending so a crash after the send cannot repeat the digest next run;
+	// a failed send puts it back so the next run retries
+	previous := preference.LastDigestAt
+	preference.LastDigestAt = &now
+	if err := s.preferenceRepo.Save(preference); err != nil {
+		return err
+	}
+
+	// Nothing to report: skip the email but still advance the window
+	if len(data.Claimed)+len(data.Pending)+len(data.ExpiringSoon) > 0 {
+		if err := s.emailService.SendTemplate(preference.Email, TemplateSenderDigest, data); err != nil {
+			preference.LastDigestAt = previous
+			if saveErr := s.preferenceRepo.Save(preference); saveErr != nil {
+				fmt.Printf("Failed to reopen digest window for %s: %v\n", preference.UserID, saveErr)
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,390 @@
+// DESIGN PATTERN: Strategy Pattern (archive stores) + Retention Policy + Scheduled Job (purge) + Envelope Encryption (key rotation)
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/idgen"
+	"sender-service/keyring"
+	"sender-service/models"
+	"sender-service/repositories"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrEmailArchiveDisabled  = apperrors.New(apperrors.ErrForbidden, "email_archive_disabled", "email archive is disabled")
+	ErrArchivedEmailNotFound = apperrors.New(apperrors.ErrNotFound, "archived_email_not_found", "archived email not found")
+	ErrArchivedEmailCorrupt  = apperrors.New(apperrors.ErrInternal, "archived_email_corrupt", "archived email does not match its recorded digest")
+	ErrArchiveKeyUnavailable = apperrors.New(apperrors.ErrInternal, "archive_key_unavailable", "archived email is encrypted with a key that is no longer configured")
+)
+
+// emailArchivePurgeBatch - Expired copies deleted per purge query
+const emailArchivePurgeBatch = 500
+
+// EmailArchiveStore - Where archived copies are kept (local directory, S3 or GCS)
+type EmailArchiveStore interface {
+	Put(key string, message []byte) error
+	Get(key string) ([]byte, error) // ErrArchivedEmailNotFound when the key is missing
+	Delete(key string) error        // Missing keys are not an error
+}
+
+// NewEmailArchiveStore - Factory method selecting the store from configuration (nil when archiving is off)
+func NewEmailArchiveStore(cfg *config.Config, clk clock.Clock) (EmailArchiveStore, error) {
+	archive := cfg.EmailArchive
+	switch archive.Backend {
+	case "":
+		return nil, nil
+	case "local":
+		return &LocalArchiveStore{dir: archive.Dir}, nil
+	case "s3", "gcs":
+		return NewObjectArchiveStore(archive, clk)
+	}
+	return nil, fmt.Errorf("unknown EMAIL_ARCHIVE_BACKEND %q", archive.Backend)
+}
+
+// LocalArchiveStore - Copies as files below a directory (single-instance deployments, mounted volumes)
+type LocalArchiveStore struct {
+	dir string // Root directory
+}
+
+// Put - Writes the message, creating date directories as needed
+func (s *LocalArchiveStore) Put(key string, message []byte) error {
+	file := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(file), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(file, message, 0o600)
+}
+
+// Get - Reads a stored message
+func (s *LocalArchiveStore) Get(key string) ([]byte, error) {
+	message, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrArchivedEmailNotFound
+	}
+	return message, err
+}
+
+// Delete - Removes a stored message
+func (s *LocalArchiveStore) Delete(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path - File for a key (keys are generated by the archive, never taken from requests)
+func (s *LocalArchiveStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
+
+// EmailArchiveService - Keeps an audit copy (headers + body) of every sent email with per-template retention
+type EmailArchiveService struct {
+	repo      *repositories.EmailArchiveRepository // Composition: HAS-A archive index
+	store     EmailArchiveStore                    // Strategy: HAS-A copy store (nil = archiving off)
+	clock     clock.Clock                          // Composition: HAS-A time source
+	ids       idgen.Generator                      // Composition: HAS-A ID generator
+	from      string                               // From header of outgoing emails
+	prefix    string                               // Key prefix inside the store
+	keys      *keyring.Keyring                     // Encryption keys, primary first (empty stores plaintext)
+	retention time.Duration                        // Default retention (0 = forever)
+	rules     map[string]time.Duration             // Template -> retention
+}
+
+// NewEmailArchiveService - Factory method with dependency injection; retention rules and encryption keys are parsed here
+func NewEmailArchiveService(repo *repositories.EmailArchiveRepository, store EmailArchiveStore, clk clock.Clock, ids idgen.Generator,
+	cfg *config.Config) (*EmailArchiveService, error) {
+	rules := make(map[string]time.Duration, len(cfg.EmailArchive.RetentionRules))
+	for template, raw := range cfg.EmailArchive.RetentionRules {
+		retention, err := time.ParseDuration(raw)
+		if err != nil || retention < 0 {
+			return nil, fmt.Errorf("invalid EMAIL_ARCHIVE_RETENTION_RULES retention %q for %s", raw, template)
+		}
+		rules[template] = retention
+	}
+	keys, err := keyring.Parse(cfg.EmailArchive.EncryptionKeys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EMAIL_ARCHIVE_ENCRYPTION_KEYS: %v", err)
+	}
+	return &EmailArchiveService{
+		repo:      repo,
+		store:     store,
+		clock:     clk,
+		ids:       ids,
+		from:      cfg.Email.From,
+		prefix:    cfg.EmailArchive.Prefix,
+		keys:      keys,
+		retention: cfg.EmailArchive.Retention,
+		rules:     rules,
+	}, nil
+}
+
+// Enabled - Whether sent emails are archived
+func (s *EmailArchiveService) Enabled() bool {
+	return s.store != nil
+}
+
+// Archive - Stores a copy of an email the provider accepted; best-effort, the send already happened
+func (s *EmailArchiveService) Archive(to, templateName string, rendered *RenderedEmail) {
+	if !s.Enabled() {
+		return
+	}
+
+	// 1. MESSAGE: The headers the service sent, plus when and from which template; claim links are
+	// redacted so the copy cannot be used to claim the transfer
+	rendered = redactSecrets(rendered)
+	now := s.clock.Now()
+	id := s.ids.NewID("email")
+	headers := append([][2]string{
+		{"Date", now.UTC().Format(time.RFC1123Z)},
+		{"X-Email-Template", templateName},
+		{"X-Archive-ID", id},
+	}, messageHeaders(s.from, to, rendered.Subject)...)
+	composed, err := composeMessage(headers, rendered.HTML, plainText(rendered))
+	if err != nil {
+		fmt.Printf("Failed to compose archived %s email to %s: %v\n", templateName, to, err)
+		return
+	}
+	message := []byte(composed)
+
+	// 2. ENCRYPT: Recipient addresses and bodies are PII; sealed with the primary key when configured
+	keyID, stored := "", message
+	if !s.keys.Empty() {
+		var err error
+		if keyID, stored, err = s.keys.Seal(message); err != nil {
+			fmt.Printf("Failed to encrypt archived %s email to %s: %v\n", templateName, to, err)
+			return
+		}
+	}
+
+	// 3. STORE: Copy first, index second (an index row never points at a missing copy)
+	key := path.Join(s.prefix, now.UTC().Format("2006/01/02"), id+".eml")
+	if err := s.store.Put(key, stored); err != nil {
+		fmt.Printf("Failed to archive %s email to %s: %v\n", templateName, to, err)
+		return
+	}
+	archived := &models.ArchivedEmail{
+		ID:         id,
+		Recipient:  to,
+		Template:   templateName,
+		Subject:    rendered.Subject,
+		StorageKey: key,
+		KeyID:      keyID,
+		Size:       len(message),
+		SHA256:     sha256Hex(message),
+		SentAt:     now,
+		ExpiresAt:  s.expiresAt(templateName, now),
+	}
+	if err := s.repo.Create(archived); err != nil {
+		fmt.Printf("Failed to index archived email %s: %v\n", id, err)
+	}
+}
+
+// archiveRedaction - Stands in for a secret in archived copies
+const archiveRedaction = "[redacted]"
+
+// redactSecrets - Copy of the email with every secret replaced (the original is left as sent)
+func redactSecrets(rendered *RenderedEmail) *RenderedEmail {
+	redacted := &RenderedEmail{Subject: rendered.Subject, HTML: rendered.HTML, Text: rendered.Text}
+	for _, secret := range rendered.Secrets {
+		if secret == "" {
+			continue
+		}
+		redacted.Subject = strings.ReplaceAll(redacted.Subject, secret, archiveRedaction)
+		redacted.HTML = strings.ReplaceAll(redacted.HTML, secret, archiveRedaction)
+		redacted.Text = strings.ReplaceAll(redacted.Text, secret, archiveRedaction)
+	}
+	return redacted
+}
+
+// expiresAt - End of retention for a template (nil keeps the copy forever)
+func (s *EmailArchiveService) expiresAt(templateName string, sentAt time.Time) *time.Time {
+	retention, ok := s.rules[templateName]
+	if !ok {
+		retention = s.retention
+	}
+	if retention == 0 {
+		return nil
+	}
+	expiresAt := sentAt.Add(retention)
+	return &expiresAt
+}
+
+// List - Archived emails matching the query, newest first
+func (s *EmailArchiveService) List(filter repositories.EmailArchiveFilter) ([]models.ArchivedEmail, error) {
+	if !s.Enabled() {
+		return nil, ErrEmailArchiveDisabled
+	}
+	return s.repo.Find(filter)
+}
+
+// Open - An archived email and its stored message, verified against the recorded digest
+func (s *EmailArchiveService) Open(id string) (*models.ArchivedEmail, []byte, error) {
+	if !s.Enabled() {
+		return nil, nil, ErrEmailArchiveDisabled
+	}
+	archived, err := s.repo.FindByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrArchivedEmailNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	message, err := s.load(archived)
+	if err != nil {
+		return nil, nil, err
+	}
+	return archived, message, nil
+}
+
+// load - Reads and decrypts a stored copy, verified against the recorded digest
+func (s *EmailArchiveService) load(archived *models.ArchivedEmail) ([]byte, error) {
+	message, err := s.store.Get(archived.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	if archived.KeyID != "" {
+		message, err = s.keys.Open(archived.KeyID, message)
+		if errors.Is(err, keyring.ErrUnknownKey) {
+			return nil, ErrArchiveKeyUnavailable
+		}
+		if err != nil {
+			return nil, ErrArchivedEmailCorrupt
+		}
+	}
+	// INTEGRITY: A copy altered in the store must not be presented as what was sent
+	if sha256Hex(message) != archived.SHA256 {
+		return nil, ErrArchivedEmailCorrupt
+	}
+	return message, nil
+}
+
+// ArchiveRekeyResult - Outcome of re-encrypting the archive with the primary key
+type ArchiveRekeyResult struct {
+	KeyID    string `json:"key_id"`   // Primary key the copies now use
+	Rekeyed  int    `json:"rekeyed"`  // Copies re-encrypted (plaintext copies included)
+	Failed   int    `json:"failed"`   // Copies left as they were (retired key, corrupt or unreachable)
+	Retained int64  `json:"retained"` // Copies still not under the primary key afterwards
+}
+
+// Rekey - Re-encrypts every copy not under the primary key (including plaintext copies) so a retired
+// key can be removed from EMAIL_ARCHIVE_ENCRYPTION_KEYS. Each copy is written under a new storage key
+// before its row is repointed and the old copy deleted, so an interrupted run can simply be repeated.
+func (s *EmailArchiveService) Rekey(ctx context.Context, batchSize int) (*ArchiveRekeyResult, error) {
+	if !s.Enabled() {
+		return nil, ErrEmailArchiveDisabled
+	}
+	primary, err := s.keys.Primary()
+	if err != nil {
+		return nil, errors.New("EMAIL_ARCHIVE_ENCRYPTION_KEYS is empty; there is no key to re-encrypt with")
+	}
+
+	result := &ArchiveRekeyResult{KeyID: primary.ID}
+	lastID := ""
+	for {
+		batch, err := s.repo.FindNotKey(primary.ID, lastID, batchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		lastID = batch[len(batch)-1].ID
+
+		for i := range batch {
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			if err := s.rekey(&batch[i]); err != nil {
+				fmt.Printf("Failed to re-encrypt archived email %s: %v\n", batch[i].ID, err)
+				result.Failed++
+				continue
+			}
+			result.Rekeyed++
+		}
+	}
+
+	counts, err := s.repo.CountByKey()
+	if err != nil {
+		return result, err
+	}
+	for keyID, count := range counts {
+		if keyID != primary.ID {
+			result.Retained += count
+		}
+	}
+	return result, nil
+}
+
+// rekey - Re-encrypts one copy: new object first, then the row, then the old object
+func (s *EmailArchiveService) rekey(archived *models.ArchivedEmail) error {
+	// 1. DECRYPT: Verified like a download so corruption is never re-sealed as genuine
+	message, err := s.load(archived)
+	if err != nil {
+		return err
+	}
+
+	// 2. SEAL + STORE: Under a key-specific name so the old copy stays readable until the row moves
+	keyID, sealed, err := s.keys.Seal(message)
+	if err != nil {
+		return err
+	}
+	oldKey := archived.StorageKey
+	newKey := path.Join(path.Dir(oldKey), archived.ID+"."+url.PathEscape(keyID)+".eml")
+	if err := s.store.Put(newKey, sealed); err != nil {
+		return err
+	}
+
+	// 3. SWITCH: Repoint the row, then drop the old copy (a leftover object is harmless)
+	archived.StorageKey, archived.KeyID = newKey, keyID
+	if err := s.repo.UpdateStorage(archived); err != nil {
+		return err
+	}
+	if err := s.store.Delete(oldKey); err != nil {
+		fmt.Printf("Re-encrypted %s but failed to delete its old copy %s: %v\n", archived.ID, oldKey, err)
+	}
+	return nil
+}
+
+// Purge - Scheduler job: deletes copies past their retention, then their index rows
+func (s *EmailArchiveService) Purge(ctx context.Context) error {
+	purged := 0
+	for {
+		expired, err := s.repo.FindExpired(s.clock.Now(), emailArchivePurgeBatch)
+		if err != nil {
+			return err
+		}
+		for i := range expired {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err := s.store.Delete(expired[i].StorageKey); err != nil {
+				return fmt.Errorf("failed to purge archived email %s: %w", expired[i].ID, err)
+			}
+			if err := s.repo.Delete(&expired[i]); err != nil {
+				return err
+			}
+			purged++
+		}
+		if len(expired) < emailArchivePurgeBatch {
+			break
+		}
+	}
+
+	if purged > 0 {
+		fmt.Printf("Email archive purge: %d copy(ies) past retention deleted\n", purged)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+// DESIGN PATTERN: Adapter Pattern (S3 REST API, AWS Signature Version 4) for the email archive
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sender-service/clock"
+	"sender-service/config"
+	"strings"
+	"time"
+)
+
+// ObjectArchiveStore - Copies as objects in an S3 bucket; GCS is reached through its S3-compatible
+// XML API with HMAC keys, so one signer serves both
+type ObjectArchiveStore struct {
+	endpoint *url.URL     // Service URL (path-style requests: <endpoint>/<bucket>/<key>)
+	bucket   string       // Bucket name
+	signer   sigV4Signer  // Region and credentials
+	clock    clock.Clock  // Composition: HAS-A time source (request signing)
+	client   *http.Client // Shared HTTP client
+}
+
+// NewObjectArchiveStore - Factory method filling backend defaults (endpoint, region)
+func NewObjectArchiveStore(archive config.EmailArchiveConfig, clk clock.Clock) (*ObjectArchiveStore, error) {
+	if archive.Bucket == "" || archive.AccessKey == "" || archive.SecretKey == "" {
+		return nil, fmt.Errorf("EMAIL_ARCHIVE_BACKEND=%s needs a bucket, access key and secret key", archive.Backend)
+	}
+
+	region, endpoint := archive.Region, archive.Endpoint
+	if archive.Backend == "gcs" {
+		if region == "" {
+			region = "auto"
+		}
+		if endpoint == "" {
+			endpoint = "https://storage.googleapis.com"
+		}
+	} else {
+		if region == "" {
+			region = "us-east-1"
+		}
+		if endpoint == "" {
+			endpoint = "https://s3." + region + ".amazonaws.com"
+		}
+	}
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid EMAIL_ARCHIVE_ENDPOINT %q", endpoint)
+	}
+	return &ObjectArchiveStore{
+		endpoint: parsed,
+		bucket:   archive.Bucket,
+		signer:   sigV4Signer{region: region, service: "s3", accessKey: archive.AccessKey, secretKey: archive.SecretKey},
+		clock:    clk,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Put - Uploads the message (PUT Object)
+func (s *ObjectArchiveStore) Put(key string, message []byte) error {
+	resp, err := s.do(http.MethodPut, key, message)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("archive store responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Get - Downloads a message (GET Object)
+func (s *ObjectArchiveStore) Get(key string) ([]byte, error) {
+	resp, err := s.do(http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrArchivedEmailNotFound
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("archive store responded with status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Delete - Removes a message (DELETE Object; missing objects answer 204/404)
+func (s *ObjectArchiveStore) Delete(key string) error {
+	resp, err := s.do(http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("archive store responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// do - Sends a signed path-style request for one object
+func (s *ObjectArchiveStore) do(method, key string, body []byte) (*http.Response, error) {
+	// 1. REQUEST: <endpoint>/<bucket>/<key>, each segment escaped once
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	target := *s.endpoint
+	target.RawPath = strings.TrimRight(s.endpoint.EscapedPath(), "/") + "/" + url.PathEscape(s.bucket) + "/" + strings.Join(segments, "/")
+	target.Path, _ = url.PathUnescape(target.RawPath)
+
+	req, err := http.NewRequest(method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "message/rfc822")
+	}
+
+	// 2. SIGNATURE: AWS Signature Version 4 over host, payload hash and date
+	s.signer.sign(req, body, target.RawPath, s.clock.Now())
+	return s.client.Do(req)
+}
+
+// sigV4Signer - AWS Signature Version 4 for one service and region (S3 archive, SES sends)
+type sigV4Signer struct {
+	region    string // Signing region
+	service   string // s3, ses
+	accessKey string // Access key ID / HMAC key ID
+	secretKey string // Secret access key / HMAC secret
+}
+
+// sign - Adds the x-amz-* headers and the SigV4 Authorization header
+func (s sigV4Signer) sign(req *http.Request, body []byte, canonicalURI string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		"", // No query string
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + s.region + "/" + s.service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), day)
+	for _, part := range []string{s.region, s.service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+// sha256Hex - Lowercase hex SHA-256 digest
+func sha256Hex(data []byte) string {
+	digest := sha256.Sum256(data)
+	return hex.EncodeToString(digest[:])
+}
+
+// hmacSHA256 - One step of the SigV4 key derivation
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
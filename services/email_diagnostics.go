@@ -0,0 +1,233 @@
+// DESIGN PATTERN: Diagnostic Report (step-by-step SMTP conversation) for "emails aren't arriving" triage
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"sender-service/clock"
+	"sender-service/config"
+	"strings"
+	"time"
+)
+
+// SMTP diagnostic steps, in order
+const (
+	DiagnosticStepDNS     = "dns"     // SMTP_HOST resolves
+	DiagnosticStepMX      = "mx"      // Recipient domain accepts mail (informational)
+	DiagnosticStepConnect = "connect" // TCP connection, greeting and EHLO
+	DiagnosticStepTLS     = "tls"     // Implicit TLS (465) or STARTTLS
+	DiagnosticStepAuth    = "auth"    // SMTP AUTH with the configured credentials
+	DiagnosticStepSend    = "send"    // MAIL FROM, RCPT TO and DATA accepted
+)
+
+// emailDiagnosticsTimeout - Upper bound for one diagnostic send
+const emailDiagnosticsTimeout = 30 * time.Second
+
+// SMTPDiagnosticStep - Outcome of one stage of the test send
+type SMTPDiagnosticStep struct {
+	Name     string        `json:"name"`              // dns, mx, connect, tls, auth, send
+	OK       bool          `json:"ok"`                // Step succeeded
+	Skipped  bool          `json:"skipped,omitempty"` // Not applicable with this configuration
+	Duration time.Duration `json:"duration"`          // Time taken (ns)
+	Detail   string        `json:"detail,omitempty"`  // What was observed (addresses, TLS version, server replies)
+	Error    string        `json:"error,omitempty"`   // Failure as reported by the resolver or server
+}
+
+// SMTPDiagnosticReport - Result of POST /admin/email/test
+type SMTPDiagnosticReport struct {
+	Host       string               `json:"host"`                  // SMTP_HOST
+	Port       string               `json:"port"`                  // SMTP_PORT
+	From       string               `json:"from"`                  // Envelope sender
+	Recipient  string               `json:"recipient"`             // Test address
+	Delivered  bool                 `json:"delivered"`             // The server accepted the message for delivery
+	FailedStep string               `json:"failed_step,omitempty"` // First blocking step that failed
+	Steps      []SMTPDiagnosticStep `json:"steps"`                 // In conversation order
+}
+
+// EmailDiagnostics - Sends a test message with the live SMTP settings, recording every stage
+type EmailDiagnostics struct {
+	config *config.Config // Composition: HAS-A SMTP settings
+	clock  clock.Clock    // Composition: HAS-A time source
+}
+
+// NewEmailDiagnostics - Factory method with dependency injection
+func NewEmailDiagnostics(config *config.Config, clk clock.Clock) *EmailDiagnostics {
+	return &EmailDiagnostics{config: config, clock: clk}
+}
+
+// TestSend - Walks the SMTP conversation SMTPSender would have, stopping at the first blocking failure.
+// The test message bypasses throttling, quota and the archive.
+func (d *EmailDiagnostics) TestSend(ctx context.Context, to string) *SMTPDiagnosticReport {
+	ctx, cancel := context.WithTimeout(ctx, emailDiagnosticsTimeout)
+	defer cancel()
+
+	email := d.config.Email
+	report := &SMTPDiagnosticReport{Host: email.SMTPHost, Port: email.SMTPPort, From: email.From, Recipient: to}
+	addr := net.JoinHostPort(email.SMTPHost, email.SMTPPort)
+
+	// 1. DNS: The configured host resolves
+	if !d.step(report, DiagnosticStepDNS, true, func() (string, error) {
+		addresses, err := net.DefaultResolver.LookupHost(ctx, email.SMTPHost)
+		return strings.Join(addresses, ", "), err
+	}) {
+		return report
+	}
+
+	// 2. MX: The recipient's domain can receive mail (the relay may still accept, so never blocking)
+	d.step(report, DiagnosticStepMX, false, func() (string, error) {
+		_, domain, _ := strings.Cut(to, "@")
+		records, err := net.DefaultResolver.LookupMX(ctx, domain)
+		if err != nil {
+			return "", fmt.Errorf("%s has no usable MX records: %v", domain, err)
+		}
+		hosts := make([]string, len(records))
+		for i, record := range records {
+			hosts[i] = fmt.Sprintf("%s (pref %d)", strings.TrimSuffix(record.Host, "."), record.Pref)
+		}
+		return strings.Join(hosts, ", "), nil
+	})
+
+	// 3. CONNECT: TCP (TLS straight away on 465), greeting and EHLO
+	implicitTLS := email.SMTPPort == "465"
+	var client *smtp.Client
+	var conn net.Conn
+	if !d.step(report, DiagnosticStepConnect, true, func() (string, error) {
+		dialer := &net.Dialer{Timeout: 10 * time.Second}
+		var err error
+		if implicitTLS {
+			conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: email.SMTPHost}}).DialContext(ctx, "tcp", addr)
+		} else {
+			conn, err = dialer.DialContext(ctx, "tcp", addr)
+		}
+		if err != nil {
+			return "", err
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			conn.SetDeadline(deadline)
+		}
+		if client, err = smtp.NewClient(conn, email.SMTPHost); err != nil {
+			conn.Close()
+			return "", fmt.Errorf("greeting: %v", err)
+		}
+		if err := client.Hello("localhost"); err != nil {
+			return "", fmt.Errorf("EHLO: %v", err)
+		}
+		return fmt.Sprintf("connected to %s; extensions: %s", conn.RemoteAddr(), smtpExtensions(client)), nil
+	}) {
+		if client != nil {
+			client.Close()
+		}
+		return report
+	}
+	defer client.Close()
+
+	// 4. TLS: Credentials must never cross the wire in clear text
+	handshakeFailed := false
+	d.step(report, DiagnosticStepTLS, false, func() (string, error) {
+		if !implicitTLS {
+			if ok, _ := client.Extension("STARTTLS"); !ok {
+				return "", fmt.Errorf("server does not offer STARTTLS; mail and credentials travel unencrypted")
+			}
+			if err := client.StartTLS(&tls.Config{ServerName: email.SMTPHost}); err != nil {
+				handshakeFailed = true
+				return "", fmt.Errorf("STARTTLS: %v", err)
+			}
+		}
+		state, _ := client.TLSConnectionState()
+		return describeTLS(state), nil
+	})
+	if handshakeFailed {
+		// A failed STARTTLS leaves the session unusable
+		report.FailedStep = DiagnosticStepTLS
+		return report
+	}
+
+	// 5. AUTH: Same strategy as SMTPSender (PLAIN with the configured account, or none)
+	if email.GmailAddress == "" || email.GmailAppPass == "" {
+		report.Steps = append(report.Steps, SMTPDiagnosticStep{Name: DiagnosticStepAuth, OK: true, Skipped: true,
+			Detail: "no SMTP credentials configured (GMAIL_ADDRESS/GMAIL_APP_PASSWORD); sending unauthenticated"})
+	} else if !d.step(report, DiagnosticStepAuth, true, func() (string, error) {
+		if ok, mechanisms := client.Extension("AUTH"); !ok {
+			return "", fmt.Errorf("server does not offer AUTH")
+		} else if err := client.Auth(smtp.PlainAuth("", email.GmailAddress, email.GmailAppPass, email.SMTPHost)); err != nil {
+			return "", err
+		} else {
+			return fmt.Sprintf("authenticated as %s (server offers %s)", email.GmailAddress, mechanisms), nil
+		}
+	}) {
+		return report
+	}
+
+	// 6. SEND: Envelope and message accepted by the server
+	report.Delivered = d.step(report, DiagnosticStepSend, true, func() (string, error) {
+		if err := client.Mail(email.From); err != nil {
+			return "", fmt.Errorf("MAIL FROM <%s>: %v", email.From, err)
+		}
+		if err := client.Rcpt(to); err != nil {
+			return "", fmt.Errorf("RCPT TO <%s>: %v", to, err)
+		}
+		writer, err := client.Data()
+		if err != nil {
+			return "", fmt.Errorf("DATA: %v", err)
+		}
+		sentAt := d.clock.Now().UTC().Format(time.RFC1123Z)
+		text := "This is a test message from the Sender Service SMTP diagnostics (POST /admin/email/test), sent " + sentAt + "."
+		message, err := composeMessage(append(messageHeaders(email.From, to, "Sender Service SMTP test"), [2]string{"Date", sentAt}),
+			"<p>"+text+"</p>", text+"\n")
+		if err != nil {
+			return "", fmt.Errorf("DATA: %v", err)
+		}
+		if _, err := writer.Write([]byte(message)); err != nil {
+			return "", fmt.Errorf("DATA: %v", err)
+		}
+		if err := writer.Close(); err != nil {
+			return "", fmt.Errorf("message rejected: %v", err)
+		}
+		client.Quit()
+		return fmt.Sprintf("accepted for delivery to %s; check the inbox and spam folder", to), nil
+	})
+	return report
+}
+
+// step - Runs one stage and records it; a failed blocking stage becomes the report's failed step
+func (d *EmailDiagnostics) step(report *SMTPDiagnosticReport, name string, blocking bool, run func() (string, error)) bool {
+	started := d.clock.Now()
+	detail, err := run()
+	step := SMTPDiagnosticStep{Name: name, OK: err == nil, Duration: d.clock.Now().Sub(started), Detail: detail}
+	if err != nil {
+		step.Error = err.Error()
+		if blocking && report.FailedStep == "" {
+			report.FailedStep = name
+		}
+	}
+	report.Steps = append(report.Steps, step)
+	return err == nil
+}
+
+// smtpExtensions - EHLO keywords the server advertised that matter for sending
+func smtpExtensions(client *smtp.Client) string {
+	var offered []string
+	for _, extension := range []string{"STARTTLS", "AUTH", "SIZE", "8BITMIME", "SMTPUTF8"} {
+		if ok, params := client.Extension(extension); ok {
+			offered = append(offered, strings.TrimSpace(extension+" "+params))
+		}
+	}
+	if len(offered) == 0 {
+		return "none"
+	}
+	return strings.Join(offered, ", ")
+}
+
+// describeTLS - Negotiated version, cipher and server certificate
+func describeTLS(state tls.ConnectionState) string {
+	detail := fmt.Sprintf("%s, %s", tls.VersionName(state.Version), tls.CipherSuiteName(state.CipherSuite))
+	if len(state.PeerCertificates) > 0 {
+		cert := state.PeerCertificates[0]
+		detail += fmt.Sprintf("; certificate %s issued by %s, valid until %s",
+			cert.Subject.CommonName, cert.Issuer.CommonName, cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	return detail
+}
@@ -0,0 +1,67 @@
+// DESIGN PATTERN: Template Method Pattern (same render path as sending) + Null Object (placeholder receiver)
+package services
+
+import (
+	"fmt"
+	"sender-service/i18n"
+	"sender-service/models"
+)
+
+// Placeholders used when a preview leaves the receiver out
+const (
+	previewReceiverName  = "Your receiver"
+	previewReceiverEmail = "receiver@example.com"
+	previewToken         = "preview"
+)
+
+// PreviewClaimEmail - Renders the claim email the receiver would get for these inputs; nothing is
+// stored or sent, and the claim link points at a placeholder token
+func (s *TransferService) PreviewClaimEmail(senderID string, req models.EmailPreviewRequest) (*RenderedEmail, error) {
+	// 1. SERVICE INTEGRATION: The real email shows the sender's address
+	sender, err := s.auth.GetUser(senderID)
+	if err != nil {
+		return nil, ErrSenderUnavailable
+	}
+
+	// 2. VALIDATION: Same bundle and theme rules as initiation
+	if len(req.Items) > 0 {
+		total, err := bundlePoints(req.Items, req.Points)
+		if err != nil {
+			return nil, err
+		}
+		req.Points = total
+	}
+	if _, ok := LookupEmailTheme(req.Theme); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEmailTheme, req.Theme)
+	}
+
+	// 3. TRANSIENT ENTITY: Never persisted; placeholders fill what the sender has not typed yet
+	transfer := &models.Transfer{
+		SenderEmail:    sender.Email,
+		ReceiverName:   req.ReceiverName,
+		ReceiverEmail:  req.ReceiverEmail,
+		Points:         req.Points,
+		Message:        req.Message,
+		Links:          req.Links,
+		PassphraseHint: req.PassphraseHint,
+		Theme:          req.Theme,
+		Locale:         i18n.Match(req.Locale),
+		ExpiresAt:      s.experiments.Timing(senderID, s.clock.Now()).ExpiresAt,
+		Tenant:         sender.Tenant,
+		Token:          previewToken,
+	}
+	if transfer.ReceiverName == "" {
+		transfer.ReceiverName = previewReceiverName
+	}
+	if transfer.ReceiverEmail == "" {
+		transfer.ReceiverEmail = previewReceiverEmail
+	}
+	if len(req.Items) > 0 {
+		transfer.Metadata = &models.TransferMetadata{Bundle: req.Items}
+	}
+	if req.Protected {
+		transfer.PassphraseHash = previewToken // Only its presence matters: shows the passphrase notice
+	}
+
+	return s.emailService.RenderClaimEmail(transfer)
+}
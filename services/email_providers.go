@@ -0,0 +1,184 @@
+// DESIGN PATTERN: Strategy Pattern (SMTP / SendGrid / SES / Mailgun senders) + Factory Method
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sender-service/clock"
+	"sender-service/config"
+	"strings"
+	"time"
+)
+
+// emailAPITimeout - Upper bound for one provider API call
+const emailAPITimeout = 30 * time.Second
+
+// NewEmailSender - Factory method selecting the delivery backend from EMAIL_PROVIDER
+func NewEmailSender(cfg *config.Config, clk clock.Clock) (EmailSender, error) {
+	email := cfg.Email
+	switch email.Provider {
+	case "", "smtp":
+		return NewSMTPSender(cfg), nil
+	case "sendgrid":
+		if email.APIKey == "" {
+			return nil, fmt.Errorf("EMAIL_PROVIDER=sendgrid needs EMAIL_API_KEY")
+		}
+		return &SendGridSender{baseURL: emailAPIBase(cfg), apiKey: email.APIKey, from: email.From,
+			client: &http.Client{Timeout: emailAPITimeout}}, nil
+	case "mailgun":
+		if email.APIKey == "" || email.MailgunDomain == "" {
+			return nil, fmt.Errorf("EMAIL_PROVIDER=mailgun needs EMAIL_API_KEY and MAILGUN_DOMAIN")
+		}
+		return &MailgunSender{baseURL: emailAPIBase(cfg), domain: email.MailgunDomain, apiKey: email.APIKey, from: email.From,
+			client: &http.Client{Timeout: emailAPITimeout}}, nil
+	case "ses":
+		if email.SESRegion == "" || email.SESAccessKey == "" || email.SESSecretKey == "" {
+			return nil, fmt.Errorf("EMAIL_PROVIDER=ses needs SES_REGION, SES_ACCESS_KEY_ID and SES_SECRET_ACCESS_KEY")
+		}
+		return &SESSender{
+			baseURL: emailAPIBase(cfg),
+			signer:  sigV4Signer{region: email.SESRegion, service: "ses", accessKey: email.SESAccessKey, secretKey: email.SESSecretKey},
+			from:    email.From,
+			clock:   clk,
+			client:  &http.Client{Timeout: emailAPITimeout},
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown EMAIL_PROVIDER %q (smtp, sendgrid, ses or mailgun)", email.Provider)
+}
+
+// EmailProviderKey - Name sends are paced and counted under: the SMTP host, or the API host of the
+// configured provider (matches EMAIL_RATE_LIMITS keys and the quota report)
+func EmailProviderKey(cfg *config.Config) string {
+	switch cfg.Email.Provider {
+	case "sendgrid", "mailgun", "ses":
+		if base, err := url.Parse(emailAPIBase(cfg)); err == nil && base.Host != "" {
+			return base.Host
+		}
+	}
+	return cfg.Email.SMTPHost
+}
+
+// emailAPIBase - EMAIL_API_URL, or the provider's public endpoint
+func emailAPIBase(cfg *config.Config) string {
+	if cfg.Email.APIURL != "" {
+		return cfg.Email.APIURL
+	}
+	switch cfg.Email.Provider {
+	case "sendgrid":
+		return "https://api.sendgrid.com/v3"
+	case "mailgun":
+		return "https://api.mailgun.net/v3"
+	case "ses":
+		return "https://email." + cfg.Email.SESRegion + ".amazonaws.com"
+	}
+	return ""
+}
+
+// SendGridSender - Delivers through the SendGrid v3 Mail Send API (text/plain must precede text/html)
+type SendGridSender struct {
+	baseURL string       // https://api.sendgrid.com/v3
+	apiKey  string       // API key with Mail Send permission
+	from    string       // Verified sender address
+	client  *http.Client // Shared HTTP client
+}
+
+// Send - POST /mail/send (202 Accepted)
+func (s *SendGridSender) Send(to string, rendered *RenderedEmail) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"personalizations": []map[string]interface{}{{"to": []map[string]string{{"email": to}}}},
+		"from":             map[string]string{"email": s.from},
+		"subject":          rendered.Subject,
+		"content":          []map[string]string{{"type": "text/plain", "value": plainText(rendered)}, {"type": "text/html", "value": rendered.HTML}},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.baseURL+"/mail/send", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	return doEmailAPI(s.client, req, "SendGrid")
+}
+
+// MailgunSender - Delivers through the Mailgun Messages API
+type MailgunSender struct {
+	baseURL string       // https://api.mailgun.net/v3 (https://api.eu.mailgun.net/v3 for EU domains)
+	domain  string       // Sending domain
+	apiKey  string       // Private API key
+	from    string       // Sender address on the sending domain
+	client  *http.Client // Shared HTTP client
+}
+
+// Send - POST /<domain>/messages as a form (200 OK)
+func (s *MailgunSender) Send(to string, rendered *RenderedEmail) error {
+	form := url.Values{}
+	form.Set("from", s.from)
+	form.Set("to", to)
+	form.Set("subject", rendered.Subject)
+	form.Set("text", plainText(rendered))
+	form.Set("html", rendered.HTML)
+
+	req, err := http.NewRequest(http.MethodPost, s.baseURL+"/"+url.PathEscape(s.domain)+"/messages", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth("api", s.apiKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doEmailAPI(s.client, req, "Mailgun")
+}
+
+// SESSender - Delivers through the Amazon SES v2 SendEmail API, signed with SigV4
+type SESSender struct {
+	baseURL string       // https://email.<region>.amazonaws.com
+	signer  sigV4Signer  // Region and IAM credentials (service "ses")
+	from    string       // Verified identity
+	clock   clock.Clock  // Composition: HAS-A time source (request signing)
+	client  *http.Client // Shared HTTP client
+}
+
+// Send - POST /v2/email/outbound-emails with simple (subject + HTML) content (200 OK)
+func (s *SESSender) Send(to string, rendered *RenderedEmail) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"FromEmailAddress": s.from,
+		"Destination":      map[string][]string{"ToAddresses": {to}},
+		"Content": map[string]interface{}{
+			"Simple": map[string]interface{}{
+				"Subject": map[string]string{"Data": rendered.Subject, "Charset": "UTF-8"},
+				"Body": map[string]interface{}{
+					"Text": map[string]string{"Data": plainText(rendered), "Charset": "UTF-8"},
+					"Html": map[string]string{"Data": rendered.HTML, "Charset": "UTF-8"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.baseURL+"/v2/email/outbound-emails", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.signer.sign(req, body, req.URL.EscapedPath(), s.clock.Now())
+	return doEmailAPI(s.client, req, "SES")
+}
+
+// doEmailAPI - Sends the request; any non-2xx answer becomes an error carrying the provider's message
+func doEmailAPI(client *http.Client, req *http.Request, provider string) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("%s responded with status %d: %s", provider, resp.StatusCode, strings.TrimSpace(string(detail)))
+}
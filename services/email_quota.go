@@ -0,0 +1,153 @@
+// DESIGN PATTERN: Service Layer + Priority gate (claim emails first, bulk mail deferred near quota)
+package services
+
+import (
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/repositories"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrEmailDeferred = apperrors.New(apperrors.ErrUnavailable, "email_deferred", "email deferred: provider is near its daily quota")
+
+// nonUrgentTemplates - Bulk/informational emails that may wait for the next quota day
+var nonUrgentTemplates = map[string]bool{
+	TemplateSenderDigest:       true,
+	TemplateStaleTransferNudge: true,
+}
+
+// EmailQuotaStatus - Admin view of one provider's usage today
+type EmailQuotaStatus struct {
+	Provider  string `json:"provider"`
+	Day       string `json:"day"`
+	Sent      int    `json:"sent"`
+	Deferred  int    `json:"deferred"`
+	Quota     int    `json:"quota"`     // 0 when the provider has no known daily cap
+	Remaining int    `json:"remaining"` // -1 when unlimited
+	NearQuota bool   `json:"near_quota"`
+}
+
+// EmailQuota - Tracks daily sends per provider and holds back non-urgent email near the cap
+type EmailQuota struct {
+	repo     *repositories.EmailQuotaRepository // Composition: HAS-A counter store
+	throttle *EmailThrottle                     // Composition: HAS-A provider limits
+	clock    clock.Clock                        // Composition: HAS-A time source
+	deferAt  float64                            // Share of the quota after which non-urgent mail waits
+	mu       sync.Mutex                         // Guards alerted
+	alerted  map[string]string                  // Provider -> day the near-quota alert was logged
+}
+
+// NewEmailQuota - Factory method with dependency injection
+func NewEmailQuota(repo *repositories.EmailQuotaRepository, throttle *EmailThrottle, clk clock.Clock, cfg *config.Config) *EmailQuota {
+	return &EmailQuota{
+		repo:     repo,
+		throttle: throttle,
+		clock:    clk,
+		deferAt:  cfg.Email.QuotaDeferAt,
+		alerted:  map[string]string{},
+	}
+}
+
+// Admit - Whether a template may be sent now (urgent emails always pass)
+func (q *EmailQuota) Admit(provider, templateName string) error {
+	if !nonUrgentTemplates[templateName] {
+		return nil
+	}
+
+	status, err := q.Status(provider)
+	if err != nil {
+		return nil // Counting must never block mail
+	}
+	if status.NearQuota {
+		q.repo.IncrementDeferred(status.Provider, status.Day)
+		return ErrEmailDeferred
+	}
+	return nil
+}
+
+// Record - Counts a successful delivery and alerts once per day when the provider nears its cap
+func (q *EmailQuota) Record(provider string) {
+	provider = strings.ToLower(provider)
+	day := q.today()
+	if err := q.repo.IncrementSent(provider, day); err != nil {
+		fmt.Printf("Failed to count email for %s: %v\n", provider, err)
+		return
+	}
+
+	status, err := q.Status(provider)
+	if err != nil || !status.NearQuota {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.alerted[provider] != day {
+		q.alerted[provider] = day
+		fmt.Printf("ALERT: email provider %s used %d of %d daily sends; deferring digests and reminders\n",
+			provider, status.Sent, status.Quota)
+	}
+}
+
+// Status - Today's usage for one provider
+func (q *EmailQuota) Status(provider string) (*EmailQuotaStatus, error) {
+	provider = strings.ToLower(provider)
+	day := q.today()
+	row, err := q.repo.Find(provider, day)
+	if err != nil {
+		return nil, err
+	}
+	return q.status(provider, day, row.Sent, row.Deferred), nil
+}
+
+// Snapshot - Today's usage for every provider that sent mail, plus configured providers
+func (q *EmailQuota) Snapshot(configured string) ([]EmailQuotaStatus, error) {
+	day := q.today()
+	rows, err := q.repo.FindDay(day)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []EmailQuotaStatus{}
+	seen := false
+	configured = strings.ToLower(configured)
+	for _, row := range rows {
+		statuses = append(statuses, *q.status(row.Provider, day, row.Sent, row.Deferred))
+		seen = seen || row.Provider == configured
+	}
+	if !seen {
+		statuses = append(statuses, *q.status(configured, day, 0, 0))
+	}
+	return statuses, nil
+}
+
+// status - Combines counters with the provider's daily cap
+func (q *EmailQuota) status(provider, day string, sent, deferred int) *EmailQuotaStatus {
+	status := &EmailQuotaStatus{Provider: provider, Day: day, Sent: sent, Deferred: deferred, Remaining: -1}
+	if quota, ok := q.throttle.DailyQuota(provider); ok {
+		status.Quota = quota
+		status.Remaining = quota - sent
+		if status.Remaining < 0 {
+			status.Remaining = 0
+		}
+		status.NearQuota = float64(sent) >= q.deferAt*float64(quota)
+	}
+	return status
+}
+
+// today - Quota days are UTC calendar days
+func (q *EmailQuota) today() string {
+	return q.clock.Now().UTC().Format("2006-01-02")
+}
+
+// DailyQuota - Sends per day implied by the provider's rate limit
+func (t *EmailThrottle) DailyQuota(provider string) (int, bool) {
+	limit, ok := t.limits[strings.ToLower(provider)]
+	if !ok {
+		return 0, false
+	}
+	return int(float64(limit.Limit) * float64(24*time.Hour) / float64(limit.Per)), true
+}
@@ -2,24 +2,224 @@
 package services
 
 import (
+	"bytes"
 	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
 	"net/smtp"
+	"net/textproto"
+	"sender-service/clock"
 	"sender-service/config"
+	"sender-service/i18n"
 	"sender-service/models"
+	"strings"
 )
 
+// EmailSender - Delivers one rendered email (SMTP, SendGrid, SES or Mailgun per EMAIL_PROVIDER; tests and
+// embedders may substitute their own)
+type EmailSender interface {
+	Send(to string, email *RenderedEmail) error
+}
+
 // EmailService - Handles email operations with configurable strategies
 type EmailService struct {
-	config *config.Config // Composition: HAS-A configuration
+	config   *config.Config       // Composition: HAS-A configuration
+	sender   EmailSender          // Strategy: HAS-A delivery channel
+	throttle *EmailThrottle       // Composition: HAS-A per-provider send pacing
+	quota    *EmailQuota          // Composition: HAS-A daily quota tracker
+	consent  *ConsentService      // Composition: HAS-A marketing consent lookup
+	monitor  *HealthMonitor       // Composition: HAS-A provider health observer (email circuit)
+	archive  *EmailArchiveService // Composition: HAS-A audit copy archive
+	clock    clock.Clock          // Composition: HAS-A time source (send latency)
 }
 
 // NewEmailService - Factory method with dependency injection
-func NewEmailService(config *config.Config) *EmailService {
-	return &EmailService{config: config}
+func NewEmailService(config *config.Config, sender EmailSender, throttle *EmailThrottle, quota *EmailQuota, consent *ConsentService,
+	monitor *HealthMonitor, archive *EmailArchiveService, clk clock.Clock) *EmailService {
+	return &EmailService{config: config, sender: sender, throttle: throttle, quota: quota, consent: consent, monitor: monitor, archive: archive, clock: clk}
+}
+
+// Deferring - True while the email circuit is open: notifications wait in the outbox until the provider recovers
+func (s *EmailService) Deferring() bool {
+	return s.monitor.DependencyDegraded(DependencyEmail)
 }
 
 // SendTransferEmail - Sends email notification for point transfers
 func (s *EmailService) SendTransferEmail(transfer *models.Transfer) error {
+	rendered, err := s.RenderClaimEmail(transfer)
+	if err != nil {
+		return err
+	}
+	rendered.Secrets = []string{transfer.Token} // Only the hash is kept at rest, archive included
+
+	if err := s.send(transfer.ReceiverEmail, TemplateTransferClaim, rendered); err != nil {
+		return err
+	}
+
+	fmt.Printf("Claim email sent for %s\n", transfer.ID) // The claim URL carries the raw token: never logged
+	return nil
+}
+
+// RenderClaimEmail - Renders the receiver's claim invitation in the transfer's tenant branding
+// (shared by sending and the preview API)
+func (s *EmailService) RenderClaimEmail(transfer *models.Transfer) (*RenderedEmail, error) {
+	//  TEMPLATE METHOD PATTERN: HTML email template
+	return RenderTenantEmail(transfer.Tenant, TemplateTransferClaim, ClaimEmailData{
+		ReceiverName:   transfer.ReceiverName,
+		ReceiverEmail:  transfer.ReceiverEmail,
+		SenderEmail:    transfer.SenderEmail,
+		Points:         transfer.Points,
+		ClaimURL:       s.claimURL(transfer),
+		Message:        transfer.Message,
+		Links:          transfer.Links,
+		Bundle:         transfer.Bundle(),
+		Protected:      transfer.PassphraseProtected(),
+		PassphraseHint: transfer.PassphraseHint,
+		Promo:          s.consent.PromoFor(transfer.ReceiverEmail),
+		Theme:          transfer.Theme,
+		Locale:         transfer.Locale,
+		ExpiresAt:      transfer.ExpiresAt,
+
+		TrackingPixelURL: s.trackingURL(transfer, "open.gif"),
+	})
+}
+
+// claimURL - FRONTEND INTEGRATION: Claim link with hash routing for SPA, plus ?lang= for receivers who
+// do not read English (routed through the click redirect when engagement tracking is enabled)
+func (s *EmailService) claimURL(transfer *models.Transfer) string {
+	if tracked := s.trackingURL(transfer, "click"); tracked != "" {
+		return tracked + langQuery(transfer.Locale)
+	}
+	return fmt.Sprintf("%s/#/claim/%s", s.config.Frontend.URL, transfer.Token) + langQuery(transfer.Locale)
+}
+
+// langQuery - "?lang=<locale>" for supported non-English locales, "" otherwise
+func langQuery(locale string) string {
+	if locale = i18n.Match(locale); locale == "" || locale == i18n.DefaultLocale {
+		return ""
+	}
+	return "?lang=" + locale
+}
+
+// trackingURL - ENGAGEMENT TRACKING: Open pixel or click redirect for the transfer ("" when disabled)
+func (s *EmailService) trackingURL(transfer *models.Transfer, endpoint string) string {
+	if s.config.Email.TrackingURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/track/%s/%s", s.config.Email.TrackingURL, transfer.Token, endpoint)
+}
+
+// PointsReceivedData - Template data telling a registered receiver that points were credited automatically
+func (s *EmailService) PointsReceivedData(transfer *models.Transfer) PointsReceivedEmailData {
+	return PointsReceivedEmailData{
+		ReceiverName: transfer.ReceiverName,
+		SenderEmail:  transfer.SenderEmail,
+		Points:       transfer.ConvertedPoints,
+		Program:      transfer.TargetProgram,
+		Message:      transfer.Message,
+		DashboardURL: s.config.Frontend.URL,
+	}
+}
+
+// SendTemplate - Renders a registered template and sends it to a single recipient
+func (s *EmailService) SendTemplate(to, templateName string, data interface{}) error {
+	// QUOTA: Digests and reminders wait for the next day when the provider is near its cap
+	if err := s.quota.Admit(EmailProviderKey(s.config), templateName); err != nil {
+		return err
+	}
+
+	rendered, err := RenderEmail(templateName, data)
+	if err != nil {
+		return err
+	}
+	return s.send(to, templateName, rendered)
+}
+
+// send - Delivers a rendered email through the sender, paced and counted per provider
+func (s *EmailService) send(to, templateName string, rendered *RenderedEmail) error {
+	// EMAIL DELIVERY: Paced by the provider's rate limit
+	provider := EmailProviderKey(s.config)
+	err := s.throttle.Do(provider, func() error {
+		// CIRCUIT: Only the provider call is observed, not the time spent waiting for a send slot
+		start := s.clock.Now()
+		sendErr := s.sender.Send(to, rendered)
+		s.monitor.Observe(DependencyEmail, s.clock.Now().Sub(start), sendErr)
+		return sendErr
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to send email to %s: %v", to, err)
+	}
+
+	s.quota.Record(provider)
+	s.archive.Archive(to, templateName, rendered)
+	fmt.Printf(" Email sent successfully to: %s (%s)\n", to, templateName)
+	return nil
+}
+
+// messageHeaders - Headers of every outgoing email, in a stable order (the archive copy records the same ones);
+// composeMessage adds the multipart Content-Type
+func messageHeaders(from, to, subject string) [][2]string {
+	// EMAIL HEADERS: Professional email formatting (RFC 2047 encoded subject when not plain ASCII)
+	return [][2]string{
+		{"From", from},
+		{"To", to},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"MIME-Version", "1.0"},
+		{"X-Priority", "1"},
+		{"Importance", "high"},
+	}
+}
+
+// composeMessage - Header block, blank line, multipart/alternative body: text/plain first, then the
+// preferred text/html, both quoted-printable
+func composeMessage(headers [][2]string, html, text string) (string, error) {
+	// 1. PARTS: Clients show the last part they can render
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range [][2]string{{"text/plain", text}, {"text/html", html}} {
+		writer, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part[0] + "; charset=\"utf-8\""},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return "", err
+		}
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err := encoder.Write([]byte(part[1])); err != nil {
+			return "", err
+		}
+		if err := encoder.Close(); err != nil {
+			return "", err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return "", err
+	}
+
+	// 2. HEADERS: The boundary is only known once the writer exists
+	var message strings.Builder
+	for _, header := range headers {
+		fmt.Fprintf(&message, "%s: %s\r\n", header[0], header[1])
+	}
+	fmt.Fprintf(&message, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", parts.Boundary())
+	message.Write(body.Bytes())
+	return message.String(), nil
+}
+
+// SMTPSender - Default EmailSender: RFC-formatted HTML mail over SMTP
+type SMTPSender struct {
+	config *config.Config // Composition: HAS-A SMTP settings
+}
+
+// NewSMTPSender - Factory method with dependency injection
+func NewSMTPSender(config *config.Config) *SMTPSender {
+	return &SMTPSender{config: config}
+}
+
+// Send - Wraps a rendered email in headers and delivers it via SMTP
+func (s *SMTPSender) Send(to string, rendered *RenderedEmail) error {
 	// STRATEGY PATTERN: Different authentication strategies
 	var auth smtp.Auth
 
@@ -33,135 +233,18 @@ func (s *EmailService) SendTransferEmail(transfer *models.Transfer) error {
 		auth = nil
 	}
 
-	// FRONTEND INTEGRATION: Generate claim URL with hash routing for SPA
-	claimURL := fmt.Sprintf("%s/#/claim/%s", s.config.Frontend.URL, transfer.Token)
-
-	subject := "You've Received Virtual Points!"
-
-	//  TEMPLATE METHOD PATTERN: HTML email template
-	body := fmt.Sprintf(`
-<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="utf-8">
-    <style>
-        body { 
-            font-family: 'Segoe UI', Tahoma, Geneva, Verdana, sans-serif; 
-            line-height: 1.6; 
-            color: #333; 
-            max-width: 600px; 
-            margin: 0 auto; 
-            padding: 20px;
-            background: #f5f5f5;
-        }
-        .container {
-            background: white;
-            border-radius: 10px;
-            overflow: hidden;
-            box-shadow: 0 4px 6px rgba(0,0,0,0.1);
-        }
-        .header { 
-            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%); 
-            color: white; 
-            padding: 30px; 
-            text-align: center; 
-        }
-        .content { 
-            padding: 30px; 
-        }
-        .button { 
-            display: inline-block; 
-            padding: 15px 30px; 
-            background: #667eea; 
-            color: white; 
-            text-decoration: none; 
-            border-radius: 5px; 
-            margin: 20px 0; 
-            font-size: 16px;
-            font-weight: bold;
-        }
-        .points { 
-            font-size: 24px; 
-            font-weight: bold; 
-            color: #667eea; 
-        }
-        .footer { 
-            text-align: center; 
-            padding: 20px; 
-            color: #666; 
-            font-size: 14px;
-            background: #f9f9f9;
-            border-top: 1px solid #eee;
-        }
-        .info-box {
-            background: #fff3cd;
-            padding: 15px;
-            border-radius: 5px;
-            margin: 20px 0;
-            border-left: 4px solid #ffc107;
-        }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <div class="header">
-            <h1> You've Received Virtual Points!</h1>
-        </div>
-        <div class="content">
-            <p>Hello <strong>%s</strong>,</p>
-            <p>Great news! You have received <span class="points">%d virtual points</span> from <strong>%s</strong>.</p>
-            
-            <div style="text-align: center;">
-                <a href="%s" class="button">Claim Your Points Now</a>
-            </div>
-            
-            <div class="info-box">
-                <p><strong> Important:</strong> This link will expire in 24 hours.</p>
-                <p>If you don't have an account yet, you'll be able to create one after clicking the link.</p>
-            </div>
-            
-            <p><strong>Email:</strong> Make sure to use <strong>%s</strong> when creating your account.</p>
-        </div>
-        <div class="footer">
-            <p>Best regards,<br><strong>Virtual Points Team</strong></p>
-            <p style="font-size: 12px; color: #999;">This is an automated message, please do not reply to this email.</p>
-        </div>
-    </div>
-</body>
-</html>
-    `, transfer.ReceiverName, transfer.Points, transfer.SenderEmail, claimURL, transfer.ReceiverEmail)
-
-	// EMAIL HEADERS: Professional email formatting
-	headers := make(map[string]string)
-	headers["From"] = s.config.Email.From
-	headers["To"] = transfer.ReceiverEmail
-	headers["Subject"] = subject
-	headers["MIME-Version"] = "1.0"
-	headers["Content-Type"] = "text/html; charset=\"utf-8\""
-	headers["X-Priority"] = "1"
-	headers["Importance"] = "high"
-
 	// MESSAGE CONSTRUCTION: Build RFC-compliant email
-	message := ""
-	for k, v := range headers {
-		message += fmt.Sprintf("%s: %s\r\n", k, v)
+	message, err := composeMessage(messageHeaders(s.config.Email.From, to, rendered.Subject), rendered.HTML, plainText(rendered))
+	if err != nil {
+		return err
 	}
-	message += "\r\n" + body
 
 	// EMAIL DELIVERY: Send via SMTP
-	err := smtp.SendMail(
+	return smtp.SendMail(
 		s.config.Email.SMTPHost+":"+s.config.Email.SMTPPort,
 		auth,
 		s.config.Email.From,
-		[]string{transfer.ReceiverEmail},
+		[]string{to},
 		[]byte(message),
 	)
-
-	if err != nil {
-		return fmt.Errorf("failed to send email to %s: %v", transfer.ReceiverEmail, err)
-	}
-
-	fmt.Printf(" Email sent successfully to: %s\n", transfer.ReceiverEmail)
-	fmt.Printf("Claim URL: %s\n", claimURL)
-	return nil
 }
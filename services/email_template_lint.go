@@ -0,0 +1,196 @@
+// DESIGN PATTERN: Specification Pattern (template lint rules) + Visitor (parse tree walk)
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"sender-service/models"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"text/template/parse"
+	"time"
+)
+
+// requiredEmailVariables - Fields an uploaded template must reference so the email still does its job
+var requiredEmailVariables = map[string][]string{
+	TemplateTransferClaim:      {"ClaimURL", "Points"},
+	TemplateSenderDigest:       {"Period"},
+	TemplatePointsReceived:     {"Points"},
+	TemplateStaleTransferNudge: {"ManageURL", "Points"},
+	TemplateTransferForwarded:  {"NewReceiverEmail", "Points"},
+	TemplateTransferExpired:    {"Points"},
+	TemplateTransferDeclined:   {"Points"},
+	TemplateTransferCompleted:  {"Points"},
+	TemplateTransferFailed:     {"Points"},
+}
+
+// emailLintSamples - Data rendered through uploaded templates; lists are non-empty so range bodies run
+var emailLintSamples = map[string]interface{}{
+	TemplateTransferClaim: ClaimEmailData{
+		ReceiverName: "Receiver", ReceiverEmail: "receiver@example.com", SenderEmail: "sender@example.com",
+		Points: 100, ClaimURL: "https://example.com/#/claim/token", Message: "Thanks!",
+		Links:     []string{"https://example.com"},
+		Bundle:    []models.BundleItem{{Type: models.BundleItemPoints, Points: 100}, {Type: models.BundleItemBadge, Title: "Star"}},
+		Protected: true, PassphraseHint: "hint", Promo: &PromoBlock{Headline: "Promo", Body: "Body", URL: "https://example.com"},
+		ExpiresAt: time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC),
+	},
+	TemplateSenderDigest: DigestEmailData{
+		Period:       "daily",
+		Claimed:      []DigestItem{{ReceiverName: "Receiver", ReceiverEmail: "receiver@example.com", Points: 100, When: "today"}},
+		Pending:      []DigestItem{{ReceiverName: "Receiver", ReceiverEmail: "receiver@example.com", Points: 100, When: "tomorrow", Deferred: true}},
+		ExpiringSoon: []DigestItem{{ReceiverName: "Receiver", ReceiverEmail: "receiver@example.com", Points: 100, When: "tomorrow"}},
+	},
+	TemplatePointsReceived: PointsReceivedEmailData{
+		ReceiverName: "Receiver", SenderEmail: "sender@example.com", Points: 100, Program: "default",
+		Message: "Thanks!", DashboardURL: "https://example.com",
+	},
+	TemplateStaleTransferNudge: StaleNudgeEmailData{
+		ReceiverName: "Receiver", ReceiverEmail: "receiver@example.com", Points: 100,
+		ExpiresAt: "tomorrow", ManageURL: "https://example.com",
+	},
+	TemplateTransferForwarded: TransferForwardedEmailData{
+		OriginalReceiverName: "Receiver", NewReceiverName: "Other", NewReceiverEmail: "other@example.com", Points: 100,
+	},
+	TemplateTransferExpired: TransferExpiredEmailData{
+		ReceiverName: "Receiver", ReceiverEmail: "receiver@example.com", Points: 100,
+		ExpiredAt: "yesterday", HistoryURL: "https://example.com",
+	},
+	TemplateTransferDeclined: TransferDeclinedEmailData{
+		ReceiverName: "Receiver", ReceiverEmail: "receiver@example.com", Points: 100, Note: "No thanks",
+	},
+	TemplateTransferCompleted: TransferCompletedEmailData{
+		ReceiverName: "Receiver", ReceiverEmail: "receiver@example.com", Points: 100, ConvertedPoints: 50,
+		CompletedAt: "today", HistoryURL: "https://example.com",
+	},
+	TemplateTransferFailed: TransferFailedEmailData{
+		ReceiverName: "Receiver", ReceiverEmail: "receiver@example.com", Points: 100, HistoryURL: "https://example.com",
+	},
+}
+
+// Script patterns refused in uploaded bodies (email clients strip them anyway; their presence means a mistake or an attack)
+var (
+	scriptTagPattern    = regexp.MustCompile(`(?i)<\s*/?\s*script\b`)
+	eventHandlerPattern = regexp.MustCompile(`(?i)<[^>]*\son[a-z]+\s*=`)
+	scriptURLPattern    = regexp.MustCompile(`(?i)(javascript|vbscript)\s*:`)
+)
+
+// LintEmailTemplate - Validates an uploaded subject/body for a built-in template name
+func LintEmailTemplate(name, subject, html string) models.TemplateLintReport {
+	report := models.TemplateLintReport{}
+
+	// 1. TARGET: Only built-in templates can be overridden
+	sample, ok := emailLintSamples[name]
+	if !ok {
+		report.Errors = append(report.Errors, fmt.Sprintf("unknown email template %s", name))
+		return report
+	}
+
+	// 2. SCRIPTS: No script tags, inline event handlers or script URLs
+	if scriptTagPattern.MatchString(html) {
+		report.Errors = append(report.Errors, "html must not contain <script> tags")
+	}
+	if eventHandlerPattern.MatchString(html) {
+		report.Errors = append(report.Errors, "html must not contain inline event handlers (on...=)")
+	}
+	if scriptURLPattern.MatchString(html) {
+		report.Errors = append(report.Errors, "html must not contain javascript: or vbscript: URLs")
+	}
+
+	// 3. PARSE: Syntax errors would otherwise surface mid-send
+	tmpl, err := compileEmailTemplate(name, subject, html)
+	if err != nil {
+		report.Errors = append(report.Errors, "parse error in "+err.Error())
+		return report
+	}
+
+	// 4. REQUIRED VARIABLES: e.g. a claim email without its link is useless
+	referenced := templateFields(subject)
+	for field := range templateFields(html) {
+		referenced[field] = true
+	}
+	for _, field := range requiredEmailVariables[name] {
+		if !referenced[field] {
+			report.Errors = append(report.Errors, fmt.Sprintf("missing required variable .%s", field))
+		}
+	}
+
+	// 5. RENDER: Unknown fields and escaping failures only show up on execution
+	rendered, err := tmpl.render(name, sample)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	} else if strings.Contains(rendered.HTML, "ZgotmplZ") {
+		report.Warnings = append(report.Warnings, "a value was replaced with ZgotmplZ (unsafe in its HTML/CSS/URL context)")
+	}
+	if err == nil && strings.TrimSpace(rendered.Subject) == "" {
+		report.Errors = append(report.Errors, "subject renders empty")
+	}
+
+	report.Valid = len(report.Errors) == 0
+	return report
+}
+
+// RequiredEmailVariables - Fields an uploaded template for name must reference
+func RequiredEmailVariables(name string) []string {
+	fields := requiredEmailVariables[name]
+	sorted := append([]string(nil), fields...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// templateFields - Every .Field name referenced anywhere in a template (parse failures yield none)
+func templateFields(source string) map[string]bool {
+	fields := map[string]bool{}
+	tmpl, err := texttemplate.New("lint").Parse(source)
+	if err != nil || tmpl.Tree == nil {
+		return fields
+	}
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.TemplateNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				for _, arg := range cmd.Args {
+					walk(arg)
+				}
+			}
+		case *parse.FieldNode:
+			for _, ident := range n.Ident {
+				fields[ident] = true
+			}
+		case *parse.ChainNode:
+			walk(n.Node)
+			for _, ident := range n.Field {
+				fields[ident] = true
+			}
+		}
+	}
+	walk(tmpl.Tree.Root)
+	return fields
+}
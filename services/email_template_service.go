@@ -0,0 +1,148 @@
+// DESIGN PATTERN: Service Layer + Guarded State Transition (draft -> active only after a clean lint) + Versioning
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/models"
+	"sender-service/repositories"
+)
+
+var (
+	ErrUnknownEmailTemplate  = apperrors.New(apperrors.ErrNotFound, "unknown_email_template", "unknown email template")
+	ErrEmailTemplateNotFound = apperrors.New(apperrors.ErrNotFound, "email_template_not_found", "email template version not found")
+	ErrEmailTemplateInvalid  = apperrors.New(apperrors.ErrUnprocessable, "email_template_invalid", "email template failed validation")
+)
+
+// EmailTemplateService - Uploads, lints, versions and activates per-tenant overrides of the built-in emails
+type EmailTemplateService struct {
+	repo  *repositories.EmailTemplateRepository // Composition: HAS-A override store
+	clock clock.Clock                           // Composition: HAS-A time source
+}
+
+// NewEmailTemplateService - Factory method with dependency injection
+func NewEmailTemplateService(repo *repositories.EmailTemplateRepository, clk clock.Clock) *EmailTemplateService {
+	return &EmailTemplateService{repo: repo, clock: clk}
+}
+
+// EmailTemplateStatus - One built-in template and the overrides currently active for it
+type EmailTemplateStatus struct {
+	Name              string                         `json:"name"`               // Built-in template name
+	RequiredVariables []string                       `json:"required_variables"` // Fields an override must reference
+	Active            []models.EmailTemplateOverride `json:"active"`             // Active version per tenant ("" = default)
+}
+
+// List - Every built-in template with its active overrides
+func (s *EmailTemplateService) List() ([]EmailTemplateStatus, error) {
+	overrides, err := s.repo.FindActive()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string][]models.EmailTemplateOverride, len(overrides))
+	for _, override := range overrides {
+		byName[override.Name] = append(byName[override.Name], override)
+	}
+
+	statuses := make([]EmailTemplateStatus, 0)
+	for _, name := range EmailTemplateNames() {
+		active := byName[name]
+		if active == nil {
+			active = []models.EmailTemplateOverride{}
+		}
+		statuses = append(statuses, EmailTemplateStatus{Name: name, RequiredVariables: RequiredEmailVariables(name), Active: active})
+	}
+	return statuses, nil
+}
+
+// Versions - Upload history of one tenant's template, newest first
+func (s *EmailTemplateService) Versions(tenant, name string) ([]models.EmailTemplateOverride, error) {
+	if _, ok := emailTemplates[name]; !ok {
+		return nil, ErrUnknownEmailTemplate
+	}
+	return s.repo.ListVersions(tenant, name)
+}
+
+// Upload - Stores the next version as a draft (inactive) even when broken, so it can be fixed;
+// the lint report says why
+func (s *EmailTemplateService) Upload(tenant, name string, upload models.EmailTemplateUpload) (*models.EmailTemplateOverride, models.TemplateLintReport, error) {
+	if _, ok := emailTemplates[name]; !ok {
+		return nil, models.TemplateLintReport{}, ErrUnknownEmailTemplate
+	}
+	report := LintEmailTemplate(name, upload.Subject, upload.HTML)
+
+	// A new upload never replaces the active version until it is activated
+	override := &models.EmailTemplateOverride{
+		Tenant:    tenant,
+		Name:      name,
+		Subject:   upload.Subject,
+		HTML:      upload.HTML,
+		CreatedAt: s.clock.Now(),
+	}
+	if err := s.repo.CreateVersion(override); err != nil {
+		return nil, report, errors.New("failed to save email template")
+	}
+	return override, report, nil
+}
+
+// Activate - Switches the tenant's sends to a version (0 = latest); refused unless it lints clean.
+// Activating an older version is how a rollback is done
+func (s *EmailTemplateService) Activate(tenant, name string, version int) (*models.EmailTemplateOverride, models.TemplateLintReport, error) {
+	override, err := s.repo.FindVersion(tenant, name, version)
+	if err != nil {
+		return nil, models.TemplateLintReport{}, ErrEmailTemplateNotFound
+	}
+
+	// GUARD: Re-lint on activation; rules may have tightened since the upload
+	report := LintEmailTemplate(name, override.Subject, override.HTML)
+	if !report.Valid {
+		return nil, report, ErrEmailTemplateInvalid
+	}
+
+	now := s.clock.Now()
+	override.Active = true
+	override.ActivatedAt = &now
+	if err := s.repo.Activate(override); err != nil {
+		return nil, report, errors.New("failed to activate email template")
+	}
+	return override, report, s.reload()
+}
+
+// Revert - Deactivates the tenant's override (versions are kept) so the fallback applies again
+func (s *EmailTemplateService) Revert(tenant, name string) error {
+	affected, err := s.repo.Deactivate(tenant, name)
+	if err != nil {
+		return errors.New("failed to deactivate email template")
+	}
+	if !affected {
+		return ErrEmailTemplateNotFound
+	}
+	return s.reload()
+}
+
+// Refresh - Scheduled job: picks up activations made by other instances
+func (s *EmailTemplateService) Refresh(ctx context.Context) error {
+	return s.reload()
+}
+
+// reload - Installs every active override that still compiles (a broken row keeps the built-in)
+func (s *EmailTemplateService) reload() error {
+	overrides, err := s.repo.FindActive()
+	if err != nil {
+		return fmt.Errorf("failed to load email templates: %w", err)
+	}
+
+	templates := make(map[string]emailTemplate, len(overrides))
+	for _, override := range overrides {
+		tmpl, err := compileEmailTemplate(override.Name, override.Subject, override.HTML)
+		if err != nil {
+			fmt.Printf("Ignoring active email template %s v%d (tenant %q): %v\n", override.Name, override.Version, override.Tenant, err)
+			continue
+		}
+		templates[overrideKey(override.Tenant, override.Name)] = tmpl
+	}
+	setEmailOverrides(templates)
+	return nil
+}
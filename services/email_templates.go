@@ -0,0 +1,389 @@
+// DESIGN PATTERN: Template Method Pattern + Registry Pattern (email templates loaded from templates/)
+package services
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sender-service/i18n"
+	"sender-service/models"
+	"sort"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+)
+
+// Email template names
+const (
+	TemplateTransferClaim      = "transfer_claim"       // Receiver claim invitation
+	TemplateSenderDigest       = "sender_digest"        // Sender daily/weekly summary
+	TemplatePointsReceived     = "points_received"      // Auto-completed transfer to a registered receiver
+	TemplateStaleTransferNudge = "stale_transfer_nudge" // Sender reminder about an unclaimed transfer
+	TemplateTransferForwarded  = "transfer_forwarded"   // Sender notice that the receiver regifted the transfer
+	TemplateTransferExpired    = "transfer_expired"     // Sender notice that a claim window ended unclaimed
+	TemplateTransferDeclined   = "transfer_declined"    // Sender notice that the receiver declined
+	TemplateTransferCompleted  = "transfer_completed"   // Sender notice that the receiver claimed
+	TemplateTransferFailed     = "transfer_failed"      // Sender notice that completion failed for insufficient points
+)
+
+// RenderedEmail - Output of rendering a template, ready to be wrapped in MIME headers
+type RenderedEmail struct {
+	Subject string // Email subject line
+	HTML    string // HTML body
+	Text    string // Plain-text alternative of the body (multipart/alternative)
+
+	Secrets []string // Values the archive copy must not contain (raw claim tokens)
+}
+
+// ClaimEmailData - Data for the receiver claim invitation template
+type ClaimEmailData struct {
+	ReceiverName   string              // Receiver display name
+	ReceiverEmail  string              // Address the receiver must sign up with
+	SenderEmail    string              // Who sent the points
+	Points         int                 // Points amount
+	ClaimURL       string              // Frontend claim link
+	Message        string              // Sender's gift message (already scanned)
+	Links          []string            // Sender's links (already scanned)
+	Bundle         []models.BundleItem // Award items rendered together (bundles only)
+	Protected      bool                // Claim requires a passphrase
+	PassphraseHint string              // Optional hint for the passphrase
+	Promo          *PromoBlock         // Promotional block (only with recorded marketing consent)
+	Theme          string              // Sender-chosen theme ("" = classic)
+	Locale         string              // Receiver's language (unsupported or empty = English)
+	ExpiresAt      time.Time           // When the claim link stops working (zero = not shown)
+
+	TrackingPixelURL string // Open-tracking image ("" when tracking is disabled)
+}
+
+// Palette - Colors and headline of the chosen theme (unknown names fall back to classic)
+func (d ClaimEmailData) Palette() EmailTheme {
+	if theme, ok := LookupEmailTheme(d.Theme); ok {
+		return theme
+	}
+	theme, _ := LookupEmailTheme(EmailThemeClassic)
+	return theme
+}
+
+// Language - Supported locale the email renders in (the <html lang> value)
+func (d ClaimEmailData) Language() string {
+	if locale := i18n.Match(d.Locale); locale != "" {
+		return locale
+	}
+	return i18n.DefaultLocale
+}
+
+// T - Catalog text in the receiver's language; entries are trusted markup, string arguments are escaped
+func (d ClaimEmailData) T(key string, args ...interface{}) template.HTML {
+	for i, arg := range args {
+		if text, ok := arg.(string); ok {
+			args[i] = template.HTMLEscapeString(text)
+		}
+	}
+	return template.HTML(i18n.Format(d.Language(), key, args...))
+}
+
+// Headline - Theme headline in the receiver's language (the theme's own headline when not translated)
+func (d ClaimEmailData) Headline() string {
+	palette := d.Palette()
+	if headline, ok := i18n.Lookup(d.Language(), "theme."+palette.Name+".headline"); ok {
+		return headline
+	}
+	return palette.Headline
+}
+
+// Expiry - Claim link expiry in the receiver's language ("" when unknown)
+func (d ClaimEmailData) Expiry() string {
+	if d.ExpiresAt.IsZero() {
+		return ""
+	}
+	return i18n.FormatDateTime(d.Language(), d.ExpiresAt)
+}
+
+// PointsReceivedEmailData - Data for the points received template
+type PointsReceivedEmailData struct {
+	ReceiverName string // Receiver display name
+	SenderEmail  string // Who sent the points
+	Points       int    // Points credited (after conversion)
+	Program      string // Program the points were credited in
+	Message      string // Sender's gift message (already scanned)
+	DashboardURL string // Frontend home
+}
+
+// StaleNudgeEmailData - Data for the stale transfer reminder template
+type StaleNudgeEmailData struct {
+	ReceiverName  string // Receiver display name
+	ReceiverEmail string // Receiver address (check for typos)
+	Points        int    // Points amount
+	ExpiresAt     string // Expiry, preformatted
+	ManageURL     string // Frontend page to resend or cancel
+}
+
+// TransferForwardedEmailData - Data for the transfer forwarded template
+type TransferForwardedEmailData struct {
+	OriginalReceiverName string // Receiver who forwarded the gift
+	NewReceiverName      string // Receiver the gift now goes to
+	NewReceiverEmail     string // New receiver's address
+	Points               int    // Points amount
+}
+
+// TransferExpiredEmailData - Data for the transfer expired template
+type TransferExpiredEmailData struct {
+	ReceiverName  string // Receiver display name
+	ReceiverEmail string // Receiver address
+	Points        int    // Points that stay with the sender
+	ExpiredAt     string // Expiry, preformatted
+	HistoryURL    string // Sender's transfer history
+}
+
+// TransferCompletedEmailData - Data for the transfer completed template
+type TransferCompletedEmailData struct {
+	ReceiverName    string // Receiver display name
+	ReceiverEmail   string // Receiver address
+	Points          int    // Points deducted from the sender
+	ConvertedPoints int    // Points credited to the receiver (differs across programs)
+	CompletedAt     string // Completion, preformatted
+	HistoryURL      string // Sender's transfer history
+}
+
+// TransferFailedEmailData - Data for the transfer failed template
+type TransferFailedEmailData struct {
+	ReceiverName  string // Receiver display name
+	ReceiverEmail string // Receiver address
+	Points        int    // Points the sender was short of
+	HistoryURL    string // Sender's transfer history
+}
+
+// TransferDeclinedEmailData - Data for the transfer declined template
+type TransferDeclinedEmailData struct {
+	ReceiverName  string // Receiver display name
+	ReceiverEmail string // Receiver address
+	Points        int    // Points that stay with the sender
+	Note          string // Optional message from the receiver
+}
+
+// DigestEmailData - Data for the sender digest template
+type DigestEmailData struct {
+	Period       string       // daily or weekly
+	Claimed      []DigestItem // Completed since the last digest
+	Pending      []DigestItem // Still waiting for the receiver
+	ExpiringSoon []DigestItem // Pending and about to expire
+}
+
+// DigestItem - One transfer line in the digest
+type DigestItem struct {
+	ReceiverName  string // Receiver display name
+	ReceiverEmail string // Receiver address
+	Points        int    // Points amount
+	When          string // Claimed at / expires at, preformatted
+	Deferred      bool   // Receiver saved the claim for later
+}
+
+// emailTemplate - Subject (text/template) plus parsed HTML body (auto-escaped by html/template)
+type emailTemplate struct {
+	subject *texttemplate.Template
+	html    *template.Template
+}
+
+// Built-in subjects and bodies: templates/<name>.subject.txt (text/template) and templates/<name>.html (html/template)
+//
+//go:embed templates/*.html templates/*.subject.txt
+var templateFiles embed.FS
+
+// builtinTemplateNames - Every email the service can send (one subject/body pair each under templates/)
+var builtinTemplateNames = []string{
+	TemplateTransferClaim, TemplateSenderDigest, TemplatePointsReceived, TemplateTransferDeclined,
+	TemplateTransferExpired, TemplateTransferForwarded, TemplateStaleTransferNudge, TemplateTransferCompleted,
+	TemplateTransferFailed,
+}
+
+// readEmailTemplate - Subject and body files of one template (the subject's trailing newline is dropped)
+func readEmailTemplate(fsys fs.FS, name string) (subject, html string, err error) {
+	subjectFile, err := fs.ReadFile(fsys, name+".subject.txt")
+	if err != nil {
+		return "", "", err
+	}
+	htmlFile, err := fs.ReadFile(fsys, name+".html")
+	if err != nil {
+		return "", "", err
+	}
+	return strings.TrimRight(string(subjectFile), "\r\n"), string(htmlFile), nil
+}
+
+// loadBuiltinTemplates - Parses the embedded templates at startup (panics on programmer error)
+func loadBuiltinTemplates() map[string]emailTemplate {
+	dir, err := fs.Sub(templateFiles, "templates")
+	if err != nil {
+		panic(err) // Embedded path is fixed at compile time
+	}
+	templates := make(map[string]emailTemplate, len(builtinTemplateNames))
+	for _, name := range builtinTemplateNames {
+		subject, html, err := readEmailTemplate(dir, name)
+		if err != nil {
+			panic(fmt.Sprintf("email template %s: %v", name, err))
+		}
+		tmpl, err := compileEmailTemplate(name, subject, html)
+		if err != nil {
+			panic(fmt.Sprintf("email template %s: %v", name, err))
+		}
+		templates[name] = tmpl
+	}
+	return templates
+}
+
+// compileEmailTemplate - Parses a subject/body pair, returning parse errors (uploaded templates)
+func compileEmailTemplate(name, subject, html string) (emailTemplate, error) {
+	subjectTmpl, err := texttemplate.New(name + "_subject").Parse(subject)
+	if err != nil {
+		return emailTemplate{}, fmt.Errorf("subject: %v", err)
+	}
+	htmlTmpl, err := template.New(name).Parse(html)
+	if err != nil {
+		return emailTemplate{}, fmt.Errorf("html: %v", err)
+	}
+	return emailTemplate{subject: subjectTmpl, html: htmlTmpl}, nil
+}
+
+// emailTemplates - Registry of every email the service can send
+var emailTemplates = loadBuiltinTemplates()
+
+// emailOverrides - Activated operator templates keyed by overrideKey, then EMAIL_TEMPLATE_DIR files
+// keyed by name, both consulted before the built-ins
+var emailOverrides = struct {
+	sync.RWMutex
+	templates map[string]emailTemplate
+	files     map[string]emailTemplate
+}{templates: map[string]emailTemplate{}, files: map[string]emailTemplate{}}
+
+// LoadEmailTemplateDir - Installs <name>.html (and optionally <name>.subject.txt) files from an operator
+// directory in place of the built-ins. Every file must lint clean and match a built-in name, so a typo or
+// a broken template stops startup instead of a send. An empty dir keeps the built-ins.
+func LoadEmailTemplateDir(dir string) error {
+	files := map[string]emailTemplate{}
+	if dir != "" {
+		// 1. DISCOVER: Bodies name the templates they replace
+		bodies, err := filepath.Glob(filepath.Join(dir, "*.html"))
+		if err != nil {
+			return err
+		}
+		if len(bodies) == 0 {
+			if _, err := os.Stat(dir); err != nil {
+				return err
+			}
+		}
+		builtins, _ := fs.Sub(templateFiles, "templates")
+
+		for _, body := range bodies {
+			name := strings.TrimSuffix(filepath.Base(body), ".html")
+			if _, ok := emailTemplates[name]; !ok {
+				return fmt.Errorf("%s: unknown email template %s", body, name)
+			}
+
+			// 2. READ: A missing subject file keeps the built-in subject
+			subject, _, err := readEmailTemplate(builtins, name)
+			if err != nil {
+				return err
+			}
+			html, err := os.ReadFile(body)
+			if err != nil {
+				return err
+			}
+			custom, err := os.ReadFile(filepath.Join(dir, name+".subject.txt"))
+			if err == nil {
+				subject = strings.TrimRight(string(custom), "\r\n")
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+
+			// 3. LINT: Same rules as uploaded overrides
+			report := LintEmailTemplate(name, subject, string(html))
+			if !report.Valid {
+				return fmt.Errorf("%s: %s", body, strings.Join(report.Errors, "; "))
+			}
+			tmpl, err := compileEmailTemplate(name, subject, string(html))
+			if err != nil {
+				return fmt.Errorf("%s: %v", body, err)
+			}
+			files[name] = tmpl
+			fmt.Printf("Email template %s loaded from %s\n", name, body)
+		}
+	}
+
+	emailOverrides.Lock()
+	defer emailOverrides.Unlock()
+	emailOverrides.files = files
+	return nil
+}
+
+// overrideKey - Registry key of a tenant's override ("" tenant = deployment default)
+func overrideKey(tenant, name string) string {
+	return tenant + "/" + name
+}
+
+// setEmailOverrides - Replaces the active overrides (loaded from the database)
+func setEmailOverrides(templates map[string]emailTemplate) {
+	emailOverrides.Lock()
+	defer emailOverrides.Unlock()
+	emailOverrides.templates = templates
+}
+
+// lookupEmailTemplate - Tenant override, then the deployment-wide override, then the EMAIL_TEMPLATE_DIR
+// file, then the built-in template
+func lookupEmailTemplate(tenant, name string) (emailTemplate, bool) {
+	emailOverrides.RLock()
+	tmpl, ok := emailOverrides.templates[overrideKey(tenant, name)]
+	if !ok && tenant != "" {
+		tmpl, ok = emailOverrides.templates[overrideKey("", name)]
+	}
+	if !ok {
+		tmpl, ok = emailOverrides.files[name]
+	}
+	emailOverrides.RUnlock()
+	if ok {
+		return tmpl, true
+	}
+	tmpl, ok = emailTemplates[name]
+	return tmpl, ok
+}
+
+// EmailTemplateNames - Lists registered templates in stable order (used by snapshot tests)
+func EmailTemplateNames() []string {
+	names := make([]string, 0, len(emailTemplates))
+	for name := range emailTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RenderEmail - Renders a registered template with its data struct
+func RenderEmail(name string, data interface{}) (*RenderedEmail, error) {
+	return RenderTenantEmail("", name, data)
+}
+
+// RenderTenantEmail - Renders a template with the tenant's branding, falling back to the default
+func RenderTenantEmail(tenant, name string, data interface{}) (*RenderedEmail, error) {
+	tmpl, ok := lookupEmailTemplate(tenant, name)
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %s", name)
+	}
+	return tmpl.render(name, data)
+}
+
+// render - Executes subject and body with the template's data struct
+func (t emailTemplate) render(name string, data interface{}) (*RenderedEmail, error) {
+	var subject, body bytes.Buffer
+	if err := t.subject.Execute(&subject, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s subject: %v", name, err)
+	}
+	if err := t.html.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %v", name, err)
+	}
+
+	return &RenderedEmail{Subject: subject.String(), HTML: body.String(), Text: htmlToText(body.String())}, nil
+}
@@ -0,0 +1,13 @@
+// DESIGN PATTERN: Snapshot (Golden File) Testing
+package services_test
+
+import (
+	"sender-service/services/emailtest"
+	"testing"
+)
+
+// TestEmailTemplatesGolden - Renders every registered template with its fixture and compares it against
+// emailtest/testdata (UPDATE_GOLDEN=1 rewrites the golden files after a reviewed template change)
+func TestEmailTemplatesGolden(t *testing.T) {
+	emailtest.AssertGolden(t, "emailtest/testdata")
+}
@@ -0,0 +1,73 @@
+// DESIGN PATTERN: Adapter Pattern (rendered HTML -> text/plain alternative part)
+package services
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Rewrite rules, applied in order, turning rendered email HTML into readable plain text
+var (
+	textHiddenPattern = regexp.MustCompile(`(?is)<(head|style|script)\b.*?</(head|style|script)\s*>`)
+	textLinkPattern   = regexp.MustCompile(`(?is)<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a\s*>`)
+	textItemPattern   = regexp.MustCompile(`(?i)<li\b[^>]*>`)
+	textLinePattern   = regexp.MustCompile(`(?i)<br\s*/?>|</(li|tr)\s*>`)
+	textBlockPattern  = regexp.MustCompile(`(?i)</(p|div|h[1-6]|ul|ol|blockquote|table)\s*>`)
+	textTagPattern    = regexp.MustCompile(`<[^>]*>`)
+	textSpacePattern  = regexp.MustCompile(`[ \t\r\f\v]+`)
+	textBlankPattern  = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText - Plain-text alternative of a rendered body: links become "label (url)", list items "- item",
+// blocks are separated by blank lines; styles, scripts and images are dropped
+func htmlToText(body string) string {
+	// 1. STRIP: Nothing in <head> or <style> is readable text
+	text := textHiddenPattern.ReplaceAllString(body, "")
+
+	// 2. STRUCTURE: Keep link targets and line structure before the tags go
+	text = textLinkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		match := textLinkPattern.FindStringSubmatch(link)
+		href, label := match[1], strings.TrimSpace(textTagPattern.ReplaceAllString(match[2], ""))
+		if label == "" || label == href {
+			return href
+		}
+		return label + " (" + href + ")"
+	})
+	text = textItemPattern.ReplaceAllString(text, "\n- ")
+	text = textLinePattern.ReplaceAllString(text, "\n")
+	text = textBlockPattern.ReplaceAllString(text, "\n\n")
+	text = html.UnescapeString(textTagPattern.ReplaceAllString(text, ""))
+
+	// 3. TIDY: Template indentation and blank runs collapse; consecutive list items stay together
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(textSpacePattern.ReplaceAllString(line, " "))
+	}
+	tidy := make([]string, 0, len(lines))
+	for i, line := range lines {
+		if line == "" && len(tidy) > 0 && strings.HasPrefix(tidy[len(tidy)-1], "- ") && nextItem(lines[i+1:]) {
+			continue
+		}
+		tidy = append(tidy, line)
+	}
+	return strings.TrimSpace(textBlankPattern.ReplaceAllString(strings.Join(tidy, "\n"), "\n\n")) + "\n"
+}
+
+// nextItem - Whether the next non-blank line is a list item
+func nextItem(lines []string) bool {
+	for _, line := range lines {
+		if line != "" {
+			return strings.HasPrefix(line, "- ")
+		}
+	}
+	return false
+}
+
+// plainText - The email's text/plain part (derived from the HTML for emails queued without one)
+func plainText(rendered *RenderedEmail) string {
+	if rendered.Text != "" {
+		return rendered.Text
+	}
+	return htmlToText(rendered.HTML)
+}
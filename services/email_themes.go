@@ -0,0 +1,51 @@
+// DESIGN PATTERN: Registry Pattern (claim email themes) + Flyweight (shared palettes)
+package services
+
+import (
+	"sender-service/apperrors"
+	"sort"
+)
+
+// ErrUnknownEmailTheme - Returned when a transfer or preview names a theme that is not registered
+var ErrUnknownEmailTheme = apperrors.New(apperrors.ErrInvalidInput, "unknown_email_theme", "unknown email theme")
+
+// Claim email themes
+const (
+	EmailThemeClassic     = "classic"     // Default purple gradient
+	EmailThemeCelebration = "celebration" // Warm colors for birthdays and milestones
+	EmailThemeThankYou    = "thank_you"   // Calm green for recognition and thanks
+)
+
+// EmailTheme - Palette and headline a sender picks for the receiver's claim email
+type EmailTheme struct {
+	Name      string `json:"name"`      // Registry key (stored on the transfer)
+	Primary   string `json:"primary"`   // Header gradient start, button, points, quote border
+	Secondary string `json:"secondary"` // Header gradient end
+	Headline  string `json:"headline"`  // Header text
+}
+
+// emailThemes - Every theme a sender may choose
+var emailThemes = map[string]EmailTheme{
+	EmailThemeClassic:     {Name: EmailThemeClassic, Primary: "#667eea", Secondary: "#764ba2", Headline: "You've Received Virtual Points!"},
+	EmailThemeCelebration: {Name: EmailThemeCelebration, Primary: "#f5576c", Secondary: "#f093fb", Headline: "Let's Celebrate! Points Are Here!"},
+	EmailThemeThankYou:    {Name: EmailThemeThankYou, Primary: "#11998e", Secondary: "#38ef7d", Headline: "Thank You! You've Received Points"},
+}
+
+// LookupEmailTheme - Theme by name ("" is classic); ok is false for unknown names
+func LookupEmailTheme(name string) (EmailTheme, bool) {
+	if name == "" {
+		name = EmailThemeClassic
+	}
+	theme, ok := emailThemes[name]
+	return theme, ok
+}
+
+// EmailThemeNames - Registered themes in stable order
+func EmailThemeNames() []string {
+	names := make([]string, 0, len(emailThemes))
+	for name := range emailThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
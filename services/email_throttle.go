@@ -0,0 +1,178 @@
+// DESIGN PATTERN: Producer-Consumer Queue + Token Bucket (per-provider send pacing)
+package services
+
+import (
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/config"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrEmailQueueFull = apperrors.New(apperrors.ErrUnavailable, "email_queue_full", "email queue is full, try again later")
+
+// defaultProviderLimits - Published sending caps applied unless EMAIL_RATE_LIMITS overrides them
+var defaultProviderLimits = map[string]string{
+	"smtp.gmail.com":                     "500/24h",
+	"smtp.sendgrid.net":                  "100/1s",
+	"email-smtp.us-east-1.amazonaws.com": "14/1s",
+	"api.sendgrid.com":                   "100/1s",
+	"email.us-east-1.amazonaws.com":      "14/1s",
+}
+
+// EmailRateLimit - At most Limit messages per Per, released evenly
+type EmailRateLimit struct {
+	Limit int           // Messages allowed per window
+	Per   time.Duration // Window length
+}
+
+// interval - Spacing between sends when the bucket is empty
+func (l EmailRateLimit) interval() time.Duration {
+	return l.Per / time.Duration(l.Limit)
+}
+
+// ParseEmailRateLimit - Parses "500/24h" style limits
+func ParseEmailRateLimit(value string) (EmailRateLimit, error) {
+	count, per, ok := strings.Cut(value, "/")
+	limit, err := strconv.Atoi(strings.TrimSpace(count))
+	if !ok || err != nil || limit <= 0 {
+		return EmailRateLimit{}, fmt.Errorf("invalid email rate limit %q (want count/duration)", value)
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(per))
+	if err != nil || window <= 0 {
+		return EmailRateLimit{}, fmt.Errorf("invalid email rate limit %q (want count/duration)", value)
+	}
+	return EmailRateLimit{Limit: limit, Per: window}, nil
+}
+
+// emailJob - One queued delivery and the channel its result is reported on
+type emailJob struct {
+	deliver func() error
+	done    chan error
+}
+
+// providerQueue - Bounded queue drained by one worker at the provider's pace
+type providerQueue struct {
+	jobs     chan *emailJob
+	interval time.Duration // Time to earn one send token
+	burst    float64       // Bucket capacity
+	tokens   float64       // Tokens currently available
+	last     time.Time     // Last refill
+}
+
+// EmailThrottle - Paces SMTP deliveries per provider so bulk sends never trip provider caps
+type EmailThrottle struct {
+	mu        sync.Mutex
+	limits    map[string]EmailRateLimit // Provider host -> limit
+	queues    map[string]*providerQueue // Started lazily per provider
+	queueSize int                       // Pending deliveries per provider before rejecting
+	burst     int                       // Sends allowed back-to-back before smoothing kicks in
+}
+
+// NewEmailThrottle - Factory method merging built-in provider caps with EMAIL_RATE_LIMITS
+func NewEmailThrottle(cfg *config.Config) (*EmailThrottle, error) {
+	throttle := &EmailThrottle{
+		limits:    map[string]EmailRateLimit{},
+		queues:    map[string]*providerQueue{},
+		queueSize: cfg.Email.QueueSize,
+		burst:     cfg.Email.RateBurst,
+	}
+	if throttle.burst < 1 {
+		throttle.burst = 1
+	}
+
+	for provider, value := range defaultProviderLimits {
+		limit, _ := ParseEmailRateLimit(value)
+		throttle.limits[provider] = limit
+	}
+	for provider, value := range cfg.Email.RateLimits {
+		limit, err := ParseEmailRateLimit(value)
+		if err != nil {
+			return nil, err
+		}
+		throttle.limits[strings.ToLower(provider)] = limit
+	}
+	return throttle, nil
+}
+
+// Do - Queues a delivery behind the provider's limit and waits for its result
+func (t *EmailThrottle) Do(provider string, deliver func() error) error {
+	queue := t.queueFor(strings.ToLower(provider))
+	if queue == nil {
+		return deliver() // No known cap for this provider
+	}
+
+	job := &emailJob{deliver: deliver, done: make(chan error, 1)}
+	select {
+	case queue.jobs <- job:
+	default:
+		// BACKPRESSURE: Callers dead-letter the email rather than pile up goroutines
+		return ErrEmailQueueFull
+	}
+	return <-job.done
+}
+
+// Pending - Queued deliveries per provider (operational visibility)
+func (t *EmailThrottle) Pending() map[string]int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pending := make(map[string]int, len(t.queues))
+	for provider, queue := range t.queues {
+		pending[provider] = len(queue.jobs)
+	}
+	return pending
+}
+
+// queueFor - Returns (starting if needed) the provider's queue; nil when the provider is unlimited
+func (t *EmailThrottle) queueFor(provider string) *providerQueue {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if queue, ok := t.queues[provider]; ok {
+		return queue
+	}
+	limit, ok := t.limits[provider]
+	if !ok {
+		return nil
+	}
+
+	queue := &providerQueue{
+		jobs:     make(chan *emailJob, t.queueSize),
+		interval: limit.interval(),
+		burst:    float64(t.burst),
+		tokens:   float64(t.burst),
+		last:     time.Now(),
+	}
+	t.queues[provider] = queue
+	go queue.run()
+	return queue
+}
+
+// run - Worker: one delivery at a time, each waiting for a token
+func (q *providerQueue) run() {
+	for job := range q.jobs {
+		q.take()
+		job.done <- job.deliver()
+	}
+}
+
+// take - Token bucket: refill by elapsed time, sleep until a whole token is available
+func (q *providerQueue) take() {
+	now := time.Now()
+	q.tokens += float64(now.Sub(q.last)) / float64(q.interval)
+	if q.tokens > q.burst {
+		q.tokens = q.burst
+	}
+	q.last = now
+
+	if q.tokens < 1 {
+		wait := time.Duration((1 - q.tokens) * float64(q.interval))
+		time.Sleep(wait)
+		q.tokens = 1
+		q.last = time.Now()
+	}
+	q.tokens--
+}
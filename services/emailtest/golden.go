@@ -0,0 +1,141 @@
+// DESIGN PATTERN: Snapshot (Golden File) Testing Helper
+package emailtest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sender-service/models"
+	"sender-service/services"
+	"testing"
+	"time"
+)
+
+// UpdateEnv - Set UPDATE_GOLDEN=1 to rewrite golden files instead of comparing
+const UpdateEnv = "UPDATE_GOLDEN"
+
+// Fixtures - Deterministic sample data for every registered email template
+func Fixtures() map[string]interface{} {
+	return map[string]interface{}{
+		services.TemplateTransferClaim: services.ClaimEmailData{
+			ReceiverName:  "Jane Receiver",
+			ReceiverEmail: "jane@example.com",
+			SenderEmail:   "sam@example.com",
+			Points:        250,
+			ClaimURL:      "https://app.example.com/#/claim/token_fixture",
+			Message:       "Happy birthday! See you at [link removed]",
+			Links:         []string{"https://example.com/card"},
+			Bundle: []models.BundleItem{
+				{Type: models.BundleItemPoints, Points: 200, Title: "Quarterly award"},
+				{Type: models.BundleItemBadge, BadgeID: "team-player", Title: "Team Player"},
+				{Type: models.BundleItemMessage, Title: "Citation", Message: "For shipping the launch on time."},
+			},
+			Protected:      true,
+			PassphraseHint: "Where we met",
+			Promo:          &services.PromoBlock{Headline: "Double points weekend", Body: "Earn 2x on every purchase this weekend.", URL: "https://example.com/promo"},
+			ExpiresAt:      time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC),
+		},
+		services.TemplatePointsReceived: services.PointsReceivedEmailData{
+			ReceiverName: "Jane Receiver",
+			SenderEmail:  "sam@example.com",
+			Points:       125,
+			Program:      "reward",
+			Message:      "Happy birthday!",
+			DashboardURL: "https://app.example.com",
+		},
+		services.TemplateStaleTransferNudge: services.StaleNudgeEmailData{
+			ReceiverName:  "Jane Receiver",
+			ReceiverEmail: "jane@example.com",
+			Points:        250,
+			ExpiresAt:     "2025-01-03 12:00 UTC",
+			ManageURL:     "https://app.example.com/#/transfers/transfer_fixture",
+		},
+		services.TemplateTransferDeclined: services.TransferDeclinedEmailData{
+			ReceiverName:  "Jane Receiver",
+			ReceiverEmail: "jane@example.com",
+			Points:        250,
+			Note:          "Thank you, but please give these to the team.",
+		},
+		services.TemplateTransferExpired: services.TransferExpiredEmailData{
+			ReceiverName:  "Jane Receiver",
+			ReceiverEmail: "jane@example.com",
+			Points:        250,
+			ExpiredAt:     "2025-01-02 09:30 UTC",
+			HistoryURL:    "https://app.example.com/#/transfers",
+		},
+		services.TemplateTransferCompleted: services.TransferCompletedEmailData{
+			ReceiverName:    "Jane Receiver",
+			ReceiverEmail:   "jane@example.com",
+			Points:          250,
+			ConvertedPoints: 125,
+			CompletedAt:     "2025-01-01 09:30 UTC",
+			HistoryURL:      "https://app.example.com/#/transfers",
+		},
+		services.TemplateTransferFailed: services.TransferFailedEmailData{
+			ReceiverName:  "Jane Receiver",
+			ReceiverEmail: "jane@example.com",
+			Points:        250,
+			HistoryURL:    "https://app.example.com/#/transfers",
+		},
+		services.TemplateTransferForwarded: services.TransferForwardedEmailData{
+			OriginalReceiverName: "Jane Receiver",
+			NewReceiverName:      "Raj Patel",
+			NewReceiverEmail:     "raj@example.com",
+			Points:               250,
+		},
+		services.TemplateSenderDigest: services.DigestEmailData{
+			Period:       "daily",
+			Claimed:      []services.DigestItem{{ReceiverName: "Jane Receiver", ReceiverEmail: "jane@example.com", Points: 250, When: "2025-01-01 09:30 UTC"}},
+			Pending:      []services.DigestItem{{ReceiverName: "Raj Patel", ReceiverEmail: "raj@example.com", Points: 40, When: "2025-01-03 12:00 UTC", Deferred: true}},
+			ExpiringSoon: []services.DigestItem{{ReceiverName: "Ana Lima", ReceiverEmail: "ana@example.com", Points: 75, When: "2025-01-02 08:00 UTC"}},
+		},
+	}
+}
+
+// AssertGolden - Renders every template with its fixture and compares against <dir>/<name>.{subject,html,text}.golden
+func AssertGolden(t testing.TB, dir string) {
+	t.Helper()
+
+	fixtures := Fixtures()
+	for _, name := range services.EmailTemplateNames() {
+		data, ok := fixtures[name]
+		if !ok {
+			t.Errorf("email template %s has no fixture in emailtest.Fixtures", name)
+			continue
+		}
+
+		rendered, err := services.RenderEmail(name, data)
+		if err != nil {
+			t.Errorf("render %s: %v", name, err)
+			continue
+		}
+
+		compareGolden(t, filepath.Join(dir, name+".subject.golden"), []byte(rendered.Subject))
+		compareGolden(t, filepath.Join(dir, name+".html.golden"), []byte(rendered.HTML))
+		compareGolden(t, filepath.Join(dir, name+".text.golden"), []byte(rendered.Text))
+	}
+}
+
+// compareGolden - Diffs output against a golden file, or rewrites it in update mode
+func compareGolden(t testing.TB, path string, got []byte) {
+	t.Helper()
+
+	if os.Getenv(UpdateEnv) == "1" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write golden %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("read golden %s: %v (run with %s=1 to create it)", path, err, UpdateEnv)
+		return
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("%s is out of date; review the template change and rerun with %s=1\n--- want\n%s\n--- got\n%s", path, UpdateEnv, want, got)
+	}
+}
@@ -0,0 +1,58 @@
+// DESIGN PATTERN: Service Layer - Receiver engagement funnel (open, click, claim device)
+package services
+
+import (
+	"fmt"
+	"sender-service/models"
+	"strings"
+)
+
+// Claim device classes reported in transfer.completed
+const (
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceDesktop = "desktop"
+)
+
+// Engagement columns written once per transfer
+const (
+	engagementOpened  = "email_opened_at"
+	engagementClicked = "link_clicked_at"
+)
+
+// RecordEmailOpen - Stamps the first claim email open (tracking pixel); best-effort, never fails the request
+func (s *TransferService) RecordEmailOpen(token string) {
+	s.recordEngagement(token, engagementOpened)
+}
+
+// RecordLinkClick - Stamps the first claim link click (tracked redirect); best-effort, never fails the request
+func (s *TransferService) RecordLinkClick(token string) {
+	s.recordEngagement(token, engagementClicked)
+}
+
+// recordEngagement - Only pending transfers are tracked; the completed event has already been published otherwise
+func (s *TransferService) recordEngagement(token, column string) {
+	transfer, err := s.transferRepo.FindByToken(token)
+	if err != nil || transfer.Status != models.TransferStatusPending {
+		return
+	}
+	if err := s.transferRepo.MarkEngagement(transfer, column, s.clock.Now()); err != nil {
+		fmt.Printf("Failed to record %s for transfer %s: %v\n", column, transfer.ID, err)
+	}
+}
+
+// ClassifyDevice - Coarse device class from a User-Agent ("" when absent); the raw header is never stored
+func ClassifyDevice(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case ua == "":
+		return ""
+	case strings.Contains(ua, "ipad") || strings.Contains(ua, "tablet") ||
+		(strings.Contains(ua, "android") && !strings.Contains(ua, "mobile")):
+		return DeviceTablet
+	case strings.Contains(ua, "mobi") || strings.Contains(ua, "iphone") || strings.Contains(ua, "ipod"):
+		return DeviceMobile
+	default:
+		return DeviceDesktop
+	}
+}
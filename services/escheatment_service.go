@@ -0,0 +1,86 @@
+// DESIGN PATTERN: Service Layer + Scheduled Job (unclaimed property reporting)
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/models"
+	"sender-service/repositories"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrInvalidReportPeriod = apperrors.New(apperrors.ErrInvalidInput, "invalid_report_period", "period must be month, quarter or year")
+	ErrInvalidReportRange  = apperrors.New(apperrors.ErrInvalidInput, "invalid_report_range", "from must be before to")
+)
+
+// EscheatmentService - Flags long-expired unclaimed transfers and reports them per sender and period
+type EscheatmentService struct {
+	transferRepo *repositories.TransferRepository // Composition: HAS-A transfer store
+	clock        clock.Clock                      // Composition: HAS-A time source
+	threshold    time.Duration                    // Time after expiry before a transfer becomes reportable
+}
+
+// NewEscheatmentService - Factory method with dependency injection
+func NewEscheatmentService(transferRepo *repositories.TransferRepository, clk clock.Clock, cfg *config.Config) *EscheatmentService {
+	return &EscheatmentService{
+		transferRepo: transferRepo,
+		clock:        clk,
+		threshold:    cfg.Escheatment.Threshold,
+	}
+}
+
+// FlagEscheatable - Scheduler job: marks transfers that crossed the reporting threshold
+func (s *EscheatmentService) FlagEscheatable(ctx context.Context) error {
+	now := s.clock.Now()
+	flagged, err := s.transferRepo.FlagEscheatable(now.Add(-s.threshold), now)
+	if err != nil {
+		return err
+	}
+	if flagged > 0 {
+		fmt.Printf("Escheatment: flagged %d unclaimed transfer(s)\n", flagged)
+	}
+	return nil
+}
+
+// Report - Aggregated unclaimed points for transfers that expired in [from, to)
+func (s *EscheatmentService) Report(period string, from, to time.Time) ([]models.EscheatmentRow, error) {
+	switch period {
+	case models.EscheatmentPeriodMonth, models.EscheatmentPeriodQuarter, models.EscheatmentPeriodYear:
+	default:
+		return nil, ErrInvalidReportPeriod
+	}
+	if to.IsZero() {
+		to = s.clock.Now()
+	}
+	if from.IsZero() {
+		from = to.AddDate(-1, 0, 0)
+	}
+	if !from.Before(to) {
+		return nil, ErrInvalidReportRange
+	}
+	return s.transferRepo.EscheatmentReport(period, from, to)
+}
+
+// WriteCSV - Export format expected by compliance filings
+func (s *EscheatmentService) WriteCSV(w io.Writer, rows []models.EscheatmentRow) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"period", "sender_id", "sender_email", "transfers", "points"})
+	for _, row := range rows {
+		writer.Write([]string{
+			row.Period.UTC().Format("2006-01-02"),
+			row.SenderID,
+			row.SenderEmail,
+			strconv.Itoa(row.Transfers),
+			strconv.Itoa(row.Points),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,159 @@
+// DESIGN PATTERN: Publisher-Subscriber Pattern + Decorator Pattern (schema validation)
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/idgen"
+	"sender-service/models"
+	"sender-service/repositories"
+	"sender-service/schemas"
+	"time"
+)
+
+// eventVersions - Current schema version emitted for each event type
+var eventVersions = map[string]int{
+	models.EventTransferInitiated:     1,
+	models.EventTransferCompleted:     1,
+	models.EventTransferFailed:        1,
+	models.EventTransferStatusChanged: 1,
+	models.EventTransferClaimDeferred: 1,
+}
+
+// EventSink - Transport strategy that delivers serialized events to consumers
+type EventSink interface {
+	Deliver(event *models.DomainEvent, payload []byte) error
+}
+
+// LogEventSink - Default sink that writes events to the service log
+type LogEventSink struct{}
+
+// Deliver - Prints the event payload
+func (LogEventSink) Deliver(event *models.DomainEvent, payload []byte) error {
+	fmt.Printf("Event published [%s]: %s\n", event.SchemaName(), payload)
+	return nil
+}
+
+// HTTPEventSink - Sink that POSTs events to a configured consumer endpoint
+type HTTPEventSink struct {
+	endpoint string         // Consumer URL
+	signer   *WebhookSigner // Delivery signatures (nil = unsigned)
+	client   *http.Client   // Shared HTTP client
+}
+
+// NewHTTPEventSink - Factory method for HTTP event delivery
+func NewHTTPEventSink(endpoint string, signer *WebhookSigner) *HTTPEventSink {
+	return &HTTPEventSink{endpoint: endpoint, signer: signer, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Deliver - POSTs the event with its schema name so consumers can pick a decoder
+func (s *HTTPEventSink) Deliver(event *models.DomainEvent, payload []byte) error {
+	req, err := http.NewRequest("POST", s.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Schema", event.SchemaName())
+	s.signer.Sign(req, payload)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("event consumer responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// NewEventSink - Factory method selecting the delivery transport from configuration
+func NewEventSink(cfg *config.Config, signer *WebhookSigner) EventSink {
+	if cfg.Events.Endpoint != "" {
+		return NewHTTPEventSink(cfg.Events.Endpoint, signer)
+	}
+	return LogEventSink{}
+}
+
+// EventPublisher - Builds versioned event envelopes, validates them and stores them in the outbox
+type EventPublisher struct {
+	outbox *repositories.OutboxRepository // Composition: HAS-A event outbox (relay delivers later)
+	clock  clock.Clock                    // Composition: HAS-A time source
+	ids    idgen.Generator                // Composition: HAS-A ID generator
+}
+
+// NewEventPublisher - Factory method with dependency injection
+func NewEventPublisher(outbox *repositories.OutboxRepository, clk clock.Clock, ids idgen.Generator) *EventPublisher {
+	return &EventPublisher{outbox: outbox, clock: clk, ids: ids}
+}
+
+// Envelope - Wraps data in a DomainEvent validated against its schema (stored in the transfer outbox
+// with the change that caused it, then handed over by Forward)
+func (p *EventPublisher) Envelope(eventType, aggregateID string, data interface{}) (string, error) {
+	_, payload, err := p.buildEvent(eventType, aggregateID, data)
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+// Forward - Appends an envelope to the event outbox for the relay (idempotent by event ID)
+func (p *EventPublisher) Forward(payload string) error {
+	var event models.DomainEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return fmt.Errorf("corrupt event envelope: %v", err)
+	}
+
+	// DEDUPE: A dispatcher crash after Append but before marking the message sent replays it
+	exists, err := p.outbox.EventExists(event.ID)
+	if err != nil || exists {
+		return err
+	}
+
+	return p.outbox.Append(&models.OutboxEvent{
+		EventID:       event.ID,
+		EventType:     event.Type,
+		AggregateID:   event.AggregateID,
+		Payload:       payload,
+		NextAttemptAt: event.OccurredAt,
+	})
+}
+
+// buildEvent - Creates the envelope and rejects payloads that break the published contract
+func (p *EventPublisher) buildEvent(eventType, aggregateID string, data interface{}) (*models.DomainEvent, []byte, error) {
+	version, ok := eventVersions[eventType]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown event type %s", eventType)
+	}
+
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode %s payload: %v", eventType, err)
+	}
+
+	event := &models.DomainEvent{
+		ID:          p.ids.NewID("event"),
+		Type:        eventType,
+		Version:     version,
+		AggregateID: aggregateID,
+		OccurredAt:  p.clock.Now().UTC(),
+		Data:        rawData,
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode %s event: %v", eventType, err)
+	}
+
+	// CONTRACT VALIDATION: Never publish a payload consumers cannot decode
+	if err := schemas.Validate(event.SchemaName(), payload); err != nil {
+		return nil, nil, fmt.Errorf("event %s violates schema: %v", event.SchemaName(), err)
+	}
+
+	return event, payload, nil
+}
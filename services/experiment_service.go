@@ -0,0 +1,100 @@
+// DESIGN PATTERN: Service Layer - Experiment cohorts for claim timing + per-cohort conversion reports
+package services
+
+import (
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/experiments"
+	"sender-service/models"
+	"sender-service/repositories"
+	"strconv"
+	"time"
+)
+
+// defaultClaimWindow - Claim link lifetime outside of expiry experiments
+const defaultClaimWindow = 24 * time.Hour
+
+// Experiment parameters understood by the transfer flow
+const (
+	ParamExpiry        = "expiry"         // Claim window, e.g. "72h"
+	ParamNudgeFraction = "nudge_fraction" // Share of the window before the sender reminder, e.g. "0.25"
+)
+
+var ErrExperimentNotFound = apperrors.New(apperrors.ErrNotFound, "experiment_not_found", "experiment not found")
+
+// ClaimTiming - Expiry and reminder schedule chosen for a new transfer
+type ClaimTiming struct {
+	Cohorts   map[string]string // Experiment -> variant, tagged on the transfer
+	ExpiresAt time.Time         // Claim expiration
+	NudgeAt   *time.Time        // Reminder time (nil = global STALE_NUDGE_LIFETIME_FRACTION)
+}
+
+// ExperimentService - Assigns cohorts at initiation and reports conversion per cohort
+type ExperimentService struct {
+	registry     *experiments.Registry            // Composition: HAS-A experiment definitions
+	transferRepo *repositories.TransferRepository // Composition: HAS-A transfer store
+	clock        clock.Clock                      // Composition: HAS-A time source
+}
+
+// NewExperimentService - Factory method with dependency injection
+func NewExperimentService(registry *experiments.Registry, transferRepo *repositories.TransferRepository, clk clock.Clock) *ExperimentService {
+	return &ExperimentService{registry: registry, transferRepo: transferRepo, clock: clk}
+}
+
+// Experiments - Configured experiments
+func (s *ExperimentService) Experiments() []experiments.Experiment {
+	return s.registry.List()
+}
+
+// Timing - Cohorts for the sender and the claim schedule their variants imply
+func (s *ExperimentService) Timing(senderID string, createdAt time.Time) ClaimTiming {
+	cohorts, params := s.registry.Assign(senderID)
+	timing := ClaimTiming{Cohorts: cohorts, ExpiresAt: createdAt.Add(defaultClaimWindow)}
+
+	window := defaultClaimWindow
+	if value, ok := params[ParamExpiry]; ok {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			window = parsed
+			timing.ExpiresAt = createdAt.Add(window)
+		} else {
+			fmt.Printf("Ignoring invalid experiment %s=%q\n", ParamExpiry, value)
+		}
+	}
+	if value, ok := params[ParamNudgeFraction]; ok {
+		if fraction, err := strconv.ParseFloat(value, 64); err == nil && fraction > 0 && fraction < 1 {
+			nudgeAt := createdAt.Add(time.Duration(fraction * float64(window)))
+			timing.NudgeAt = &nudgeAt
+		} else {
+			fmt.Printf("Ignoring invalid experiment %s=%q\n", ParamNudgeFraction, value)
+		}
+	}
+	return timing
+}
+
+// Report - Conversion per variant for transfers created in [from, to) (defaults: last 30 days)
+func (s *ExperimentService) Report(name string, from, to time.Time) ([]models.CohortConversion, error) {
+	if _, ok := s.registry.Find(name); !ok {
+		return nil, ErrExperimentNotFound
+	}
+	if to.IsZero() {
+		to = s.clock.Now()
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -30)
+	}
+	if !from.Before(to) {
+		return nil, ErrInvalidReportRange
+	}
+
+	rows, err := s.transferRepo.CohortConversion(name, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		if rows[i].Transfers > 0 {
+			rows[i].ConversionRate = float64(rows[i].Completed) / float64(rows[i].Transfers)
+		}
+	}
+	return rows, nil
+}
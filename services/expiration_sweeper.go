@@ -0,0 +1,76 @@
+// DESIGN PATTERN: Scheduled Job - Background expiry of unclaimed transfers
+package services
+
+import (
+	"context"
+	"fmt"
+	"sender-service/models"
+	"sender-service/repositories"
+	"time"
+)
+
+// SweepExpired - Scheduler job: flips pending transfers past ExpiresAt to expired, batch by batch
+func (s *TransferService) SweepExpired(ctx context.Context) error {
+	now := s.clock.Now()
+	candidates, err := s.transferRepo.FindExpiredPending(now, s.config.Expiration.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	expired, refunded := 0, 0
+	for i := range candidates {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		done, settled := s.expireLocked(candidates[i].ID, now)
+		if done {
+			expired++
+		}
+		if settled {
+			refunded++
+		}
+	}
+
+	if expired > 0 {
+		fmt.Printf("Expiration sweep: %d transfer(s) expired, %d reserved deduction(s) refunded\n", expired, refunded)
+	}
+	return nil
+}
+
+// expireLocked - Expires one sweep candidate under its row lock. The candidate list is a snapshot:
+// by now the transfer may have been claimed, declined or had its expiry extended, so the status
+// and deadline are re-checked on the locked row and anything no longer due is left alone.
+// A set PointsMutationKey means a claim reserved (and may have applied) the deduction, possibly
+// one still between Auth and its completion lock; expire refunds it first, so that claim finds its
+// key superseded instead of completing, and the transfer stays pending if the refund cannot start
+func (s *TransferService) expireLocked(transferID string, now time.Time) (expired, settled bool) {
+	err := s.transferRepo.LockByID(transferID, func(locked *repositories.TransferRepository, current *models.Transfer) error {
+		if current.Status != models.TransferStatusPending || now.Before(current.ExpiresAt) {
+			return nil
+		}
+		reserved := current.PointsMutationKey != ""
+		tx := *s
+		tx.transferRepo = locked
+		if err := tx.expire(current); err != nil {
+			return err
+		}
+		expired, settled = true, reserved
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("Failed to expire transfer %s: %v\n", transferID, err)
+		return false, false
+	}
+	return expired, settled
+}
+
+// expiredEmailData - Template data for the sender expiry notice
+func (s *TransferService) expiredEmailData(transfer *models.Transfer) TransferExpiredEmailData {
+	return TransferExpiredEmailData{
+		ReceiverName:  transfer.ReceiverName,
+		ReceiverEmail: transfer.ReceiverEmail,
+		Points:        transfer.Points,
+		ExpiredAt:     transfer.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"),
+		HistoryURL:    s.config.Frontend.URL + "/#/transfers",
+	}
+}
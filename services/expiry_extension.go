@@ -0,0 +1,74 @@
+// DESIGN PATTERN: Service Layer - Sender extends a pending transfer's claim window
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/models"
+	"sender-service/repositories"
+	"time"
+)
+
+var (
+	ErrTransferNotExtendable = apperrors.New(apperrors.ErrConflict, "transfer_not_extendable", "only pending, unexpired transfers can be extended")
+	ErrExtensionNotLater     = apperrors.New(apperrors.ErrUnprocessable, "extension_not_later", "new expiry must be later than the current one")
+	ErrExtensionTooLong      = apperrors.New(apperrors.ErrUnprocessable, "extension_too_long", "new expiry exceeds the maximum transfer lifetime")
+)
+
+// ReasonSenderExtended - Audit reason for a sender-initiated expiry extension
+const ReasonSenderExtended = "sender_extended"
+
+// ExtendExpiration - Pushes a sender's pending transfer expiry forward, bounded by the max lifetime
+func (s *TransferService) ExtendExpiration(transferID, senderID string, req models.ExtendTransferRequest, clientIP string) (*models.Transfer, error) {
+	var (
+		transfer       *models.Transfer
+		previousExpiry time.Time
+	)
+	now := s.clock.Now()
+
+	// 1-2. VALIDATION + PERSISTENCE: Under the row lock so the sweeper or a claim cannot race the change
+	err := s.transferRepo.LockByID(transferID, func(locked *repositories.TransferRepository, current *models.Transfer) error {
+		// OWNERSHIP: Other senders' transfers look like they do not exist
+		if current.SenderID != senderID {
+			return ErrTransferNotFound
+		}
+		if current.Status != models.TransferStatusPending || !now.Before(current.ExpiresAt) {
+			return ErrTransferNotExtendable
+		}
+		if !req.ExpiresAt.After(current.ExpiresAt) {
+			return ErrExtensionNotLater
+		}
+		if req.ExpiresAt.Sub(current.CreatedAt) > s.config.Claims.MaxLifetime {
+			return fmt.Errorf("%w (%s from creation)", ErrExtensionTooLong, s.config.Claims.MaxLifetime)
+		}
+
+		previousExpiry = current.ExpiresAt
+		current.ExpiresAt = req.ExpiresAt
+		if err := locked.Update(current); err != nil {
+			return errors.New("failed to extend transfer")
+		}
+
+		// 3. AUDIT: Status is unchanged; the reason code and note record the extension (committed with it)
+		tx := *s
+		tx.transferRepo = locked
+		tx.audit(current, &models.TransferAuditLog{
+			ID:         s.ids.NewID("audit"),
+			TransferID: current.ID,
+			FromStatus: current.Status,
+			ToStatus:   current.Status,
+			ReasonCode: ReasonSenderExtended,
+			Actor:      "sender",
+			ClientIP:   clientIP,
+			Note: fmt.Sprintf("expiry extended from %s to %s",
+				previousExpiry.UTC().Format("2006-01-02T15:04:05Z"), current.ExpiresAt.UTC().Format("2006-01-02T15:04:05Z")),
+			CreatedAt: now,
+		})
+		transfer = current
+		return nil
+	})
+	if err != nil {
+		return nil, transferLookupError(err)
+	}
+	return transfer, nil
+}
@@ -0,0 +1,172 @@
+// DESIGN PATTERN: Service Layer - Receiver forwards (regifts) an unclaimed transfer
+package services
+
+import (
+	"errors"
+	"sender-service/apperrors"
+	"sender-service/models"
+	"sender-service/repositories"
+	"strings"
+)
+
+var (
+	ErrForwardingDisabled   = apperrors.New(apperrors.ErrForbidden, "forwarding_disabled", "forwarding transfers is disabled")
+	ErrClaimNotForwardable  = apperrors.New(apperrors.ErrConflict, "claim_not_forwardable", "only pending, unexpired claims can be forwarded")
+	ErrInvalidForwardTarget = apperrors.New(apperrors.ErrInvalidInput, "invalid_forward_target", "transfer cannot be forwarded to the sender or the current receiver")
+)
+
+// ForwardClaim - Supersedes a pending transfer with a child transfer to a new receiver
+func (s *TransferService) ForwardClaim(token string, req models.ForwardTransferRequest, clientIP string) (*models.Transfer, error) {
+	if !s.config.Claims.Forwarding {
+		return nil, ErrForwardingDisabled
+	}
+
+	original, err := s.transferRepo.FindByToken(token)
+	if err != nil {
+		return nil, transferLookupError(err)
+	}
+	if err := s.throttleReceiver(original); err != nil {
+		return nil, err
+	}
+
+	// 1. VALIDATION: Only live claims; protected claims need the passphrase to be forwarded too
+	// (re-checked under the row lock below, this pass just avoids calling out for dead claims)
+	now := s.clock.Now()
+	if original.Status != models.TransferStatusPending || !now.Before(original.ExpiresAt) {
+		return nil, ErrClaimNotForwardable
+	}
+	if err := s.verifyPassphrase(original, req.Passphrase); err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(req.ReceiverEmail, original.ReceiverEmail) || strings.EqualFold(req.ReceiverEmail, original.SenderEmail) {
+		return nil, ErrInvalidForwardTarget
+	}
+
+	// 2. CUSTOM HOOKS: The new receiver must pass the same deployment rules as a fresh transfer
+	sender, err := s.auth.GetUser(original.SenderID)
+	if err != nil {
+		return nil, ErrSenderUnavailable
+	}
+	if err := s.hooks.BeforeInitiate(sender, &models.TransferRequest{
+		ReceiverEmail: req.ReceiverEmail,
+		ReceiverName:  req.ReceiverName,
+		Points:        original.Points,
+		Message:       original.Message,
+		Links:         original.Links,
+		SourceProgram: original.SourceProgram,
+		TargetProgram: original.TargetProgram,
+	}); err != nil {
+		return nil, err
+	}
+
+	// 3. ENTITY CREATION: Child keeps the gift and the original expiry (forwarding never extends it)
+	id, err := s.generateID()
+	if err != nil {
+		return nil, err
+	}
+	childToken, err := s.generateToken()
+	if err != nil {
+		return nil, err
+	}
+	child := &models.Transfer{
+		ID:               id,
+		SenderID:         original.SenderID,
+		SenderEmail:      original.SenderEmail,
+		ReceiverEmail:    req.ReceiverEmail,
+		ReceiverName:     req.ReceiverName,
+		Points:           original.Points,
+		SourceProgram:    original.SourceProgram,
+		TargetProgram:    original.TargetProgram,
+		Message:          original.Message,
+		Links:            original.Links,
+		Theme:            original.Theme,
+		Tenant:           original.Tenant,
+		CallbackURL:      original.CallbackURL,
+		Locale:           original.Locale,
+		Metadata:         original.Metadata,
+		Kind:             models.TransferKindForward,
+		Region:           original.Region,
+		ParentTransferID: original.ID,
+		Status:           models.TransferStatusPending,
+		ExpiresAt:        original.ExpiresAt,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	child.SetClaimToken(childToken)
+
+	// 4. SUPERSEDE: Status check, transition and child insert commit together under the original's row
+	// lock (the child routes to the same shard: same sender, same region), so a concurrent claim either
+	// wins outright or finds the transfer already forwarded
+	err = s.createFresh([]*models.Transfer{child}, func() error {
+		return s.transferRepo.LockByID(original.ID, func(locked *repositories.TransferRepository, current *models.Transfer) error {
+			if current.Status != models.TransferStatusPending || !current.MatchesClaimToken(token) || !now.Before(current.ExpiresAt) {
+				return ErrClaimNotForwardable
+			}
+			if err := s.settleDeduction(current, "receiver forwarded"); err != nil {
+				return err
+			}
+			if err := current.TransitionTo(models.TransferStatusForwarded); err != nil {
+				return ErrClaimNotForwardable
+			}
+			child.ExpiresAt = current.ExpiresAt
+			if err := locked.Create(child); err != nil {
+				return err // Collisions roll the whole transaction back and are retried by createFresh
+			}
+			current.ForwardedToID = child.ID
+			if err := locked.Update(current); err != nil {
+				return errors.New("failed to forward transfer")
+			}
+			original = current
+
+			// 5. AUDIT + EVENTS: Same trail as a trusted status change, plus a normal initiation for the
+			// child; committed with the forward (a collision retry rebuilds them for the new child ID)
+			tx := *s
+			tx.transferRepo = locked
+			tx.audit(original, &models.TransferAuditLog{
+				ID:         s.ids.NewID("audit"),
+				TransferID: original.ID,
+				FromStatus: models.TransferStatusPending,
+				ToStatus:   models.TransferStatusForwarded,
+				ReasonCode: models.ReasonReceiverForwarded,
+				Actor:      "receiver",
+				ClientIP:   clientIP,
+				Note:       "forwarded as " + child.ID,
+				CreatedAt:  now,
+			})
+			tx.publish(models.EventTransferStatusChanged, original, models.TransferStatusChangedData{
+				TransferID: original.ID,
+				SenderID:   original.SenderID,
+				FromStatus: models.TransferStatusPending,
+				ToStatus:   models.TransferStatusForwarded,
+				ReasonCode: models.ReasonReceiverForwarded,
+				Actor:      "receiver",
+			})
+			tx.publish(models.EventTransferInitiated, child, models.TransferInitiatedData{
+				TransferID:    child.ID,
+				SenderID:      child.SenderID,
+				ReceiverEmail: child.ReceiverEmail,
+				Points:        child.Points,
+				ExpiresAt:     child.ExpiresAt,
+			})
+
+			// 6. OBSERVER PATTERN: Claim link for the new receiver, heads-up for the original sender
+			tx.notifyReceiver(child)
+			tx.queueEmail(original, original.SenderEmail, TemplateTransferForwarded, TransferForwardedEmailData{
+				OriginalReceiverName: original.ReceiverName,
+				NewReceiverName:      child.ReceiverName,
+				NewReceiverEmail:     child.ReceiverEmail,
+				Points:               child.Points,
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		if errors.Is(err, ErrClaimNotForwardable) || errors.Is(err, ErrIdentifierExhausted) || errors.Is(err, ErrDeductionUnsettled) {
+			return nil, err
+		}
+		return nil, errors.New("failed to forward transfer")
+	}
+	s.volume.ObserveInitiated(child.Points)
+
+	return child, nil
+}
@@ -0,0 +1,133 @@
+// DESIGN PATTERN: Observer Pattern + Sliding Window (downstream health tracking)
+package services
+
+import (
+	"sender-service/clock"
+	"sender-service/config"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Downstream dependency names observed by the monitor
+const (
+	DependencyAuth     = "auth"     // Auth Service HTTP calls
+	DependencyDatabase = "database" // PostgreSQL queries
+	DependencyEmail    = "email"    // SMTP sends (notifications are deferred, not shed, while degraded)
+)
+
+// deferrableDependencies - Dependencies whose work waits in the outbox instead of shedding initiations
+var deferrableDependencies = map[string]bool{DependencyEmail: true}
+
+// healthSample - Outcome of one downstream call
+type healthSample struct {
+	at      time.Time
+	latency time.Duration
+	failed  bool
+}
+
+// DependencyHealth - Snapshot of one dependency over the current window
+type DependencyHealth struct {
+	Samples    int           `json:"samples"`     // Calls observed in the window
+	ErrorRate  float64       `json:"error_rate"`  // Failed / total
+	P95Latency time.Duration `json:"p95_latency"` // 95th percentile latency
+	Degraded   bool          `json:"degraded"`    // Above error-rate or latency thresholds
+}
+
+// HealthMonitor - Tracks downstream error rates and latencies to drive load shedding
+type HealthMonitor struct {
+	mu         sync.Mutex
+	samples    map[string][]healthSample // Recent samples per dependency
+	clock      clock.Clock               // Composition: HAS-A time source
+	window     time.Duration             // How far back samples count
+	minSamples int                       // Samples required before judging a dependency
+	errorRate  float64                   // Error-rate threshold (0..1)
+	latency    time.Duration             // p95 latency threshold
+}
+
+// NewHealthMonitor - Factory method with thresholds from configuration
+func NewHealthMonitor(clk clock.Clock, cfg *config.Config) *HealthMonitor {
+	return &HealthMonitor{
+		samples:    map[string][]healthSample{},
+		clock:      clk,
+		window:     cfg.LoadShedding.Window,
+		minSamples: cfg.LoadShedding.MinSamples,
+		errorRate:  cfg.LoadShedding.ErrorRateThreshold,
+		latency:    cfg.LoadShedding.LatencyThreshold,
+	}
+}
+
+// Observe - Records the outcome of a downstream call
+func (m *HealthMonitor) Observe(dependency string, latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	samples := append(m.prune(m.samples[dependency], now), healthSample{at: now, latency: latency, failed: err != nil})
+	m.samples[dependency] = samples
+}
+
+// Snapshot - Health of every observed dependency
+func (m *HealthMonitor) Snapshot() map[string]DependencyHealth {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	snapshot := map[string]DependencyHealth{}
+	for dependency, samples := range m.samples {
+		samples = m.prune(samples, now)
+		m.samples[dependency] = samples
+		snapshot[dependency] = m.evaluate(samples)
+	}
+	return snapshot
+}
+
+// Degraded - True when any dependency initiations need is over its thresholds (deferrable ones are ignored)
+func (m *HealthMonitor) Degraded() bool {
+	for dependency, health := range m.Snapshot() {
+		if health.Degraded && !deferrableDependencies[dependency] {
+			return true
+		}
+	}
+	return false
+}
+
+// DependencyDegraded - True when one dependency is over its thresholds; it recovers once its failing
+// samples age out of the window (the next call then acts as the half-open probe)
+func (m *HealthMonitor) DependencyDegraded(dependency string) bool {
+	return m.Snapshot()[dependency].Degraded
+}
+
+// evaluate - Computes error rate and p95 latency for a window of samples
+func (m *HealthMonitor) evaluate(samples []healthSample) DependencyHealth {
+	health := DependencyHealth{Samples: len(samples)}
+	if len(samples) == 0 {
+		return health
+	}
+
+	failures := 0
+	latencies := make([]time.Duration, 0, len(samples))
+	for _, sample := range samples {
+		if sample.failed {
+			failures++
+		}
+		latencies = append(latencies, sample.latency)
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	health.ErrorRate = float64(failures) / float64(len(samples))
+	health.P95Latency = latencies[(len(latencies)*95+99)/100-1] // Nearest-rank percentile
+	health.Degraded = len(samples) >= m.minSamples &&
+		(health.ErrorRate >= m.errorRate || health.P95Latency >= m.latency)
+	return health
+}
+
+// prune - Drops samples older than the window
+func (m *HealthMonitor) prune(samples []healthSample, now time.Time) []healthSample {
+	cutoff := now.Add(-m.window)
+	i := 0
+	for i < len(samples) && samples[i].at.Before(cutoff) {
+		i++
+	}
+	return samples[i:]
+}
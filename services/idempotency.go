@@ -0,0 +1,54 @@
+// DESIGN PATTERN: Idempotent Receiver (client Idempotency-Key on initiation)
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sender-service/apperrors"
+	"sender-service/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrIdempotencyKeyReused - Returned when a key comes back with a different request body
+var ErrIdempotencyKeyReused = apperrors.New(apperrors.ErrUnprocessable, "idempotency_key_reused", "Idempotency-Key was already used with a different request")
+
+// Replay - The transfer a previous request with the same Idempotency-Key created, or nil when the
+// key is new (or older than IDEMPOTENCY_WINDOW, in which case it is released for reuse)
+func (s *TransferService) Replay(senderID string, req models.TransferRequest) (*models.Transfer, error) {
+	if req.IdempotencyKey == "" {
+		return nil, nil
+	}
+
+	// 1. LOOKUP: Keys are scoped to the sender
+	existing, err := s.transferRepo.FindByIdempotencyKey(senderID, req.IdempotencyKey)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.New("failed to check Idempotency-Key")
+	}
+
+	// 2. WINDOW: Past it, the key starts a new transfer
+	if s.clock.Now().Sub(existing.CreatedAt) > s.config.Initiation.IdempotencyWindow {
+		if err := s.transferRepo.ReleaseIdempotencyKey(existing); err != nil {
+			return nil, errors.New("failed to release expired Idempotency-Key")
+		}
+		return nil, nil
+	}
+
+	// 3. FINGERPRINT: Same key, different body is a client bug, not a retry
+	if existing.IdempotencyHash != requestFingerprint(req) {
+		return nil, ErrIdempotencyKeyReused
+	}
+	return existing, nil
+}
+
+// requestFingerprint - Hash of the request as the client sent it (before defaults and hooks apply)
+func requestFingerprint(req models.TransferRequest) string {
+	encoded, _ := json.Marshal(req) // Plain DTO (the key itself is json:"-"); cannot fail
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
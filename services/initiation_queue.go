@@ -0,0 +1,181 @@
+// DESIGN PATTERN: Producer-Consumer Pattern (persisted queue + competing worker pools)
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/idgen"
+	"sender-service/models"
+	"sender-service/repositories"
+	"time"
+)
+
+// Initiation queue errors
+var (
+	ErrInitiationQueueFull = apperrors.New(apperrors.ErrUnavailable, "initiation_queue_full", "initiation queue is full, please retry later")
+	ErrJobNotFound         = apperrors.New(apperrors.ErrNotFound, "job_not_found", "job not found")
+)
+
+// initiationMaxAttempts - Claims of one job before it is failed instead of run again (a job that keeps
+// outliving its lease most likely takes its worker down with it)
+const initiationMaxAttempts = 3
+
+// errInitiationInterrupted - Recorded on a job whose workers kept stopping before it finished
+var errInitiationInterrupted = errors.New("initiation was interrupted too many times")
+
+// InitiationQueue - Decouples POST /transfer latency from Auth Service/DB spikes. Jobs live in the
+// initiation_jobs table, so they survive restarts and every replica can run and report them
+type InitiationQueue struct {
+	transferService *TransferService                      // Composition: HAS-A business service
+	repo            *repositories.InitiationJobRepository // Composition: HAS-A job store
+	clock           clock.Clock                           // Composition: HAS-A time source
+	ids             idgen.Generator                       // Composition: HAS-A ID generator
+	wake            chan struct{}                         // Nudges idle local workers when a job is enqueued here
+	workers         int                                   // Concurrent consumers on this instance
+	capacity        int                                   // Queued jobs (all instances) before 503
+	pollInterval    time.Duration                         // Idle workers look for jobs enqueued elsewhere this often
+	lease           time.Duration                         // Processing jobs older than this are claimed again
+	retention       time.Duration                         // How long finished jobs stay pollable
+}
+
+// NewInitiationQueue - Factory method with sizing from configuration
+func NewInitiationQueue(transferService *TransferService, repo *repositories.InitiationJobRepository, clk clock.Clock, ids idgen.Generator, cfg *config.Config) *InitiationQueue {
+	return &InitiationQueue{
+		transferService: transferService,
+		repo:            repo,
+		clock:           clk,
+		ids:             ids,
+		wake:            make(chan struct{}, cfg.Initiation.Workers),
+		workers:         cfg.Initiation.Workers,
+		capacity:        cfg.Initiation.QueueSize,
+		pollInterval:    cfg.Initiation.PollInterval,
+		lease:           cfg.Initiation.JobLease,
+		retention:       cfg.Initiation.JobRetention,
+	}
+}
+
+// Start - Launches the worker pool; workers stop when the context is cancelled
+func (q *InitiationQueue) Start(ctx context.Context) {
+	for i := 0; i < q.workers; i++ {
+		go q.work(ctx)
+	}
+}
+
+// Enqueue - Stores a request for background processing
+func (q *InitiationQueue) Enqueue(senderID string, req models.TransferRequest) (*models.InitiationJob, error) {
+	// 1. BACKPRESSURE: Never let the backlog grow without bound
+	queued, err := q.repo.CountQueued()
+	if err != nil {
+		return nil, errors.New("failed to queue transfer initiation")
+	}
+	if queued >= int64(q.capacity) {
+		return nil, ErrInitiationQueueFull
+	}
+
+	// 2. PERSISTENCE: Committed before 202 so a restart cannot lose it
+	now := q.clock.Now()
+	job := &models.InitiationJob{
+		ID:             q.ids.NewID("job"),
+		SenderID:       senderID,
+		Request:        &req,
+		IdempotencyKey: req.IdempotencyKey,
+		Status:         models.InitiationJobQueued,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	// EXACTLY-ONCE: A job run again after a crash must resolve to the transfer its first run created
+	if job.IdempotencyKey == "" {
+		job.IdempotencyKey = "job-" + job.ID
+	}
+	if err := q.repo.Create(job); err != nil {
+		return nil, errors.New("failed to queue transfer initiation")
+	}
+
+	// 3. WAKE-UP: A local idle worker starts right away (others find it on their next poll)
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// Get - Current state of a job owned by senderID (from whichever instance ran it)
+func (q *InitiationQueue) Get(jobID, senderID string) (*models.InitiationJob, error) {
+	job, err := q.repo.FindByID(jobID)
+	if err != nil || job.SenderID != senderID {
+		return nil, ErrJobNotFound
+	}
+	return job, nil
+}
+
+// Purge - Scheduler job: forgets finished jobs past the retention window
+func (q *InitiationQueue) Purge(ctx context.Context) error {
+	purged, err := q.repo.PurgeFinished(q.clock.Now().Add(-q.retention))
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		fmt.Printf("Purged %d finished initiation job(s)\n", purged)
+	}
+	return nil
+}
+
+// work - Consumer loop: drains claimable jobs, then waits for a wake-up or the next poll
+func (q *InitiationQueue) work(ctx context.Context) {
+	ticker := time.NewTicker(q.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil && q.runNext() {
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// runNext - Claims and runs one job through the regular service path; false when none was claimable
+func (q *InitiationQueue) runNext() bool {
+	now := q.clock.Now()
+	job, err := q.repo.ClaimNext(now, now.Add(-q.lease))
+	if err != nil {
+		fmt.Printf("Failed to claim initiation job: %v\n", err)
+		return false
+	}
+	if job == nil {
+		return false
+	}
+
+	var transfer *models.Transfer
+	if job.Attempts > initiationMaxAttempts || job.Request == nil {
+		err = errInitiationInterrupted
+	} else {
+		req := *job.Request
+		req.IdempotencyKey = job.IdempotencyKey
+		transfer, err = q.transferService.InitiateTransfer(job.SenderID, req)
+	}
+
+	// PRIVACY: The request carries the claim passphrase, so it is kept only until the job finishes
+	job.Request = nil
+	if err != nil {
+		job.Status = models.InitiationJobFailed
+		job.Error = err.Error()
+		fmt.Printf("Async initiation %s failed: %v\n", job.ID, err)
+	} else {
+		job.Status = models.InitiationJobSucceeded
+		job.TransferID = transfer.ID
+		job.ConsistencyToken = q.transferService.ConsistencyToken(transfer)
+	}
+	job.UpdatedAt = q.clock.Now()
+	if err := q.repo.Update(job); err != nil {
+		fmt.Printf("Failed to record async initiation %s: %v\n", job.ID, err)
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+// DESIGN PATTERN: Adapter Pattern (outbox events -> polling triggers) + Iterator (cursor over the outbox)
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/models"
+	"sender-service/repositories"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidSince - Returned when ?since= is not a cursor issued by the feed
+var ErrInvalidSince = apperrors.New(apperrors.ErrInvalidInput, "invalid_since", "invalid since cursor")
+
+// integrationScanBatches - Outbox batches read per poll before returning a short page (bounds status_changed filtering)
+const integrationScanBatches = 5
+
+// IntegrationFeedService - Serves claims and expirations from the outbox to polling integrations
+type IntegrationFeedService struct {
+	outbox *repositories.OutboxRepository // Composition: HAS-A event store (global order)
+	clock  clock.Clock                    // Composition: HAS-A time source
+	config *config.Config                 // Settle delay
+}
+
+// NewIntegrationFeedService - Factory method with dependency injection
+func NewIntegrationFeedService(outbox *repositories.OutboxRepository, clk clock.Clock, config *config.Config) *IntegrationFeedService {
+	return &IntegrationFeedService{outbox: outbox, clock: clk, config: config}
+}
+
+// Events - Trigger occurrences after req.Since, oldest first
+func (s *IntegrationFeedService) Events(req models.IntegrationFeedRequest) (*models.IntegrationFeed, error) {
+	// 1. CURSOR: Empty starts at the beginning of the outbox
+	afterID, err := decodeFeedCursor(req.Since)
+	if err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = models.DefaultIntegrationPageSize
+	}
+	if limit > models.MaxIntegrationPageSize {
+		limit = models.MaxIntegrationPageSize
+	}
+
+	// 2. SETTLE DELAY: Outbox IDs can commit out of order; only rows older than the delay are served,
+	// so a cursor never moves past an ID that is still in flight
+	settledBefore := s.clock.Now().Add(-s.config.Integrations.SettleDelay)
+	eventTypes := []string{models.EventTransferCompleted, models.EventTransferStatusChanged}
+
+	// 3. SCAN: status_changed rows that are not expirations are skipped, but the cursor still moves past them
+	feed := &models.IntegrationFeed{Events: []models.IntegrationEvent{}}
+	for batch := 0; batch < integrationScanBatches && len(feed.Events) < limit; batch++ {
+		want := limit - len(feed.Events)
+		rows, err := s.outbox.FetchSince(afterID, eventTypes, settledBefore, want)
+		if err != nil {
+			return nil, errors.New("failed to fetch integration events")
+		}
+		for _, row := range rows {
+			afterID = row.ID
+			event, ok, err := toIntegrationEvent(row)
+			if err != nil {
+				fmt.Printf("Skipping unreadable outbox event %d in integration feed: %v\n", row.ID, err)
+				continue
+			}
+			if ok && (req.Trigger == "" || req.Trigger == event.Trigger) {
+				feed.Events = append(feed.Events, *event)
+			}
+		}
+		// A full batch means more settled rows may follow
+		feed.HasMore = len(rows) == want
+		if !feed.HasMore {
+			break
+		}
+	}
+
+	feed.NextCursor = encodeFeedCursor(afterID)
+	return feed, nil
+}
+
+// toIntegrationEvent - Maps an outbox row to a trigger; ok is false for events no trigger covers
+func toIntegrationEvent(row models.OutboxEvent) (*models.IntegrationEvent, bool, error) {
+	var event models.DomainEvent
+	if err := json.Unmarshal([]byte(row.Payload), &event); err != nil {
+		return nil, false, err
+	}
+
+	var trigger string
+	switch event.Type {
+	case models.EventTransferCompleted:
+		trigger = models.TriggerTransferClaimed
+	case models.EventTransferStatusChanged:
+		var data models.TransferStatusChangedData
+		if err := json.Unmarshal(event.Data, &data); err != nil {
+			return nil, false, err
+		}
+		if data.ToStatus != models.TransferStatusExpired {
+			return nil, false, nil
+		}
+		trigger = models.TriggerTransferExpired
+	default:
+		return nil, false, nil
+	}
+
+	return &models.IntegrationEvent{
+		Cursor:     encodeFeedCursor(row.ID),
+		EventID:    event.ID,
+		Trigger:    trigger,
+		TransferID: event.AggregateID,
+		OccurredAt: event.OccurredAt,
+		Data:       event.Data,
+	}, true, nil
+}
+
+// encodeFeedCursor - Opaque cursor for an outbox position
+func encodeFeedCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("outbox|" + strconv.FormatUint(uint64(id), 10)))
+}
+
+// decodeFeedCursor - Reverses encodeFeedCursor ("" is the start of the outbox)
+func decodeFeedCursor(cursor string) (uint, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidSince
+	}
+	value, ok := strings.CutPrefix(string(raw), "outbox|")
+	if !ok {
+		return 0, ErrInvalidSince
+	}
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidSince
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,152 @@
+// DESIGN PATTERN: Strategy Pattern (per-channel notifiers) + Chain of Responsibility (email fallback)
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sender-service/config"
+	"sender-service/models"
+	"sender-service/repositories"
+	"time"
+)
+
+// Claim notification channels (stored on the receiver's Auth Service profile)
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+	ChannelInApp = "in_app"
+)
+
+// ClaimNotifier - Strategy delivering the claim notification on one channel
+type ClaimNotifier interface {
+	NotifyClaim(transfer *models.Transfer, receiver *models.User) error
+}
+
+// EmailClaimNotifier - Claim email (works for everyone, so it is also the fallback)
+type EmailClaimNotifier struct {
+	emailService *EmailService // Composition: HAS-A email service
+}
+
+// NotifyClaim - Sends the claim invitation email
+func (n *EmailClaimNotifier) NotifyClaim(transfer *models.Transfer, receiver *models.User) error {
+	return n.emailService.SendTransferEmail(transfer)
+}
+
+// SMSClaimNotifier - Claim text message through an HTTP SMS gateway
+type SMSClaimNotifier struct {
+	gatewayURL  string       // SMS gateway endpoint
+	apiKey      string       // Sent as X-API-Key when set
+	frontendURL string       // Claim link base
+	client      *http.Client // Shared HTTP client
+}
+
+// NotifyClaim - POSTs {"to", "body"} to the gateway
+func (n *SMSClaimNotifier) NotifyClaim(transfer *models.Transfer, receiver *models.User) error {
+	if receiver == nil || receiver.Phone == "" {
+		return fmt.Errorf("receiver %s has no phone number", transfer.ReceiverEmail)
+	}
+
+	body, _ := json.Marshal(map[string]string{
+		"to": receiver.Phone,
+		"body": fmt.Sprintf("%s sent you %d virtual points. Claim within 24h: %s/#/claim/%s",
+			transfer.SenderEmail, transfer.Points, n.frontendURL, transfer.Token),
+	})
+	req, err := http.NewRequest("POST", n.gatewayURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.apiKey != "" {
+		req.Header.Set("X-API-Key", n.apiKey)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sms gateway responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// NotificationRouter - Routes claim notifications to the receiver's preferred channel
+type NotificationRouter struct {
+	auth         AuthGateway                      // Composition: HAS-A receiver lookup
+	transferRepo *repositories.TransferRepository // Composition: HAS-A transfer store (records the channel)
+	channels     map[string]ClaimNotifier         // Strategy per channel
+}
+
+// NewNotificationRouter - Factory method; SMS is only available when a gateway is configured
+func NewNotificationRouter(auth AuthGateway, transferRepo *repositories.TransferRepository, emailService *EmailService, cfg *config.Config) *NotificationRouter {
+	router := &NotificationRouter{
+		auth:         auth,
+		transferRepo: transferRepo,
+		channels: map[string]ClaimNotifier{
+			ChannelEmail: &EmailClaimNotifier{emailService: emailService},
+		},
+	}
+	if cfg.Notifications.SMSGatewayURL != "" {
+		router.channels[ChannelSMS] = &SMSClaimNotifier{
+			gatewayURL:  cfg.Notifications.SMSGatewayURL,
+			apiKey:      cfg.Notifications.SMSAPIKey,
+			frontendURL: cfg.Frontend.URL,
+			client:      &http.Client{Timeout: 10 * time.Second},
+		}
+	}
+	return router
+}
+
+// Register - Adds or replaces the notifier for a channel
+func (r *NotificationRouter) Register(channel string, notifier ClaimNotifier) {
+	r.channels[channel] = notifier
+}
+
+// NotifyClaim - Looks up the receiver and notifies on their preferred channel, falling back to email
+func (r *NotificationRouter) NotifyClaim(transfer *models.Transfer) error {
+	// 1. RECEIVER LOOKUP: Unknown receivers (or Auth outages) simply get the email
+	receiver, err := r.auth.FindUserByEmail(transfer.ReceiverEmail)
+	if err != nil {
+		fmt.Printf("Receiver lookup failed for %s, defaulting to email: %v\n", transfer.ReceiverEmail, err)
+	}
+
+	channel := ChannelEmail
+	if receiver != nil && receiver.NotificationChannel != "" {
+		channel = receiver.NotificationChannel
+	}
+
+	// 2. IN-APP: Registered receivers always get an in-app record so they see the gift on login
+	if inApp, ok := r.channels[ChannelInApp]; ok && receiver != nil && channel != ChannelInApp {
+		if err := inApp.NotifyClaim(transfer, receiver); err != nil {
+			fmt.Printf("Failed to create in-app notification for %s: %v\n", transfer.ID, err)
+		}
+	}
+
+	// 3. PREFERRED CHANNEL: Fall back to email if unsupported or failing
+	if notifier, ok := r.channels[channel]; ok && channel != ChannelEmail {
+		err := notifier.NotifyClaim(transfer, receiver)
+		if err == nil {
+			r.recordChannel(transfer, channel)
+			return nil
+		}
+		fmt.Printf("Claim notification via %s failed for %s, falling back to email: %v\n", channel, transfer.ID, err)
+	}
+
+	if err := r.channels[ChannelEmail].NotifyClaim(transfer, receiver); err != nil {
+		return err
+	}
+	r.recordChannel(transfer, ChannelEmail)
+	return nil
+}
+
+// recordChannel - Stores where the claim notification went (single-column update, safe alongside the saga)
+func (r *NotificationRouter) recordChannel(transfer *models.Transfer, channel string) {
+	transfer.NotificationChannel = channel
+	if err := r.transferRepo.UpdateNotificationChannel(transfer); err != nil {
+		fmt.Printf("Failed to record notification channel for %s: %v\n", transfer.ID, err)
+	}
+}
@@ -0,0 +1,71 @@
+// DESIGN PATTERN: Service Layer + Strategy Pattern (in-app ClaimNotifier)
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/idgen"
+	"sender-service/models"
+	"sender-service/repositories"
+)
+
+var ErrNotificationNotFound = apperrors.New(apperrors.ErrNotFound, "notification_not_found", "notification not found")
+
+// notificationPageSize - Maximum notifications returned per request
+const notificationPageSize = 50
+
+// NotificationService - In-app notifications for registered users
+type NotificationService struct {
+	notificationRepo *repositories.NotificationRepository // Composition: HAS-A repository
+	clock            clock.Clock                          // Composition: HAS-A time source
+	ids              idgen.Generator                      // Composition: HAS-A ID generator
+	frontendURL      string                               // Claim deep link base
+}
+
+// NewNotificationService - Factory method with dependency injection
+func NewNotificationService(notificationRepo *repositories.NotificationRepository, clk clock.Clock, ids idgen.Generator, cfg *config.Config) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		clock:            clk,
+		ids:              ids,
+		frontendURL:      cfg.Frontend.URL,
+	}
+}
+
+// NotifyClaim - ClaimNotifier: records a pending-gift notification for a registered receiver
+func (s *NotificationService) NotifyClaim(transfer *models.Transfer, receiver *models.User) error {
+	if receiver == nil {
+		return errors.New("in-app notifications require a registered receiver")
+	}
+
+	return s.notificationRepo.Create(&models.Notification{
+		ID:         s.ids.NewID("notification"),
+		UserID:     receiver.ID,
+		Type:       models.NotificationTransferReceived,
+		Title:      fmt.Sprintf("You received %d points", transfer.Points),
+		Body:       fmt.Sprintf("%s sent you %d virtual points. Claim them before they expire.", transfer.SenderEmail, transfer.Points),
+		TransferID: transfer.ID,
+		ClaimURL:   fmt.Sprintf("%s/#/claim/%s", s.frontendURL, transfer.Token),
+		CreatedAt:  s.clock.Now(),
+	})
+}
+
+// List - User's latest notifications
+func (s *NotificationService) List(userID string, unreadOnly bool) ([]models.Notification, error) {
+	return s.notificationRepo.FindByUserID(userID, unreadOnly, notificationPageSize)
+}
+
+// MarkRead - Marks a notification read (idempotent)
+func (s *NotificationService) MarkRead(userID, notificationID string) error {
+	found, err := s.notificationRepo.MarkRead(userID, notificationID, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// DESIGN PATTERN: Service Layer + Scheduled Job
+package services
+
+import (
+	"context"
+	"fmt"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/models"
+	"sender-service/repositories"
+)
+
+// nudgeBatchSize - Transfers nudged per shard per run
+const nudgeBatchSize = 500
+
+// NudgeService - Reminds senders about transfers that are still unclaimed late in their lifetime
+type NudgeService struct {
+	transferRepo   *repositories.TransferRepository   // Composition: HAS-A transfer store
+	preferenceRepo *repositories.PreferenceRepository // Composition: HAS-A preference store (opt-out)
+	emailService   *EmailService                      // Composition: HAS-A email service
+	clock          clock.Clock                        // Composition: HAS-A time source
+	config         *config.Config                     // Composition: HAS-A configuration
+}
+
+// NewNudgeService - Factory method with dependency injection
+func NewNudgeService(transferRepo *repositories.TransferRepository,
+	preferenceRepo *repositories.PreferenceRepository,
+	emailService *EmailService,
+	clk clock.Clock,
+	cfg *config.Config) *NudgeService {
+	return &NudgeService{
+		transferRepo:   transferRepo,
+		preferenceRepo: preferenceRepo,
+		emailService:   emailService,
+		clock:          clk,
+		config:         cfg,
+	}
+}
+
+// Run - Scheduler job: nudges senders of stale pending transfers
+func (s *NudgeService) Run(ctx context.Context) error {
+	now := s.clock.Now()
+	stale, err := s.transferRepo.FindStalePending(s.config.Nudge.LifetimeFraction, now, nudgeBatchSize)
+	if err != nil {
+		return err
+	}
+
+	optedOut := map[string]bool{}
+	for i := range stale {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		transfer := &stale[i]
+
+		// 1. OPT-OUT: Look each sender up once per run
+		skip, seen := optedOut[transfer.SenderID]
+		if !seen {
+			preference, err := s.preferenceRepo.FindByUserID(transfer.SenderID)
+			skip = err == nil && preference.StaleNudgeOptOut
+			optedOut[transfer.SenderID] = skip
+		}
+
+		// 2. SEND: Opted-out transfers are still marked so they are not re-checked every run
+		if !skip {
+			if err := s.send(transfer); err != nil {
+				fmt.Printf("Failed to nudge sender %s about transfer %s: %v\n", transfer.SenderID, transfer.ID, err)
+				continue
+			}
+		}
+
+		if err := s.transferRepo.MarkNudged(transfer, now); err != nil {
+			fmt.Printf("Failed to mark transfer %s as nudged: %v\n", transfer.ID, err)
+		}
+	}
+	return nil
+}
+
+// send - Emails the sender a reminder with resend/cancel suggestions
+func (s *NudgeService) send(transfer *models.Transfer) error {
+	return s.emailService.SendTemplate(transfer.SenderEmail, TemplateStaleTransferNudge, StaleNudgeEmailData{
+		ReceiverName:  transfer.ReceiverName,
+		ReceiverEmail: transfer.ReceiverEmail,
+		Points:        transfer.Points,
+		ExpiresAt:     transfer.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"),
+		ManageURL:     fmt.Sprintf("%s/#/transfers/%s", s.config.Frontend.URL, transfer.ID),
+	})
+}
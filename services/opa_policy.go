@@ -0,0 +1,170 @@
+// DESIGN PATTERN: Adapter Pattern (Open Policy Agent as a ValidationHook)
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"sender-service/config"
+	"sender-service/models"
+	"strings"
+	"time"
+)
+
+// PolicyEvaluator - Evaluates an OPA decision for the given input document
+type PolicyEvaluator interface {
+	Evaluate(ctx context.Context, input map[string]interface{}) (*PolicyDecision, error)
+}
+
+// PolicyDecision - Expected shape of the policy result: {"allow": bool, "reasons": [...]}
+type PolicyDecision struct {
+	Allow   bool     `json:"allow"`
+	Reasons []string `json:"reasons"`
+}
+
+// OPAPolicyHook - Vetoes initiations/completions that the organization's policy denies
+type OPAPolicyHook struct {
+	evaluator PolicyEvaluator // Strategy: sidecar HTTP or local bundle
+	timeout   time.Duration   // Per-evaluation timeout
+	failOpen  bool            // Allow operations when OPA is unavailable
+}
+
+// NewOPAPolicyHook - Factory method; returns nil when no policy source is configured
+func NewOPAPolicyHook(cfg *config.Config) *OPAPolicyHook {
+	var evaluator PolicyEvaluator
+	switch {
+	case cfg.Policy.URL != "":
+		evaluator = &OPASidecarEvaluator{
+			url:    strings.TrimSuffix(cfg.Policy.URL, "/") + "/v1/data/" + strings.Trim(cfg.Policy.DecisionPath, "/"),
+			client: &http.Client{},
+		}
+	case cfg.Policy.BundlePath != "":
+		evaluator = &OPABundleEvaluator{
+			binary: cfg.Policy.Binary,
+			bundle: cfg.Policy.BundlePath,
+			query:  "data." + strings.ReplaceAll(strings.Trim(cfg.Policy.DecisionPath, "/"), "/", "."),
+		}
+	default:
+		return nil
+	}
+	return &OPAPolicyHook{evaluator: evaluator, timeout: cfg.Policy.Timeout, failOpen: cfg.Policy.FailOpen}
+}
+
+// Name - Hook identifier
+func (h *OPAPolicyHook) Name() string {
+	return "opa"
+}
+
+// BeforeInitiate - Input: {"stage": "initiate", "sender", "request"}
+func (h *OPAPolicyHook) BeforeInitiate(sender *models.User, req *models.TransferRequest) error {
+	return h.check(map[string]interface{}{"stage": "initiate", "sender": sender, "request": req})
+}
+
+// BeforeComplete - Input: {"stage": "complete", "transfer"}
+func (h *OPAPolicyHook) BeforeComplete(transfer *models.Transfer) error {
+	return h.check(map[string]interface{}{"stage": "complete", "transfer": transfer})
+}
+
+// check - Evaluates the policy and turns a deny into a veto
+func (h *OPAPolicyHook) check(input map[string]interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	decision, err := h.evaluator.Evaluate(ctx, input)
+	if err != nil {
+		fmt.Printf("OPA policy evaluation failed: %v\n", err)
+		if h.failOpen {
+			return nil
+		}
+		return errors.New("policy engine unavailable")
+	}
+	if !decision.Allow {
+		if len(decision.Reasons) == 0 {
+			return errors.New("denied by policy")
+		}
+		return errors.New(strings.Join(decision.Reasons, "; "))
+	}
+	return nil
+}
+
+// OPASidecarEvaluator - Queries an OPA server via its Data API
+type OPASidecarEvaluator struct {
+	url    string       // e.g. http://localhost:8181/v1/data/sender/transfer
+	client *http.Client // Shared HTTP client
+}
+
+// Evaluate - POST {"input": ...} and read {"result": {...}}
+func (e *OPASidecarEvaluator) Evaluate(ctx context.Context, input map[string]interface{}) (*PolicyDecision, error) {
+	body, err := json.Marshal(map[string]interface{}{"input": input})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OPA responded with status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Result *PolicyDecision `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid OPA response: %v", err)
+	}
+	if result.Result == nil {
+		return nil, errors.New("policy decision is undefined")
+	}
+	return result.Result, nil
+}
+
+// OPABundleEvaluator - Evaluates a local policy bundle with the opa CLI
+type OPABundleEvaluator struct {
+	binary string // Path to the opa executable
+	bundle string // Bundle directory or .tar.gz
+	query  string // e.g. data.sender.transfer
+}
+
+// Evaluate - Runs `opa eval --bundle <path> --stdin-input <query>`
+func (e *OPABundleEvaluator) Evaluate(ctx context.Context, input map[string]interface{}) (*PolicyDecision, error) {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, e.binary, "eval", "--format", "json", "--bundle", e.bundle, "--stdin-input", e.query)
+	cmd.Stdin = bytes.NewReader(body)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("opa eval failed: %v", err)
+	}
+
+	var result struct {
+		Result []struct {
+			Expressions []struct {
+				Value PolicyDecision `json:"value"`
+			} `json:"expressions"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, fmt.Errorf("invalid opa eval output: %v", err)
+	}
+	if len(result.Result) == 0 || len(result.Result[0].Expressions) == 0 {
+		return nil, errors.New("policy decision is undefined")
+	}
+	return &result.Result[0].Expressions[0].Value, nil
+}
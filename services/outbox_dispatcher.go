@@ -0,0 +1,490 @@
+// DESIGN PATTERN: Transactional Outbox Dispatcher + Polling Consumer
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/models"
+	"sender-service/repositories"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OutboxDispatcher - Delivers transfer outbox messages (emails, claim notifications, events, audit entries) with retries
+type OutboxDispatcher struct {
+	transferRepo *repositories.TransferRepository // Composition: HAS-A shard-local outboxes
+	auditRepo    *repositories.AuditRepository    // Composition: HAS-A audit trail (primary database)
+	events       *EventPublisher                  // Composition: HAS-A event outbox (relay delivers to consumers)
+	notifier     *NotificationRouter              // Composition: HAS-A claim notification router
+	emailService *EmailService                    // Composition: HAS-A email service
+	deadLetters  *DeadLetterService               // Composition: HAS-A dead-letter channel
+	clock        clock.Clock                      // Composition: HAS-A time source (retry scheduling)
+	interval     time.Duration                    // Poll interval
+	batchSize    int                              // Messages fetched per shard per poll
+	maxAttempts  int                              // Attempts before a message is dead-lettered
+	emailWorkers int                              // Email-bound messages delivered at once
+	provider     string                           // EmailProviderKey, recorded on each email-bound attempt
+	emailMax     int                              // Attempts before an email-bound message is dead-lettered
+	emailDelay   time.Duration                    // First email retry delay (doubles per attempt)
+	emailCap     time.Duration                    // Largest email retry delay
+	emailJitter  float64                          // Random spread of email retry delays (fraction)
+}
+
+// NewOutboxDispatcher - Factory method with dependency injection
+func NewOutboxDispatcher(transferRepo *repositories.TransferRepository, auditRepo *repositories.AuditRepository, events *EventPublisher,
+	notifier *NotificationRouter, emailService *EmailService, deadLetters *DeadLetterService, clk clock.Clock, cfg *config.Config) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		transferRepo: transferRepo,
+		auditRepo:    auditRepo,
+		events:       events,
+		notifier:     notifier,
+		emailService: emailService,
+		deadLetters:  deadLetters,
+		clock:        clk,
+		interval:     cfg.Outbox.DispatchInterval,
+		batchSize:    cfg.Outbox.BatchSize,
+		maxAttempts:  cfg.Outbox.MaxAttempts,
+		emailWorkers: cfg.Outbox.EmailWorkers,
+		provider:     EmailProviderKey(cfg),
+		emailMax:     cfg.Outbox.EmailMaxAttempts,
+		emailDelay:   cfg.Outbox.EmailBaseBackoff,
+		emailCap:     cfg.Outbox.EmailMaxBackoff,
+		emailJitter:  cfg.Outbox.EmailBackoffJitter,
+	}
+}
+
+// Start - Polls the transfer outboxes until the context is cancelled (run in its own goroutine)
+func (d *OutboxDispatcher) Start(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.DispatchOnce(); err != nil {
+				fmt.Printf("Outbox dispatcher error: %v\n", err)
+			}
+		}
+	}
+}
+
+// NotificationBacklog - Claim notifications held back by the email circuit
+type NotificationBacklog struct {
+	EmailCircuitOpen bool  `json:"email_circuit_open"` // Email-bound outbox messages are being held
+	Deferred         int64 `json:"deferred"`           // Pending transfers flagged notification_deferred
+}
+
+// NotificationBacklog - Current deferred-notification count (admin metrics)
+func (d *OutboxDispatcher) NotificationBacklog() (*NotificationBacklog, error) {
+	deferred, err := d.transferRepo.CountNotificationDeferred()
+	if err != nil {
+		return nil, err
+	}
+	return &NotificationBacklog{EmailCircuitOpen: d.emailService.Deferring(), Deferred: deferred}, nil
+}
+
+// EmailQueue - Durable email jobs (email and claim notification outbox messages) across every shard
+type EmailQueue struct {
+	Workers        int              `json:"workers"`                    // OUTBOX_EMAIL_WORKERS
+	Queued         int64            `json:"queued"`                     // Waiting for their first attempt
+	Retrying       int64            `json:"retrying"`                   // Failed at least once; retried after backoff
+	Dead           int64            `json:"dead"`                       // Attempts exhausted; see the dead-letter API
+	OldestQueuedAt *time.Time       `json:"oldest_queued_at,omitempty"` // Creation time of the oldest undelivered job
+	Failing        []EmailJobStatus `json:"failing"`                    // Retrying jobs, next retry first
+}
+
+// EmailJobStatus - One retrying email job (the payload is never exposed)
+type EmailJobStatus struct {
+	MessageID     uint      `json:"message_id"`      // Outbox message ID (per shard)
+	TransferID    string    `json:"transfer_id"`     // Transfer the email belongs to
+	Kind          string    `json:"kind"`            // email, claim_notification
+	Attempts      int       `json:"attempts"`        // Attempts so far
+	LastError     string    `json:"last_error"`      // Most recent delivery error
+	NextAttemptAt time.Time `json:"next_attempt_at"` // When the next attempt is due
+}
+
+// emailQueueFailingLimit - Retrying jobs listed by EmailQueue
+const emailQueueFailingLimit = 50
+
+// EmailQueue - Backlog, retries and dead jobs of the email queue (admin metrics)
+func (d *OutboxDispatcher) EmailQueue() (*EmailQueue, error) {
+	counts, err := d.transferRepo.CountOutboxQueue(emailBoundKinds)
+	if err != nil {
+		return nil, err
+	}
+	retrying, err := d.transferRepo.FindRetryingOutbox(emailBoundKinds, emailQueueFailingLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := &EmailQueue{Workers: d.emailWorkers, Queued: counts.Queued, Retrying: counts.Retrying, Dead: counts.Dead,
+		OldestQueuedAt: counts.Oldest, Failing: make([]EmailJobStatus, 0, len(retrying))}
+	for _, message := range retrying {
+		queue.Failing = append(queue.Failing, EmailJobStatus{MessageID: message.ID, TransferID: message.TransferID, Kind: message.Kind,
+			Attempts: message.Attempts, LastError: message.LastError, NextAttemptAt: message.NextAttemptAt})
+	}
+	return queue, nil
+}
+
+// EmailRequeue - Outcome of a bulk re-queue of email jobs (or its preview, on a dry run)
+type EmailRequeue struct {
+	DryRun   bool              `json:"dry_run"`  // Nothing was changed
+	Matched  int               `json:"matched"`  // Jobs selected (at most the limit)
+	Requeued int               `json:"requeued"` // Jobs handed back to the dispatcher
+	Failed   int               `json:"failed"`   // Jobs that could not be re-queued (see their error)
+	HasMore  bool              `json:"has_more"` // More jobs match than the limit allowed
+	Jobs     []EmailRequeueJob `json:"jobs"`     // Selected jobs, oldest first
+}
+
+// EmailRequeueJob - One selected email job (the payload is never exposed)
+type EmailRequeueJob struct {
+	MessageID    uint      `json:"message_id"`               // Outbox message ID (per shard)
+	TransferID   string    `json:"transfer_id"`              // Transfer the email belongs to
+	Kind         string    `json:"kind"`                     // email, claim_notification
+	Provider     string    `json:"provider,omitempty"`       // Provider of the latest attempt
+	Attempts     int       `json:"attempts"`                 // Attempts before the re-queue
+	LastError    string    `json:"last_error"`               // Most recent delivery error
+	CreatedAt    time.Time `json:"created_at"`               // When the job was queued
+	DeadLetterID string    `json:"dead_letter_id,omitempty"` // Open dead letter resolved by the re-queue
+	Error        string    `json:"error,omitempty"`          // Why the re-queue failed
+}
+
+// RequeueEmails - Hands dead (or still retrying) email jobs back to the dispatcher with a fresh retry budget,
+// e.g. after an SMTP outage. Dead jobs are re-driven through their dead letter so its history records the retry.
+func (d *OutboxDispatcher) RequeueEmails(filter repositories.OutboxRequeueFilter, dryRun bool) (*EmailRequeue, error) {
+	// 1. SELECT: One extra row tells whether more jobs match
+	limit := filter.Limit
+	filter.Kinds = emailBoundKinds
+	filter.Limit = limit + 1
+	messages, err := d.transferRepo.FindOutboxForRequeue(filter)
+	if err != nil {
+		return nil, err
+	}
+	result := &EmailRequeue{DryRun: dryRun, HasMore: len(messages) > limit, Jobs: []EmailRequeueJob{}}
+	if result.HasMore {
+		messages = messages[:limit]
+	}
+	result.Matched = len(messages)
+
+	// 2. RE-QUEUE: Each job on its own, so one stale transfer does not stop the rest
+	now := d.clock.Now()
+	for i := range messages {
+		message := &messages[i]
+		job := EmailRequeueJob{MessageID: message.ID, TransferID: message.TransferID, Kind: message.Kind, Provider: message.Provider,
+			Attempts: message.Attempts, LastError: message.LastError, CreatedAt: message.CreatedAt}
+		var deadLetter *models.DeadLetter
+		if !filter.Retrying {
+			if open, err := d.deadLetters.OpenFor(models.DeadLetterKindOutbox, outboxReference(message)); err == nil {
+				deadLetter = open
+				job.DeadLetterID = open.ID
+			}
+		}
+		if !dryRun {
+			var requeueErr error
+			switch {
+			case filter.Retrying:
+				requeueErr = d.transferRepo.RescheduleOutbox(message.TransferID, message.ID, now)
+			case deadLetter != nil:
+				_, requeueErr = d.deadLetters.Retry(deadLetter.ID)
+			default:
+				requeueErr = d.transferRepo.RequeueOutbox(message.TransferID, message.ID, now)
+			}
+			if requeueErr != nil {
+				job.Error = requeueErr.Error()
+				result.Failed++
+			} else {
+				result.Requeued++
+			}
+		}
+		result.Jobs = append(result.Jobs, job)
+	}
+
+	if !dryRun {
+		fmt.Printf("Email requeue: %d of %d job(s) re-queued, %d failed\n", result.Requeued, result.Matched, result.Failed)
+	}
+	return result, nil
+}
+
+// errNotificationObsolete - The transfer left pending before its claim notification went out; the message
+// is settled without notifying anyone
+var errNotificationObsolete = errors.New("transfer is no longer pending")
+
+// emailBoundKinds - Outbox kinds that need the email provider (claim notifications fall back to email)
+var emailBoundKinds = []string{models.OutboxMessageClaimNotification, models.OutboxMessageEmail}
+
+// emailBound - Whether an outbox kind is delivered by the email worker pool with the email retry budget
+func emailBound(kind string) bool {
+	return kind == models.OutboxMessageClaimNotification || kind == models.OutboxMessageEmail
+}
+
+// DispatchOnce - Delivers one batch per shard; events of a transfer keep their order, other kinds retry independently
+// and are handed to a pool of OUTBOX_EMAIL_WORKERS concurrent senders
+func (d *OutboxDispatcher) DispatchOnce() error {
+	return d.transferRepo.ForEachOutbox(func(outbox *repositories.TransferOutbox) error {
+		// GRACEFUL DEGRADATION: While the email circuit is open, email-bound messages stay queued without
+		// using up attempts; they are flushed on the first poll after the provider recovers
+		var held []string
+		if d.emailService.Deferring() {
+			held = emailBoundKinds
+			flagged, err := outbox.FlagDeferredNotifications()
+			if err != nil {
+				return err
+			}
+			if flagged > 0 {
+				fmt.Printf("Email circuit open: deferred %d claim notifications\n", flagged)
+			}
+		}
+
+		messages, err := outbox.FetchPending(d.batchSize, held...)
+		if err != nil {
+			return err
+		}
+		deadTransfers, err := outbox.DeadEventTransferIDs()
+		if err != nil {
+			return err
+		}
+
+		now := d.clock.Now()
+		blocked := map[string]bool{} // Transfers whose earlier event is not yet handed over
+		for _, transferID := range deadTransfers {
+			blocked[transferID] = true
+		}
+		var emails []*models.TransferOutboxMessage // Due email-bound messages, sent by the worker pool
+
+		for i := range messages {
+			message := &messages[i]
+			isEvent := message.Kind == models.OutboxMessageEvent
+
+			// ORDERING GUARANTEE: Never forward an event before its predecessors
+			if isEvent && blocked[message.TransferID] {
+				continue
+			}
+			if message.NextAttemptAt.After(now) {
+				if isEvent {
+					blocked[message.TransferID] = true
+				}
+				continue
+			}
+
+			if emailBound(message.Kind) {
+				emails = append(emails, message)
+				continue
+			}
+
+			message.Attempts++
+			deliveryErr := d.deliver(message)
+			if deliveryErr != nil {
+				blocked[message.TransferID] = true
+			}
+			// AT-LEAST-ONCE: The outcome is written after delivery; a crash here means redelivery, never loss
+			if err := d.settle(outbox, message, deliveryErr, now); err != nil {
+				return err
+			}
+		}
+
+		// WORKER POOL: Emails do not depend on each other, so a slow provider call no longer holds up the rest
+		for i, deliveryErr := range d.deliverConcurrently(emails) {
+			if err := d.settle(outbox, emails[i], deliveryErr, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// deliverConcurrently - Delivers the messages on at most emailWorkers goroutines; errors line up with messages
+func (d *OutboxDispatcher) deliverConcurrently(messages []*models.TransferOutboxMessage) []error {
+	errs := make([]error, len(messages))
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for range min(d.emailWorkers, len(messages)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				messages[i].Attempts++
+				messages[i].Provider = d.provider // Bulk re-queues filter on it
+				errs[i] = d.deliver(messages[i])
+			}
+		}()
+	}
+	for i := range messages {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+	return errs
+}
+
+// settle - Records a delivery outcome (sent, retry scheduled or dead-lettered) in the shard's outbox;
+// a delivered or dead claim notification also updates its transfer's email status
+func (d *OutboxDispatcher) settle(outbox *repositories.TransferOutbox, message *models.TransferOutboxMessage, deliveryErr error, now time.Time) error {
+	obsolete := errors.Is(deliveryErr, errNotificationObsolete)
+	if deliveryErr != nil && !obsolete {
+		d.fail(message, deliveryErr, now)
+	} else {
+		message.Status = models.OutboxMessageSent
+		message.LastError = ""
+		message.SentAt = &now
+	}
+	if err := outbox.Save(message); err != nil {
+		return err
+	}
+
+	if message.Kind != models.OutboxMessageClaimNotification || obsolete {
+		return nil
+	}
+	switch message.Status {
+	case models.OutboxMessageSent:
+		return outbox.SetEmailStatus(message.TransferID, models.EmailStatusSent, &now)
+	case models.OutboxMessageDead:
+		return outbox.SetEmailStatus(message.TransferID, models.EmailStatusFailed, nil)
+	}
+	return nil
+}
+
+// deliver - Performs the side effect a message stands for
+func (d *OutboxDispatcher) deliver(message *models.TransferOutboxMessage) error {
+	switch message.Kind {
+	case models.OutboxMessageEvent:
+		return d.events.Forward(message.Payload)
+
+	case models.OutboxMessageClaimNotification:
+		var notification models.OutboxClaimNotification
+		if err := json.Unmarshal([]byte(message.Payload), &notification); err != nil || notification.Token == "" {
+			return errors.New("claim notification carries no claim token")
+		}
+		transfer, err := d.transferRepo.FindByID(message.TransferID)
+		if err != nil {
+			return errors.New("transfer not found")
+		}
+		if transfer.Status != models.TransferStatusPending {
+			fmt.Printf("Skipping claim notification for %s: transfer is %s\n", transfer.ID, transfer.Status)
+			message.Payload = "" // The link is dead; drop the raw token
+			return errNotificationObsolete
+		}
+		transfer.Token = notification.Token // Stored hashed; the links need the raw token
+		notifyErr := d.notifier.NotifyClaim(transfer)
+		transfer.Token = "" // Not kept past the send, whatever its outcome
+		if notifyErr != nil {
+			return notifyErr
+		}
+		message.Payload = "" // The raw token now lives only in the delivered notification
+		fmt.Printf("Claim notification sent to %s via %s\n", transfer.ReceiverEmail, transfer.NotificationChannel)
+		if transfer.NotificationDeferred {
+			if err := d.transferRepo.MarkNotificationDeferred(transfer, false); err != nil {
+				fmt.Printf("Failed to clear deferred notification for %s: %v\n", transfer.ID, err)
+			}
+		}
+		return nil
+
+	case models.OutboxMessageEmail:
+		var email models.OutboxEmail
+		if err := json.Unmarshal([]byte(message.Payload), &email); err != nil {
+			return fmt.Errorf("corrupt outbox email: %v", err)
+		}
+		return d.emailService.send(email.To, email.Template, &RenderedEmail{Subject: email.Subject, HTML: email.HTML, Text: email.Text})
+
+	case models.OutboxMessageAudit:
+		var entry models.TransferAuditLog
+		if err := json.Unmarshal([]byte(message.Payload), &entry); err != nil {
+			return fmt.Errorf("corrupt outbox audit entry: %v", err)
+		}
+		return d.auditRepo.CreateOnce(&entry)
+	}
+	return fmt.Errorf("unknown outbox message kind %s", message.Kind)
+}
+
+// fail - Schedules a retry, or dead-letters the message once its attempts are exhausted
+// (emails and claim notifications follow OUTBOX_EMAIL_*, events and audit entries the relay's backoff)
+func (d *OutboxDispatcher) fail(message *models.TransferOutboxMessage, deliveryErr error, now time.Time) {
+	message.LastError = deliveryErr.Error()
+	maxAttempts, backoff := d.maxAttempts, relayBackoff(message.Attempts)
+	if emailBound(message.Kind) {
+		maxAttempts, backoff = d.emailMax, d.emailBackoff(message.Attempts)
+	}
+	if message.Attempts < maxAttempts {
+		message.NextAttemptAt = now.Add(backoff)
+		return
+	}
+
+	message.Status = models.OutboxMessageDead
+	payload := message.Payload
+	if message.Kind == models.OutboxMessageClaimNotification {
+		payload = "" // Retries use the outbox row; the raw claim token is not copied into dead letters
+	}
+	if err := d.deadLetters.Record(models.DeadLetterKindOutbox, outboxReference(message), payload,
+		fmt.Sprintf("%s delivery failed after %d attempts: %v", message.Kind, message.Attempts, deliveryErr), message.Attempts,
+		[]models.DeadLetterAttempt{{At: now, Error: deliveryErr.Error()}}); err != nil {
+		fmt.Printf("%v\n", err)
+	}
+}
+
+// emailBackoff - Exponential email retry delay, capped and spread by +/- the jitter fraction so
+// messages that failed together (provider outage) do not all retry at the same moment
+func (d *OutboxDispatcher) emailBackoff(attempt int) time.Duration {
+	backoff := d.emailDelay
+	for i := 1; i < attempt && backoff < d.emailCap; i++ {
+		backoff *= 2
+	}
+	if backoff > d.emailCap {
+		backoff = d.emailCap
+	}
+	if d.emailJitter > 0 {
+		backoff = time.Duration(float64(backoff) * (1 + d.emailJitter*(2*rand.Float64()-1)))
+	}
+	return backoff
+}
+
+// outboxReference - Dead-letter reference "<transfer ID>/<message ID>" (message IDs are per shard)
+func outboxReference(message *models.TransferOutboxMessage) string {
+	return fmt.Sprintf("%s/%d", message.TransferID, message.ID)
+}
+
+// OutboxDeadLetterHandler - Re-queues dead transfer outbox messages
+type OutboxDeadLetterHandler struct {
+	transferRepo *repositories.TransferRepository // Composition: HAS-A shard-local outboxes
+	clock        clock.Clock                      // Composition: HAS-A time source
+}
+
+// NewOutboxDeadLetterHandler - Factory method with dependency injection
+func NewOutboxDeadLetterHandler(transferRepo *repositories.TransferRepository, clk clock.Clock) *OutboxDeadLetterHandler {
+	return &OutboxDeadLetterHandler{transferRepo: transferRepo, clock: clk}
+}
+
+// Retry - Puts the message back in its shard's outbox; the dispatcher delivers it (and unblocks later events)
+func (h *OutboxDeadLetterHandler) Retry(deadLetter *models.DeadLetter) error {
+	transferID, id, err := parseOutboxReference(deadLetter.ReferenceID)
+	if err != nil {
+		return err
+	}
+	return h.transferRepo.RequeueOutbox(transferID, id, h.clock.Now())
+}
+
+// Discard - Drops the message so the transfer's later events can flow
+func (h *OutboxDeadLetterHandler) Discard(deadLetter *models.DeadLetter) error {
+	transferID, id, err := parseOutboxReference(deadLetter.ReferenceID)
+	if err != nil {
+		return err
+	}
+	return h.transferRepo.DiscardOutbox(transferID, id)
+}
+
+// parseOutboxReference - Splits "<transfer ID>/<message ID>"
+func parseOutboxReference(reference string) (string, uint, error) {
+	transferID, rawID, ok := strings.Cut(reference, "/")
+	id, err := strconv.ParseUint(rawID, 10, 64)
+	if !ok || err != nil {
+		return "", 0, fmt.Errorf("invalid outbox reference %s", reference)
+	}
+	return transferID, uint(id), nil
+}
@@ -0,0 +1,149 @@
+// DESIGN PATTERN: Transactional Outbox Relay + Polling Consumer
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/models"
+	"sender-service/repositories"
+	"strconv"
+	"time"
+)
+
+// Relay retry backoff bounds
+const (
+	relayBaseBackoff = 2 * time.Second
+	relayMaxBackoff  = 5 * time.Minute
+)
+
+// OutboxRelay - Delivers outbox events to the sink in per-aggregate order with at-least-once semantics
+type OutboxRelay struct {
+	outbox      *repositories.OutboxRepository // Composition: HAS-A outbox store
+	sink        EventSink                      // Composition: HAS-A delivery transport
+	deadLetters *DeadLetterService             // Composition: HAS-A dead-letter channel
+	clock       clock.Clock                    // Composition: HAS-A time source (retry scheduling)
+	interval    time.Duration                  // Poll interval
+	batchSize   int                            // Events fetched per poll
+	maxAttempts int                            // Attempts before an event is dead-lettered
+}
+
+// NewOutboxRelay - Factory method with dependency injection
+func NewOutboxRelay(outbox *repositories.OutboxRepository, sink EventSink, deadLetters *DeadLetterService, clk clock.Clock, cfg *config.Config) *OutboxRelay {
+	return &OutboxRelay{
+		outbox:      outbox,
+		sink:        sink,
+		deadLetters: deadLetters,
+		clock:       clk,
+		interval:    cfg.Outbox.RelayInterval,
+		batchSize:   cfg.Outbox.BatchSize,
+		maxAttempts: cfg.Outbox.MaxAttempts,
+	}
+}
+
+// Start - Polls the outbox until the context is cancelled (run in its own goroutine)
+func (r *OutboxRelay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.RelayOnce(); err != nil {
+				fmt.Printf("Outbox relay error: %v\n", err)
+			}
+		}
+	}
+}
+
+// RelayOnce - Delivers one batch; only one instance relays at a time to keep ordering intact
+func (r *OutboxRelay) RelayOnce() error {
+	_, err := r.outbox.WithRelayLock(func(repo *repositories.OutboxRepository) error {
+		events, err := repo.FetchPendingBatch(r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		deadAggregates, err := repo.DeadAggregateIDs()
+		if err != nil {
+			return err
+		}
+
+		now := r.clock.Now()
+		blocked := map[string]bool{} // Aggregates whose earlier event is not yet delivered
+		for _, aggregateID := range deadAggregates {
+			blocked[aggregateID] = true
+		}
+		delivered := []uint{}
+
+		for _, event := range events {
+			// ORDERING GUARANTEE: Never deliver an event before its predecessors
+			if blocked[event.AggregateID] {
+				continue
+			}
+			if event.NextAttemptAt.After(now) {
+				blocked[event.AggregateID] = true
+				continue
+			}
+
+			if err := r.deliver(&event); err != nil {
+				blocked[event.AggregateID] = true
+				if markErr := r.fail(repo, &event, err, now); markErr != nil {
+					return markErr
+				}
+				continue
+			}
+			delivered = append(delivered, event.ID)
+		}
+
+		// AT-LEAST-ONCE: Markers are written after the sink acknowledged; a crash here means redelivery, never loss
+		return repo.MarkDelivered(delivered, now)
+	})
+	return err
+}
+
+// fail - Schedules a retry, or dead-letters the event once its attempts are exhausted
+func (r *OutboxRelay) fail(repo *repositories.OutboxRepository, event *models.OutboxEvent, deliveryErr error, now time.Time) error {
+	attempts := event.Attempts + 1
+	if attempts < r.maxAttempts {
+		return repo.MarkFailed(event.ID, deliveryErr.Error(), now.Add(relayBackoff(attempts)))
+	}
+
+	if err := repo.MarkDead(event.ID, deliveryErr.Error()); err != nil {
+		return err
+	}
+	return r.deadLetters.Record(models.DeadLetterKindEvent, strconv.FormatUint(uint64(event.ID), 10), event.Payload,
+		fmt.Sprintf("delivery failed after %d attempts: %v", attempts, deliveryErr), attempts,
+		[]models.DeadLetterAttempt{{At: now, Error: deliveryErr.Error()}})
+}
+
+// deliver - Stamps the per-aggregate sequence onto the envelope and hands it to the sink
+func (r *OutboxRelay) deliver(event *models.OutboxEvent) error {
+	var envelope models.DomainEvent
+	if err := json.Unmarshal([]byte(event.Payload), &envelope); err != nil {
+		return fmt.Errorf("corrupt outbox payload: %v", err)
+	}
+	envelope.Sequence = event.Sequence
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	return r.sink.Deliver(&envelope, payload)
+}
+
+// relayBackoff - Exponential backoff capped at relayMaxBackoff
+func relayBackoff(attempt int) time.Duration {
+	backoff := relayBaseBackoff
+	for i := 1; i < attempt && backoff < relayMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > relayMaxBackoff {
+		return relayMaxBackoff
+	}
+	return backoff
+}
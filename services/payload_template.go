@@ -0,0 +1,137 @@
+// DESIGN PATTERN: Interpreter Pattern (declarative payload mapping)
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"sender-service/apperrors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidTemplate - Returned when a webhook payload template cannot be compiled
+var ErrInvalidTemplate = apperrors.New(apperrors.ErrUnprocessable, "invalid_payload_template", "invalid payload template")
+
+// maxTemplateDepth - Nesting allowed in a template (keeps rendering cheap and bounded)
+const maxTemplateDepth = 8
+
+// PayloadTemplate - JSON document mirroring the desired payload. String values of the form
+// "$.path.to.field" are replaced by that field of the event envelope (null when missing); "$$..."
+// escapes a literal leading "$"; everything else is copied as is. No expressions are evaluated,
+// so templates can select and rename fields but never run code.
+type PayloadTemplate struct {
+	root interface{} // Parsed template
+}
+
+// CompilePayloadTemplate - Parses and checks a template (empty input means "send the envelope as is")
+func CompilePayloadTemplate(raw string) (*PayloadTemplate, error) {
+	if strings.TrimSpace(raw) == "" || strings.TrimSpace(raw) == "null" {
+		return nil, nil
+	}
+	var root interface{}
+	if err := json.Unmarshal([]byte(raw), &root); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	if _, ok := root.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("%w: template must be a JSON object", ErrInvalidTemplate)
+	}
+	if err := checkTemplate(root, 0); err != nil {
+		return nil, err
+	}
+	return &PayloadTemplate{root: root}, nil
+}
+
+// checkTemplate - Validates depth and field references
+func checkTemplate(node interface{}, depth int) error {
+	if depth > maxTemplateDepth {
+		return fmt.Errorf("%w: nested deeper than %d levels", ErrInvalidTemplate, maxTemplateDepth)
+	}
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for _, child := range value {
+			if err := checkTemplate(child, depth+1); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, child := range value {
+			if err := checkTemplate(child, depth+1); err != nil {
+				return err
+			}
+		}
+	case string:
+		if path, ok := templatePath(value); ok {
+			for _, segment := range path {
+				if segment == "" {
+					return fmt.Errorf("%w: empty segment in %q", ErrInvalidTemplate, value)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Render - Builds the payload for one event envelope
+func (t *PayloadTemplate) Render(envelope []byte) ([]byte, error) {
+	var event interface{}
+	if err := json.Unmarshal(envelope, &event); err != nil {
+		return nil, err
+	}
+	return json.Marshal(renderTemplate(t.root, event))
+}
+
+// renderTemplate - Recursively substitutes field references
+func renderTemplate(node, event interface{}) interface{} {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		rendered := make(map[string]interface{}, len(value))
+		for key, child := range value {
+			rendered[key] = renderTemplate(child, event)
+		}
+		return rendered
+	case []interface{}:
+		rendered := make([]interface{}, len(value))
+		for i, child := range value {
+			rendered[i] = renderTemplate(child, event)
+		}
+		return rendered
+	case string:
+		if strings.HasPrefix(value, "$$") {
+			return value[1:]
+		}
+		if path, ok := templatePath(value); ok {
+			return lookupPath(event, path)
+		}
+	}
+	return node
+}
+
+// templatePath - Splits "$.a.b" into ["a", "b"]; "$" alone selects the whole envelope
+func templatePath(value string) ([]string, bool) {
+	if value == "$" {
+		return []string{}, true
+	}
+	if !strings.HasPrefix(value, "$.") {
+		return nil, false
+	}
+	return strings.Split(value[2:], "."), true
+}
+
+// lookupPath - Walks objects by key (and arrays by index); missing fields yield nil
+func lookupPath(node interface{}, path []string) interface{} {
+	for _, segment := range path {
+		switch value := node.(type) {
+		case map[string]interface{}:
+			node = value[segment]
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(value) {
+				return nil
+			}
+			node = value[index]
+		default:
+			return nil
+		}
+	}
+	return node
+}
@@ -0,0 +1,50 @@
+// DESIGN PATTERN: Service Layer
+package services
+
+import (
+	"errors"
+	"sender-service/models"
+	"sender-service/repositories"
+)
+
+// PreferenceService - Business logic for per-user notification preferences
+type PreferenceService struct {
+	preferenceRepo *repositories.PreferenceRepository // Composition: HAS-A repository
+	auth           AuthGateway                        // Composition: HAS-A Auth Service gateway
+}
+
+// NewPreferenceService - Factory method with dependency injection
+func NewPreferenceService(preferenceRepo *repositories.PreferenceRepository, auth AuthGateway) *PreferenceService {
+	return &PreferenceService{preferenceRepo: preferenceRepo, auth: auth}
+}
+
+// Get - Current preferences (defaults when the user never set any)
+func (s *PreferenceService) Get(userID string) *models.NotificationPreference {
+	preference, err := s.preferenceRepo.FindByUserID(userID)
+	if err != nil {
+		return &models.NotificationPreference{UserID: userID, DigestFrequency: models.DigestNone}
+	}
+	return preference
+}
+
+// Update - Saves preferences, refreshing the delivery address from the Auth Service
+func (s *PreferenceService) Update(userID string, req models.PreferenceRequest) (*models.NotificationPreference, error) {
+	user, err := s.auth.GetUser(userID)
+	if err != nil {
+		return nil, errors.New("failed to get user details")
+	}
+
+	preference := s.Get(userID)
+	preference.Email = user.Email
+	if req.DigestFrequency != "" {
+		preference.DigestFrequency = req.DigestFrequency
+	}
+	if req.StaleNudgeOptOut != nil {
+		preference.StaleNudgeOptOut = *req.StaleNudgeOptOut
+	}
+
+	if err := s.preferenceRepo.Save(preference); err != nil {
+		return nil, errors.New("failed to save preferences")
+	}
+	return preference, nil
+}
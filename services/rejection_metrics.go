@@ -0,0 +1,104 @@
+// DESIGN PATTERN: Service Layer + Labeled Counter metric (why operations fail, by error code)
+package services
+
+import (
+	"errors"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sync"
+	"time"
+)
+
+// Operations whose outcomes are counted
+const (
+	OperationInitiate = "initiate" // Single and bulk transfer initiation
+	OperationClaim    = "claim"    // Claim link redemption and service-to-service completion
+)
+
+// OperationOutcomes - Outcome counters of one operation since process start
+type OperationOutcomes struct {
+	Attempts  int64            `json:"attempts"`  // Calls observed
+	Succeeded int64            `json:"succeeded"` // Calls that succeeded (idempotent replays included)
+	Rejected  int64            `json:"rejected"`  // Refused by a business rule (see Reasons)
+	Failed    int64            `json:"failed"`    // Internal, upstream or availability failures (see Failures)
+	Reasons   map[string]int64 `json:"reasons"`   // Error code -> rejections, e.g. insufficient_points
+	Failures  map[string]int64 `json:"failures"`  // Error code -> failures, e.g. upstream_failed
+}
+
+// RejectionStats - Outcome counters per operation, served by GET /admin/metrics/rejections
+type RejectionStats struct {
+	Since      time.Time                    `json:"since"`      // Process start
+	Operations map[string]OperationOutcomes `json:"operations"` // initiate, claim
+}
+
+// RejectionMetrics - Live counters of business-rule rejections, labeled by operation and error code
+type RejectionMetrics struct {
+	since      time.Time                     // Process start
+	mu         sync.Mutex                    // Guards operations
+	operations map[string]*OperationOutcomes // Operation -> counters
+}
+
+// NewRejectionMetrics - Factory method with dependency injection
+func NewRejectionMetrics(clk clock.Clock) *RejectionMetrics {
+	return &RejectionMetrics{since: clk.Now(), operations: map[string]*OperationOutcomes{}}
+}
+
+// Observe - Counts one call of operation by its outcome (nil = success); errors are labeled with
+// their apperrors code
+func (m *RejectionMetrics) Observe(operation string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	outcomes := m.outcomes(operation)
+	outcomes.Attempts++
+	switch {
+	case err == nil:
+		outcomes.Succeeded++
+	case isSystemFailure(err):
+		outcomes.Failed++
+		outcomes.Failures[apperrors.From(err).Code]++
+	default:
+		outcomes.Rejected++
+		outcomes.Reasons[apperrors.From(err).Code]++
+	}
+}
+
+// Snapshot - Copy of the counters for the metrics endpoint
+func (m *RejectionMetrics) Snapshot() RejectionStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := RejectionStats{Since: m.since, Operations: map[string]OperationOutcomes{}}
+	for _, operation := range []string{OperationInitiate, OperationClaim} {
+		outcomes := *m.outcomes(operation)
+		outcomes.Reasons = copyCounts(outcomes.Reasons)
+		outcomes.Failures = copyCounts(outcomes.Failures)
+		stats.Operations[operation] = outcomes
+	}
+	return stats
+}
+
+// outcomes - Counters for operation, created on first use (caller holds mu)
+func (m *RejectionMetrics) outcomes(operation string) *OperationOutcomes {
+	outcomes, ok := m.operations[operation]
+	if !ok {
+		outcomes = &OperationOutcomes{Reasons: map[string]int64{}, Failures: map[string]int64{}}
+		m.operations[operation] = outcomes
+	}
+	return outcomes
+}
+
+// isSystemFailure - Errors that are not a business rule saying no (unclassified errors count as internal)
+func isSystemFailure(err error) bool {
+	err = apperrors.From(err)
+	return errors.Is(err, apperrors.ErrInternal) || errors.Is(err, apperrors.ErrUpstream) || errors.Is(err, apperrors.ErrUnavailable)
+}
+
+// copyCounts - Detached copy of a label -> count map
+func copyCounts(counts map[string]int64) map[string]int64 {
+	copied := make(map[string]int64, len(counts))
+	for label, count := range counts {
+		copied[label] = count
+	}
+	return copied
+}
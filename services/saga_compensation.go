@@ -0,0 +1,163 @@
+// DESIGN PATTERN: Saga Pattern (compensating transactions) + Exactly-Once Replay
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/idgen"
+	"sender-service/models"
+	"sender-service/repositories"
+)
+
+// Saga compensation errors
+var (
+	ErrSagaStepNotFound = apperrors.New(apperrors.ErrNotFound, "saga_step_not_found", "saga step not found")
+	ErrSagaStepResolved = apperrors.New(apperrors.ErrConflict, "saga_step_resolved", "saga step already compensated")
+)
+
+// sagaRetryBatchSize - Pending compensations retried per scheduler run
+const sagaRetryBatchSize = 100
+
+// SagaService - Undoes the sender deduction when a claim fails after points moved
+type SagaService struct {
+	repo   *repositories.SagaRepository // Composition: HAS-A compensation log
+	auth   AuthGateway                  // Composition: HAS-A Auth Service gateway
+	clock  clock.Clock                  // Composition: HAS-A time source
+	ids    idgen.Generator              // Composition: HAS-A ID generator
+	config *config.Config               // Composition: HAS-A configuration
+}
+
+// NewSagaService - Factory method with dependency injection
+func NewSagaService(repo *repositories.SagaRepository, auth AuthGateway, clk clock.Clock, ids idgen.Generator, config *config.Config) *SagaService {
+	return &SagaService{repo: repo, auth: auth, clock: clk, ids: ids, config: config}
+}
+
+// CompensateDeduction - Records and applies the refund of a deduction whose transfer did not complete.
+// The step is logged before Auth is called so a crash or Auth outage leaves it retryable.
+func (s *SagaService) CompensateDeduction(transfer *models.Transfer, cause error) (*models.SagaStep, error) {
+	// 1. LOG: Persist intent first (outside the transfer transaction, which is rolling back)
+	step := &models.SagaStep{
+		ID:             s.ids.NewID("saga"),
+		TransferID:     transfer.ID,
+		Step:           models.SagaStepRefundSender,
+		UserID:         transfer.SenderID,
+		Points:         transfer.Points,
+		DeductionKey:   transfer.PointsMutationKey,
+		IdempotencyKey: "refund-" + transfer.PointsMutationKey,
+		Status:         models.SagaStepPending,
+		Cause:          cause.Error(),
+	}
+	if err := s.repo.Create(step); err != nil {
+		// Nothing durable to retry from: surface loudly for manual reconciliation
+		fmt.Printf("SAGA: failed to record refund of %d points to %s for transfer %s (deduction %s): %v\n",
+			transfer.Points, transfer.SenderID, transfer.ID, transfer.PointsMutationKey, err)
+		return nil, err
+	}
+
+	// 2. APPLY: First attempt inline; the retry job picks it up on failure
+	return step, s.apply(step, false)
+}
+
+// Compensated - Whether the deduction with this key has been (or is being) refunded
+func (s *SagaService) Compensated(deductionKey string) (bool, error) {
+	return s.repo.ExistsForDeduction(deductionKey)
+}
+
+// List - Saga steps filtered by status
+func (s *SagaService) List(status string, limit int) ([]models.SagaStep, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return s.repo.List(status, limit)
+}
+
+// ForTransfer - Compensations recorded for one transfer
+func (s *SagaService) ForTransfer(transferID string) ([]models.SagaStep, error) {
+	return s.repo.FindByTransfer(transferID)
+}
+
+// Retry - Operator re-drive of a pending or failed compensation
+func (s *SagaService) Retry(id string) (*models.SagaStep, error) {
+	step, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrSagaStepNotFound
+	}
+	if step.Status == models.SagaStepSucceeded {
+		return nil, ErrSagaStepResolved
+	}
+	if err := s.apply(step, true); err != nil {
+		return step, apperrors.ErrUpstream.WithMessage(fmt.Sprintf("retry failed: %v", err))
+	}
+	return step, nil
+}
+
+// RetryPending - Scheduler job re-applying compensations whose earlier attempts failed
+func (s *SagaService) RetryPending(ctx context.Context) error {
+	steps, err := s.repo.FindPending(sagaRetryBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range steps {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.apply(&steps[i], false); err != nil {
+			fmt.Printf("SAGA: refund %s for transfer %s still failing (attempt %d): %v\n",
+				steps[i].ID, steps[i].TransferID, steps[i].Attempts, err)
+		}
+	}
+	return nil
+}
+
+// apply - Re-credits the user; the target balance is fixed once so every retry replays the same request
+func (s *SagaService) apply(step *models.SagaStep, manual bool) error {
+	step.Attempts++
+
+	applyErr := s.credit(step)
+	if applyErr != nil {
+		step.LastError = applyErr.Error()
+		if manual {
+			step.Status = models.SagaStepFailed
+		} else if step.Attempts >= s.config.Saga.MaxAttempts {
+			step.Status = models.SagaStepFailed
+			fmt.Printf("SAGA: giving up on refund %s for transfer %s after %d attempts\n", step.ID, step.TransferID, step.Attempts)
+		}
+	} else {
+		now := s.clock.Now()
+		step.Status = models.SagaStepSucceeded
+		step.LastError = ""
+		step.CompletedAt = &now
+	}
+
+	if err := s.repo.Update(step); err != nil {
+		fmt.Printf("SAGA: failed to save refund %s: %v\n", step.ID, err)
+		if applyErr == nil {
+			return err
+		}
+	}
+	return applyErr
+}
+
+// credit - Reads the balance on the first attempt, persists the target, then calls Auth with the fixed key
+func (s *SagaService) credit(step *models.SagaStep) error {
+	if step.Balance == nil {
+		user, err := s.auth.GetUser(step.UserID)
+		if err != nil {
+			return errors.New("failed to get user details")
+		}
+		balance := user.Points + step.Points
+		step.Balance = &balance
+		if err := s.repo.Update(step); err != nil {
+			step.Balance = nil
+			return errors.New("failed to record refund balance")
+		}
+	}
+	if err := s.auth.UpdateUserPoints(step.UserID, *step.Balance, step.IdempotencyKey); err != nil {
+		return fmt.Errorf("failed to re-credit user: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+// DESIGN PATTERN: Scheduler Pattern (periodic background jobs on intervals or cron schedules)
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/cron"
+	"sender-service/idgen"
+	"sender-service/models"
+	"sender-service/repositories"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Job - Unit of periodic background work
+type Job func(ctx context.Context) error
+
+// scheduledJob - Job plus its cadence
+type scheduledJob struct {
+	name     string
+	interval time.Duration  // Built-in cadence (used when schedule is nil)
+	schedule *cron.Schedule // JOB_<NAME>_SCHEDULE, overriding interval
+	local    bool           // Runs on every instance (per-instance caches), bypassing the cluster-wide lock
+	run      Job
+}
+
+// intervalSlack - Share of an interval by which another replica's run may precede this one and still
+// cover the period (replica timers drift apart and jitter differently)
+const intervalSlack = 10
+
+// JobStatus - Admin view of one registered job (GET /admin/jobs)
+type JobStatus struct {
+	Name     string         `json:"name"`               // e.g. expiration_sweep
+	Enabled  bool           `json:"enabled"`            // False when switched off or without a cadence
+	Schedule string         `json:"schedule"`           // Cron expression, or "@every <interval>"
+	NextRun  *time.Time     `json:"next_run,omitempty"` // When this instance runs it next
+	Running  bool           `json:"running"`            // A run is in progress on this instance
+	LastRun  *models.JobRun `json:"last_run,omitempty"` // Latest recorded run on any instance
+}
+
+// Scheduler - Runs registered jobs in their own goroutines, once per period across instances, recording every
+// run in job_runs
+type Scheduler struct {
+	runs      *repositories.JobRunRepository // Composition: HAS-A run history
+	clock     clock.Clock                    // Composition: HAS-A time source
+	ids       idgen.Generator                // Composition: HAS-A run ID source
+	config    *config.Config                 // Composition: HAS-A jitter and per-job overrides
+	instance  string                         // Host name recorded with each run
+	schedules map[string]*cron.Schedule      // Parsed JOB_<NAME>_SCHEDULE values
+	jobs      []scheduledJob                 // Jobs started by Start
+	disabled  []string                       // Jobs registered but switched off
+
+	mu      sync.Mutex           // Guards nextRun and running
+	nextRun map[string]time.Time // Job -> next planned start
+	running map[string]bool      // Job -> run in progress
+}
+
+// NewScheduler - Factory method; fails on an unparsable JOB_<NAME>_SCHEDULE
+func NewScheduler(runs *repositories.JobRunRepository, clk clock.Clock, ids idgen.Generator, cfg *config.Config) (*Scheduler, error) {
+	schedules := map[string]*cron.Schedule{}
+	for name, override := range cfg.Jobs.Schedules {
+		if override.Schedule == "" {
+			continue
+		}
+		schedule, err := cron.Parse(override.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("JOB_%s_SCHEDULE: %w", strings.ToUpper(name), err)
+		}
+		schedules[name] = schedule
+	}
+	instance, _ := os.Hostname()
+	return &Scheduler{runs: runs, clock: clk, ids: ids, config: cfg, instance: instance, schedules: schedules,
+		nextRun: map[string]time.Time{}, running: map[string]bool{}}, nil
+}
+
+// Every - Registers a job to run at the given interval, or on its JOB_<NAME>_SCHEDULE when set.
+// Ignored when JOB_<NAME>_ENABLED=false, or when the interval is <= 0 and no schedule is set.
+func (s *Scheduler) Every(name string, interval time.Duration, job Job) {
+	s.register(scheduledJob{name: name, interval: interval, run: job})
+}
+
+// EveryInstance - Like Every, but the job runs on every instance (e.g. refreshing an in-process cache)
+// rather than once per period across the cluster
+func (s *Scheduler) EveryInstance(name string, interval time.Duration, job Job) {
+	s.register(scheduledJob{name: name, interval: interval, local: true, run: job})
+}
+
+// register - Adds the job with its JOB_<NAME>_SCHEDULE, or records it as disabled
+func (s *Scheduler) register(job scheduledJob) {
+	name, interval := job.name, job.interval
+	override, configured := s.config.Jobs.Schedules[name]
+	schedule := s.schedules[name]
+	switch {
+	case configured && !override.Enabled:
+		fmt.Printf("Scheduler: job %s disabled (JOB_%s_ENABLED=false)\n", name, strings.ToUpper(name))
+	case schedule == nil && interval <= 0:
+		fmt.Printf("Scheduler: job %s disabled (interval %s)\n", name, interval)
+	default:
+		job.schedule = schedule
+		s.jobs = append(s.jobs, job)
+		return
+	}
+	s.disabled = append(s.disabled, name)
+}
+
+// Start - Launches every registered job until the context is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		fmt.Printf("Scheduler: job %s runs %s\n", job.name, describeCadence(job))
+		go s.loop(ctx, job)
+	}
+}
+
+// Jobs - Every registered job with its cadence, next run and latest recorded run
+func (s *Scheduler) Jobs() ([]JobStatus, error) {
+	latest, err := s.runs.FindLatest()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := make([]JobStatus, 0, len(s.jobs)+len(s.disabled))
+	for _, job := range s.jobs {
+		status := JobStatus{Name: job.name, Enabled: true, Schedule: describeCadence(job), Running: s.running[job.name]}
+		if next, ok := s.nextRun[job.name]; ok {
+			status.NextRun = &next
+		}
+		if run, ok := latest[job.name]; ok {
+			status.LastRun = &run
+		}
+		statuses = append(statuses, status)
+	}
+	for _, name := range s.disabled {
+		status := JobStatus{Name: name, Enabled: false}
+		if run, ok := latest[name]; ok {
+			status.LastRun = &run
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Runs - Recent run history of one job, newest first
+func (s *Scheduler) Runs(name string, limit int) ([]models.JobRun, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+	return s.runs.FindRecent(name, limit)
+}
+
+// PurgeHistory - Drops job runs older than JOB_HISTORY_RETENTION (scheduled as job_run_purge)
+func (s *Scheduler) PurgeHistory(ctx context.Context) error {
+	removed, err := s.runs.DeleteBefore(s.clock.Now().Add(-s.config.Jobs.HistoryRetention))
+	if err != nil {
+		return err
+	}
+	if removed > 0 {
+		fmt.Printf("Scheduler: purged %d job run(s) older than %s\n", removed, s.config.Jobs.HistoryRetention)
+	}
+	return nil
+}
+
+// loop - Waits for the job's next start (plus jitter), runs it, repeats; a failing run is logged and
+// retried at the next start
+func (s *Scheduler) loop(ctx context.Context, job scheduledJob) {
+	for {
+		// 1. PLAN: Next cron match or one interval from now
+		now := s.clock.Now()
+		next := now.Add(job.interval)
+		if job.schedule != nil {
+			if next = job.schedule.Next(now.UTC()); next.IsZero() {
+				fmt.Printf("Scheduler: job %s schedule %q never fires; stopping it\n", job.name, job.schedule)
+				return
+			}
+		}
+		wait := next.Sub(now)
+		if jitter := s.config.Jobs.Jitter; jitter > 0 {
+			wait += time.Duration(rand.Int63n(int64(jitter)))
+		}
+		s.mu.Lock()
+		s.nextRun[job.name] = now.Add(wait)
+		s.mu.Unlock()
+
+		// 2. WAIT: Cancellation ends the loop
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// 3. RUN: Execute and record
+		s.execute(ctx, job)
+	}
+}
+
+// execute - Runs the job once and records the outcome (history is best-effort). Every replica schedules every
+// job; the run takes the job's advisory lock and is skipped when another instance already ran the job this
+// period (see due), so digests, nudges and sweeps are not repeated per instance. Local jobs run unconditionally
+func (s *Scheduler) execute(ctx context.Context, job scheduledJob) {
+	s.mu.Lock()
+	s.running[job.name] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, job.name)
+		s.mu.Unlock()
+	}()
+
+	started := s.clock.Now()
+	var err error
+	if job.local {
+		err = job.run(ctx)
+	} else {
+		var ran bool
+		ran, err = s.runs.RunIfDue(job.name, s.instance, started, func(last *time.Time) bool { return due(job, last, started) },
+			func() error { return job.run(ctx) })
+		if err == nil && !ran {
+			return // Running on, or already run this period by, another instance; that instance records the run
+		}
+	}
+	finished := s.clock.Now()
+
+	run := &models.JobRun{ID: s.ids.NewID("jobrun"), Job: job.name, Instance: s.instance, Status: models.JobRunSucceeded,
+		StartedAt: started, FinishedAt: finished, DurationMS: finished.Sub(started).Milliseconds()}
+	if err != nil {
+		fmt.Printf("Scheduled job %s failed: %v\n", job.name, err)
+		run.Status, run.Error = models.JobRunFailed, err.Error()
+	}
+	if err := s.runs.Create(run); err != nil {
+		fmt.Printf("Scheduler: failed to record run of %s: %v\n", job.name, err)
+	}
+}
+
+// due - Whether a run starting now is needed given the latest run on any instance: a cron job once its next
+// match after that run has come, an interval job once the interval (less intervalSlack) has passed
+func due(job scheduledJob, last *time.Time, now time.Time) bool {
+	if last == nil {
+		return true
+	}
+	if job.schedule != nil {
+		next := job.schedule.Next(last.UTC())
+		return !next.IsZero() && !next.After(now.UTC())
+	}
+	return now.Sub(*last) >= job.interval-job.interval/intervalSlack
+}
+
+// describeCadence - Cron expression, or the built-in interval as "@every <interval>"
+func describeCadence(job scheduledJob) string {
+	if job.schedule != nil {
+		return job.schedule.String()
+	}
+	return "@every " + job.interval.String()
+}
@@ -0,0 +1,35 @@
+// DESIGN PATTERN: Transactional Outbox (sender status emails queued with the status change)
+package services
+
+import "sender-service/models"
+
+// notifySender - Queues a status email to the sender when its switch is on (never for canary transfers,
+// whose sender is a synthetic account); joins the caller's transaction like any outbox message
+func (s *TransferService) notifySender(transfer *models.Transfer, enabled bool, templateName string, data interface{}) {
+	if !enabled || transfer.Canary {
+		return
+	}
+	s.queueEmail(transfer, transfer.SenderEmail, templateName, data)
+}
+
+// completedEmailData - Template data for the sender claim notice
+func (s *TransferService) completedEmailData(transfer *models.Transfer) TransferCompletedEmailData {
+	return TransferCompletedEmailData{
+		ReceiverName:    transfer.ReceiverName,
+		ReceiverEmail:   transfer.ReceiverEmail,
+		Points:          transfer.Points,
+		ConvertedPoints: transfer.ConvertedPoints,
+		CompletedAt:     transfer.UpdatedAt.UTC().Format("2006-01-02 15:04 MST"),
+		HistoryURL:      s.config.Frontend.URL + "/#/transfers",
+	}
+}
+
+// failedEmailData - Template data for the sender insufficient-points notice
+func (s *TransferService) failedEmailData(transfer *models.Transfer) TransferFailedEmailData {
+	return TransferFailedEmailData{
+		ReceiverName:  transfer.ReceiverName,
+		ReceiverEmail: transfer.ReceiverEmail,
+		Points:        transfer.Points,
+		HistoryURL:    s.config.Frontend.URL + "/#/transfers",
+	}
+}
@@ -0,0 +1,123 @@
+// DESIGN PATTERN: Decorator Pattern (sink) + Allowlist Guard (per-transfer callback URLs)
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sender-service/apperrors"
+	"sender-service/config"
+	"sender-service/models"
+	"sender-service/repositories"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrCallbackNotAllowed - callback_url is not https or its host is not on CALLBACK_URL_ALLOWLIST
+var ErrCallbackNotAllowed = apperrors.New(apperrors.ErrInvalidInput, "callback_url_not_allowed", "callback_url must be an https URL on an allowlisted host")
+
+// CallbackAllowed - Whether a callback URL may be used: https (or http with CALLBACK_ALLOW_HTTP) and a host
+// matching CALLBACK_URL_ALLOWLIST exactly or, for "*.example.com" entries, as a subdomain
+func CallbackAllowed(cfg *config.Config, rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.User != nil {
+		return false
+	}
+	if parsed.Scheme != "https" && !(parsed.Scheme == "http" && cfg.Events.CallbackAllowHTTP) {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	for _, allowed := range cfg.Events.CallbackHosts {
+		allowed = strings.ToLower(allowed)
+		if suffix, wildcard := strings.CutPrefix(allowed, "*."); wildcard {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+		} else if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// TransferCallbackSink - Delivers each event to the wrapped sink, then to its transfer's callback_url.
+// Callbacks are best-effort like chat posts: failures are logged, never returned, so one integrator's
+// broken endpoint cannot fail (and replay) the delivery for every other sink in the chain
+type TransferCallbackSink struct {
+	next      EventSink                        // Decorated sink (subscriptions, endpoint or log)
+	transfers *repositories.TransferRepository // Composition: HAS-A transfer lookup (callback URL)
+	signer    *WebhookSigner                   // Delivery signatures (nil = unsigned)
+	config    *config.Config                   // Composition: HAS-A current allowlist
+	client    *http.Client                     // Shared HTTP client (redirects refused)
+}
+
+// NewTransferCallbackSink - Factory method decorating the sink with per-transfer callbacks
+func NewTransferCallbackSink(next EventSink, transfers *repositories.TransferRepository, signer *WebhookSigner, cfg *config.Config) *TransferCallbackSink {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		// ALLOWLIST: A redirect could send the signed payload to a host that was never allowlisted
+		// (or into the internal network), so the 3xx itself is the response and counts as a failure
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	return &TransferCallbackSink{next: next, transfers: transfers, signer: signer, config: cfg, client: client}
+}
+
+// Deliver - Wrapped sink first (its failure retries the event), then the best-effort callback
+// registered with the event's transfer (if any)
+func (s *TransferCallbackSink) Deliver(event *models.DomainEvent, payload []byte) error {
+	if err := s.next.Deliver(event, payload); err != nil {
+		return err
+	}
+	if len(s.config.Events.CallbackHosts) == 0 {
+		return nil
+	}
+
+	transfer, err := s.transfers.FindByID(event.AggregateID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil // Purged since the event was recorded
+	}
+	if err != nil {
+		fmt.Printf("Failed to look up callback for %s: %v\n", event.AggregateID, err)
+		return nil
+	}
+	if transfer.CallbackURL == "" {
+		return nil
+	}
+	// ALLOWLIST: Re-checked at delivery so a host removed from the list stops receiving calls
+	if !CallbackAllowed(s.config, transfer.CallbackURL) {
+		fmt.Printf("Skipping callback for %s: %s is no longer allowlisted\n", transfer.ID, transfer.CallbackURL)
+		return nil
+	}
+	if err := s.deliver(transfer, event, payload); err != nil {
+		fmt.Printf("Failed to deliver %s to callback of %s: %v\n", event.ID, transfer.ID, err)
+	}
+	return nil
+}
+
+// deliver - POSTs the event envelope, signed like every other delivery
+func (s *TransferCallbackSink) deliver(transfer *models.Transfer, event *models.DomainEvent, payload []byte) error {
+	req, err := http.NewRequest("POST", transfer.CallbackURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Schema", event.SchemaName())
+	req.Header.Set("X-Event-ID", event.ID)
+	req.Header.Set("X-Transfer-ID", transfer.ID)
+	s.signer.Sign(req, payload)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("callback responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+// DESIGN PATTERN: Specification Pattern (runbook rules evaluated against one transfer) + Report Object
+package services
+
+import (
+	"fmt"
+	"sender-service/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ExplainTransfer - Evaluates a transfer against the state machine, its outbox, dead letters,
+// compensations and the sender's balance, returning why it is where it is and the allowed next actions
+func (s *TransferService) ExplainTransfer(transferID string) (*models.TransferExplanation, error) {
+	// 1. LOAD: The transfer and everything recorded about it
+	transfer, err := s.GetTransfer(transferID, "")
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.auditRepo.FindByTransferID(transfer.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load audit trail: %v", err)
+	}
+	if history == nil {
+		history = []models.TransferAuditLog{}
+	}
+	outbox, err := s.transferRepo.FindOutbox(transfer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load outbox: %v", err)
+	}
+	deadLetters, err := s.deadLetters.ForTransfer(transfer.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dead letters: %v", err)
+	}
+	sagaSteps, err := s.saga.ForTransfer(transfer.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load saga steps: %v", err)
+	}
+
+	now := s.clock.Now()
+	explanation := &models.TransferExplanation{
+		TransferID:  transfer.ID,
+		Status:      transfer.Status,
+		Terminal:    len(models.NextStatuses(transfer.Status)) == 0,
+		ExplainedAt: now,
+		History:     history,
+	}
+	x := &explainer{explanation: explanation, transfer: transfer, now: now}
+
+	// 2. STATE: What the status itself means
+	s.explainStatus(x, lastChange(history, transfer.Status))
+
+	// 3. SIDE EFFECTS: Outbox deliveries and the dead letters they left behind
+	openDeadLetters := map[string]*models.DeadLetter{}
+	for i := range deadLetters {
+		if deadLetters[i].Status == models.DeadLetterOpen {
+			openDeadLetters[deadLetters[i].ReferenceID] = &deadLetters[i]
+		}
+	}
+	s.explainOutbox(x, outbox, openDeadLetters)
+	for i := range deadLetters { // Not tied to an outbox message (e.g. receiver credits)
+		deadLetter := &deadLetters[i]
+		if openDeadLetters[deadLetter.ReferenceID] != deadLetter {
+			continue
+		}
+		x.add(models.FindingBlocking, deadLetter.Kind+"_dead_lettered", deadLetter.ID,
+			"%s dead-lettered after %d attempt(s): %s", deadLetter.Kind, deadLetter.Attempts, deadLetter.Reason)
+		x.deadLetterActions(deadLetter)
+	}
+
+	// 4. COMPENSATION: Refunds of deductions whose claim did not complete
+	for _, step := range sagaSteps {
+		switch step.Status {
+		case models.SagaStepPending:
+			x.add(models.FindingWarning, "refund_pending", step.ID,
+				"refund of %d points to %s pending after %d attempt(s): %s", step.Points, step.UserID, step.Attempts, step.LastError)
+		case models.SagaStepFailed:
+			x.add(models.FindingBlocking, "refund_failed", step.ID,
+				"refund of %d points to %s failed after %d attempt(s), waiting for an operator: %s", step.Points, step.UserID, step.Attempts, step.LastError)
+		default:
+			continue
+		}
+		x.action("retry_saga_step", "POST", "/admin/saga-steps/"+step.ID+"/retry", nil, "Re-apply the refund now")
+	}
+
+	// 5. TRANSITIONS: Every status change a trusted service may still make
+	for _, to := range models.NextStatuses(transfer.Status) {
+		for _, reason := range models.TransitionReasons(to) {
+			x.action("change_status", "PATCH", "/internal/transfer/"+transfer.ID+"/status",
+				map[string]string{"status": string(to), "reason_code": reason},
+				fmt.Sprintf("Move to %s (%s)", to, reason))
+		}
+	}
+
+	x.finish()
+	return explanation, nil
+}
+
+// explainStatus - Findings and flow actions specific to the current status
+func (s *TransferService) explainStatus(x *explainer, change *models.TransferAuditLog) {
+	transfer := x.transfer
+	switch transfer.Status {
+	case models.TransferStatusPending:
+		s.explainPending(x)
+
+	case models.TransferStatusOnHold:
+		message := "on hold"
+		if change != nil {
+			message = fmt.Sprintf("on hold since %s by %s (%s)", change.CreatedAt.UTC().Format(time.RFC3339), change.Actor, change.ReasonCode)
+			if change.Note != "" {
+				message += ": " + change.Note
+			}
+		}
+		x.add(models.FindingBlocking, "on_hold", "", "%s; release it to pending or fail/cancel it", message)
+		x.summary = message
+
+	case models.TransferStatusCompleted:
+		how := "claimed"
+		if transfer.AutoCompleted {
+			how = "auto-completed for registered receiver " + transfer.ReceiverID
+		}
+		x.add(models.FindingInfo, "completed", "", "%s; %d points deducted from the sender", how, transfer.Points)
+		x.summary = "completed (" + how + ")"
+
+	case models.TransferStatusExpired:
+		x.add(models.FindingInfo, "expired", "", "claim window ended at %s; the points stayed with the sender",
+			transfer.ExpiresAt.UTC().Format(time.RFC3339))
+		x.summary = "expired unclaimed"
+
+	case models.TransferStatusForwarded:
+		x.add(models.FindingInfo, "forwarded", transfer.ForwardedToID, "receiver forwarded the gift; follow transfer %s", transfer.ForwardedToID)
+		x.action("view_chain", "GET", "/admin/transfers/"+transfer.ID+"/chain", nil, "Follow the gift to the transfer that superseded this one")
+		x.summary = "forwarded to " + transfer.ForwardedToID
+
+	default: // failed, cancelled, declined
+		message := string(transfer.Status)
+		if change != nil {
+			message = fmt.Sprintf("%s at %s by %s (%s)", transfer.Status, change.CreatedAt.UTC().Format(time.RFC3339), change.Actor, change.ReasonCode)
+			if change.Note != "" {
+				message += ": " + change.Note
+			}
+		} else if transfer.Status == models.TransferStatusFailed {
+			message = "failed during completion (no recorded status change: the sender lacked points or the deduction was refused)"
+		}
+		x.add(models.FindingInfo, string(transfer.Status), "", "%s", message)
+		x.summary = message
+	}
+}
+
+// explainPending - Claim window, passphrase lockout, deductions in flight and the sender's balance
+func (s *TransferService) explainPending(x *explainer) {
+	transfer := x.transfer
+
+	// CLAIM WINDOW: An overdue pending transfer is waiting for the sweeper
+	if !x.now.Before(transfer.ExpiresAt) {
+		x.add(models.FindingBlocking, "claim_window_ended", "",
+			"claim window ended at %s but the transfer is still pending; the expiration sweeper (every %s) has not reached it",
+			transfer.ExpiresAt.UTC().Format(time.RFC3339), s.config.Expiration.SweepInterval)
+	} else {
+		x.add(models.FindingInfo, "awaiting_claim", "", "waiting for the receiver to claim; expires in %s",
+			transfer.ExpiresAt.Sub(x.now).Round(time.Minute))
+		x.summary = "waiting for the receiver to claim"
+		x.action("extend_expiry", "POST", "/transfer/"+transfer.ID+"/extend", nil, "Sender pushes the claim deadline out")
+		x.action("complete", "POST", "/transfer/"+transfer.ID+"/complete", nil, "Trusted service completes the claim on the receiver's behalf")
+	}
+	if transfer.DeferredAt != nil {
+		x.add(models.FindingInfo, "claim_deferred", "", "receiver saved the claim for later at %s", transfer.DeferredAt.UTC().Format(time.RFC3339))
+	}
+
+	// CLAIM PROTECTION: A locked passphrase can never be claimed
+	if max := s.config.Claims.PassphraseMaxAttempts; transfer.PassphraseProtected() && max > 0 && transfer.PassphraseAttempts >= max {
+		x.add(models.FindingBlocking, "passphrase_locked", "",
+			"claim passphrase locked after %d wrong attempts; the receiver cannot claim, cancel the transfer", transfer.PassphraseAttempts)
+	}
+
+	// DEDUCTION: A key without acknowledgement is replayed by the next claim attempt
+	if transfer.PointsMutationKey != "" {
+		if transfer.PointsMutatedAt != nil {
+			x.add(models.FindingBlocking, "deducted_not_completed", "",
+				"sender's points were deducted at %s (key %s) but the transfer never completed; a claim retry finishes it; expiring, declining, cancelling or failing it refunds the sender first",
+				transfer.PointsMutatedAt.UTC().Format(time.RFC3339), transfer.PointsMutationKey)
+		} else {
+			x.add(models.FindingWarning, "deduction_in_flight", "",
+				"a deduction (key %s) was requested but not acknowledged; the next claim replays it without a new balance check, and ending the transfer any other way refunds it",
+				transfer.PointsMutationKey)
+		}
+		return
+	}
+
+	// BALANCE: The claim fails (and the transfer with it) if the sender cannot cover it
+	if transfer.Canary {
+		return // Synthetic sender: balance is simulated
+	}
+	sender, err := s.auth.GetUser(transfer.SenderID)
+	if err != nil {
+		x.add(models.FindingWarning, "sender_balance_unknown", "", "could not read the sender's balance from the Auth Service: %v", err)
+		return
+	}
+	if sender.Points < transfer.Points {
+		x.add(models.FindingBlocking, "insufficient_points", "",
+			"sender balance %d < %d; a claim now marks the transfer failed", sender.Points, transfer.Points)
+	} else {
+		x.add(models.FindingInfo, "balance_sufficient", "", "sender balance %d covers %d points", sender.Points, transfer.Points)
+	}
+}
+
+// explainOutbox - Delivery state of the claim notification, emails and events (consumes the dead
+// letters it accounts for)
+func (s *TransferService) explainOutbox(x *explainer, outbox []models.TransferOutboxMessage, openDeadLetters map[string]*models.DeadLetter) {
+	emailDown := s.emailService.Deferring()
+	for i := range outbox {
+		message := &outbox[i]
+		label := outboxLabel(message.Kind)
+		switch message.Status {
+		case models.OutboxMessageDead:
+			reference := outboxReference(message)
+			deadLetter := openDeadLetters[reference]
+			delete(openDeadLetters, reference)
+			code := strings.ReplaceAll(message.Kind, "claim_", "") + "_dead_lettered"
+			detail := ""
+			if message.Kind == models.OutboxMessageEvent {
+				detail = "; later events of this transfer are held until it is retried or discarded"
+			}
+			if deadLetter == nil {
+				x.add(models.FindingBlocking, code, "", "%s dead-lettered after %d attempts: %s%s", label, message.Attempts, message.LastError, detail)
+				continue
+			}
+			x.add(models.FindingBlocking, code, deadLetter.ID, "%s dead-lettered after %d attempts: %s%s", label, deadLetter.Attempts, deadLetter.Reason, detail)
+			x.deadLetterActions(deadLetter)
+
+		case models.OutboxMessagePending:
+			switch {
+			case emailDown && emailBound(message.Kind):
+				x.add(models.FindingWarning, "email_circuit_open", "", "%s deferred while the email circuit is open; it is sent once the provider recovers", label)
+			case message.Attempts > 0:
+				x.add(models.FindingWarning, "delivery_retrying", "", "%s failed %d time(s), next attempt at %s: %s",
+					label, message.Attempts, message.NextAttemptAt.UTC().Format(time.RFC3339), message.LastError)
+			default:
+				x.add(models.FindingInfo, "delivery_queued", "", "%s queued for the outbox dispatcher", label)
+			}
+
+		case models.OutboxMessageSent:
+			if message.Kind == models.OutboxMessageClaimNotification && message.SentAt != nil {
+				channel := x.transfer.NotificationChannel
+				if channel == "" {
+					channel = "email"
+				}
+				x.add(models.FindingInfo, "notification_sent", "", "claim notification sent via %s at %s", channel, message.SentAt.UTC().Format(time.RFC3339))
+				if x.transfer.EmailStatus == models.EmailStatusBounced {
+					x.add(models.FindingWarning, "claim_email_bounced", "", "the provider reported a bounce for %s; the receiver may not have the claim link", x.transfer.ReceiverEmail)
+				}
+			}
+		}
+	}
+}
+
+// outboxLabel - Human name of an outbox message kind
+func outboxLabel(kind string) string {
+	switch kind {
+	case models.OutboxMessageClaimNotification:
+		return "claim notification"
+	case models.OutboxMessageEmail:
+		return "email"
+	case models.OutboxMessageAudit:
+		return "audit entry"
+	}
+	return "domain event"
+}
+
+// lastChange - Most recent audited change into the current status
+func lastChange(history []models.TransferAuditLog, status models.TransferStatus) *models.TransferAuditLog {
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].ToStatus == status {
+			return &history[i]
+		}
+	}
+	return nil
+}
+
+// explainer - Accumulates findings and actions while the rules run
+type explainer struct {
+	explanation *models.TransferExplanation
+	transfer    *models.Transfer
+	now         time.Time
+	summary     string // Verdict when nothing is blocking
+}
+
+// add - Records one finding
+func (x *explainer) add(severity, code, reference, format string, args ...interface{}) {
+	x.explanation.Findings = append(x.explanation.Findings, models.ExplainFinding{
+		Code: code, Severity: severity, Message: fmt.Sprintf(format, args...), Reference: reference,
+	})
+}
+
+// action - Records one allowed next action
+func (x *explainer) action(name, method, path string, body map[string]string, description string) {
+	x.explanation.NextActions = append(x.explanation.NextActions, models.TransferAction{
+		Action: name, Method: method, Path: path, Body: body, Description: description,
+	})
+}
+
+// deadLetterActions - Retry and discard of one open dead letter
+func (x *explainer) deadLetterActions(deadLetter *models.DeadLetter) {
+	x.action("retry_dead_letter", "POST", "/admin/dead-letters/"+deadLetter.ID+"/retry", nil, "Re-drive the "+deadLetter.Kind+" dead letter")
+	x.action("discard_dead_letter", "POST", "/admin/dead-letters/"+deadLetter.ID+"/discard", nil, "Drop the "+deadLetter.Kind+" dead letter")
+}
+
+// finish - Orders findings by severity and derives the verdict
+func (x *explainer) finish() {
+	rank := map[string]int{models.FindingBlocking: 0, models.FindingWarning: 1, models.FindingInfo: 2}
+	findings := x.explanation.Findings
+	sort.SliceStable(findings, func(i, j int) bool { return rank[findings[i].Severity] < rank[findings[j].Severity] })
+
+	x.explanation.Summary = x.summary
+	if len(findings) > 0 && findings[0].Severity == models.FindingBlocking {
+		x.explanation.Stuck = true
+		x.explanation.Summary = findings[0].Message
+	}
+	if x.explanation.NextActions == nil {
+		x.explanation.NextActions = []models.TransferAction{}
+	}
+}
@@ -2,201 +2,1107 @@
 package services
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
+	"sender-service/apperrors"
+	"sender-service/clock"
 	"sender-service/config"
+	"sender-service/i18n"
+	"sender-service/idgen"
 	"sender-service/models"
 	"sender-service/repositories"
+	"strings"
 	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPassphraseRequired  = apperrors.New(apperrors.ErrForbidden, "passphrase_required", "this transfer requires a passphrase")
+	ErrPassphraseMismatch  = apperrors.New(apperrors.ErrForbidden, "passphrase_mismatch", "incorrect passphrase")
+	ErrPassphraseLocked    = apperrors.New(apperrors.ErrLocked, "passphrase_locked", "too many incorrect passphrase attempts")
+	ErrTransferNotFound    = apperrors.New(apperrors.ErrNotFound, "transfer_not_found", "transfer not found")
+	ErrInvalidTransition   = models.ErrInvalidTransition
+	ErrInvalidCursor       = repositories.ErrInvalidCursor
+	ErrInvalidFilter       = apperrors.New(apperrors.ErrInvalidInput, "invalid_filter", "min_points must not exceed max_points and from must precede to")
+	ErrInvalidReasonCode   = apperrors.New(apperrors.ErrUnprocessable, "invalid_reason_code", "reason code not valid for this status")
+	ErrIdentifierExhausted = apperrors.New(apperrors.ErrInternal, "identifier_exhausted", "failed to generate a unique identifier")
+	ErrClaimExpired        = apperrors.ErrTransferExpired
+	ErrTransferNotPending  = apperrors.New(apperrors.ErrConflict, "transfer_not_pending", "transfer is no longer pending")
+
+	ErrSelfTransfer          = apperrors.New(apperrors.ErrInvalidInput, "self_transfer", "cannot transfer points to yourself")
+	ErrInvalidPoints         = apperrors.New(apperrors.ErrInvalidInput, "invalid_points", "points must be greater than zero")
+	ErrPassphrasePolicy      = apperrors.New(apperrors.ErrInvalidInput, "passphrase_policy", "this transfer requires a claim passphrase")
+	ErrHintRevealsPassphrase = apperrors.New(apperrors.ErrInvalidInput, "passphrase_hint_invalid", "passphrase hint must not contain the passphrase")
+	ErrPassphraseTooLong     = apperrors.New(apperrors.ErrInvalidInput, "passphrase_too_long", "passphrase must be at most 72 bytes")
+	ErrTransferLimit         = apperrors.New(apperrors.ErrUnprocessable, "transfer_limit_exceeded", "transfer exceeds the maximum points per transfer")
+	ErrDailyLimit            = apperrors.New(apperrors.ErrUnprocessable, "daily_limit_exceeded", "transfer exceeds the sender's daily points limit")
+	ErrPendingLimit          = apperrors.New(apperrors.ErrUnprocessable, "pending_limit_exceeded", "sender has too many transfers waiting to be claimed")
+	ErrLimitsUnavailable     = apperrors.ErrInternal.WithMessage("failed to check transfer limits")
+	ErrSenderUnavailable     = apperrors.ErrUpstream.WithMessage("failed to get sender details")
+	ErrDeductionUnsettled    = apperrors.New(apperrors.ErrUpstream, "deduction_unsettled", "the claim's points deduction could not be refunded; retry later")
 )
 
 // TransferService - Orchestrates transfer business logic and coordinates with other services
 type TransferService struct {
 	transferRepo *repositories.TransferRepository // Composition: HAS-A repository
+	auditRepo    *repositories.AuditRepository    // Composition: HAS-A audit trail
 	emailService *EmailService                    // Composition: HAS-A email service
+	auth         AuthGateway                      // Composition: HAS-A Auth Service gateway
+	events       *EventPublisher                  // Composition: HAS-A domain event publisher
+	deadLetters  *DeadLetterService               // Composition: HAS-A dead-letter channel
+	saga         *SagaService                     // Composition: HAS-A saga compensator
+	scanner      *ContentScanner                  // Composition: HAS-A message/link scanner
+	conversions  *ConversionTable                 // Composition: HAS-A program rate table
+	hooks        *HookRegistry                    // Composition: HAS-A custom validation hooks
+	assertions   *ClaimAssertionVerifier          // Composition: HAS-A receiver assertion verifier
+	claimLatency *ClaimLatencyService             // Composition: HAS-A claim latency histogram
+	volume       *TransferVolumeService           // Composition: HAS-A volume counters
+	rejections   *RejectionMetrics                // Composition: HAS-A rejection counters
+	throttle     *ClaimThrottle                   // Composition: HAS-A per-receiver claim rate limit
+	experiments  *ExperimentService               // Composition: HAS-A experiment cohorts
+	clock        clock.Clock                      // Composition: HAS-A time source
+	ids          idgen.Generator                  // Composition: HAS-A ID/token generator
 	config       *config.Config                   // Composition: HAS-A configuration
+	canary       bool                             // Copy created by Canary: new transfers are synthetic
 }
 
 // NewTransferService - Factory method with dependency injection
 func NewTransferService(transferRepo *repositories.TransferRepository,
+	auditRepo *repositories.AuditRepository,
 	emailService *EmailService,
+	auth AuthGateway,
+	events *EventPublisher,
+	deadLetters *DeadLetterService,
+	saga *SagaService,
+	scanner *ContentScanner,
+	conversions *ConversionTable,
+	hooks *HookRegistry,
+	assertions *ClaimAssertionVerifier,
+	claimLatency *ClaimLatencyService,
+	volume *TransferVolumeService,
+	rejections *RejectionMetrics,
+	throttle *ClaimThrottle,
+	experiments *ExperimentService,
+	clk clock.Clock,
+	ids idgen.Generator,
 	config *config.Config) *TransferService {
 	return &TransferService{
 		transferRepo: transferRepo,
+		auditRepo:    auditRepo,
 		emailService: emailService,
+		auth:         auth,
+		events:       events,
+		deadLetters:  deadLetters,
+		saga:         saga,
+		scanner:      scanner,
+		conversions:  conversions,
+		hooks:        hooks,
+		assertions:   assertions,
+		claimLatency: claimLatency,
+		volume:       volume,
+		rejections:   rejections,
+		throttle:     throttle,
+		experiments:  experiments,
+		clock:        clk,
+		ids:          ids,
 		config:       config,
 	}
 }
 
 // InitiateTransfer - Business logic for creating a new points transfer
 func (s *TransferService) InitiateTransfer(senderID string, req models.TransferRequest) (*models.Transfer, error) {
+	transfer, err := s.initiate(senderID, req)
+	s.observeOutcome(OperationInitiate, err)
+	return transfer, err
+}
+
+// initiate - Creates the transfer (InitiateTransfer counts the outcome)
+func (s *TransferService) initiate(senderID string, req models.TransferRequest) (*models.Transfer, error) {
+	// 0. IDEMPOTENCY: A retried request gets the transfer the first attempt created
+	original := req
+	if replay, err := s.Replay(senderID, original); err != nil || replay != nil {
+		return replay, err
+	}
+
 	// 1. SERVICE INTEGRATION: Get sender details from Auth Service
-	sender, err := s.getUser(senderID)
+	sender, err := s.auth.GetUser(senderID)
 	if err != nil {
-		return nil, errors.New("failed to get sender details")
+		return nil, ErrSenderUnavailable
 	}
 
-	// 2. BUSINESS VALIDATION: Check transfer feasibility
-	if err := s.validateTransfer(sender, req); err != nil {
+	// 2. BUSINESS VALIDATION: Check transfer feasibility (bundles derive the points total)
+	if len(req.Items) > 0 {
+		total, err := bundlePoints(req.Items, req.Points)
+		if err != nil {
+			return nil, err
+		}
+		req.Points = total
+	}
+	if req.SourceProgram == "" {
+		req.SourceProgram = s.conversions.DefaultProgram()
+	}
+	if req.TargetProgram == "" {
+		req.TargetProgram = req.SourceProgram
+	}
+	if err := s.validateTransfer(senderID, sender, req); err != nil {
+		return nil, err
+	}
+	if err := s.hooks.BeforeInitiate(sender, &req); err != nil {
 		return nil, err
 	}
 
-	// 3. ENTITY CREATION: Create transfer record (points NOT deducted yet - Saga Pattern)
-	transfer := &models.Transfer{
-		ID:            generateID(),                   // Unique identifier
-		SenderID:      senderID,                       // Sender user ID
-		SenderEmail:   sender.Email,                   // Sender email
-		ReceiverEmail: req.ReceiverEmail,              // Receiver email
-		ReceiverName:  req.ReceiverName,               // Receiver name
-		Points:        req.Points,                     // Points amount
-		Status:        "pending",                      // Initial status
-		Token:         generateToken(),                // Unique claim token
-		ExpiresAt:     time.Now().Add(24 * time.Hour), // 24-hour expiration
-		CreatedAt:     time.Now(),                     // Creation timestamp
-		UpdatedAt:     time.Now(),                     // Update timestamp
-	}
-
-	// 4. PERSISTENCE: Save transfer to database
-	if err := s.transferRepo.Create(transfer); err != nil {
+	// 3. CONTENT SCANNING: Protect receivers from phishing via the gift message
+	content, err := s.scanner.Scan(req.Message, req.Links)
+	if err != nil {
+		return nil, err
+	}
+	if len(content.Flagged) > 0 {
+		fmt.Printf("Stripped %d flagged URL(s) from transfer by %s\n", len(content.Flagged), senderID)
+	}
+	metadata, err := s.scanBundle(req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. CLAIM PROTECTION: Only the bcrypt hash is stored; the passphrase travels out-of-band
+	passphraseHash, err := s.hashPassphrase(req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. ENTITY CREATION: Create transfer record (points NOT deducted yet - Saga Pattern)
+	transfer, err := s.newTransfer(sender, senderID, req, content, metadata, passphraseHash)
+	if err != nil {
+		return nil, err
+	}
+	if original.IdempotencyKey != "" {
+		key := original.IdempotencyKey
+		transfer.IdempotencyKey = &key
+		transfer.IdempotencyHash = requestFingerprint(original)
+	}
+
+	// 6. PERSISTENCE: The transfer, its initiated event and the claim notification commit in one
+	// transaction (TRANSACTIONAL OUTBOX), so a crash can no longer lose the receiver's invitation
+	receiver := s.autoCompleteReceiver(transfer) // Registered receivers get "points received" instead
+	err = s.createFresh([]*models.Transfer{transfer}, func() error {
+		messages := s.initiatedMessages(transfer)
+		if receiver == nil {
+			messages = append(messages, s.claimNotificationMessage(transfer))
+		}
+		return s.transferRepo.CreateWithOutbox(transfer, messages...)
+	})
+	if errors.Is(err, ErrIdentifierExhausted) {
+		return nil, err
+	}
+	if err != nil {
+		// A concurrent retry with the same key won the unique index: answer with its transfer
+		if replay, replayErr := s.Replay(senderID, original); replayErr != nil || replay != nil {
+			return replay, replayErr
+		}
 		return nil, errors.New("failed to create transfer")
 	}
+	if !transfer.Canary {
+		s.volume.ObserveInitiated(transfer.Points)
+	}
 
 	//  SAGA PATTERN: Points are NOT deducted here - only when receiver claims
 	// This ensures points remain with sender if receiver doesn't claim
 
-	// 5. OBSERVER PATTERN: Send email notification asynchronously
-	go func() {
-		if err := s.emailService.SendTransferEmail(transfer); err != nil {
-			fmt.Printf("Failed to send email to %s: %v\n", transfer.ReceiverEmail, err)
-		} else {
-			fmt.Printf("Email sent successfully to: %s\n", transfer.ReceiverEmail)
+	// 7. AUTO-COMPLETE: Registered receivers are credited instantly when enabled
+	if receiver != nil && !s.tryAutoComplete(transfer, receiver) {
+		// 8. OBSERVER PATTERN: Auto-complete fell through, so invite the receiver after all
+		s.notifyReceiver(transfer)
+	}
+
+	return transfer, nil
+}
+
+// newTransfer - Builds a pending transfer from a validated, scanned request (not yet persisted)
+func (s *TransferService) newTransfer(sender *models.User, senderID string, req models.TransferRequest,
+	content *ScannedContent, metadata *models.TransferMetadata, passphraseHash string) (*models.Transfer, error) {
+	id, err := s.generateID()
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.generateToken()
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	timing := s.experiments.Timing(senderID, now)         // Cohorts may vary expiry/reminder timing
+	region := s.transferRepo.ResolveRegion(sender.Region) // Unconfigured regions fall back to the default
+	transfer := &models.Transfer{
+		ID:             id,                           // Unique identifier
+		Kind:           models.TransferKindOriginal,  // Root of its chain
+		Region:         region,                       // Data residency: stored in the sender's region
+		SenderID:       senderID,                     // Sender user ID
+		SenderEmail:    sender.Email,                 // Sender email
+		ReceiverEmail:  req.ReceiverEmail,            // Receiver email
+		ReceiverName:   req.ReceiverName,             // Receiver name
+		Points:         req.Points,                   // Points amount
+		SourceProgram:  req.SourceProgram,            // Sender's program
+		TargetProgram:  req.TargetProgram,            // Receiver's program (converted on completion)
+		Message:        content.Message,              // Scanned gift message
+		Links:          content.Links,                // Scanned links
+		Metadata:       metadata,                     // Scanned bundle items (nil for plain transfers)
+		PassphraseHash: passphraseHash,               // Empty when unprotected
+		PassphraseHint: req.PassphraseHint,           // Shown in the claim email
+		Theme:          req.Theme,                    // Claim email theme
+		Tenant:         sender.Tenant,                // White-label branding of the claim email
+		CallbackURL:    req.CallbackURL,              // Per-transfer event callback (allowlisted)
+		Locale:         i18n.Match(req.Locale),       // Claim email language ("" = English fallback)
+		Status:         models.TransferStatusPending, // Initial status
+		ExpiresAt:      timing.ExpiresAt,             // 24-hour expiration unless an experiment says otherwise
+		NudgeAt:        timing.NudgeAt,               // Experiment reminder time (nil = global fraction)
+		Cohorts:        timing.Cohorts,               // Experiment variants for conversion reporting
+		Canary:         s.canary,                     // Synthetic self-test transfer
+		CreatedAt:      now,                          // Creation timestamp
+		UpdatedAt:      now,                          // Update timestamp
+	}
+	transfer.SetClaimToken(token) // Only the hash is persisted
+	return transfer, nil
+}
+
+// initiatedMessages - Outbox rows committed with a new transfer: its transfer.initiated event
+func (s *TransferService) initiatedMessages(transfer *models.Transfer) []*models.TransferOutboxMessage {
+	if transfer.Canary {
+		return nil
+	}
+	initiated, err := s.eventMessage(models.EventTransferInitiated, transfer, models.TransferInitiatedData{
+		TransferID:    transfer.ID,
+		SenderID:      transfer.SenderID,
+		ReceiverEmail: transfer.ReceiverEmail,
+		Points:        transfer.Points,
+		ExpiresAt:     transfer.ExpiresAt,
+	})
+	if err != nil {
+		fmt.Printf("Failed to publish %s for %s: %v\n", models.EventTransferInitiated, transfer.ID, err)
+		return nil
+	}
+	return []*models.TransferOutboxMessage{initiated}
+}
+
+// notifyReceiver - Queues the claim notification for an already persisted transfer; the outbox dispatcher
+// delivers it with retries
+func (s *TransferService) notifyReceiver(transfer *models.Transfer) {
+	if err := s.transferRepo.AppendOutbox(transfer, s.claimNotificationMessage(transfer)); err != nil {
+		fmt.Printf("Failed to queue claim notification for %s: %v\n", transfer.ID, err)
+		return
+	}
+	if err := s.transferRepo.UpdateEmailStatus(transfer); err != nil {
+		fmt.Printf("Failed to record queued claim notification for %s: %v\n", transfer.ID, err)
+	}
+	if transfer.NotificationDeferred {
+		if err := s.transferRepo.MarkNotificationDeferred(transfer, true); err != nil {
+			fmt.Printf("Failed to flag deferred notification for %s: %v\n", transfer.ID, err)
 		}
-	}()
+	}
+}
+
+// claimNotificationMessage - Outbox row asking the dispatcher to invite the receiver on their channel.
+// It carries the raw claim token, which the transfer row only stores hashed.
+// GRACEFUL DEGRADATION: While the email circuit is open the transfer is flagged notification_deferred;
+// the row waits in the outbox and the dispatcher flushes it once the provider recovers
+func (s *TransferService) claimNotificationMessage(transfer *models.Transfer) *models.TransferOutboxMessage {
+	transfer.NotificationDeferred = s.emailService.Deferring()
+	transfer.EmailStatus = models.EmailStatusQueued
+	payload, _ := json.Marshal(models.OutboxClaimNotification{Token: transfer.Token})
+	return &models.TransferOutboxMessage{Kind: models.OutboxMessageClaimNotification, Payload: string(payload), NextAttemptAt: s.clock.Now()}
+}
+
+// queueEmail - Renders a template now and queues it for the outbox dispatcher
+func (s *TransferService) queueEmail(transfer *models.Transfer, to, templateName string, data interface{}) {
+	rendered, err := RenderEmail(templateName, data)
+	if err != nil {
+		fmt.Printf("Failed to render %s for %s: %v\n", templateName, transfer.ID, err)
+		return
+	}
+	payload, err := json.Marshal(models.OutboxEmail{To: to, Template: templateName, Subject: rendered.Subject, HTML: rendered.HTML, Text: rendered.Text})
+	if err != nil {
+		fmt.Printf("Failed to encode %s for %s: %v\n", templateName, transfer.ID, err)
+		return
+	}
+	message := &models.TransferOutboxMessage{Kind: models.OutboxMessageEmail, Payload: string(payload), NextAttemptAt: s.clock.Now()}
+	if err := s.transferRepo.AppendOutbox(transfer, message); err != nil {
+		fmt.Printf("Failed to queue %s for %s: %v\n", templateName, transfer.ID, err)
+	}
+}
+
+// RecordEmailBounce - Marks sent claim notifications to the address as bounced on its pending transfers
+func (s *TransferService) RecordEmailBounce(req models.EmailBounceRequest) (int64, error) {
+	bounced, err := s.transferRepo.MarkEmailBounced(req.Email)
+	if err != nil {
+		return 0, err
+	}
+	if bounced > 0 {
+		fmt.Printf("Claim email to %s bounced (%s): %d pending transfer(s) flagged\n", req.Email, req.Reason, bounced)
+	}
+	return bounced, nil
+}
+
+// GetUserTransfers - Business logic to retrieve one page of a user's filtered transfer history
+func (s *TransferService) GetUserTransfers(userID, consistencyToken string, filter models.TransferFilter, page models.PageRequest) (*models.TransferPage, error) {
+	if page.Limit <= 0 {
+		page.Limit = models.DefaultPageSize
+	}
+	if page.Limit > models.MaxPageSize {
+		page.Limit = models.MaxPageSize
+	}
+	if filter.MinPoints > 0 && filter.MaxPoints > 0 && filter.MinPoints > filter.MaxPoints {
+		return nil, ErrInvalidFilter
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, ErrInvalidFilter
+	}
+	return s.transferRepo.FindBySenderID(userID, consistencyToken, filter, page)
+}
 
+// GetTransferByToken - Looks up a transfer by its claim token
+func (s *TransferService) GetTransferByToken(token string) (*models.Transfer, error) {
+	transfer, err := s.transferRepo.FindByToken(token)
+	if err != nil {
+		return nil, transferLookupError(err)
+	}
 	return transfer, nil
 }
 
-// GetUserTransfers - Business logic to retrieve user's transfer history
-func (s *TransferService) GetUserTransfers(userID string) ([]models.Transfer, error) {
-	return s.transferRepo.FindBySenderID(userID)
+// PreviewClaim - Transfer shown on the claim landing page, counted against the receiver's claim rate limit
+func (s *TransferService) PreviewClaim(token string) (*models.Transfer, error) {
+	return s.ResolveClaim(token)
 }
 
-// CompleteTransfer - SAGA PATTERN: Finalize transfer when receiver claims points
-func (s *TransferService) CompleteTransfer(transferID string) error {
+// ResolveClaim - Transfer behind a claim link for a receiver action (e.g. consent), counted against the
+// receiver's claim rate limit like every other use of the token
+func (s *TransferService) ResolveClaim(token string) (*models.Transfer, error) {
+	transfer, err := s.GetTransferByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.throttleReceiver(transfer); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// throttleReceiver - ENUMERATION DEFENSE: Caps claim attempts and previews per receiver address, however
+// many IPs they come from (canary claims are not counted)
+func (s *TransferService) throttleReceiver(transfer *models.Transfer) error {
+	if s.canary {
+		return nil
+	}
+	return s.throttle.AllowEmail(transfer.ReceiverEmail)
+}
+
+// GetTransfer - Looks up a transfer by ID; a non-empty senderID must own it (others see not found)
+func (s *TransferService) GetTransfer(transferID, senderID string) (*models.Transfer, error) {
 	transfer, err := s.transferRepo.FindByID(transferID)
 	if err != nil {
-		return errors.New("transfer not found")
+		return nil, transferLookupError(err)
+	}
+	if senderID != "" && transfer.SenderID != senderID {
+		return nil, ErrTransferNotFound
 	}
+	return transfer, nil
+}
 
-	// 1. SERVICE INTEGRATION: Get current sender details
-	sender, err := s.getUser(transfer.SenderID)
+// GetTransferChain - Audit view: every transfer linked to the given one, root first
+func (s *TransferService) GetTransferChain(transferID string) ([]models.Transfer, error) {
+	chain, err := s.transferRepo.FindChain(transferID)
+	if err != nil {
+		return nil, transferLookupError(err)
+	}
+	return chain, nil
+}
+
+// ConsistencyToken - Read-your-writes token for the sender's latest mutation ("" without replicas)
+func (s *TransferService) ConsistencyToken(transfer *models.Transfer) string {
+	token, err := s.transferRepo.ConsistencyToken(transfer)
 	if err != nil {
-		return errors.New("failed to get sender details")
+		fmt.Printf("Failed to issue consistency token for %s: %v\n", transfer.SenderID, err)
+		return ""
 	}
+	return token
+}
 
-	// 2. VALIDATION: Ensure sender still has sufficient points
-	if sender.Points < transfer.Points {
-		// Mark transfer as failed due to insufficient points
-		transfer.Status = "failed"
-		s.transferRepo.Update(transfer)
-		return errors.New("sender no longer has sufficient points")
+// CompleteTransfer - SAGA PATTERN: Finalize transfer when receiver claims points.
+// Idempotent: repeating it for a completed transfer returns the final state (replayed = true).
+// The assertion is the Auth Service JWT proving which receiver holds the claim token.
+// The User-Agent is reduced to a device class for the engagement data of transfer.completed.
+func (s *TransferService) CompleteTransfer(transferID, passphrase, assertion, userAgent string) (*models.Transfer, bool, error) {
+	transfer, replayed, err := s.completeLocked(transferID, passphrase, assertion, userAgent)
+	s.observeOutcome(OperationClaim, err)
+	return transfer, replayed, err
+}
+
+// ClaimTransfer - SAGA PATTERN: Receiver completes the transfer with the token from the claim link
+func (s *TransferService) ClaimTransfer(token, passphrase, assertion, userAgent string) (*models.Transfer, bool, error) {
+	transfer, err := s.transferRepo.FindByToken(token)
+	if err != nil || !transfer.MatchesClaimToken(token) {
+		s.observeOutcome(OperationClaim, ErrTransferNotFound)
+		return nil, false, ErrTransferNotFound
+	}
+	if err := s.throttleReceiver(transfer); err != nil {
+		s.observeOutcome(OperationClaim, err)
+		return nil, false, err
 	}
+	completed, replayed, err := s.completeLocked(transfer.ID, passphrase, assertion, userAgent)
+	s.observeOutcome(OperationClaim, err)
+	return completed, replayed, err
+}
 
-	// 3. POINT DEDUCTION: Deduct points from sender (Saga commitment)
-	if err := s.updateUserPoints(transfer.SenderID, sender.Points-transfer.Points); err != nil {
-		return errors.New("failed to deduct points from sender")
+// observeOutcome - Counts a rejection/failure/success for the metrics endpoint (canary runs excluded)
+func (s *TransferService) observeOutcome(operation string, err error) {
+	if !s.canary {
+		s.rejections.Observe(operation, err)
 	}
+}
 
-	// 4. STATUS UPDATE: Mark transfer as completed
-	transfer.Status = "completed"
+// completeLocked - Checks the claim under a row lock, then runs the saga with the lock released;
+// finalize re-takes it for each write, so concurrent/double calls still deduct at most once
+func (s *TransferService) completeLocked(transferID, passphrase, rawAssertion, userAgent string) (*models.Transfer, bool, error) {
+	var (
+		result   *models.Transfer
+		replayed bool
+		claimErr error
+	)
+
+	// SIGNED ASSERTION: Verified before any lock is taken (signature, issuer, audience, lifetime)
+	assertion, err := s.assertions.Verify(rawAssertion)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = s.transferRepo.LockByID(transferID, func(locked *repositories.TransferRepository, transfer *models.Transfer) error {
+		result = transfer
+
+		// AUTHORIZATION: The assertion must be for this transfer's claim token, not just any valid one
+		if assertion != nil {
+			if !transfer.MatchesClaimToken(assertion.ClaimToken) ||
+				(assertion.TransferID != "" && assertion.TransferID != transfer.ID) {
+				claimErr = ErrAssertionMismatch
+				return nil
+			}
+		}
+
+		// IDEMPOTENCY: A second call after success is a no-op that reports the final state
+		if transfer.Status == models.TransferStatusCompleted {
+			replayed = true
+			return nil
+		}
+
+		// Writes made by the checks (attempt counters, expiry) must commit even when the
+		// claim is rejected, so business errors are captured rather than rolling back
+		tx := *s
+		tx.transferRepo = locked
+		if assertion != nil && transfer.Status == models.TransferStatusPending {
+			transfer.ReceiverID = assertion.ReceiverID // Persisted with the completion
+		}
+		if transfer.Status == models.TransferStatusPending {
+			transfer.ClaimDevice = ClassifyDevice(userAgent) // Persisted with the completion
+		}
+		claimErr = tx.claim(transfer, passphrase)
+		return nil
+	})
+	if claimErr != nil {
+		return nil, false, claimErr
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, ErrTransferNotFound
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if replayed {
+		return result, true, nil
+	}
+
+	// SAGA: Points move outside the lock transaction (no row lock is held across Auth calls)
+	if err := s.finalize(result); err != nil {
+		return nil, false, err
+	}
+	return result, false, nil
+}
+
+// claim - Claim checks on the locked row: status, expiry and passphrase (the saga runs after the lock is released)
+func (s *TransferService) claim(transfer *models.Transfer, passphrase string) error {
+	// 1. STATE CHECKS: Only live, unexpired claims can complete
+	if transfer.Status != models.TransferStatusPending {
+		return fmt.Errorf("%w (%s)", ErrTransferNotPending, transfer.Status)
+	}
+	if !s.clock.Now().Before(transfer.ExpiresAt) {
+		s.expire(transfer)
+		return ErrClaimExpired
+	}
+
+	// 2. CLAIM PROTECTION: Verify the passphrase before touching any points
+	return s.verifyPassphrase(transfer, passphrase)
+}
+
+// expire - Records that the claim window ended (on a late claim attempt or by the sweeper); a reserved
+// deduction is refunded first, and the transfer stays pending if that fails
+func (s *TransferService) expire(transfer *models.Transfer) error {
+	if err := s.settleDeduction(transfer, "claim window ended"); err != nil {
+		fmt.Printf("Not expiring transfer %s: %v\n", transfer.ID, err)
+		return err
+	}
+	if err := transfer.TransitionTo(models.TransferStatusExpired); err != nil {
+		return err
+	}
 	if err := s.transferRepo.Update(transfer); err != nil {
-		//  SAGA COMPENSATION: Points deducted but transfer not completed
-		// In production, implement compensation logic here
-		return errors.New("failed to complete transfer")
+		fmt.Printf("Failed to mark transfer %s as expired: %v\n", transfer.ID, err)
+		return err
+	}
+	s.audit(transfer, &models.TransferAuditLog{
+		ID:         s.ids.NewID("audit"),
+		TransferID: transfer.ID,
+		FromStatus: models.TransferStatusPending,
+		ToStatus:   models.TransferStatusExpired,
+		ReasonCode: models.ReasonClaimWindowEnded,
+		Actor:      "system",
+		CreatedAt:  s.clock.Now(),
+	})
+	s.publish(models.EventTransferStatusChanged, transfer, models.TransferStatusChangedData{
+		TransferID: transfer.ID,
+		SenderID:   transfer.SenderID,
+		FromStatus: models.TransferStatusPending,
+		ToStatus:   models.TransferStatusExpired,
+		ReasonCode: models.ReasonClaimWindowEnded,
+		Actor:      "system",
+	})
+
+	// Heads-up so the sender can resend; points never left their account
+	s.notifySender(transfer, s.config.Expiration.NotifySender, TemplateTransferExpired, s.expiredEmailData(transfer))
+	return nil
+}
+
+// finalize - Deducts the sender's points and marks the transfer completed (shared by claim and auto-complete).
+// Called outside any transaction: every write re-takes the row lock in its own short transaction
+func (s *TransferService) finalize(transfer *models.Transfer) error {
+	// 0. CUSTOM HOOKS: Deployment rules may veto before any points move
+	if err := s.hooks.BeforeComplete(transfer); err != nil {
+		return err
+	}
+
+	// 1. SERVICE INTEGRATION: Get current sender details
+	sender, err := s.auth.GetUser(transfer.SenderID)
+	if err != nil {
+		return ErrSenderUnavailable
+	}
+
+	// REFUNDED DEDUCTION: A compensated key must not be replayed (Auth would de-duplicate it as already applied)
+	compensatedKey := ""
+	if transfer.PointsMutationKey != "" {
+		compensated, err := s.saga.Compensated(transfer.PointsMutationKey)
+		if err != nil {
+			return errors.New("failed to check points mutation")
+		}
+		if compensated {
+			compensatedKey = transfer.PointsMutationKey
+			transfer.PointsMutationKey = ""
+			transfer.PointsMutationBalance = 0
+			transfer.PointsMutatedAt = nil
+		}
+	}
+
+	// 2. VALIDATION: Ensure sender still has sufficient points (skipped when replaying a
+	// deduction that may already have landed; Auth Service de-duplicates by key)
+	if transfer.PointsMutationKey == "" && sender.Points < transfer.Points {
+		return s.failInsufficient(transfer)
+	}
+
+	// 3. CONVERSION: Rate in effect now is the rate used (tables can change while pending)
+	rate, err := s.resolveRate(transfer)
+	if err != nil {
+		return err
+	}
+
+	// 4. POINT DEDUCTION: Deduct points from sender (Saga commitment)
+	// EXACTLY-ONCE: The mutation key commits in its own transaction before Auth is called, so a crash
+	// or retry replays the same request instead of deducting twice
+	if err := s.reserveMutation(transfer, sender.Points-transfer.Points, compensatedKey); err != nil {
+		return err
+	}
+	if err := s.auth.UpdateUserPoints(transfer.SenderID, transfer.PointsMutationBalance, transfer.PointsMutationKey); err != nil {
+		return apperrors.ErrUpstream.WithMessage("failed to deduct points from sender")
+	}
+	mutatedAt := s.clock.Now()
+
+	// 5. STATUS UPDATE: Mark transfer as completed with the conversion actually applied, under the row
+	// lock so a transition that landed while Auth was called is seen rather than overwritten
+	completedNow := false
+	err = s.transferRepo.LockByID(transfer.ID, func(locked *repositories.TransferRepository, current *models.Transfer) error {
+		if current.PointsMutationKey != transfer.PointsMutationKey {
+			return errMutationSuperseded
+		}
+		if current.Status == models.TransferStatusCompleted {
+			*transfer = *current // A concurrent claim finished the same deduction
+			return nil
+		}
+		current.PointsMutatedAt = &mutatedAt
+		current.ConversionRate = rate.Rate
+		current.ConvertedPoints = rate.Convert(current.Points)
+		if transfer.ReceiverID != "" {
+			current.ReceiverID = transfer.ReceiverID
+		}
+		if transfer.ClaimDevice != "" {
+			current.ClaimDevice = transfer.ClaimDevice
+		}
+		if err := current.TransitionTo(models.TransferStatusCompleted); err != nil {
+			return err
+		}
+		if err := locked.Update(current); err != nil {
+			return err
+		}
+
+		tx := *s
+		tx.transferRepo = locked
+		tx.publish(models.EventTransferCompleted, current, models.TransferCompletedData{
+			TransferID:  current.ID,
+			SenderID:    current.SenderID,
+			Points:      current.Points,
+			CompletedAt: current.UpdatedAt,
+
+			SourceProgram:   current.SourceProgram,
+			TargetProgram:   current.TargetProgram,
+			ConversionRate:  current.ConversionRate,
+			ConvertedPoints: current.ConvertedPoints,
+
+			Engagement: current.Engagement(),
+		})
+		tx.notifySender(current, s.config.Notifications.SenderOnClaim, TemplateTransferCompleted, s.completedEmailData(current))
+		*transfer = *current
+		completedNow = true
+		return nil
+	})
+	if errors.Is(err, errMutationSuperseded) {
+		return errors.New("failed to complete transfer; the deduction was already refunded")
+	}
+	if err != nil {
+		//  SAGA COMPENSATION: Points deducted but transfer not completed - refund the sender
+		if _, compErr := s.saga.CompensateDeduction(transfer, err); compErr != nil {
+			return errors.New("failed to complete transfer; sender refund is pending")
+		}
+		return errors.New("failed to complete transfer; sender points were refunded")
+	}
+
+	if completedNow && !transfer.Canary {
+		s.claimLatency.Observe(mutatedAt.Sub(transfer.CreatedAt))
+		s.volume.ObserveCompleted(transfer.Points)
 	}
 
 	return nil
 }
 
-// validateTransfer - Business rules validation
-func (s *TransferService) validateTransfer(sender *models.User, req models.TransferRequest) error {
+// transferLookupError - ErrTransferNotFound for a missing transfer; other failures (outages) pass through
+func transferLookupError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrTransferNotFound
+	}
+	return err
+}
+
+// errMutationSuperseded - The key this claim deducted with was compensated and replaced meanwhile
+var errMutationSuperseded = errors.New("points mutation was superseded")
+
+// reserveMutation - Commits the Auth idempotency key for the deduction in its own locked transaction.
+// A key another claim already committed is reused (Auth de-duplicates it); a compensated one is replaced
+func (s *TransferService) reserveMutation(transfer *models.Transfer, balance int, compensatedKey string) error {
+	return s.transferRepo.LockByID(transfer.ID, func(locked *repositories.TransferRepository, current *models.Transfer) error {
+		if current.Status != models.TransferStatusPending {
+			return fmt.Errorf("%w (%s)", ErrTransferNotPending, current.Status)
+		}
+		if current.PointsMutationKey == "" || current.PointsMutationKey == compensatedKey {
+			current.PointsMutationKey = s.ids.NewID("ptsmut")
+			current.PointsMutationBalance = balance
+			current.PointsMutatedAt = nil
+			if err := locked.Update(current); err != nil {
+				return errors.New("failed to record points mutation")
+			}
+		}
+		transfer.PointsMutationKey = current.PointsMutationKey
+		transfer.PointsMutationBalance = current.PointsMutationBalance
+		return nil
+	})
+}
+
+// failInsufficient - Marks the transfer failed under the row lock when the sender can no longer cover it
+func (s *TransferService) failInsufficient(transfer *models.Transfer) error {
+	err := s.transferRepo.LockByID(transfer.ID, func(locked *repositories.TransferRepository, current *models.Transfer) error {
+		if current.Status != models.TransferStatusPending {
+			return fmt.Errorf("%w (%s)", ErrTransferNotPending, current.Status)
+		}
+		if err := current.TransitionTo(models.TransferStatusFailed); err != nil {
+			return err
+		}
+		if err := locked.Update(current); err != nil {
+			return errors.New("failed to record insufficient points")
+		}
+
+		tx := *s
+		tx.transferRepo = locked
+		tx.publish(models.EventTransferFailed, current, models.TransferFailedData{
+			TransferID: current.ID,
+			SenderID:   current.SenderID,
+			Points:     current.Points,
+			Reason:     "insufficient_points",
+		})
+		tx.notifySender(current, s.config.Notifications.SenderOnFail, TemplateTransferFailed, s.failedEmailData(current))
+		*transfer = *current
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return apperrors.ErrInsufficientPoints.WithMessage("sender no longer has sufficient points")
+}
+
+// settleDeduction - Refunds a claim's reserved deduction before the transfer ends any other way than
+// completion. A set key means Auth may already have taken the points, so the deduction is replayed
+// first (Auth de-duplicates by key) and then refunded through the saga; the caller holds the row lock
+// and persists the cleared key. An error means the transfer must be left alone.
+func (s *TransferService) settleDeduction(transfer *models.Transfer, cause string) error {
+	if transfer.Status == models.TransferStatusCompleted || transfer.PointsMutationKey == "" {
+		return nil
+	}
+
+	// 1. ALREADY REFUNDED: The saga step exists (its retry job finishes it)
+	compensated, err := s.saga.Compensated(transfer.PointsMutationKey)
+	if err != nil {
+		return ErrDeductionUnsettled
+	}
+	if !compensated {
+		// 2. REPLAY: Make sure the deduction landed exactly once, so the refund never mints points
+		if err := s.auth.UpdateUserPoints(transfer.SenderID, transfer.PointsMutationBalance, transfer.PointsMutationKey); err != nil {
+			return ErrDeductionUnsettled
+		}
+		// 3. COMPENSATION: A recorded step is durable even if its first attempt fails
+		if step, _ := s.saga.CompensateDeduction(transfer, errors.New(cause)); step == nil {
+			return ErrDeductionUnsettled
+		}
+	}
+
+	// 4. RELEASE: A claim still between Auth and its completion lock now sees its key superseded
+	transfer.PointsMutationKey = ""
+	transfer.PointsMutationBalance = 0
+	transfer.PointsMutatedAt = nil
+	return nil
+}
+
+// ChangeStatus - Trusted-service status change validated against the state machine
+func (s *TransferService) ChangeStatus(transferID string, req models.StatusChangeRequest, actor, clientIP string) (*models.Transfer, error) {
+	var (
+		transfer *models.Transfer
+		from     models.TransferStatus
+	)
+
+	// 1-2. STATE MACHINE + PERSISTENCE: Check and apply under the row lock so a concurrent claim cannot race;
+	// the audit entry and events join the same transaction
+	err := s.transferRepo.LockByID(transferID, func(locked *repositories.TransferRepository, current *models.Transfer) error {
+		transfer, from = current, current.Status
+		if !models.CanTransition(from, req.Status) {
+			return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, req.Status)
+		}
+		if !models.ValidReason(req.Status, req.ReasonCode) {
+			return fmt.Errorf("%w: %s", ErrInvalidReasonCode, req.ReasonCode)
+		}
+
+		// REFUND: A claim's reserved deduction is settled before the transfer ends any other way
+		if req.Status != models.TransferStatusCompleted && len(models.NextStatuses(req.Status)) == 0 {
+			if err := s.settleDeduction(transfer, "transfer "+string(req.Status)+" by "+actor); err != nil {
+				return err
+			}
+		}
+		if err := transfer.TransitionTo(req.Status); err != nil {
+			return err
+		}
+
+		if err := locked.Update(transfer); err != nil {
+			return errors.New("failed to update transfer status")
+		}
+
+		// 3. AUDIT: Who changed what and why (committed with the change)
+		tx := *s
+		tx.transferRepo = locked
+		tx.audit(transfer, &models.TransferAuditLog{
+			ID:         s.ids.NewID("audit"),
+			TransferID: transfer.ID,
+			FromStatus: from,
+			ToStatus:   req.Status,
+			ReasonCode: req.ReasonCode,
+			Actor:      actor,
+			ClientIP:   clientIP,
+			Note:       req.Note,
+			CreatedAt:  s.clock.Now(),
+		})
+
+		// 4. EVENTS: Consumers and webhooks see every trusted change
+		tx.publish(models.EventTransferStatusChanged, transfer, models.TransferStatusChangedData{
+			TransferID: transfer.ID,
+			SenderID:   transfer.SenderID,
+			FromStatus: from,
+			ToStatus:   req.Status,
+			ReasonCode: req.ReasonCode,
+			Actor:      actor,
+		})
+		if req.Status == models.TransferStatusFailed {
+			tx.publish(models.EventTransferFailed, transfer, models.TransferFailedData{
+				TransferID: transfer.ID,
+				SenderID:   transfer.SenderID,
+				Points:     transfer.Points,
+				Reason:     req.ReasonCode,
+			})
+		}
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTransferNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// publish - Records a domain event in the transfer's outbox (inside the lock transaction when tx-bound);
+// failures are logged so they never break the saga
+func (s *TransferService) publish(eventType string, transfer *models.Transfer, data interface{}) {
+	// SYNTHETIC: Consumers must never credit or report canary transfers
+	if transfer.Canary {
+		return
+	}
+	message, err := s.eventMessage(eventType, transfer, data)
+	if err == nil {
+		err = s.transferRepo.AppendOutbox(transfer, message)
+	}
+	if err != nil {
+		fmt.Printf("Failed to publish %s for %s: %v\n", eventType, transfer.ID, err)
+	}
+}
+
+// audit - Records an audit entry in the transfer's outbox (inside the lock transaction when tx-bound), so it
+// commits with the change it describes; the dispatcher copies it to the audit trail
+func (s *TransferService) audit(transfer *models.Transfer, entry *models.TransferAuditLog) {
+	payload, err := json.Marshal(entry)
+	if err == nil {
+		err = s.transferRepo.AppendOutbox(transfer, &models.TransferOutboxMessage{
+			Kind: models.OutboxMessageAudit, Payload: string(payload), NextAttemptAt: s.clock.Now()})
+	}
+	if err != nil {
+		fmt.Printf("Failed to audit %s -> %s of %s: %v\n", entry.FromStatus, entry.ToStatus, transfer.ID, err)
+	}
+}
+
+// eventMessage - Outbox row carrying a schema-validated event envelope
+func (s *TransferService) eventMessage(eventType string, transfer *models.Transfer, data interface{}) (*models.TransferOutboxMessage, error) {
+	payload, err := s.events.Envelope(eventType, transfer.ID, data)
+	if err != nil {
+		return nil, err
+	}
+	return &models.TransferOutboxMessage{Kind: models.OutboxMessageEvent, Payload: payload, NextAttemptAt: s.clock.Now()}, nil
+}
+
+// validateTransfer - Business rules validation, including the sender's daily and pending limits
+func (s *TransferService) validateTransfer(senderID string, sender *models.User, req models.TransferRequest) error {
+	if err := s.validateRules(sender, req); err != nil {
+		return err
+	}
+
+	// Business Rule 9: Daily points and pending transfer limits (aggregated from the database)
+	return s.checkSenderLimits(senderID, req.Points, 1)
+}
+
+// validateRules - Business rules that depend on the request alone (bulk entries are checked one by one)
+func (s *TransferService) validateRules(sender *models.User, req models.TransferRequest) error {
 	// Business Rule 1: Sufficient points
 	if sender.Points < req.Points {
-		return errors.New("insufficient points")
+		return apperrors.ErrInsufficientPoints
 	}
 
 	// Business Rule 2: Cannot transfer to self
 	if sender.Email == req.ReceiverEmail {
-		return errors.New("cannot transfer points to yourself")
+		return ErrSelfTransfer
 	}
 
 	// Business Rule 3: Positive points amount
 	if req.Points <= 0 {
-		return errors.New("points must be greater than zero")
+		return ErrInvalidPoints
 	}
 
-	return nil
-}
-
-// getUser - Service-to-service call to Auth Service
-func (s *TransferService) getUser(userID string) (*models.User, error) {
-	resp, err := http.Get(s.config.AuthService + "/users/" + userID)
+	// Business Rule 4: Program pair must be convertible to at least one target point
+	rate, err := s.conversions.Resolve(req.SourceProgram, req.TargetProgram)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if rate.Convert(req.Points) < 1 {
+		return ErrInvalidPoints.WithMessage(fmt.Sprintf("at least %d %s points are needed to send 1 %s point", rate.From, rate.Source, rate.Target))
+	}
+
+	// Business Rule 5: High-value transfers must be passphrase-protected
+	if min := s.config.Claims.PassphraseMinPoints; min > 0 && req.Points >= min && req.Passphrase == "" {
+		return ErrPassphrasePolicy.WithMessage(fmt.Sprintf("transfers of %d points or more require a claim passphrase", min))
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New("user not found")
+	// Business Rule 6: The hint travels by email, so it must not reveal the passphrase
+	if req.Passphrase != "" && req.PassphraseHint != "" &&
+		strings.Contains(strings.ToLower(req.PassphraseHint), strings.ToLower(req.Passphrase)) {
+		return ErrHintRevealsPassphrase
 	}
 
-	var response struct {
-		Success bool         `json:"success"`
-		Data    *models.User `json:"data"`
+	// Business Rule 7: Only registered claim email themes
+	if _, ok := LookupEmailTheme(req.Theme); !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownEmailTheme, req.Theme)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil || !response.Success {
-		return nil, errors.New("failed to get user data")
+	// Business Rule 8: Largest single transfer
+	if max := s.config.Limits.MaxPointsPerTransfer; max > 0 && req.Points > max {
+		return ErrTransferLimit.WithMessage(fmt.Sprintf("transfers are limited to %d points", max))
 	}
 
-	return response.Data, nil
+	// Business Rule 10: Callbacks only go to allowlisted hosts (no arbitrary outbound requests)
+	if req.CallbackURL != "" && !CallbackAllowed(s.config, req.CallbackURL) {
+		return ErrCallbackNotAllowed
+	}
+
+	// Business Rule 11: bcrypt only hashes the first 72 bytes (and rejects longer input), so the
+	// passphrase is capped in bytes; the binding's max counts characters, which may be multi-byte
+	if len(req.Passphrase) > maxPassphraseBytes {
+		return ErrPassphraseTooLong
+	}
+
+	return nil
 }
 
-// updateUserPoints - Service-to-service call to update user points
-func (s *TransferService) updateUserPoints(userID string, points int) error {
-	requestBody := map[string]int{"points": points}
-	jsonData, _ := json.Marshal(requestBody)
+// checkSenderLimits - Whether the sender may start count more transfers worth points in total.
+// Best effort: concurrent initiations are not serialised, so a burst may overshoot by its own size
+func (s *TransferService) checkSenderLimits(senderID string, points, count int) error {
+	limits := s.config.Limits
+	if limits.MaxPointsPerDay > 0 {
+		now := s.clock.Now().UTC()
+		startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		sent, err := s.transferRepo.SumPointsSince(senderID, startOfDay)
+		if err != nil {
+			return ErrLimitsUnavailable
+		}
+		if sent+int64(points) > int64(limits.MaxPointsPerDay) {
+			return ErrDailyLimit.WithMessage(fmt.Sprintf("daily limit of %d points reached: %d sent today, %d requested",
+				limits.MaxPointsPerDay, sent, points))
+		}
+	}
+	if limits.MaxPending > 0 {
+		pending, err := s.transferRepo.CountPending(senderID)
+		if err != nil {
+			return ErrLimitsUnavailable
+		}
+		if pending+int64(count) > int64(limits.MaxPending) {
+			return ErrPendingLimit.WithMessage(fmt.Sprintf("at most %d transfers may wait to be claimed; %d already pending",
+				limits.MaxPending, pending))
+		}
+	}
+	return nil
+}
 
-	req, err := http.NewRequest("PUT", s.config.AuthService+"/users/"+userID+"/points",
-		bytes.NewBuffer(jsonData))
+// resolveRate - Conversion for a stored transfer (legacy rows predate programs and convert 1:1)
+func (s *TransferService) resolveRate(transfer *models.Transfer) (ConversionRate, error) {
+	if transfer.SourceProgram == "" && transfer.TargetProgram == "" {
+		return ConversionRate{From: 1, To: 1, Rate: 1}, nil
+	}
+	rate, err := s.conversions.Resolve(transfer.SourceProgram, transfer.TargetProgram)
 	if err != nil {
-		return err
+		return ConversionRate{}, ErrUnsupportedConversion.WithMessage(fmt.Sprintf("conversion from %s to %s is no longer supported", transfer.SourceProgram, transfer.TargetProgram))
 	}
-	req.Header.Set("Content-Type", "application/json")
+	return rate, nil
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// maxPassphraseBytes - Longest passphrase bcrypt accepts
+const maxPassphraseBytes = 72
+
+// hashPassphrase - bcrypt hash of the claim passphrase ("" when none was set)
+func (s *TransferService) hashPassphrase(passphrase string) (string, error) {
+	if passphrase == "" {
+		return "", nil
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(passphrase), bcrypt.DefaultCost)
 	if err != nil {
-		return err
+		return "", errors.New("failed to secure claim passphrase")
 	}
-	defer resp.Body.Close()
+	return string(hash), nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return errors.New("failed to update points")
+// verifyPassphrase - Checks the claim passphrase and counts failures towards the lockout
+func (s *TransferService) verifyPassphrase(transfer *models.Transfer, passphrase string) error {
+	if !transfer.PassphraseProtected() {
+		return nil
+	}
+	if max := s.config.Claims.PassphraseMaxAttempts; max > 0 && transfer.PassphraseAttempts >= max {
+		return ErrPassphraseLocked
+	}
+	if passphrase == "" {
+		return ErrPassphraseRequired
 	}
 
+	if bcrypt.CompareHashAndPassword([]byte(transfer.PassphraseHash), []byte(passphrase)) != nil {
+		transfer.PassphraseAttempts++
+		if err := s.transferRepo.Update(transfer); err != nil {
+			fmt.Printf("Failed to record passphrase attempt for %s: %v\n", transfer.ID, err)
+		}
+		return ErrPassphraseMismatch
+	}
 	return nil
 }
 
-// generateID - Utility function for unique ID generation
-func generateID() string {
-	return fmt.Sprintf("transfer_%d", time.Now().UnixNano())
+// maxIdentifierAttempts - Fresh draws tried before giving up on a collision-free value
+const maxIdentifierAttempts = 3
+
+// generateID - Random transfer ID, checked against the primary key on every shard
+func (s *TransferService) generateID() (string, error) {
+	return s.unique(func() string { return s.ids.NewID("transfer") }, s.transferRepo.IDExists)
+}
+
+// generateToken - Random claim token, checked against the token uniqueIndex on every shard
+func (s *TransferService) generateToken() (string, error) {
+	return s.unique(s.ids.NewToken, s.transferRepo.TokenExists)
 }
 
-// generateToken - Utility function for unique token generation
-func generateToken() string {
-	return fmt.Sprintf("token_%d", time.Now().UnixNano())
+// createFresh - Runs the insert of new transfers; when it loses a race on the ID or claim token index (another
+// instance drew the same value after the uniqueness check) every transfer draws fresh values and the insert
+// runs again, so it must rebuild anything derived from them (outbox rows carry the ID and raw token)
+func (s *TransferService) createFresh(transfers []*models.Transfer, insert func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := insert()
+		if !repositories.IdentifierCollision(err) {
+			return err
+		}
+		if attempt == maxIdentifierAttempts {
+			return ErrIdentifierExhausted
+		}
+		for _, transfer := range transfers {
+			if transfer.ID, err = s.generateID(); err != nil {
+				return err
+			}
+			token, err := s.generateToken()
+			if err != nil {
+				return err
+			}
+			transfer.SetClaimToken(token)
+		}
+	}
+}
+
+// unique - Draws values until one is unused (collisions are astronomically rare, but the index would reject them)
+func (s *TransferService) unique(draw func() string, exists func(string) (bool, error)) (string, error) {
+	for attempt := 0; attempt < maxIdentifierAttempts; attempt++ {
+		value := draw()
+		taken, err := exists(value)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return value, nil
+		}
+	}
+	return "", ErrIdentifierExhausted
 }
@@ -0,0 +1,168 @@
+// DESIGN PATTERN: Service Layer + Counter metric (volume series for capacity/budget planning)
+package services
+
+import (
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/repositories"
+	"sort"
+	"sync"
+	"time"
+)
+
+// liveVolumeWindow - Hourly counters kept in memory
+const liveVolumeWindow = 48 * time.Hour
+
+// maxVolumeDays - Longest series the report returns
+const maxVolumeDays = 366
+
+var ErrInvalidVolumeDays = apperrors.New(apperrors.ErrInvalidInput, "invalid_volume_days", "days must be between 1 and 366")
+
+// VolumePoint - Volume in one hour or day
+type VolumePoint struct {
+	Start           time.Time `json:"start"`            // Bucket start (UTC)
+	Transfers       int64     `json:"transfers"`        // Transfers initiated
+	Points          int64     `json:"points"`           // Points initiated
+	Completed       int64     `json:"completed"`        // Transfers completed (live: in this hour; report: of those initiated)
+	CompletedPoints int64     `json:"completed_points"` // Points completed
+}
+
+// VolumeSeries - Time-bucketed volume plus naive forecasts for planning
+type VolumeSeries struct {
+	Bucket string        `json:"bucket"` // hour or day
+	Points []VolumePoint `json:"points"` // Oldest first, gaps filled with zeros
+
+	TotalTransfers int64 `json:"total_transfers"` // Sum over the series
+	TotalPoints    int64 `json:"total_points"`
+
+	// FORECAST: Trailing 7-bucket mean, the simplest baseline for "what does next period look like"
+	ForecastTransfers float64 `json:"forecast_transfers"`
+	ForecastPoints    float64 `json:"forecast_points"`
+}
+
+// TransferVolumeService - Live hourly counters since start plus daily series from the database
+type TransferVolumeService struct {
+	transferRepo *repositories.TransferRepository // Composition: HAS-A transfer store
+	clock        clock.Clock                      // Composition: HAS-A time source
+	mu           sync.Mutex                       // Guards hours
+	hours        map[time.Time]*VolumePoint       // Live: hour start -> counters
+}
+
+// NewTransferVolumeService - Factory method with dependency injection
+func NewTransferVolumeService(transferRepo *repositories.TransferRepository, clk clock.Clock) *TransferVolumeService {
+	return &TransferVolumeService{transferRepo: transferRepo, clock: clk, hours: map[time.Time]*VolumePoint{}}
+}
+
+// ObserveInitiated - Counts a newly created transfer in the current hour
+func (s *TransferVolumeService) ObserveInitiated(points int) {
+	s.observe(func(hour *VolumePoint) {
+		hour.Transfers++
+		hour.Points += int64(points)
+	})
+}
+
+// ObserveCompleted - Counts a completed transfer in the current hour
+func (s *TransferVolumeService) ObserveCompleted(points int) {
+	s.observe(func(hour *VolumePoint) {
+		hour.Completed++
+		hour.CompletedPoints += int64(points)
+	})
+}
+
+// observe - Applies fn to the current hour's counters, dropping hours outside the live window
+func (s *TransferVolumeService) observe(fn func(hour *VolumePoint)) {
+	now := s.clock.Now().UTC()
+	start := now.Truncate(time.Hour)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hour, ok := s.hours[start]
+	if !ok {
+		hour = &VolumePoint{Start: start}
+		s.hours[start] = hour
+		for key := range s.hours {
+			if now.Sub(key) > liveVolumeWindow {
+				delete(s.hours, key)
+			}
+		}
+	}
+	fn(hour)
+}
+
+// Live - Hourly counters for the last 48 hours (process-local; since start)
+func (s *TransferVolumeService) Live() *VolumeSeries {
+	end := s.clock.Now().UTC().Truncate(time.Hour)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	points := make([]VolumePoint, 0, int(liveVolumeWindow/time.Hour))
+	for start := end.Add(-liveVolumeWindow + time.Hour); !start.After(end); start = start.Add(time.Hour) {
+		point := VolumePoint{Start: start}
+		if hour, ok := s.hours[start]; ok {
+			point = *hour
+		}
+		points = append(points, point)
+	}
+	return buildVolumeSeries("hour", points)
+}
+
+// Daily - Initiation volume per UTC day for the last days (today included, partial)
+func (s *TransferVolumeService) Daily(days int) (*VolumeSeries, error) {
+	if days < 1 || days > maxVolumeDays {
+		return nil, ErrInvalidVolumeDays
+	}
+	now := s.clock.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	from, to := today.AddDate(0, 0, -(days-1)), today.AddDate(0, 0, 1)
+
+	buckets, err := s.transferRepo.VolumeByDay(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]VolumePoint, 0, days)
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		point := VolumePoint{Start: day}
+		if bucket, ok := buckets[day]; ok {
+			point = VolumePoint{
+				Start:           day,
+				Transfers:       bucket.Transfers,
+				Points:          bucket.Points,
+				Completed:       bucket.Completed,
+				CompletedPoints: bucket.CompletedPoints,
+			}
+		}
+		points = append(points, point)
+	}
+	return buildVolumeSeries("day", points), nil
+}
+
+// buildVolumeSeries - Totals and the trailing mean over complete buckets (the current one is partial)
+func buildVolumeSeries(bucket string, points []VolumePoint) *VolumeSeries {
+	sort.Slice(points, func(i, j int) bool { return points[i].Start.Before(points[j].Start) })
+	series := &VolumeSeries{Bucket: bucket, Points: points}
+	for _, point := range points {
+		series.TotalTransfers += point.Transfers
+		series.TotalPoints += point.Points
+	}
+
+	complete := points
+	if len(complete) > 0 {
+		complete = complete[:len(complete)-1]
+	}
+	if len(complete) > 7 {
+		complete = complete[len(complete)-7:]
+	}
+	if len(complete) > 0 {
+		var transfers, total int64
+		for _, point := range complete {
+			transfers += point.Transfers
+			total += point.Points
+		}
+		series.ForecastTransfers = float64(transfers) / float64(len(complete))
+		series.ForecastPoints = float64(total) / float64(len(complete))
+	}
+	return series
+}
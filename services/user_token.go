@@ -0,0 +1,227 @@
+// DESIGN PATTERN: Strategy Pattern (HS256 secret / RS256 JWKS) + Cache-Aside (signing keys)
+package services
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/keyring"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Access token errors
+var (
+	ErrTokenInvalid = apperrors.New(apperrors.ErrUnauthorized, "token_invalid", "access token is invalid")
+	ErrTokenExpired = apperrors.New(apperrors.ErrUnauthorized, "token_expired", "access token has expired")
+)
+
+// jwksRetryInterval - Minimum time between JWKS fetches triggered by unknown key IDs
+const jwksRetryInterval = 30 * time.Second
+
+// UserClaims - Verified claims of an access token minted by the Auth Service at login
+type UserClaims struct {
+	Issuer    string       `json:"iss"`   // Minting service
+	Audience  claimStrings `json:"aud"`   // Intended recipient (this service)
+	UserID    string       `json:"sub"`   // Authenticated user
+	Roles     claimStrings `json:"roles"` // Roles granted by the Auth Service (string or array)
+	ExpiresAt int64        `json:"exp"`   // Unix seconds
+	NotBefore int64        `json:"nbf"`   // Unix seconds (optional)
+}
+
+// UserTokenVerifier - Checks signature, issuer, audience and lifetime of access tokens
+type UserTokenVerifier struct {
+	secrets *keyring.Keyring      // HS256 keys by kid (empty disables HS256)
+	jwks    *jwksCache            // RS256 keys by kid (nil disables RS256)
+	config  config.UserAuthConfig // Expected issuer/audience, leeway
+	clock   clock.Clock           // Composition: HAS-A time source
+}
+
+// NewUserTokenVerifier - Factory method; with neither a secret nor a JWKS URL every token is rejected
+func NewUserTokenVerifier(cfg *config.Config, clk clock.Clock) (*UserTokenVerifier, error) {
+	secrets, err := hmacKeyring(cfg.UserAuth.Secret, cfg.UserAuth.Secrets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT_SECRETS: %v", err)
+	}
+	v := &UserTokenVerifier{secrets: secrets, config: cfg.UserAuth, clock: clk}
+	if cfg.UserAuth.JWKSURL != "" {
+		v.jwks = &jwksCache{
+			url:     cfg.UserAuth.JWKSURL,
+			refresh: cfg.UserAuth.JWKSRefresh,
+			clock:   clk,
+			client:  &http.Client{Timeout: 10 * time.Second},
+		}
+	}
+	return v, nil
+}
+
+// VerifyUserToken - Validates a compact JWT and returns the user it identifies and their roles
+// (satisfies middleware.UserTokenVerifier)
+func (v *UserTokenVerifier) VerifyUserToken(raw string) (string, []string, error) {
+	claims, err := v.Verify(raw)
+	if err != nil {
+		return "", nil, err
+	}
+	return claims.UserID, claims.Roles, nil
+}
+
+// Verify - Parses and validates a compact JWT
+func (v *UserTokenVerifier) Verify(raw string) (*UserClaims, error) {
+	// 1. STRUCTURE: header.payload.signature
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrTokenInvalid)
+	}
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: bad header", ErrTokenInvalid)
+	}
+
+	// 2. SIGNATURE: Only algorithms with a configured key ("none" is never accepted)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: bad signature encoding", ErrTokenInvalid)
+	}
+	if err := v.verifySignature(header.Algorithm, header.KeyID, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	// 3. CLAIMS: Issued for this service by the expected issuer, naming a user
+	var claims UserClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: bad payload", ErrTokenInvalid)
+	}
+	if v.config.Issuer != "" && claims.Issuer != v.config.Issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrTokenInvalid)
+	}
+	if v.config.Audience != "" && !claims.Audience.contains(v.config.Audience) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrTokenInvalid)
+	}
+	if claims.UserID == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrTokenInvalid)
+	}
+
+	// 4. LIFETIME: exp is mandatory, nbf optional
+	now := v.clock.Now()
+	if claims.ExpiresAt == 0 {
+		return nil, fmt.Errorf("%w: missing exp", ErrTokenInvalid)
+	}
+	if claims.NotBefore != 0 && time.Unix(claims.NotBefore, 0).After(now.Add(v.config.Leeway)) {
+		return nil, fmt.Errorf("%w: not valid yet", ErrTokenInvalid)
+	}
+	if !now.Before(time.Unix(claims.ExpiresAt, 0).Add(v.config.Leeway)) {
+		return nil, ErrTokenExpired
+	}
+	return &claims, nil
+}
+
+// verifySignature - Strategy per algorithm
+func (v *UserTokenVerifier) verifySignature(algorithm, keyID, signingInput string, signature []byte) error {
+	switch {
+	case algorithm == "HS256" && !v.secrets.Empty():
+		if _, ok := v.secrets.VerifyMAC(keyID, []byte(signingInput), signature); !ok {
+			return fmt.Errorf("%w: bad signature", ErrTokenInvalid)
+		}
+		return nil
+	case algorithm == "RS256" && v.jwks != nil:
+		key, err := v.jwks.key(keyID)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrTokenInvalid, err)
+		}
+		digest := sha256.Sum256([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("%w: bad signature", ErrTokenInvalid)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unsupported algorithm %q", ErrTokenInvalid, algorithm)
+}
+
+// jwksCache - Auth Service signing keys, re-fetched when stale or when a token names an unknown kid
+type jwksCache struct {
+	url       string                    // JWKS endpoint
+	refresh   time.Duration             // Key set lifetime
+	clock     clock.Clock               // Composition: HAS-A time source
+	client    *http.Client              // Shared HTTP client
+	mu        sync.Mutex                // Guards keys/fetchedAt (one fetch at a time)
+	keys      map[string]*rsa.PublicKey // kid -> key
+	fetchedAt time.Time                 // Last fetch attempt
+}
+
+// key - Signing key for a kid; a stale set is kept when the endpoint is unreachable
+func (c *jwksCache) key(keyID string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	key, known := c.keys[keyID]
+	stale := now.Sub(c.fetchedAt) >= c.refresh
+	if (!known || stale) && now.Sub(c.fetchedAt) >= jwksRetryInterval {
+		c.fetchedAt = now
+		keys, err := c.fetch()
+		if err != nil {
+			fmt.Printf("JWKS refresh from %s failed: %v\n", c.url, err)
+		} else {
+			c.keys = keys
+			key, known = keys[keyID]
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("unknown signing key %q", keyID)
+	}
+	return key, nil
+}
+
+// fetch - Downloads the key set and keeps its RSA signing keys
+func (c *jwksCache) fetch() (map[string]*rsa.PublicKey, error) {
+	resp, err := c.client.Get(c.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("JWKS endpoint responded with status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []struct {
+			KeyType string `json:"kty"`
+			KeyID   string `json:"kid"`
+			Use     string `json:"use"`
+			N       string `json:"n"`
+			E       string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %v", err)
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, jwk := range set.Keys {
+		if jwk.KeyType != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		modulus, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+		exponent, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+		if errN != nil || errE != nil || len(exponent) == 0 || len(exponent) > 4 {
+			continue
+		}
+		keys[jwk.KeyID] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(modulus),
+			E: int(new(big.Int).SetBytes(exponent).Int64()),
+		}
+	}
+	return keys, nil
+}
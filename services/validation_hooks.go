@@ -0,0 +1,163 @@
+// DESIGN PATTERN: Plugin Pattern + Chain of Responsibility (any hook can veto)
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"plugin"
+	"sender-service/apperrors"
+	"sender-service/config"
+	"sender-service/models"
+	"time"
+)
+
+var ErrOperationVetoed = apperrors.New(apperrors.ErrUnprocessable, "operation_vetoed", "operation rejected by validation hook")
+
+// ValidationHook - Deployment-specific rule that can veto initiations and completions
+type ValidationHook interface {
+	Name() string
+	BeforeInitiate(sender *models.User, req *models.TransferRequest) error
+	BeforeComplete(transfer *models.Transfer) error
+}
+
+// HookRegistry - Ordered set of validation hooks; the first veto wins
+type HookRegistry struct {
+	hooks []ValidationHook
+}
+
+// NewHookRegistry - Factory method loading webhook validators and Go plugins from configuration
+func NewHookRegistry(cfg *config.Config) (*HookRegistry, error) {
+	registry := &HookRegistry{}
+
+	if policy := NewOPAPolicyHook(cfg); policy != nil {
+		registry.Register(policy)
+	}
+
+	for name, url := range cfg.Hooks.Webhooks {
+		registry.Register(NewWebhookValidationHook(name, url, cfg.Hooks.Timeout, cfg.Hooks.FailOpen))
+	}
+
+	for _, path := range cfg.Hooks.Plugins {
+		hook, err := loadPluginHook(path)
+		if err != nil {
+			return nil, err
+		}
+		registry.Register(hook)
+	}
+	return registry, nil
+}
+
+// Register - Appends a hook (compiled-in hooks can be registered from main)
+func (r *HookRegistry) Register(hook ValidationHook) {
+	r.hooks = append(r.hooks, hook)
+}
+
+// BeforeInitiate - Runs every hook before a transfer is created
+func (r *HookRegistry) BeforeInitiate(sender *models.User, req *models.TransferRequest) error {
+	for _, hook := range r.hooks {
+		if err := hook.BeforeInitiate(sender, req); err != nil {
+			return fmt.Errorf("%w (%s): %v", ErrOperationVetoed, hook.Name(), err)
+		}
+	}
+	return nil
+}
+
+// BeforeComplete - Runs every hook before points are deducted
+func (r *HookRegistry) BeforeComplete(transfer *models.Transfer) error {
+	for _, hook := range r.hooks {
+		if err := hook.BeforeComplete(transfer); err != nil {
+			return fmt.Errorf("%w (%s): %v", ErrOperationVetoed, hook.Name(), err)
+		}
+	}
+	return nil
+}
+
+// loadPluginHook - Opens a Go plugin (.so) exporting `var Hook services.ValidationHook`
+func loadPluginHook(path string) (ValidationHook, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open validation plugin %s: %v", path, err)
+	}
+	symbol, err := p.Lookup("Hook")
+	if err != nil {
+		return nil, fmt.Errorf("validation plugin %s does not export Hook: %v", path, err)
+	}
+	hook, ok := symbol.(*ValidationHook)
+	if !ok || *hook == nil {
+		return nil, fmt.Errorf("validation plugin %s: Hook is not a services.ValidationHook", path)
+	}
+	return *hook, nil
+}
+
+// WebhookValidationHook - Asks an external system (e.g. HR) to allow or veto the operation
+type WebhookValidationHook struct {
+	name     string       // Hook name used in veto messages
+	url      string       // Validator endpoint
+	failOpen bool         // Allow the operation when the validator is unreachable
+	client   *http.Client // Shared HTTP client
+}
+
+// NewWebhookValidationHook - Factory method for webhook-based validators
+func NewWebhookValidationHook(name, url string, timeout time.Duration, failOpen bool) *WebhookValidationHook {
+	return &WebhookValidationHook{name: name, url: url, failOpen: failOpen, client: &http.Client{Timeout: timeout}}
+}
+
+// Name - Hook identifier
+func (h *WebhookValidationHook) Name() string {
+	return h.name
+}
+
+// BeforeInitiate - POSTs {"stage": "before_initiate", "sender", "request"}
+func (h *WebhookValidationHook) BeforeInitiate(sender *models.User, req *models.TransferRequest) error {
+	return h.ask(map[string]interface{}{"stage": "before_initiate", "sender": sender, "request": req})
+}
+
+// BeforeComplete - POSTs {"stage": "before_complete", "transfer"}
+func (h *WebhookValidationHook) BeforeComplete(transfer *models.Transfer) error {
+	return h.ask(map[string]interface{}{"stage": "before_complete", "transfer": transfer})
+}
+
+// ask - Expects 200 {"allow": bool, "reason": string}
+func (h *WebhookValidationHook) ask(payload map[string]interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.client.Post(h.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return h.unavailable(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return h.unavailable(fmt.Errorf("validator responded with status %d", resp.StatusCode))
+	}
+
+	var decision struct {
+		Allow  bool   `json:"allow"`
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return h.unavailable(fmt.Errorf("invalid validator response: %v", err))
+	}
+	if !decision.Allow {
+		if decision.Reason == "" {
+			decision.Reason = "not allowed"
+		}
+		return errors.New(decision.Reason)
+	}
+	return nil
+}
+
+// unavailable - Validator errors veto unless the hook fails open
+func (h *WebhookValidationHook) unavailable(err error) error {
+	fmt.Printf("Validation hook %s unavailable: %v\n", h.name, err)
+	if h.failOpen {
+		return nil
+	}
+	return errors.New("validator unavailable")
+}
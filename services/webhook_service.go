@@ -0,0 +1,302 @@
+// DESIGN PATTERN: Publisher-Subscriber Pattern (per-integrator fan-out) + Decorator Pattern (sink)
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sender-service/apperrors"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/idgen"
+	"sender-service/models"
+	"sender-service/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWebhookNotFound  = apperrors.New(apperrors.ErrNotFound, "webhook_not_found", "webhook subscription not found")
+	ErrUnknownEventType = apperrors.New(apperrors.ErrUnprocessable, "unknown_event_type", "unknown event type")
+)
+
+// webhookRetryBatchSize - Due deliveries retried per poll
+const webhookRetryBatchSize = 100
+
+// WebhookService - Manages integrator subscriptions and their payload templates
+type WebhookService struct {
+	repo       *repositories.WebhookRepository         // Composition: HAS-A subscription store
+	deliveries *repositories.WebhookDeliveryRepository // Composition: HAS-A delivery log
+	clock      clock.Clock                             // Composition: HAS-A time source
+	ids        idgen.Generator                         // Composition: HAS-A ID generator
+}
+
+// NewWebhookService - Factory method with dependency injection
+func NewWebhookService(repo *repositories.WebhookRepository, deliveries *repositories.WebhookDeliveryRepository, clk clock.Clock, ids idgen.Generator) *WebhookService {
+	return &WebhookService{repo: repo, deliveries: deliveries, clock: clk, ids: ids}
+}
+
+// List - All subscriptions
+func (s *WebhookService) List() ([]models.WebhookSubscription, error) {
+	return s.repo.List()
+}
+
+// Create - Registers a subscription after compiling its template
+func (s *WebhookService) Create(req models.WebhookSubscriptionRequest) (*models.WebhookSubscription, error) {
+	now := s.clock.Now()
+	subscription := &models.WebhookSubscription{ID: s.ids.NewID("whk"), CreatedAt: now}
+	if err := applyWebhookRequest(subscription, req, now); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(subscription); err != nil {
+		return nil, errors.New("failed to create webhook subscription")
+	}
+	return subscription, nil
+}
+
+// Update - Replaces a subscription's URL, event types, template and active flag
+func (s *WebhookService) Update(id string, req models.WebhookSubscriptionRequest) (*models.WebhookSubscription, error) {
+	subscription, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrWebhookNotFound
+	}
+	if err := applyWebhookRequest(subscription, req, s.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(subscription); err != nil {
+		return nil, errors.New("failed to update webhook subscription")
+	}
+	return subscription, nil
+}
+
+// Delete - Removes a subscription
+func (s *WebhookService) Delete(id string) error {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return ErrWebhookNotFound
+	}
+	return s.repo.Delete(id)
+}
+
+// Deliveries - A subscription's delivery log, newest first (?status=pending|delivered|failed)
+func (s *WebhookService) Deliveries(id, status string, limit int) ([]models.WebhookDelivery, error) {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return nil, ErrWebhookNotFound
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return s.deliveries.ListBySubscription(id, status, limit)
+}
+
+// applyWebhookRequest - Copies a validated request onto the entity
+func applyWebhookRequest(subscription *models.WebhookSubscription, req models.WebhookSubscriptionRequest, now time.Time) error {
+	// VALIDATION: Unknown event types and broken templates are rejected at registration, not delivery
+	for _, eventType := range req.EventTypes {
+		if _, ok := eventVersions[eventType]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
+		}
+	}
+	template, err := CompilePayloadTemplate(string(req.Template))
+	if err != nil {
+		return err
+	}
+
+	subscription.URL = req.URL
+	subscription.EventTypes = req.EventTypes
+	subscription.Template = ""
+	if template != nil {
+		subscription.Template = string(req.Template)
+	}
+	subscription.Active = req.Active == nil || *req.Active
+	subscription.UpdatedAt = now
+	return nil
+}
+
+// WebhookSink - Delivers each event to the wrapped sink, then to every matching subscription.
+// Each subscription gets its own delivery record and retry schedule, so one failing subscriber
+// neither blocks the others nor makes them receive the event again
+type WebhookSink struct {
+	next        EventSink                               // Decorated sink (configured endpoint or log)
+	repo        *repositories.WebhookRepository         // Composition: HAS-A subscription store
+	deliveries  *repositories.WebhookDeliveryRepository // Composition: HAS-A delivery log
+	signer      *WebhookSigner                          // Delivery signatures (nil = unsigned)
+	deadLetters *DeadLetterService                      // Deliveries that exhausted their retries
+	config      *config.Config                          // Composition: HAS-A retry settings
+	clock       clock.Clock                             // Composition: HAS-A time source
+	ids         idgen.Generator                         // Composition: HAS-A ID generator
+	client      *http.Client                            // Shared HTTP client
+}
+
+// NewWebhookSink - Factory method decorating the configured sink with subscriptions
+func NewWebhookSink(next EventSink, repo *repositories.WebhookRepository, deliveries *repositories.WebhookDeliveryRepository, signer *WebhookSigner, deadLetters *DeadLetterService, cfg *config.Config, clk clock.Clock, ids idgen.Generator) *WebhookSink {
+	return &WebhookSink{
+		next: next, repo: repo, deliveries: deliveries, signer: signer, deadLetters: deadLetters,
+		config: cfg, clock: clk, ids: ids, client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Deliver - Configured sink first (its failure retries the event), then a first attempt for each
+// active subscription; failed subscriptions are retried by RetryDue, not by replaying the event
+func (s *WebhookSink) Deliver(event *models.DomainEvent, payload []byte) error {
+	if err := s.next.Deliver(event, payload); err != nil {
+		return err
+	}
+
+	subscriptions, err := s.repo.FindActive()
+	if err != nil {
+		return err
+	}
+	for i := range subscriptions {
+		subscription := &subscriptions[i]
+		if !subscribesTo(subscription, event.Type) {
+			continue
+		}
+
+		// 1. RECORD: One delivery per subscription and event; a replayed event finds it and moves on
+		_, err := s.deliveries.FindByEvent(subscription.ID, event.ID)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("webhook %s: %w", subscription.ID, err)
+		}
+		delivery := &models.WebhookDelivery{
+			ID:             s.ids.NewID("whd"),
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			EventSchema:    event.SchemaName(),
+			Payload:        payload,
+			Status:         models.WebhookDeliveryPending,
+		}
+
+		// 2. FIRST ATTEMPT: Outcome and retry time are saved with the delivery
+		if err := s.attempt(subscription, delivery); err != nil {
+			return fmt.Errorf("webhook %s: %w", subscription.ID, err)
+		}
+	}
+	return nil
+}
+
+// RetryDue - Scheduler job: re-attempts pending deliveries whose backoff has elapsed
+func (s *WebhookSink) RetryDue(ctx context.Context) error {
+	now := s.clock.Now()
+	due, err := s.deliveries.FindDue(now, webhookRetryBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range due {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		delivery := &due[i]
+		subscription, err := s.repo.FindByID(delivery.SubscriptionID)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			// Unsubscribed since: nobody left to deliver to
+			delivery.Status = models.WebhookDeliveryFailed
+			delivery.LastError = "subscription removed"
+			err = s.deliveries.Save(delivery)
+		case err != nil:
+			// Lookup failed: still due, so the next poll tries again
+		case !subscription.Active:
+			// Paused: keep it pending and check again later without spending an attempt
+			delivery.NextAttemptAt = now.Add(s.config.Events.WebhookRetryInterval)
+			err = s.deliveries.Save(delivery)
+		default:
+			err = s.attempt(subscription, delivery)
+		}
+		if err != nil {
+			fmt.Printf("Failed to retry webhook delivery %s: %v\n", delivery.ID, err)
+		}
+	}
+	return nil
+}
+
+// attempt - One POST to the subscription; records success, the next retry, or the dead letter once
+// WEBHOOK_MAX_ATTEMPTS is reached. Only a failure to save the delivery is returned
+func (s *WebhookSink) attempt(subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) error {
+	delivery.Attempts++
+	deliverErr := s.deliver(subscription, delivery)
+	now := s.clock.Now()
+
+	switch {
+	case deliverErr == nil:
+		delivery.Status = models.WebhookDeliveryDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= s.config.Events.WebhookMaxAttempts:
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.LastError = deliverErr.Error()
+		fmt.Printf("Giving up on webhook delivery %s of %s to %s after %d attempts: %v\n",
+			delivery.ID, delivery.EventID, subscription.ID, delivery.Attempts, deliverErr)
+	default:
+		delivery.LastError = deliverErr.Error()
+		delivery.NextAttemptAt = now.Add(relayBackoff(delivery.Attempts))
+		fmt.Printf("Webhook delivery %s of %s to %s failed (attempt %d): %v\n",
+			delivery.ID, delivery.EventID, subscription.ID, delivery.Attempts, deliverErr)
+	}
+
+	if err := s.deliveries.Save(delivery); err != nil {
+		return err
+	}
+	if delivery.Status == models.WebhookDeliveryFailed {
+		if err := s.deadLetters.Record(models.DeadLetterKindWebhook, delivery.ID, subscription.ID, delivery.LastError, delivery.Attempts, nil); err != nil {
+			fmt.Printf("Failed to dead-letter webhook delivery %s: %v\n", delivery.ID, err)
+		}
+	}
+	return nil
+}
+
+// deliver - POSTs one subscription's (optionally templated) payload
+func (s *WebhookSink) deliver(subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) error {
+	// 1. TEMPLATE: Reshape the envelope for legacy consumers
+	body := delivery.Payload
+	template, err := CompilePayloadTemplate(subscription.Template)
+	if err != nil {
+		return err
+	}
+	if template != nil {
+		if body, err = template.Render(delivery.Payload); err != nil {
+			return err
+		}
+	}
+
+	// 2. DELIVERY: Same headers as the configured endpoint, plus the subscription for routing
+	req, err := http.NewRequest("POST", subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Schema", delivery.EventSchema)
+	req.Header.Set("X-Event-ID", delivery.EventID)
+	req.Header.Set("X-Webhook-Subscription", subscription.ID)
+	s.signer.Sign(req, body)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// subscribesTo - Whether the subscription wants this event type
+func subscribesTo(subscription *models.WebhookSubscription, eventType string) bool {
+	if len(subscription.EventTypes) == 0 {
+		return true
+	}
+	for _, subscribed := range subscription.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
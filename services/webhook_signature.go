@@ -0,0 +1,48 @@
+// DESIGN PATTERN: Decorator-style request signing (HMAC-SHA256 per active key) for event deliveries
+package services
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sender-service/clock"
+	"sender-service/config"
+	"sender-service/keyring"
+	"strconv"
+	"strings"
+)
+
+// WebhookSigner - Signs event deliveries with every active signing key, so consumers still holding the
+// previous secret keep verifying while a rotation is rolled out
+type WebhookSigner struct {
+	keys  *keyring.Keyring // Active keys, primary first
+	clock clock.Clock      // Composition: HAS-A time source (replay window)
+}
+
+// NewWebhookSigner - Factory method; nil (deliveries unsigned) when WEBHOOK_SIGNING_KEYS is empty
+func NewWebhookSigner(cfg *config.Config, clk clock.Clock) (*WebhookSigner, error) {
+	keys, err := keyring.Parse(cfg.Events.SigningKeys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WEBHOOK_SIGNING_KEYS: %v", err)
+	}
+	if keys.Empty() {
+		return nil, nil
+	}
+	return &WebhookSigner{keys: keys, clock: clk}, nil
+}
+
+// Sign - Sets X-Webhook-Timestamp and X-Webhook-Signature ("<kid>=<hex>, ..." over "<timestamp>.<body>")
+func (s *WebhookSigner) Sign(req *http.Request, body []byte) {
+	if s == nil {
+		return
+	}
+	timestamp := strconv.FormatInt(s.clock.Now().Unix(), 10)
+	signed := append([]byte(timestamp+"."), body...)
+
+	signatures := make([]string, 0, len(s.keys.Keys()))
+	for _, key := range s.keys.Keys() {
+		signatures = append(signatures, key.ID+"="+hex.EncodeToString(keyring.MAC(key, signed)))
+	}
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", strings.Join(signatures, ", "))
+}
@@ -0,0 +1,47 @@
+// DESIGN PATTERN: Registry Pattern (embedded static assets and page templates)
+package web
+
+import (
+	"embed"
+	"html/template"
+	"io/fs"
+)
+
+// Embedded claim landing page assets
+//
+//go:embed static/* claim.html
+var files embed.FS
+
+// CriticalAssets - Assets the claim page cannot render without; pushed/preloaded with the HTML
+var CriticalAssets = []struct {
+	Path string // URL under /static
+	As   string // Preload destination (style, image)
+}{
+	{Path: "/static/claim.css", As: "style"},
+	{Path: "/static/logo.svg", As: "image"},
+}
+
+// ClaimPage - Parsed claim landing page template
+var ClaimPage = template.Must(template.ParseFS(files, "claim.html"))
+
+// Static - File system served at /static
+func Static() fs.FS {
+	static, err := fs.Sub(files, "static")
+	if err != nil {
+		panic(err) // Embedded path is fixed at compile time
+	}
+	return static
+}
+
+// ClaimPageData - Data for the claim landing page
+type ClaimPageData struct {
+	ReceiverName   string // Greeting
+	SenderEmail    string // Who sent the points
+	Points         int    // Points amount
+	Message        string // Optional gift message (already scanned)
+	ExpiresAt      string // Preformatted expiry
+	Claimable      bool   // Still pending and not expired
+	Protected      bool   // Passphrase required
+	PassphraseHint string // Optional passphrase hint
+	ClaimURL       string // Frontend claim route
+}